@@ -0,0 +1,72 @@
+// Command eventsub-fake は Twitch の代わりに署名付きの EventSub 通知を送る
+//
+// 使い方（サーバーの TWITCH_EVENTSUB_SECRET と同じシークレットを指定する）:
+//
+//	go run ./cmd/eventsub-fake -secret s3cret redeem yamada 1000
+//	go run ./cmd/eventsub-fake -secret s3cret subscribe yamada 2000
+//	go run ./cmd/eventsub-fake -secret s3cret verify
+//
+// -id を指定すると同じ Message-Id で再送できる（重複排除の確認用）
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/presentation/eventsub"
+)
+
+func main() {
+	url := flag.String("url", "http://localhost:8000/api/twitch/eventsub", "EventSub callback URL")
+	secret := flag.String("secret", os.Getenv("TWITCH_EVENTSUB_SECRET"), "EventSub secret (defaults to $TWITCH_EVENTSUB_SECRET)")
+	messageID := flag.String("id", "", "message ID (generated when empty)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: eventsub-fake [flags] redeem <user> <cost> | subscribe <user> <1000|2000|3000> | verify")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *secret == "" {
+		log.Fatal("eventsub-fake: -secret is required")
+	}
+
+	fake := &eventsub.Fake{URL: *url, Secret: *secret}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	args := flag.Args()
+	var (
+		resp *http.Response
+		err  error
+	)
+	switch {
+	case len(args) == 3 && args[0] == "redeem":
+		cost, parseErr := strconv.ParseInt(args[2], 10, 64)
+		if parseErr != nil {
+			log.Fatalf("eventsub-fake: invalid cost %q", args[2])
+		}
+		redemptionID := fmt.Sprintf("fake-redemption-%d", time.Now().UnixNano())
+		resp, err = fake.Notify(ctx, *messageID, domain.EventSubTypeChannelPointsRedemption, eventsub.RedemptionEvent(redemptionID, args[1], cost))
+	case len(args) == 3 && args[0] == "subscribe":
+		resp, err = fake.Notify(ctx, *messageID, domain.EventSubTypeChannelSubscribe, eventsub.SubscribeEvent(args[1], args[2]))
+	case len(args) == 1 && args[0] == "verify":
+		resp, err = fake.Verify(ctx, domain.EventSubTypeChannelPointsRedemption, "fake-challenge")
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("eventsub-fake: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	fmt.Printf("%s %s\n", resp.Status, body)
+}
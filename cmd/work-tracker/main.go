@@ -2,7 +2,8 @@ package main
 
 import (
 	"context"
-	"log"
+	"flag"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -16,38 +17,86 @@ import (
 	"github.com/yamada-ai/workspace-backend/infrastructure/database"
 	infraRepo "github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
 	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+	"github.com/yamada-ai/workspace-backend/infrastructure/metrics"
+	"github.com/yamada-ai/workspace-backend/infrastructure/telemetry"
+	"github.com/yamada-ai/workspace-backend/presentation/eventsub"
 	"github.com/yamada-ai/workspace-backend/presentation/http/dto"
 	"github.com/yamada-ai/workspace-backend/presentation/http/handler"
+	appMiddleware "github.com/yamada-ai/workspace-backend/presentation/http/middleware"
+	"github.com/yamada-ai/workspace-backend/presentation/twitchirc"
 	"github.com/yamada-ai/workspace-backend/presentation/ws"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
 	"github.com/yamada-ai/workspace-backend/usecase/command"
+	"github.com/yamada-ai/workspace-backend/usecase/icon"
+	"github.com/yamada-ai/workspace-backend/usecase/moderation"
+	"github.com/yamada-ai/workspace-backend/usecase/outbox"
 	"github.com/yamada-ai/workspace-backend/usecase/query"
+	"github.com/yamada-ai/workspace-backend/usecase/ratelimit"
 	"github.com/yamada-ai/workspace-backend/usecase/session"
+	"github.com/yamada-ai/workspace-backend/usecase/settings"
+	"github.com/yamada-ai/workspace-backend/usecase/twitch"
+	"github.com/yamada-ai/workspace-backend/usecase/webhook"
 )
 
+// migrationsPath マイグレーションのディレクトリ（作業ディレクトリからの相対パス）
+const migrationsPath = "migrations"
+
 func main() {
-	// Load configuration
-	cfg, err := config.Load()
+	// Load configuration (defaults < -config file < environment < flags)
+	configFlags := config.BindFlags(flag.CommandLine)
+	printConfig := flag.Bool("print-config", false, "print the effective configuration (secrets redacted) and exit")
+	flag.Parse()
+	cfg, err := config.Load(configFlags)
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		fatal("failed to load configuration", err)
+	}
+	if *printConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			fatal("failed to print configuration", err)
+		}
+		return
 	}
-	log.Printf("Starting work-tracker server on %s", cfg.ServerPort)
+
+	// Structured logger (LOG_FORMAT / LOG_LEVEL); request-scoped loggers are derived from it
+	logger := logging.New(os.Stderr, cfg.LogFormat, cfg.LogLevel)
+	slog.SetDefault(logger)
+	logger.Info("starting work-tracker server", "addr", cfg.ServerAddr(), "config", cfg)
 
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	ctx = logging.WithLogger(ctx, logger)
+
+	// Tracing (OTLP when a collector is configured, otherwise stdout)
+	shutdownTracing, err := telemetry.SetupTracing(ctx, cfg.TracesExporter, os.Stdout)
+	if err != nil {
+		fatal("failed to set up tracing", err)
+	}
+	defer func() {
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer flushCancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Error("failed to flush traces", logging.Err(err))
+		}
+	}()
+	logger.Info("tracing configured", "exporter", cfg.TracesExporter)
 
 	// Run database migrations
-	if err := database.RunMigrations(cfg.DatabaseURL, "migrations"); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+	if err := database.RunMigrations(cfg.DatabaseURL, migrationsPath); err != nil {
+		fatal("failed to run migrations", err)
+	}
+	expectedMigration, err := database.LatestMigrationVersion(migrationsPath)
+	if err != nil {
+		fatal("failed to read migrations", err)
 	}
 
 	// Connect to database
-	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL, cfg.DatabasePool)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("failed to connect to database", err)
 	}
 	defer pool.Close()
-	log.Println("✅ Connected to database")
+	logger.Info("connected to database")
 
 	// === Dependency Injection (Bottom-up) ===
 
@@ -57,63 +106,182 @@ func main() {
 	// 2. Create Repository implementations
 	userRepository := infraRepo.NewUserRepositoryWithPool(pool)
 	sessionRepository := infraRepo.NewSessionRepository(queries)
+	idempotencyRepository := infraRepo.NewIdempotencyRepository(queries)
+	bannedTermRepository := infraRepo.NewBannedTermRepository(queries)
+	auditLogRepository := infraRepo.NewAuditLogRepository(queries)
+	outboxRepository := infraRepo.NewOutboxRepository(queries)
+	webhookSubscriptionRepository := infraRepo.NewWebhookSubscriptionRepository(queries)
+	webhookDeliveryRepository := infraRepo.NewWebhookDeliveryRepository(queries)
+	iconRepository := infraRepo.NewIconRepository(queries)
+	iconCommissionRepository := infraRepo.NewIconCommissionRepository(queries)
+	pointLedgerRepository := infraRepo.NewPointLedgerRepository(queries)
+	twitchEventSubRepository := infraRepo.NewTwitchEventSubRepository()
+	cheerRepository := infraRepo.NewCheerRepository()
+	runtimeSettingRepository := infraRepo.NewRuntimeSettingRepository(queries)
 
 	// 3. Create WebSocket Hub
-	wsHub := ws.NewHub()
-	go wsHub.Run() // Start hub in background goroutine
+	wsHubOptions, err := hubOptions(cfg.WSHub)
+	if err != nil {
+		fatal("invalid websocket configuration", err)
+	}
+	wsHub := ws.NewHubWithOptions(wsHubOptions)
+	wsHub.Start(ctx) // Start hub in background goroutine (stopped after the HTTP server on shutdown)
+
+	// 4. Create webhook delivery (signed POSTs retried from a persistent queue)
+	webhookDeliverer := webhook.NewDeliverer(webhookSubscriptionRepository, webhookDeliveryRepository)
+	go webhookDeliverer.Run(ctx)
+	webhookSink := webhook.NewSink(webhookSubscriptionRepository, webhookDeliveryRepository, webhookDeliverer)
+
+	// 5. Fan events out to the in-memory consumers (a full queue drops events; overlays replay them on reconnect)
+	eventFanOut := command.NewFanOutBroadcaster(command.DefaultSinkQueueSize)
+	eventFanOut.Add(ctx, "websocket", wsHub)
 
-	// 4. Create Session Services
-	completeSessionService := session.NewCompleteSessionService(sessionRepository, wsHub)
+	// 6. Create event outbox (events are recorded in the command transaction and delivered after commit)
+	// Webhook deliveries are queued synchronously, so an event is retried until they are stored
+	outboxDispatcher := outbox.NewDispatcher(outboxRepository, eventFanOut)
+	outboxDispatcher.AddSink(webhookSink)
+	dispatcherDone := make(chan struct{})
+	go func() {
+		defer close(dispatcherDone)
+		outboxDispatcher.Run(ctx)
+	}()
+	eventOutbox := outbox.NewRecorder(outboxRepository, outboxDispatcher)
+
+	// 7. Create Session Services
+	completeSessionService := session.NewCompleteSessionService(userRepository, sessionRepository, eventOutbox)
 	expirationManager := session.NewSessionExpirationManager(sessionRepository, completeSessionService)
 
-	// 5. Initialize session expiration timers from database
-	if err := expirationManager.InitializeFromDatabase(ctx); err != nil {
-		log.Fatalf("Failed to initialize session expiration timers: %v", err)
+	// 8. Start session expiration timers (restored from the active sessions in the database)
+	if err := expirationManager.Start(ctx); err != nil {
+		fatal("failed to initialize session expiration timers", err)
 	}
 
-	// 6. Create Use Cases (inject dependencies)
-	joinUsecase := command.NewJoinCommandUseCase(userRepository, sessionRepository, wsHub, expirationManager)
-	outUseCase := command.NewOutCommandUseCase(userRepository, sessionRepository, completeSessionService, expirationManager)
-	moreUseCase := command.NewMoreCommandUseCase(userRepository, sessionRepository, wsHub, expirationManager)
-	changeUseCase := command.NewChangeCommandUseCase(userRepository, sessionRepository, wsHub)
+	// 9. Load runtime settings (the startup config overridden by values changed through the admin API)
+	// Use cases read them on every command, so changes apply without a restart
+	settingsWatcher := settings.NewWatcher(runtimeSettingRepository, cfg.RuntimeSettings())
+	if err := settingsWatcher.Start(ctx); err != nil {
+		fatal("failed to load runtime settings", err)
+	}
+	go settingsWatcher.Run(ctx)
+
+	// 10. Create rate limiter (in-process token buckets), moderation, webhook and settings services and icon picker
+	rateLimiter := ratelimit.NewLimiter(rateLimitPolicy(cfg.RateLimit), ratelimit.NewMemoryStore())
+	moderationService := moderation.NewService(bannedTermRepository, userRepository, auditLogRepository, settingsWatcher)
+	webhookService := webhook.NewService(webhookSubscriptionRepository, webhookDeliveryRepository, auditLogRepository, webhookDeliverer)
+	iconPicker := icon.NewPicker(iconRepository, nil)
+	settingsService := settings.NewService(userRepository, runtimeSettingRepository, auditLogRepository, eventOutbox, settingsWatcher)
+	twitchService := twitch.NewService(userRepository, pointLedgerRepository, twitchEventSubRepository, settingsWatcher)
+
+	// 11. Create Use Cases (inject dependencies)
+	joinUsecase := command.NewJoinCommandUseCase(userRepository, sessionRepository, eventOutbox, expirationManager, rateLimiter, moderationService, iconPicker, settingsWatcher)
+	outUseCase := command.NewOutCommandUseCase(userRepository, sessionRepository, completeSessionService, expirationManager, rateLimiter)
+	moreUseCase := command.NewMoreCommandUseCase(userRepository, sessionRepository, eventOutbox, expirationManager, rateLimiter, settingsWatcher)
+	changeUseCase := command.NewChangeCommandUseCase(userRepository, sessionRepository, eventOutbox, rateLimiter, moderationService)
 	getActiveSessionsUseCase := query.NewGetActiveSessionsUseCase(sessionRepository)
 	getUserInfoUseCase := query.NewGetUserInfoUseCase(userRepository, sessionRepository)
+	listAuditLogsUseCase := query.NewListAuditLogsUseCase(auditLogRepository)
+	listDeadOutboxEventsUseCase := query.NewListDeadOutboxEventsUseCase(outboxRepository)
+	forceOutUseCase := command.NewForceOutCommandUseCase(sessionRepository, auditLogRepository, completeSessionService, expirationManager)
+	kickAllUseCase := command.NewKickAllCommandUseCase(sessionRepository, forceOutUseCase)
+	sessionCorrectionUseCase := command.NewSessionCorrectionUseCase(userRepository, sessionRepository, auditLogRepository, eventOutbox)
+	iconCommissionUseCase := command.NewIconCommissionUseCase(userRepository, iconCommissionRepository, pointLedgerRepository, iconRepository, auditLogRepository, rateLimiter)
+	// コメントは一時的な表示なので outbox を通さず Hub に直接送る
+	cheerUseCase := command.NewCheerCommandUseCase(userRepository, sessionRepository, cheerRepository, pointLedgerRepository, wsHub, rateLimiter, settingsWatcher, cfg.StreamLocation)
+	commentUseCase := command.NewCommentCommandUseCase(userRepository, sessionRepository, wsHub, rateLimiter, moderationService)
+
+	// 12. Create metrics (HTTP requests and command outcomes, plus runtime gauges read on every scrape)
+	appMetrics := metrics.New()
+	registerRuntimeMetrics(appMetrics.Registry, sessionRepository, wsHub, eventFanOut, expirationManager, pool)
 
-	// 7. Create HTTP Handlers
-	commandHandler := handler.NewCommandHandler(joinUsecase, outUseCase, moreUseCase, changeUseCase)
+	// 13. Create HTTP Handlers
+	commandHandler := handler.NewCommandHandler(joinUsecase, outUseCase, moreUseCase, changeUseCase, iconCommissionUseCase, cheerUseCase, commentUseCase, appMetrics)
 	queryHandler := handler.NewQueryHandler(getActiveSessionsUseCase, getUserInfoUseCase)
-	unifiedHandler := handler.NewHandler(commandHandler, queryHandler)
-	wsHandler := ws.NewHandler(wsHub)
+	adminHandler := handler.NewAdminHandler(moderationService, listAuditLogsUseCase, forceOutUseCase, kickAllUseCase, sessionCorrectionUseCase, webhookService, iconCommissionUseCase, settingsService, listDeadOutboxEventsUseCase)
+	// /readyz で確認する依存先（DB・マイグレーション・Hub・期限切れタイマー）
+	healthHandler := handler.NewHealthHandler(handler.DefaultReadinessTimeout,
+		handler.ReadinessCheck{Name: "database", Check: pool.Ping},
+		handler.ReadinessCheck{Name: "migrations", Check: func(ctx context.Context) error {
+			return database.CheckMigrationApplied(ctx, pool, expectedMigration)
+		}},
+		handler.ReadinessCheck{Name: "websocket_hub", Check: func(ctx context.Context) error { return wsHub.Ready() }},
+		handler.ReadinessCheck{Name: "session_expiration", Check: func(ctx context.Context) error { return expirationManager.Ready() }},
+	)
+	unifiedHandler := handler.NewHandler(commandHandler, queryHandler, adminHandler, healthHandler)
+	// ブラウザから使ってよいオリジン（API の CORS と /ws の Origin の確認）
+	corsMiddleware := appMiddleware.NewCORSMiddleware(cfg.HTTP.CORSAllowedOrigins)
+	wsHandler := ws.NewHandlerWithOptions(wsHub, ws.HandlerOptions{CheckOrigin: corsMiddleware.CheckOrigin})
+	sseHandler := ws.NewSSEHandler(wsHub, outbox.NewReplayer(outboxRepository))
+
+	// Twitch チャットのコマンド（!in など）を HTTP を経由せずに usecase に渡す（トークンが空なら接続しない）
+	if cfg.TwitchIRC.Token == "" {
+		logger.Info("TWITCH_IRC_TOKEN is not set; Twitch chat connector is disabled")
+	} else {
+		chatCommands := twitchirc.NewCommands(joinUsecase, outUseCase, moreUseCase, changeUseCase, getUserInfoUseCase, cheerUseCase, commentUseCase, appMetrics)
+		chatClient := twitchirc.NewClient(twitchIRCConfig(cfg.TwitchIRC), chatCommands)
+		go func() {
+			if err := chatClient.Run(ctx); err != nil {
+				logger.Error("twitch chat connector stopped", logging.Err(err))
+			}
+		}()
+	}
 
-	// 8. Setup Router
+	// 14. Setup Router
 	r := chi.NewRouter()
 
 	// Middleware
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
+	r.Use(appMiddleware.NewMetricsMiddleware(appMetrics).Handler)
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
+	r.Use(appMiddleware.NewRequestLogger(logger).Handler)
+	r.Use(appMiddleware.NewTracingMiddleware().Handler)
+	r.Use(middleware.Recoverer)
+	r.Use(corsMiddleware.Handler)
+
+	// 管理 API（/api/admin/*）の認証
+	if cfg.AdminAPIToken == "" {
+		logger.Info("ADMIN_API_TOKEN is not set; admin API is disabled")
+	}
+	r.Use(appMiddleware.NewAdminAuthMiddleware(cfg.AdminAPIToken).Handler)
+
+	// Idempotency-Key によるコマンドの重複実行防止
+	idempotencyMiddleware := appMiddleware.NewIdempotencyMiddleware(idempotencyRepository, cfg.IdempotencyKeyTTL)
+	r.Use(idempotencyMiddleware.Handler)
+	go idempotencyMiddleware.RunPurge(ctx, time.Hour)
+
+	// Prometheus metrics (not routed through nginx; scrape the backend directly)
+	r.Method(http.MethodGet, "/metrics", metrics.Handler(appMetrics.Registry))
 
 	// Register WebSocket endpoint
 	r.Get("/ws", wsHandler.ServeWS)
+	// Same events over Server-Sent Events (for curl-based monitors and dashboards)
+	r.Get("/api/events/stream", sseHandler.ServeSSE)
+
+	// Twitch EventSub（チャンネルポイント・サブスク）の通知の受け口（シークレットが空なら無効）
+	if cfg.TwitchEventSubSecret == "" {
+		logger.Info("TWITCH_EVENTSUB_SECRET is not set; Twitch EventSub is disabled")
+	} else {
+		r.Post("/api/twitch/eventsub", eventsub.NewHandler(cfg.TwitchEventSubSecret, twitchService).ServeHTTP)
+	}
 
 	// Register OpenAPI-generated routes
 	handlerFunc := dto.HandlerFromMux(unifiedHandler, r)
 
 	// Create HTTP server
 	server := &http.Server{
-		Addr:         cfg.ServerPort,
+		Addr:         cfg.ServerAddr(),
 		Handler:      handlerFunc,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
+		IdleTimeout:  cfg.HTTP.IdleTimeout,
 	}
+	// SSE のストリームは終わらないので、Shutdown が待たないよう先に閉じる
+	server.RegisterOnShutdown(sseHandler.Shutdown)
 
 	// Start server in goroutine
 	go func() {
-		log.Printf("🚀 Server listening on %s", cfg.ServerPort)
+		logger.Info("server listening", "addr", cfg.ServerAddr())
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server failed: %v", err)
+			fatal("server failed", err)
 		}
 	}()
 
@@ -122,15 +290,38 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	logger.Info("shutting down server")
 
 	// Graceful shutdown
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 	defer shutdownCancel()
 
+	// 1. Stop accepting requests and wait for in-flight commands
 	if err := server.Shutdown(shutdownCtx); err != nil {
-		log.Printf("Server forced to shutdown: %v", err)
+		logger.Error("server forced to shutdown", logging.Err(err))
+	}
+	// 2. Stop expiration timers before the pool closes (the next instance restores them)
+	if err := expirationManager.Stop(shutdownCtx); err != nil {
+		logger.Error("session expiration timers did not stop", logging.Err(err))
 	}
+	// 3. Stop the background workers; events the dispatcher has not picked up stay in the outbox
+	cancel()
+	select {
+	case <-dispatcherDone:
+	case <-shutdownCtx.Done():
+		logger.Error("outbox dispatcher did not stop", logging.Err(shutdownCtx.Err()))
+	}
+	// 4. Drain the events already handed to the sinks, then close WebSocket clients with 1001
+	eventFanOut.Close()
+	if err := wsHub.Stop(shutdownCtx); err != nil {
+		logger.Error("websocket hub did not stop cleanly", logging.Err(err))
+	}
+
+	logger.Info("server exited gracefully")
+}
 
-	log.Println("✅ Server exited gracefully")
+// fatal エラーをログに書いて終了する
+func fatal(msg string, err error) {
+	slog.Error(msg, logging.Err(err))
+	os.Exit(1)
 }
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/presentation/ws"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+	"github.com/yamada-ai/workspace-backend/usecase/session"
+)
+
+// activeSessionsTimeout 作業中のセッション数を数えるクエリの上限時間
+const activeSessionsTimeout = 2 * time.Second
+
+// registerRuntimeMetrics 各コンポーネントが持っている数を /metrics で読めるようにする（値はスクレイプのたびに取得する）
+func registerRuntimeMetrics(
+	reg prometheus.Registerer,
+	sessionRepository repository.SessionRepository,
+	hub *ws.Hub,
+	fanOut *command.FanOutBroadcaster,
+	expirationManager *session.SessionExpirationManager,
+	pool *pgxpool.Pool,
+) {
+	gauge := func(name, help string, read func() float64) {
+		reg.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help}, read))
+	}
+	counter := func(name, help string, read func() float64) {
+		reg.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{Name: name, Help: help}, read))
+	}
+
+	// Sessions
+	reg.MustRegister(&activeSessionsCollector{
+		desc:  prometheus.NewDesc("workspace_active_sessions", "Sessions that have not ended.", nil, nil),
+		count: sessionRepository.CountActive,
+	})
+	gauge("workspace_session_expiration_timers", "Pending automatic session expiration timers.",
+		func() float64 { return float64(expirationManager.PendingTimers()) })
+
+	// WebSocket hub
+	gauge("workspace_ws_clients", "Connected WebSocket clients.",
+		func() float64 { return float64(hub.Stats().Clients) })
+	gauge("workspace_ws_queue_depth", "Events waiting to be sent by the WebSocket hub.",
+		func() float64 { return float64(hub.Stats().QueueDepth) })
+	counter("workspace_ws_dropped_events_total", "Events dropped because the WebSocket hub was not keeping up.",
+		func() float64 { return float64(hub.Stats().DroppedEvents) })
+	counter("workspace_ws_dropped_messages_total", "Messages dropped for clients whose send buffer was full.",
+		func() float64 { return float64(hub.Stats().DroppedMessages) })
+	counter("workspace_ws_evicted_clients_total", "Clients disconnected because their send buffer was full.",
+		func() float64 { return float64(hub.Stats().EvictedClients) })
+
+	// Event fan-out (one queue per consumer)
+	reg.MustRegister(&sinkCollector{
+		fanOut: fanOut,
+		queued: prometheus.NewDesc("workspace_event_sink_queue_depth",
+			"Events waiting in the queue of each event consumer.", []string{"sink"}, nil),
+		dropped: prometheus.NewDesc("workspace_event_sink_dropped_total",
+			"Events dropped because the queue of the consumer was full.", []string{"sink"}, nil),
+	})
+
+	// Database connection pool
+	gauge("workspace_db_pool_acquired_conns", "Connections currently in use.",
+		func() float64 { return float64(pool.Stat().AcquiredConns()) })
+	gauge("workspace_db_pool_idle_conns", "Idle connections in the pool.",
+		func() float64 { return float64(pool.Stat().IdleConns()) })
+	gauge("workspace_db_pool_total_conns", "Open connections in the pool.",
+		func() float64 { return float64(pool.Stat().TotalConns()) })
+	gauge("workspace_db_pool_max_conns", "Maximum size of the pool.",
+		func() float64 { return float64(pool.Stat().MaxConns()) })
+	counter("workspace_db_pool_acquires_total", "Successful connection acquires.",
+		func() float64 { return float64(pool.Stat().AcquireCount()) })
+	counter("workspace_db_pool_empty_acquires_total", "Acquires that had to wait for a connection.",
+		func() float64 { return float64(pool.Stat().EmptyAcquireCount()) })
+	counter("workspace_db_pool_canceled_acquires_total", "Acquires canceled by their context.",
+		func() float64 { return float64(pool.Stat().CanceledAcquireCount()) })
+	counter("workspace_db_pool_acquire_duration_seconds_total", "Total time spent acquiring connections.",
+		func() float64 { return pool.Stat().AcquireDuration().Seconds() })
+}
+
+// activeSessionsCollector 作業中のセッション数を COUNT(*) で数える
+// 数えられなかったときはそのスクレイプでは省略する
+type activeSessionsCollector struct {
+	desc  *prometheus.Desc
+	count func(ctx context.Context) (int64, error)
+}
+
+func (c *activeSessionsCollector) Describe(ch chan<- *prometheus.Desc) { ch <- c.desc }
+
+func (c *activeSessionsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), activeSessionsTimeout)
+	defer cancel()
+	n, err := c.count(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(n))
+}
+
+// sinkCollector イベントの配信先ごとのキューの状態を sink ラベル付きで公開する
+type sinkCollector struct {
+	fanOut  *command.FanOutBroadcaster
+	queued  *prometheus.Desc
+	dropped *prometheus.Desc
+}
+
+func (c *sinkCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.queued
+	ch <- c.dropped
+}
+
+func (c *sinkCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, s := range c.fanOut.Stats() {
+		ch <- prometheus.MustNewConstMetric(c.queued, prometheus.GaugeValue, float64(s.Queued), s.Name)
+		ch <- prometheus.MustNewConstMetric(c.dropped, prometheus.CounterValue, float64(s.Dropped), s.Name)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"maps"
+
+	"github.com/yamada-ai/workspace-backend/infrastructure/config"
+	"github.com/yamada-ai/workspace-backend/presentation/twitchirc"
+	"github.com/yamada-ai/workspace-backend/presentation/ws"
+	"github.com/yamada-ai/workspace-backend/usecase/ratelimit"
+)
+
+// hubOptions 設定値から WebSocket Hub のオプションを作る
+func hubOptions(cfg config.WSHubConfig) (ws.HubOptions, error) {
+	policy, err := ws.ParseSlowClientPolicy(cfg.SlowClientPolicy)
+	if err != nil {
+		return ws.HubOptions{}, err
+	}
+	return ws.HubOptions{
+		BroadcastQueueSize: cfg.BroadcastQueueSize,
+		ClientBufferSize:   cfg.ClientBufferSize,
+		SlowClientPolicy:   policy,
+	}, nil
+}
+
+// rateLimitPolicy 設定値からレート制限のポリシーを作る
+func rateLimitPolicy(cfg config.RateLimitConfig) ratelimit.Policy {
+	return ratelimit.Policy{
+		PerUser: maps.Clone(cfg.PerUser),
+		Global:  cfg.Global,
+	}
+}
+
+// twitchIRCConfig 設定値から Twitch チャットの接続設定を作る
+func twitchIRCConfig(cfg config.TwitchIRCConfig) twitchirc.Config {
+	return twitchirc.Config{
+		Addr:      cfg.Addr,
+		Nick:      cfg.Nick,
+		Token:     cfg.Token,
+		Channels:  cfg.Channels,
+		SendLimit: cfg.SendLimit,
+	}
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database"
+	infraRepo "github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+	"github.com/yamada-ai/workspace-backend/usecase/outbox"
+	"github.com/yamada-ai/workspace-backend/usecase/query"
+	"github.com/yamada-ai/workspace-backend/usecase/session"
+)
+
+// commandFunc サブコマンド（args はサブコマンド名より後の引数）
+type commandFunc func(ctx context.Context, a *app, args []string) error
+
+var commands = map[string]commandFunc{
+	"sessions":  runSessions,
+	"force-out": runForceOut,
+	"tier":      runTier,
+	"points":    runPoints,
+	"migrate":   runMigrate,
+	"export":    runExport,
+	"import":    runImport,
+}
+
+// parseFlags サブコマンドのフラグを解釈する（フラグは引数より前に書く）
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		return usageError("%s: %v", fs.Name(), err)
+	}
+	return nil
+}
+
+func runSessions(ctx context.Context, a *app, args []string) error {
+	if len(args) != 0 {
+		return usageError("sessions takes no arguments")
+	}
+	pool, err := a.openPool(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	uc := query.NewGetActiveSessionsUseCase(infraRepo.NewSessionRepository(sqlc.New(pool)))
+	out, err := uc.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	rows := make([][]string, 0, len(out.Sessions))
+	for _, s := range out.Sessions {
+		rows = append(rows, []string{
+			strconv.FormatInt(s.SessionID, 10),
+			s.UserName,
+			strconv.Itoa(s.Tier),
+			s.WorkName,
+			formatTime(s.StartTime),
+			formatTime(s.PlannedEnd),
+			max(s.PlannedEnd.Sub(now), 0).Truncate(time.Second).String(),
+		})
+	}
+	return a.print(result{
+		value:  out,
+		header: []string{"ID", "USER", "TIER", "WORK", "STARTED", "PLANNED END", "REMAINING"},
+		rows:   rows,
+	})
+}
+
+func runForceOut(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("force-out", flag.ContinueOnError)
+	reason := fs.String("reason", "", "reason recorded in the audit log")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("force-out takes a session ID")
+	}
+	sessionID, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+	if err != nil {
+		return usageError("invalid session ID %q", fs.Arg(0))
+	}
+
+	pool, err := a.openPool(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	queries := sqlc.New(pool)
+	userRepository := infraRepo.NewUserRepositoryWithPool(pool)
+	sessionRepository := infraRepo.NewSessionRepository(queries)
+	// session_end はアウトボックスに記録し、実行中のサーバーが次のポーリングで配信する
+	// 期限切れタイマーはサーバー側にあり、終了済みのセッションは期限が来ても何もしない
+	eventOutbox := outbox.NewRecorder(infraRepo.NewOutboxRepository(queries), nil)
+	completeService := session.NewCompleteSessionService(userRepository, sessionRepository, eventOutbox)
+	uc := command.NewForceOutCommandUseCase(sessionRepository, infraRepo.NewAuditLogRepository(queries), completeService, command.NoOpExpirationCanceller{})
+
+	out, err := uc.Execute(ctx, command.ForceOutCommandInput{SessionID: sessionID, Actor: a.actor, Reason: *reason})
+	if err != nil {
+		return err
+	}
+	return a.print(result{
+		value: struct {
+			SessionID int64     `json:"session_id"`
+			UserID    int64     `json:"user_id"`
+			ActualEnd time.Time `json:"actual_end"`
+		}{out.SessionID, out.UserID, out.ActualEnd},
+		header: []string{"ID", "USER ID", "ENDED"},
+		rows:   [][]string{{strconv.FormatInt(out.SessionID, 10), strconv.FormatInt(out.UserID, 10), formatTime(out.ActualEnd)}},
+	})
+}
+
+func runTier(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("tier", flag.ContinueOnError)
+	reason := fs.String("reason", "", "reason recorded in the audit log")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return usageError("tier takes a user name and a tier")
+	}
+	tier, err := domain.ParseTier(fs.Arg(1))
+	if err != nil {
+		return usageError("%v", err)
+	}
+
+	pool, err := a.openPool(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	uc := newUserAdjustmentUseCase(pool)
+	user, err := uc.ChangeTier(ctx, command.ChangeTierInput{UserName: fs.Arg(0), Tier: tier, Actor: a.actor, Reason: *reason})
+	if err != nil {
+		return err
+	}
+	return a.print(result{
+		value: struct {
+			UserID   int64  `json:"user_id"`
+			UserName string `json:"user_name"`
+			Tier     int    `json:"tier"`
+		}{user.ID, user.Name, user.Tier.Int()},
+		header: []string{"USER ID", "USER", "TIER"},
+		rows:   [][]string{{strconv.FormatInt(user.ID, 10), user.Name, strconv.Itoa(user.Tier.Int())}},
+	})
+}
+
+func runPoints(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 || (args[0] != "grant" && args[0] != "revoke") {
+		return usageError("points takes grant or revoke")
+	}
+	fs := flag.NewFlagSet("points "+args[0], flag.ContinueOnError)
+	reference := fs.String("reference", "", "ticket or request ID; the same reference is applied only once")
+	reason := fs.String("reason", "", "reason recorded in the audit log")
+	if err := parseFlags(fs, args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return usageError("points %s takes a user name and an amount", args[0])
+	}
+	amount, err := strconv.ParseInt(fs.Arg(1), 10, 64)
+	if err != nil || amount <= 0 {
+		return usageError("amount must be a positive integer (got %q)", fs.Arg(1))
+	}
+	delta := amount
+	if args[0] == "revoke" {
+		delta = -amount
+	}
+
+	pool, err := a.openPool(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	uc := newUserAdjustmentUseCase(pool)
+	out, err := uc.AdjustPoints(ctx, command.AdjustPointsInput{
+		UserName:  fs.Arg(0),
+		Delta:     delta,
+		Reference: *reference,
+		Actor:     a.actor,
+		Reason:    *reason,
+	})
+	if err != nil {
+		return err
+	}
+	return a.print(result{
+		value: struct {
+			EntryID int64 `json:"entry_id"`
+			UserID  int64 `json:"user_id"`
+			Delta   int64 `json:"delta"`
+			Balance int64 `json:"balance"`
+		}{out.Entry.ID, out.Entry.UserID, out.Entry.Delta, out.Balance},
+		header: []string{"ENTRY ID", "USER ID", "DELTA", "BALANCE"},
+		rows: [][]string{{
+			strconv.FormatInt(out.Entry.ID, 10),
+			strconv.FormatInt(out.Entry.UserID, 10),
+			strconv.FormatInt(out.Entry.Delta, 10),
+			strconv.FormatInt(out.Balance, 10),
+		}},
+	})
+}
+
+func newUserAdjustmentUseCase(pool *pgxpool.Pool) *command.UserAdjustmentUseCase {
+	queries := sqlc.New(pool)
+	return command.NewUserAdjustmentUseCase(
+		infraRepo.NewUserRepositoryWithPool(pool),
+		infraRepo.NewPointLedgerRepository(queries),
+		infraRepo.NewAuditLogRepository(queries),
+	)
+}
+
+func runMigrate(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return usageError("migrate takes up, down, to or version")
+	}
+	url, path := a.cfg.DatabaseURL, a.migrationsPath
+
+	var err error
+	switch sub, rest := args[0], args[1:]; {
+	case sub == "up" && len(rest) == 0:
+		err = database.RunMigrations(url, path)
+	case sub == "down" && len(rest) <= 1:
+		steps := 1
+		if len(rest) == 1 {
+			if steps, err = strconv.Atoi(rest[0]); err != nil || steps < 1 {
+				return usageError("migrate down takes a positive number of steps (got %q)", rest[0])
+			}
+		}
+		err = database.RollbackMigrations(url, path, steps)
+	case sub == "to" && len(rest) == 1:
+		version, parseErr := strconv.ParseUint(rest[0], 10, 64)
+		if parseErr != nil {
+			return usageError("invalid migration version %q", rest[0])
+		}
+		err = database.MigrateTo(url, path, uint(version))
+	case sub == "version" && len(rest) == 0:
+	default:
+		return usageError("migrate takes up, down [n], to <version> or version")
+	}
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := database.MigrationVersion(url, path)
+	if err != nil {
+		return err
+	}
+	return a.print(result{
+		value: struct {
+			Version uint `json:"version"`
+			Dirty   bool `json:"dirty"`
+		}{version, dirty},
+		header: []string{"VERSION", "DIRTY"},
+		rows:   [][]string{{strconv.FormatUint(uint64(version), 10), strconv.FormatBool(dirty)}},
+	})
+}
+
+func runExport(ctx context.Context, a *app, args []string) (err error) {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	file := fs.String("file", "", "output file (stdout when empty)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("export takes no arguments")
+	}
+
+	pool, err := a.openPool(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	dump, err := database.Export(ctx, pool)
+	if err != nil {
+		return err
+	}
+
+	// 標準出力に書く場合は、出力をそのままインポートできるようダンプだけを書く
+	if *file == "" {
+		return json.NewEncoder(a.stdout).Encode(dump)
+	}
+	f, err := os.Create(*file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	if err := json.NewEncoder(f).Encode(dump); err != nil {
+		return err
+	}
+
+	counts := make(map[string]int, len(dump.Tables))
+	for _, t := range dump.Tables {
+		counts[t.Table] = len(t.Rows)
+	}
+	return a.printCounts(dump, counts)
+}
+
+func runImport(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := fs.String("file", "", "input file (stdin when empty)")
+	yes := fs.Bool("yes", false, "confirm that the current data is replaced")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageError("import takes no arguments")
+	}
+	if !*yes {
+		return usageError("import replaces the users, sessions, points and settings in the database; pass -yes to confirm")
+	}
+
+	in := a.stdin
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	var dump database.Dump
+	if err := json.NewDecoder(in).Decode(&dump); err != nil {
+		return fmt.Errorf("failed to read the export: %w", err)
+	}
+
+	pool, err := a.openPool(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	counts, err := database.Import(ctx, pool, &dump)
+	if err != nil {
+		return err
+	}
+	return a.printCounts(&dump, counts)
+}
+
+// printCounts テーブルごとの行数をダンプの順に表示する
+func (a *app) printCounts(dump *database.Dump, counts map[string]int) error {
+	rows := make([][]string, 0, len(counts))
+	for _, t := range dump.Tables {
+		rows = append(rows, []string{t.Table, strconv.Itoa(counts[t.Table])})
+	}
+	return a.print(result{
+		value:  counts,
+		header: []string{"TABLE", "ROWS"},
+		rows:   rows,
+	})
+}
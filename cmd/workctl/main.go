@@ -0,0 +1,151 @@
+// Command workctl は運用作業（セッションの強制終了、ティア・ポイントの修正、マイグレーション、データの移行）を行う管理 CLI
+//
+// サーバーと同じ設定（-config・環境変数・フラグ）でデータベースに接続し、サーバーと同じユースケースを使う。
+// 変更は監査ログに記録され、セッションの終了は実行中のサーバーがアウトボックスから配信する。
+//
+//	go run ./cmd/workctl sessions
+//	go run ./cmd/workctl -output json force-out -reason "放置" 123
+//	go run ./cmd/workctl tier yamada 3
+//	go run ./cmd/workctl points grant -reference support-12 yamada 500
+//	go run ./cmd/workctl migrate down 1
+//	go run ./cmd/workctl export -file backup.json
+//	go run ./cmd/workctl import -file backup.json -yes
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"os/user"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/yamada-ai/workspace-backend/infrastructure/config"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+)
+
+// usageText サブコマンドの一覧（フラグは -h で表示する）
+const usageText = `usage: workctl [flags] <command> [args]
+
+commands:
+  sessions                                   list active sessions
+  force-out [-reason r] <session-id>         end a session (the overlay receives session_end)
+  tier [-reason r] <user> <1|2|3>            change a user's tier
+  points grant|revoke [-reference ref] [-reason r] <user> <amount>
+                                             credit or debit Raziiipo
+  migrate up | down [n] | to <version> | version
+                                             apply, roll back or inspect migrations
+  export [-file path]                        write the data as JSON (stdout by default)
+  import [-file path] -yes                   replace the data with an export (stop the server first)
+`
+
+// errUsage 引数の誤り（使い方を表示して終了コード 2 で終了する）
+var errUsage = errors.New("invalid arguments")
+
+// usageError 引数の誤りの理由
+func usageError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// app サブコマンドが共有する設定と出力先
+type app struct {
+	cfg            *config.Config
+	migrationsPath string
+	actor          string
+	output         outputFormat
+	stdin          io.Reader
+	stdout         io.Writer
+}
+
+// run 引数を解釈してサブコマンドを実行し、終了コードを返す
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("workctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configFlags := config.BindFlags(fs)
+	output := fs.String("output", string(outputTable), "output format: table or json")
+	actor := fs.String("actor", defaultActor(), "name recorded in the audit log")
+	migrationsPath := fs.String("migrations", "migrations", "migrations directory")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usageText+"\nflags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		if fs.NArg() > 0 {
+			fmt.Fprintf(stderr, "workctl: unknown command %q\n", fs.Arg(0))
+		}
+		fs.Usage()
+		return 2
+	}
+	format, err := parseOutputFormat(*output)
+	if err != nil {
+		fmt.Fprintf(stderr, "workctl: %v\n", err)
+		return 2
+	}
+
+	cfg, err := config.Load(configFlags)
+	if err != nil {
+		fmt.Fprintf(stderr, "workctl: failed to load configuration: %v\n", err)
+		return 1
+	}
+	// ログは標準エラーに出し、標準出力は結果だけにする
+	logger := logging.New(stderr, cfg.LogFormat, cfg.LogLevel)
+	slog.SetDefault(logger)
+	ctx = logging.WithLogger(ctx, logger)
+
+	a := &app{
+		cfg:            cfg,
+		migrationsPath: *migrationsPath,
+		actor:          *actor,
+		output:         format,
+		stdin:          stdin,
+		stdout:         stdout,
+	}
+	if err := cmd(ctx, a, fs.Args()[1:]); err != nil {
+		fmt.Fprintf(stderr, "workctl: %v\n", err)
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(stderr, usageText)
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
+
+// openPool サーバーと同じ接続プールの設定でデータベースに接続する
+func (a *app) openPool(ctx context.Context) (*pgxpool.Pool, error) {
+	pool, err := database.NewPool(ctx, a.cfg.DatabaseURL, a.cfg.DatabasePool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return pool, nil
+}
+
+// defaultActor 監査ログに記録する既定の実行者（workctl:<OS のユーザー名>）
+func defaultActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return "workctl:" + u.Username
+	}
+	return "workctl"
+}
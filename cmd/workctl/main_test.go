@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRun_RejectsInvalidArguments(t *testing.T) {
+	// 引数の誤りはデータベースに接続する前に報告する（接続できない URL でも終了コード 2）
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("DATABASE_URL", "postgres://workctl@127.0.0.1:1/none")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"コマンドなし", nil, "usage: workctl"},
+		{"未知のコマンド", []string{"reboot"}, `unknown command "reboot"`},
+		{"未知の表示形式", []string{"-output", "xml", "sessions"}, `unknown output format "xml"`},
+		{"grant / revoke 以外", []string{"points", "give", "yamada", "10"}, "points takes grant or revoke"},
+		{"負のポイント", []string{"points", "revoke", "yamada", "-10"}, "amount must be a positive integer"},
+		{"不正なティア", []string{"tier", "yamada", "4"}, "invalid tier: 4"},
+		{"不正なセッション ID", []string{"force-out", "abc"}, `invalid session ID "abc"`},
+		{"未知のフラグ", []string{"force-out", "-force", "1"}, "flag provided but not defined: -force"},
+		{"migrate の誤り", []string{"migrate", "sideways"}, "migrate takes up, down [n], to <version> or version"},
+		{"確認なしのインポート", []string{"import", "-file", "backup.json"}, "pass -yes to confirm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(context.Background(), tt.args, strings.NewReader(""), &stdout, &stderr)
+			if code != 2 {
+				t.Errorf("expected exit code 2, got %d", code)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("expected %q in:\n%s", tt.want, stderr.String())
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("expected nothing on stdout, got %q", stdout.String())
+			}
+		})
+	}
+}
+
+func TestApp_Print(t *testing.T) {
+	r := result{
+		value:  map[string]int{"users": 2},
+		header: []string{"TABLE", "ROWS"},
+		rows:   [][]string{{"users", "2"}, {"runtime_settings", "0"}},
+	}
+
+	var table bytes.Buffer
+	if err := (&app{output: outputTable, stdout: &table}).print(r); err != nil {
+		t.Fatal(err)
+	}
+	want := "TABLE             ROWS\nusers             2\nruntime_settings  0\n"
+	if table.String() != want {
+		t.Errorf("unexpected table:\n%s", table.String())
+	}
+
+	var js bytes.Buffer
+	if err := (&app{output: outputJSON, stdout: &js}).print(r); err != nil {
+		t.Fatal(err)
+	}
+	if js.String() != "{\n  \"users\": 2\n}\n" {
+		t.Errorf("unexpected JSON: %s", js.String())
+	}
+}
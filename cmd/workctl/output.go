@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// outputFormat 結果の表示形式
+type outputFormat string
+
+const (
+	outputTable outputFormat = "table"
+	outputJSON  outputFormat = "json"
+)
+
+func parseOutputFormat(s string) (outputFormat, error) {
+	switch f := outputFormat(strings.ToLower(strings.TrimSpace(s))); f {
+	case outputTable, outputJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (want table or json)", s)
+	}
+}
+
+// result コマンドの結果（JSON では value を、表では header と rows を表示する）
+type result struct {
+	value  any
+	header []string
+	rows   [][]string
+}
+
+// print 結果を a.output の形式で標準出力に書く
+func (a *app) print(r result) error {
+	if a.output == outputJSON {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r.value)
+	}
+	return writeTable(a.stdout, r.header, r.rows)
+}
+
+func writeTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// formatTime 表に出す時刻（秒まで、UTC）
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.DateTime)
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+const generatedHeader = "Code generated by wsgen from shared/ws/events.yaml. DO NOT EDIT."
+
+// GenerateGo イベントの構造体・種別の定数・マーカーメソッドを生成する
+// BaseEvent・Event インターフェース・変換関数は presentation/ws/events.go に手書きする
+func GenerateGo(schema *Schema, pkg string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n\npackage %s\n\n", generatedHeader, pkg)
+	if schema.usesTime() {
+		b.WriteString("import \"time\"\n\n")
+	}
+
+	b.WriteString("// EventType represents the type of WebSocket event\ntype EventType string\n\n")
+	b.WriteString("const (\n")
+	for _, msg := range schema.Events {
+		fmt.Fprintf(&b, "\tEventType%s EventType = %q\n", camelCase(msg.Key), msg.Type)
+	}
+	b.WriteString(")\n\n")
+
+	if len(schema.ClientMessages) > 0 {
+		b.WriteString("// ClientMessageType クライアントから送られるメッセージの種別\ntype ClientMessageType string\n\n")
+		b.WriteString("const (\n")
+		for _, msg := range schema.ClientMessages {
+			fmt.Fprintf(&b, "\tClientMessage%s ClientMessageType = %q\n", camelCase(msg.Key), msg.Type)
+		}
+		b.WriteString(")\n\n")
+	}
+
+	for _, msg := range schema.Events {
+		name := eventName(msg)
+		writeGoComment(&b, name, msg.Description)
+		fmt.Fprintf(&b, "type %s struct {\n", name)
+		b.WriteString("\tType EventType `json:\"type\"`\n")
+		for _, f := range msg.Fields {
+			tag := f.Name
+			if f.Optional {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:%q`", camelCase(f.Name), f.goType(), tag)
+			if f.Description != "" {
+				fmt.Fprintf(&b, " // %s", f.Description)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("}\n\n")
+	}
+
+	for _, msg := range schema.Events {
+		fmt.Fprintf(&b, "func (%s) isEvent() {}\n", eventName(msg))
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated Go code: %w", err)
+	}
+	return src, nil
+}
+
+// GenerateTypeScript フロントエンド向けの型定義を生成する
+func GenerateTypeScript(schema *Schema) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n\n", generatedHeader)
+
+	if len(schema.Topics) > 0 {
+		b.WriteString("/** クライアントが購読できるトピック */\nexport type Topic =\n")
+		for i, t := range schema.Topics {
+			sep := ""
+			if i == len(schema.Topics)-1 {
+				sep = ";"
+			}
+			fmt.Fprintf(&b, "  | %s%s // %s\n", tsTopic(t.Name), sep, t.Description)
+		}
+		b.WriteString("\n")
+	}
+
+	writeTSInterfaces(&b, schema.Events, eventName)
+	writeTSUnion(&b, "ServerEvent", schema.Events, eventName)
+	b.WriteString("export type ServerEventType = ServerEvent[\"type\"];\n")
+
+	if len(schema.ClientMessages) > 0 {
+		b.WriteString("\n")
+		writeTSInterfaces(&b, schema.ClientMessages, messageName)
+		writeTSUnion(&b, "ClientMessage", schema.ClientMessages, messageName)
+		b.WriteString("export type ClientMessageType = ClientMessage[\"type\"];\n")
+	}
+	return b.Bytes()
+}
+
+// GenerateJSONSchema JSON Schema (draft 2020-12) を生成する
+// どのメッセージも宣言されていないプロパティを許可しない
+func GenerateJSONSchema(schema *Schema) ([]byte, error) {
+	defs := make(map[string]any)
+	eventRefs := make([]any, 0, len(schema.Events))
+	for _, msg := range schema.Events {
+		name := eventName(msg)
+		defs[name] = msg.jsonSchema()
+		eventRefs = append(eventRefs, map[string]any{"$ref": "#/$defs/" + name})
+	}
+	defs["ServerEvent"] = map[string]any{"oneOf": eventRefs}
+
+	if len(schema.ClientMessages) > 0 {
+		messageRefs := make([]any, 0, len(schema.ClientMessages))
+		for _, msg := range schema.ClientMessages {
+			name := messageName(msg)
+			defs[name] = msg.jsonSchema()
+			messageRefs = append(messageRefs, map[string]any{"$ref": "#/$defs/" + name})
+		}
+		defs["ClientMessage"] = map[string]any{"oneOf": messageRefs}
+	}
+
+	doc := map[string]any{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"$comment":    generatedHeader,
+		"title":       "WebSocket events",
+		"description": "サーバーからクライアントへ送信されるイベント",
+		"$ref":        "#/$defs/ServerEvent",
+		"$defs":       defs,
+	}
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+func (m Message) jsonSchema() map[string]any {
+	properties := map[string]any{
+		"type": map[string]any{"const": m.Type},
+	}
+	required := []string{"type"}
+	for _, f := range m.Fields {
+		properties[f.Name] = f.jsonSchema()
+		if !f.Optional {
+			required = append(required, f.Name)
+		}
+	}
+	return map[string]any{
+		"type":                 "object",
+		"description":          m.Description,
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func (f Field) jsonSchema() map[string]any {
+	var s map[string]any
+	switch f.Type {
+	case "integer":
+		s = map[string]any{"type": "integer", "format": "int64"}
+	case "string":
+		s = map[string]any{"type": "string"}
+		if f.Format == "ISO8601" {
+			s["format"] = "date-time"
+		}
+	case "array":
+		s = map[string]any{"type": "array", "items": map[string]any{"type": f.Items}}
+	default:
+		s = map[string]any{"type": f.Type}
+	}
+	if f.Description != "" {
+		s["description"] = f.Description
+	}
+	return s
+}
+
+func (f Field) goType() string {
+	var t string
+	switch f.Type {
+	case "integer":
+		t = "int64"
+	case "boolean":
+		t = "bool"
+	case "string":
+		t = "string"
+		if f.Format == "ISO8601" {
+			t = "time.Time"
+		}
+	case "array":
+		if f.Items == "integer" {
+			return "[]int64"
+		}
+		return "[]string"
+	}
+	if f.Optional {
+		return "*" + t
+	}
+	return t
+}
+
+func (f Field) tsType() string {
+	switch f.Type {
+	case "integer":
+		return "number"
+	case "array":
+		if f.Items == "integer" {
+			return "number[]"
+		}
+		return "string[]"
+	default:
+		return f.Type
+	}
+}
+
+func (s *Schema) usesTime() bool {
+	for _, msg := range s.Events {
+		for _, f := range msg.Fields {
+			if f.Type == "string" && f.Format == "ISO8601" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeTSInterfaces(b *bytes.Buffer, messages []Message, name func(Message) string) {
+	for _, msg := range messages {
+		if msg.Description != "" {
+			fmt.Fprintf(b, "/** %s */\n", msg.Description)
+		}
+		fmt.Fprintf(b, "export interface %s {\n", name(msg))
+		fmt.Fprintf(b, "  type: %q;\n", msg.Type)
+		for _, f := range msg.Fields {
+			if f.Description != "" {
+				fmt.Fprintf(b, "  /** %s */\n", f.Description)
+			}
+			optional := ""
+			if f.Optional {
+				optional = "?"
+			}
+			comment := ""
+			if f.Format == "ISO8601" {
+				comment = " // ISO8601"
+			}
+			fmt.Fprintf(b, "  %s%s: %s;%s\n", f.Name, optional, f.tsType(), comment)
+		}
+		b.WriteString("}\n\n")
+	}
+}
+
+func writeTSUnion(b *bytes.Buffer, union string, messages []Message, name func(Message) string) {
+	fmt.Fprintf(b, "export type %s =\n", union)
+	for i, msg := range messages {
+		sep := ""
+		if i == len(messages)-1 {
+			sep = ";"
+		}
+		fmt.Fprintf(b, "  | %s%s\n", name(msg), sep)
+	}
+	b.WriteString("\n")
+}
+
+// tsTopic "user:<user_id>" のようなプレースホルダーをテンプレートリテラル型にする
+func tsTopic(name string) string {
+	start := strings.Index(name, "<")
+	end := strings.Index(name, ">")
+	if start < 0 || end < start {
+		return fmt.Sprintf("%q", name)
+	}
+	return "`" + name[:start] + "${number}" + name[end+1:] + "`"
+}
+
+func writeGoComment(b *bytes.Buffer, name, description string) {
+	if description == "" {
+		return
+	}
+	fmt.Fprintf(b, "// %s %s\n", name, description)
+}
+
+func eventName(msg Message) string {
+	return camelCase(msg.Key) + "Event"
+}
+
+func messageName(msg Message) string {
+	return camelCase(msg.Key) + "Message"
+}
+
+// camelCase snake_case を Go の識別子にする（id は ID にする）
+func camelCase(s string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(s, "_") {
+		if part == "" {
+			continue
+		}
+		if part == "id" {
+			b.WriteString("ID")
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
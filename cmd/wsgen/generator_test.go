@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerate_UpToDate コミットされた生成物が events.yaml と一致するか確認する
+// 失敗したら `go generate ./presentation/ws` を実行する
+func TestGenerate_UpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+	schema, err := LoadSchema(filepath.Join(root, "shared", "ws", "events.yaml"))
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+	outputs, err := Generate(schema, "ws")
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	for path, want := range map[string][]byte{
+		filepath.Join(root, "presentation", "ws", "events.gen.go"): outputs.Go,
+		filepath.Join(root, "shared", "ws", "events.gen.ts"):       outputs.TypeScript,
+		filepath.Join(root, "shared", "ws", "events.schema.json"):  outputs.JSONSchema,
+	} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date; run `go generate ./presentation/ws`", path)
+		}
+	}
+}
+
+func TestParseSchema_PreservesOrder(t *testing.T) {
+	schema, err := ParseSchema([]byte(`
+events:
+  b_event:
+    type: b_event
+    fields:
+      zeta: {type: string}
+      alpha: {type: integer, optional: true}
+  a_event:
+    type: a_event
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schema.Events) != 2 || schema.Events[0].Key != "b_event" || schema.Events[1].Key != "a_event" {
+		t.Fatalf("events are not in definition order: %+v", schema.Events)
+	}
+	fields := schema.Events[0].Fields
+	if len(fields) != 2 || fields[0].Name != "zeta" || fields[1].Name != "alpha" || !fields[1].Optional {
+		t.Fatalf("fields are not in definition order: %+v", fields)
+	}
+}
+
+func TestParseSchema_RejectsInvalidFields(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"no events", "topics: {}\n", "no events"},
+		{"missing type", "events:\n  x:\n    fields: {}\n", "type is required"},
+		{"unknown field type", "events:\n  x:\n    type: x\n    fields:\n      a: {type: float}\n", "unsupported type"},
+		{"unknown format", "events:\n  x:\n    type: x\n    fields:\n      a: {type: string, format: uuid}\n", "unsupported string format"},
+		{"reserved type field", "events:\n  x:\n    type: x\n    fields:\n      type: {type: string}\n", "must not be declared"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSchema([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"session_start":   "SessionStart",
+		"event_id":        "EventID",
+		"id":              "ID",
+		"new_planned_end": "NewPlannedEnd",
+	}
+	for in, want := range tests {
+		if got := camelCase(in); got != want {
+			t.Errorf("camelCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
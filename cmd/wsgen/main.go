@@ -0,0 +1,66 @@
+// Command wsgen は shared/ws/events.yaml から WebSocket イベントの型を生成する
+//
+// 出力:
+//   - Go の構造体・定数・マーカーメソッド（presentation/ws/events.gen.go）
+//   - TypeScript の型定義（shared/ws/events.gen.ts）
+//   - JSON Schema（shared/ws/events.schema.json）
+//
+// 使い方（リポジトリのルートで実行）:
+//
+//	go run ./cmd/wsgen
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+)
+
+func main() {
+	in := flag.String("in", "shared/ws/events.yaml", "event schema (YAML)")
+	goOut := flag.String("go", "presentation/ws/events.gen.go", "output path of the Go code")
+	goPkg := flag.String("package", "ws", "package name of the Go code")
+	tsOut := flag.String("ts", "shared/ws/events.gen.ts", "output path of the TypeScript definitions")
+	jsonOut := flag.String("jsonschema", "shared/ws/events.schema.json", "output path of the JSON Schema")
+	flag.Parse()
+
+	schema, err := LoadSchema(*in)
+	if err != nil {
+		log.Fatalf("wsgen: %s: %v", *in, err)
+	}
+
+	outputs, err := Generate(schema, *goPkg)
+	if err != nil {
+		log.Fatalf("wsgen: %v", err)
+	}
+
+	for path, data := range map[string][]byte{
+		*goOut:   outputs.Go,
+		*tsOut:   outputs.TypeScript,
+		*jsonOut: outputs.JSONSchema,
+	} {
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			log.Fatalf("wsgen: %v", err)
+		}
+	}
+}
+
+// Outputs 生成したファイルの内容
+type Outputs struct {
+	Go         []byte
+	TypeScript []byte
+	JSONSchema []byte
+}
+
+// Generate すべての出力を生成する
+func Generate(schema *Schema, pkg string) (*Outputs, error) {
+	goSrc, err := GenerateGo(schema, pkg)
+	if err != nil {
+		return nil, err
+	}
+	jsonSchema, err := GenerateJSONSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	return &Outputs{Go: goSrc, TypeScript: GenerateTypeScript(schema), JSONSchema: jsonSchema}, nil
+}
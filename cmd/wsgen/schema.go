@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema shared/ws/events.yaml の内容（定義順を保持する）
+type Schema struct {
+	Events         []Message
+	Topics         []Topic
+	ClientMessages []Message
+}
+
+// Message サーバー→クライアントのイベント、またはクライアント→サーバーのメッセージ
+type Message struct {
+	Key         string
+	Description string
+	Type        string
+	Topics      []string
+	Fields      []Field
+}
+
+// Field メッセージのフィールド
+type Field struct {
+	Name        string
+	Type        string // integer, string, boolean, array
+	Format      string // ISO8601 なら日時
+	Items       string // array の要素の型
+	Description string
+	Optional    bool
+}
+
+// Topic 購読できるトピック
+type Topic struct {
+	Name        string
+	Description string
+}
+
+type rawSchema struct {
+	Events         yaml.Node `yaml:"events"`
+	Topics         yaml.Node `yaml:"topics"`
+	ClientMessages yaml.Node `yaml:"client_messages"`
+}
+
+type rawMessage struct {
+	Description string    `yaml:"description"`
+	Type        string    `yaml:"type"`
+	Topics      []string  `yaml:"topics"`
+	Fields      yaml.Node `yaml:"fields"`
+}
+
+type rawField struct {
+	Type        string `yaml:"type"`
+	Format      string `yaml:"format"`
+	Items       string `yaml:"items"`
+	Description string `yaml:"description"`
+	Optional    bool   `yaml:"optional"`
+}
+
+// LoadSchema YAML を読み込んで検証する
+func LoadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSchema(data)
+}
+
+// ParseSchema YAML を解析して検証する
+func ParseSchema(data []byte) (*Schema, error) {
+	var raw rawSchema
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	events, err := parseMessages(&raw.Events)
+	if err != nil {
+		return nil, fmt.Errorf("events: %w", err)
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("events: no events defined")
+	}
+	clientMessages, err := parseMessages(&raw.ClientMessages)
+	if err != nil {
+		return nil, fmt.Errorf("client_messages: %w", err)
+	}
+
+	var topics []Topic
+	err = eachMapping(&raw.Topics, func(name string, node *yaml.Node) error {
+		var t struct {
+			Description string `yaml:"description"`
+		}
+		if err := node.Decode(&t); err != nil {
+			return err
+		}
+		topics = append(topics, Topic{Name: name, Description: t.Description})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("topics: %w", err)
+	}
+
+	return &Schema{Events: events, Topics: topics, ClientMessages: clientMessages}, nil
+}
+
+func parseMessages(node *yaml.Node) ([]Message, error) {
+	var messages []Message
+	err := eachMapping(node, func(key string, value *yaml.Node) error {
+		var raw rawMessage
+		if err := value.Decode(&raw); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if raw.Type == "" {
+			return fmt.Errorf("%s: type is required", key)
+		}
+
+		msg := Message{Key: key, Description: raw.Description, Type: raw.Type, Topics: raw.Topics}
+		err := eachMapping(&raw.Fields, func(name string, fieldNode *yaml.Node) error {
+			var f rawField
+			if err := fieldNode.Decode(&f); err != nil {
+				return err
+			}
+			field := Field{
+				Name:        name,
+				Type:        f.Type,
+				Format:      f.Format,
+				Items:       f.Items,
+				Description: f.Description,
+				Optional:    f.Optional,
+			}
+			if err := field.validate(); err != nil {
+				return fmt.Errorf("%s.%s: %w", key, name, err)
+			}
+			msg.Fields = append(msg.Fields, field)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		messages = append(messages, msg)
+		return nil
+	})
+	return messages, err
+}
+
+func (f Field) validate() error {
+	if f.Name == "type" {
+		return fmt.Errorf("type is added automatically and must not be declared")
+	}
+	switch f.Type {
+	case "integer", "boolean":
+	case "string":
+		if f.Format != "" && f.Format != "ISO8601" {
+			return fmt.Errorf("unsupported string format %q", f.Format)
+		}
+	case "array":
+		if f.Items != "string" && f.Items != "integer" {
+			return fmt.Errorf("unsupported array items %q", f.Items)
+		}
+	default:
+		return fmt.Errorf("unsupported type %q", f.Type)
+	}
+	return nil
+}
+
+// eachMapping マッピングの要素を定義順に処理する（ノードが空なら何もしない）
+func eachMapping(node *yaml.Node, fn func(key string, value *yaml.Node) error) error {
+	if node.Kind == 0 {
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if err := fn(node.Content[i].Value, node.Content[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
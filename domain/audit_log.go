@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyAuditActor  = errors.New("audit actor must not be empty")
+	ErrEmptyAuditAction = errors.New("audit action must not be empty")
+)
+
+// 監査ログのアクション
+const (
+	AuditActionBannedTermCreate      = "banned_term.create"
+	AuditActionBannedTermUpdate      = "banned_term.update"
+	AuditActionBannedTermDelete      = "banned_term.delete"
+	AuditActionUserBlock             = "user.block"
+	AuditActionUserUnblock           = "user.unblock"
+	AuditActionUserTierChange        = "user.tier_change"
+	AuditActionUserPointsAdjust      = "user.points_adjust"
+	AuditActionSessionForceOut       = "session.force_out"
+	AuditActionSessionCreate         = "session.create"
+	AuditActionSessionCorrect        = "session.correct"
+	AuditActionSessionDelete         = "session.delete"
+	AuditActionWebhookCreate         = "webhook.create"
+	AuditActionWebhookDelete         = "webhook.delete"
+	AuditActionWebhookRetry          = "webhook.retry"
+	AuditActionIconCommissionStart   = "icon_commission.start"
+	AuditActionIconCommissionDeliver = "icon_commission.deliver"
+	AuditActionIconCommissionRefund  = "icon_commission.refund"
+	AuditActionRuntimeSettingUpdate  = "runtime_setting.update"
+	AuditActionRuntimeSettingReset   = "runtime_setting.reset"
+)
+
+// 監査ログの対象種別
+const (
+	AuditTargetBannedTerm     = "banned_term"
+	AuditTargetUser           = "user"
+	AuditTargetSession        = "session"
+	AuditTargetWebhook        = "webhook"
+	AuditTargetIconCommission = "icon_commission"
+	AuditTargetRuntimeSetting = "runtime_setting"
+)
+
+// SystemActorModeration 自動モデレーションによる操作の実行者
+const SystemActorModeration = "system:moderation"
+
+// AuditLog 管理操作・自動処理の監査記録
+type AuditLog struct {
+	ID         int64
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     json.RawMessage // 変更前の状態（作成時は nil）
+	After      json.RawMessage // 変更後の状態（削除時は nil）
+	Reason     string
+	CreatedAt  time.Time
+}
+
+// NewAuditLog 監査ログを作成する（before/after は JSON に変換して保持する）
+func NewAuditLog(actor, action, targetType, targetID string, before, after any, reason string, now func() time.Time) (*AuditLog, error) {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return nil, ErrEmptyAuditActor
+	}
+	if strings.TrimSpace(action) == "" {
+		return nil, ErrEmptyAuditAction
+	}
+
+	beforeJSON, err := marshalAuditState(before)
+	if err != nil {
+		return nil, err
+	}
+	afterJSON, err := marshalAuditState(after)
+	if err != nil {
+		return nil, err
+	}
+
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+
+	return &AuditLog{
+		Actor:      actor,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		Reason:     strings.TrimSpace(reason),
+		CreatedAt:  t(),
+	}, nil
+}
+
+func marshalAuditState(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if raw, ok := v.(json.RawMessage); ok {
+		return raw, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	// nil ポインタは状態なしとして扱う
+	if string(b) == "null" {
+		return nil, nil
+	}
+	return b, nil
+}
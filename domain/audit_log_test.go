@@ -0,0 +1,42 @@
+package domain
+
+import "testing"
+
+func TestNewAuditLog(t *testing.T) {
+	type state struct {
+		Tier int `json:"tier"`
+	}
+
+	t.Run("状態を JSON で保持する", func(t *testing.T) {
+		log, err := NewAuditLog("moderator", AuditActionUserBlock, AuditTargetUser, "42", state{Tier: 1}, &state{Tier: 2}, " spam ", fixedNow)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(log.Before) != `{"tier":1}` || string(log.After) != `{"tier":2}` {
+			t.Errorf("unexpected states: before=%s after=%s", log.Before, log.After)
+		}
+		if log.Reason != "spam" || !log.CreatedAt.Equal(fixedNow()) {
+			t.Errorf("unexpected log: %+v", log)
+		}
+	})
+
+	t.Run("nil の状態は記録しない", func(t *testing.T) {
+		var missing *state
+		log, err := NewAuditLog("moderator", AuditActionBannedTermCreate, AuditTargetBannedTerm, "1", missing, nil, "", fixedNow)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if log.Before != nil || log.After != nil {
+			t.Errorf("expected nil states, got before=%s after=%s", log.Before, log.After)
+		}
+	})
+
+	t.Run("実行者とアクションは必須", func(t *testing.T) {
+		if _, err := NewAuditLog(" ", AuditActionUserBlock, AuditTargetUser, "1", nil, nil, "", fixedNow); err != ErrEmptyAuditActor {
+			t.Errorf("expected ErrEmptyAuditActor, got %v", err)
+		}
+		if _, err := NewAuditLog("moderator", "", AuditTargetUser, "1", nil, nil, "", fixedNow); err != ErrEmptyAuditAction {
+			t.Errorf("expected ErrEmptyAuditAction, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyBannedTerm        = errors.New("banned term must not be empty")
+	ErrBannedTermTooLong      = errors.New("banned term is too long")
+	ErrInvalidMatchType       = errors.New("invalid match type")
+	ErrInvalidModerationMode  = errors.New("invalid moderation action")
+	ErrInvalidBannedTermRegex = errors.New("invalid banned term regular expression")
+	ErrBannedTermNotFound     = errors.New("banned term not found")
+	ErrBannedTermExists       = errors.New("banned term already exists")
+	ErrBannedTermDetected     = errors.New("text contains a banned term")
+)
+
+// MaxBannedTermLength 禁止ワードの最大長
+const MaxBannedTermLength = 200
+
+// MatchType 禁止ワードの照合方法
+type MatchType string
+
+const (
+	MatchExact     MatchType = "exact"     // 正規化後の全体一致
+	MatchSubstring MatchType = "substring" // 正規化後の部分一致
+	MatchRegex     MatchType = "regex"     // 正規化後のテキストへの正規表現
+)
+
+// Valid 定義済みの照合方法かを確認する
+func (m MatchType) Valid() bool {
+	return m == MatchExact || m == MatchSubstring || m == MatchRegex
+}
+
+// ModerationAction 禁止ワードを検出したときの処理
+type ModerationAction string
+
+const (
+	ModerationReject ModerationAction = "reject" // コマンドを拒否する
+	ModerationMask   ModerationAction = "mask"   // 該当部分を伏せ字にして受け付ける
+)
+
+// Valid 定義済みの処理かを確認する
+func (a ModerationAction) Valid() bool {
+	return a == ModerationReject || a == ModerationMask
+}
+
+// BannedTerm 管理者が登録した禁止ワード
+type BannedTerm struct {
+	ID        int64
+	Term      string
+	MatchType MatchType
+	Action    ModerationAction
+	AutoBlock bool // 検出したユーザーを自動でブロックするか
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewBannedTerm 禁止ワードを作成する
+func NewBannedTerm(term string, matchType MatchType, action ModerationAction, autoBlock bool, createdBy string, now func() time.Time) (*BannedTerm, error) {
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	nowT := t()
+
+	b := &BannedTerm{
+		Term:      strings.TrimSpace(term),
+		MatchType: matchType,
+		Action:    action,
+		AutoBlock: autoBlock,
+		CreatedBy: strings.TrimSpace(createdBy),
+		CreatedAt: nowT,
+		UpdatedAt: nowT,
+	}
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Update 禁止ワードの内容を変更する
+func (b *BannedTerm) Update(term string, matchType MatchType, action ModerationAction, autoBlock bool, now func() time.Time) error {
+	updated := *b
+	updated.Term = strings.TrimSpace(term)
+	updated.MatchType = matchType
+	updated.Action = action
+	updated.AutoBlock = autoBlock
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	updated.UpdatedAt = t()
+	*b = updated
+	return nil
+}
+
+// Validate 禁止ワードの内容を検証する
+func (b *BannedTerm) Validate() error {
+	if b.Term == "" {
+		return ErrEmptyBannedTerm
+	}
+	if len([]rune(b.Term)) > MaxBannedTermLength {
+		return ErrBannedTermTooLong
+	}
+	if !b.MatchType.Valid() {
+		return ErrInvalidMatchType
+	}
+	if !b.Action.Valid() {
+		return ErrInvalidModerationMode
+	}
+	if b.MatchType == MatchRegex {
+		if _, err := regexp.Compile(b.Term); err != nil {
+			return ErrInvalidBannedTermRegex
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewBannedTerm(t *testing.T) {
+	tests := []struct {
+		name      string
+		term      string
+		matchType MatchType
+		action    ModerationAction
+		wantErr   error
+	}{
+		{name: "部分一致", term: " ばか ", matchType: MatchSubstring, action: ModerationMask},
+		{name: "完全一致", term: "spam", matchType: MatchExact, action: ModerationReject},
+		{name: "正規表現", term: `b+a+k+a+`, matchType: MatchRegex, action: ModerationReject},
+		{name: "空のワード", term: "  ", matchType: MatchExact, action: ModerationReject, wantErr: ErrEmptyBannedTerm},
+		{name: "長すぎるワード", term: string(make([]rune, MaxBannedTermLength+1)), matchType: MatchExact, action: ModerationReject, wantErr: ErrBannedTermTooLong},
+		{name: "不正な照合方法", term: "x", matchType: "prefix", action: ModerationReject, wantErr: ErrInvalidMatchType},
+		{name: "不正な処理", term: "x", matchType: MatchExact, action: "warn", wantErr: ErrInvalidModerationMode},
+		{name: "不正な正規表現", term: "(", matchType: MatchRegex, action: ModerationReject, wantErr: ErrInvalidBannedTermRegex},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term, err := NewBannedTerm(tt.term, tt.matchType, tt.action, true, "admin", fixedNow)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if term.Term == "" || term.Term != strings.TrimSpace(tt.term) {
+				t.Errorf("term should be trimmed, got %q", term.Term)
+			}
+			if !term.AutoBlock || term.CreatedBy != "admin" || !term.CreatedAt.Equal(fixedNow()) {
+				t.Errorf("unexpected term: %+v", term)
+			}
+		})
+	}
+}
+
+func TestBannedTerm_Update(t *testing.T) {
+	term, _ := NewBannedTerm("ばか", MatchSubstring, ModerationMask, false, "admin", fixedNow)
+	later := func() time.Time { return fixedNow().Add(time.Hour) }
+
+	if err := term.Update("(", MatchRegex, ModerationReject, true, later); err != ErrInvalidBannedTermRegex {
+		t.Fatalf("expected ErrInvalidBannedTermRegex, got %v", err)
+	}
+	if term.Term != "ばか" || term.MatchType != MatchSubstring || !term.UpdatedAt.Equal(fixedNow()) {
+		t.Errorf("failed update should not change the term: %+v", term)
+	}
+
+	if err := term.Update("あほ", MatchExact, ModerationReject, true, later); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if term.Term != "あほ" || term.MatchType != MatchExact || term.Action != ModerationReject || !term.AutoBlock {
+		t.Errorf("unexpected term after update: %+v", term)
+	}
+	if !term.UpdatedAt.Equal(later()) || !term.CreatedAt.Equal(fixedNow()) {
+		t.Errorf("timestamps: created=%v updated=%v", term.CreatedAt, term.UpdatedAt)
+	}
+}
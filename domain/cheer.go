@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCannotCheerSelf      = errors.New("cannot cheer yourself")
+	ErrCheerAlreadySent     = errors.New("cheer already sent to this user today")
+	ErrCheerTargetNotInRoom = errors.New("cheer target has no active session")
+)
+
+// Cheer /cheer @ユーザー名 の記録
+// 同じ相手への応援は配信のタイムゾーンの 1 日に 1 回まで（CheeredOn で重複を判定する）
+type Cheer struct {
+	ID         int64
+	FromUserID int64
+	ToUserID   int64
+	CheeredOn  time.Time // 応援した日（配信のタイムゾーンでの日付を UTC の 0 時で表す）
+	CreatedAt  time.Time
+}
+
+// NewCheer 応援を作成する
+// loc は応援の回数を数える日を決めるタイムゾーン（nil なら UTC）
+func NewCheer(fromUserID, toUserID int64, now func() time.Time, loc *time.Location) (*Cheer, error) {
+	if fromUserID == toUserID {
+		return nil, ErrCannotCheerSelf
+	}
+
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	createdAt := t()
+
+	return &Cheer{
+		FromUserID: fromUserID,
+		ToUserID:   toUserID,
+		CheeredOn:  CheerDate(createdAt, loc),
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+// CheerDate 応援の回数を数える日（loc での日付、nil なら UTC）
+// 日本の配信なら朝 9 時（UTC の 0 時）ではなく、日本時間の 0 時で日が変わる
+func CheerDate(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// CheerComment 応援した人のコメント欄に出す文（「{対象}さんを応援中！」）
+// 定型文なのでコメントの文字数制限は適用せず、ユーザー名だけを表示の規則どおり省略する
+func CheerComment(targetName string) string {
+	return TruncateUserName(targetName) + "さんを応援中！"
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewCheer(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	now := func() time.Time { return time.Date(2025, 10, 10, 8, 30, 0, 0, jst) }
+
+	c, err := NewCheer(1, 2, now, jst)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.FromUserID != 1 || c.ToUserID != 2 {
+		t.Errorf("unexpected cheer: %+v", c)
+	}
+	// 配信のタイムゾーン（JST）の日付で数える
+	if want := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC); !c.CheeredOn.Equal(want) {
+		t.Errorf("CheeredOn = %s, want %s", c.CheeredOn, want)
+	}
+
+	if _, err := NewCheer(1, 1, now, jst); !errors.Is(err, ErrCannotCheerSelf) {
+		t.Errorf("expected ErrCannotCheerSelf, got %v", err)
+	}
+}
+
+func TestCheerDate(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name string
+		at   time.Time
+		loc  *time.Location
+		want time.Time
+	}{
+		// JST 10/10 8:30 は UTC では 10/9
+		{"UTC の日付", time.Date(2025, 10, 10, 8, 30, 0, 0, jst), nil, time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC)},
+		{"JST の 9 時前", time.Date(2025, 10, 9, 23, 30, 0, 0, time.UTC), jst, time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)},
+		{"JST の 0 時前", time.Date(2025, 10, 10, 14, 59, 0, 0, time.UTC), jst, time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)},
+		{"JST の 0 時", time.Date(2025, 10, 10, 15, 0, 0, 0, time.UTC), jst, time.Date(2025, 10, 11, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheerDate(tt.at, tt.loc); !got.Equal(tt.want) {
+				t.Errorf("CheerDate(%s) = %s, want %s", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheerComment(t *testing.T) {
+	if got := CheerComment("yamad"); got != "yamadさんを応援中！" {
+		t.Errorf("got %q", got)
+	}
+	// ユーザー名は 6 文字目以降を省略する
+	if got := CheerComment("raziii03"); got != "razii…さんを応援中！" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var ErrEmptyComment = errors.New("comment must not be empty")
+
+const (
+	// CommentMaxDisplayLength コメント欄に表示する最大文字数（超えた分は「…」で省略する）
+	CommentMaxDisplayLength = 10
+	// UserNameMaxDisplayLength 画面に表示するユーザー名の最大文字数
+	UserNameMaxDisplayLength = 5
+	// CommentDisplayDuration コメントを表示する時間（この間に次のコメントが来たら上書きして延長する）
+	CommentDisplayDuration = 5 * time.Second
+)
+
+// NewCommentText 視聴者のコメントをコメント欄に表示する文にする
+// 制御文字・ゼロ幅文字を除いて空白を 1 つにまとめ、CommentMaxDisplayLength を超えたら省略する
+func NewCommentText(text string) (string, error) {
+	text = SanitizeComment(text)
+	if text == "" {
+		return "", ErrEmptyComment
+	}
+	return TruncateComment(text), nil
+}
+
+// SanitizeComment 表示を崩す文字（改行・制御文字・ゼロ幅文字・双方向制御文字）を取り除く
+func SanitizeComment(text string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			space = b.Len() > 0
+			continue
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r):
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// TruncateComment 11 文字目以降を「…」で省略する
+func TruncateComment(text string) string {
+	return truncateDisplay(text, CommentMaxDisplayLength)
+}
+
+// TruncateUserName 6 文字目以降を「…」で省略する
+func TruncateUserName(name string) string {
+	return truncateDisplay(name, UserNameMaxDisplayLength)
+}
+
+func truncateDisplay(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength]) + "…"
+}
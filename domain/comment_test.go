@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewCommentText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"そのまま", "こんにちは", "こんにちは"},
+		{"10文字ちょうど", "あいうえおかきくけこ", "あいうえおかきくけこ"},
+		{"11文字目以降は省略", "あいうえおかきくけこさ", "あいうえおかきくけこ…"},
+		{"空白をまとめる", "  a \n\t b  ", "a b"},
+		{"制御文字とゼロ幅文字を除く", "a\x00b\u200bc\u202ed", "abcd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCommentText(tt.text)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for _, text := range []string{"", "  \n ", "\u200b"} {
+		if _, err := NewCommentText(text); !errors.Is(err, ErrEmptyComment) {
+			t.Errorf("NewCommentText(%q): expected ErrEmptyComment, got %v", text, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyIconAssetKey  = errors.New("icon asset key must not be empty")
+	ErrIconAssetKeyExists = errors.New("icon asset key already exists")
+)
+
+// Icon アバターのアイコン
+// OwnerUserID が nil の場合は Tier の共通アイコン、設定されている場合はそのユーザーの専用アイコン
+type Icon struct {
+	ID          int64
+	Tier        Tier
+	OwnerUserID *int64
+	AssetKey    string // スプライトのファイル名の接頭辞（例: "tier1-01"）
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IsExclusive 専用アイコンかを確認する
+func (i *Icon) IsExclusive() bool {
+	return i.OwnerUserID != nil
+}
+
+// ChooseIcon セッションで使うアイコンを選ぶ
+// ユーザーの専用アイコンがあればその中から、なければユーザーのティアの共通アイコンからランダムに選ぶ
+// intn は [0, n) の乱数を返す関数。候補がない場合は nil を返す
+func ChooseIcon(icons []*Icon, userID int64, tier Tier, intn func(n int) int) *Icon {
+	var exclusive, shared []*Icon
+	for _, icon := range icons {
+		switch {
+		case icon.OwnerUserID != nil && *icon.OwnerUserID == userID:
+			exclusive = append(exclusive, icon)
+		case icon.OwnerUserID == nil && icon.Tier == tier:
+			shared = append(shared, icon)
+		}
+	}
+
+	candidates := exclusive
+	if len(candidates) == 0 {
+		candidates = shared
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates[intn(len(candidates))]
+}
+
+// NewExclusiveIcon ユーザーの専用アイコンを作成する
+func NewExclusiveIcon(ownerUserID int64, tier Tier, assetKey string, now func() time.Time) (*Icon, error) {
+	assetKey = strings.TrimSpace(assetKey)
+	if assetKey == "" {
+		return nil, ErrEmptyIconAssetKey
+	}
+
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	nowT := t()
+
+	return &Icon{
+		Tier:        tier,
+		OwnerUserID: &ownerUserID,
+		AssetKey:    assetKey,
+		CreatedAt:   nowT,
+		UpdatedAt:   nowT,
+	}, nil
+}
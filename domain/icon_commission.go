@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrIconCommissionNotFound      = errors.New("icon commission not found")
+	ErrIconCommissionAlreadyOpen   = errors.New("user already has an open icon commission")
+	ErrInvalidIconCommissionCost   = errors.New("invalid icon commission points")
+	ErrInvalidIconCommissionStatus = errors.New("invalid icon commission status")
+	ErrIconCommissionTransition    = errors.New("icon commission cannot change to the requested status")
+)
+
+// IconCommissionCost 専用アイコンの作成依頼に必要な Raziiipo（/icon_creation 1000000）
+const IconCommissionCost int64 = 1_000_000
+
+// IconCommissionStatus 専用アイコンの作成依頼の状態
+type IconCommissionStatus string
+
+const (
+	IconCommissionPending    IconCommissionStatus = "pending"     // 依頼を受け付けた
+	IconCommissionInProgress IconCommissionStatus = "in_progress" // 作成中
+	IconCommissionDelivered  IconCommissionStatus = "delivered"   // 専用アイコンとして登録した
+	IconCommissionRefunded   IconCommissionStatus = "refunded"    // 取り消してポイントを返した
+)
+
+// Valid 定義済みの状態かを確認する
+func (s IconCommissionStatus) Valid() bool {
+	switch s {
+	case IconCommissionPending, IconCommissionInProgress, IconCommissionDelivered, IconCommissionRefunded:
+		return true
+	}
+	return false
+}
+
+// IconCommission 専用アイコンの作成依頼
+// pending → in_progress → delivered の順に進み、完了前なら refunded（ポイント返却）にできる
+type IconCommission struct {
+	ID        int64
+	UserID    int64
+	Status    IconCommissionStatus
+	Cost      int64  // 消費したポイント（返金額）
+	IconID    *int64 // 納品した専用アイコン
+	Note      string // 管理者のメモ（取り消し理由など）
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewIconCommission 作成依頼を受け付ける（points は IconCommissionCost と一致する必要がある）
+func NewIconCommission(userID, points int64, now func() time.Time) (*IconCommission, error) {
+	if points != IconCommissionCost {
+		return nil, ErrInvalidIconCommissionCost
+	}
+
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	nowT := t()
+
+	return &IconCommission{
+		UserID:    userID,
+		Status:    IconCommissionPending,
+		Cost:      points,
+		CreatedAt: nowT,
+		UpdatedAt: nowT,
+	}, nil
+}
+
+// IsOpen 納品・返金の前かを確認する
+func (c *IconCommission) IsOpen() bool {
+	return c.Status == IconCommissionPending || c.Status == IconCommissionInProgress
+}
+
+// Start 作成に着手する
+func (c *IconCommission) Start(now func() time.Time) error {
+	if c.Status != IconCommissionPending {
+		return ErrIconCommissionTransition
+	}
+	c.Status = IconCommissionInProgress
+	c.touch(now)
+	return nil
+}
+
+// Deliver 専用アイコンを納品する
+func (c *IconCommission) Deliver(iconID int64, now func() time.Time) error {
+	if !c.IsOpen() {
+		return ErrIconCommissionTransition
+	}
+	c.Status = IconCommissionDelivered
+	c.IconID = &iconID
+	c.touch(now)
+	return nil
+}
+
+// Refund 依頼を取り消す（ポイントの返却は呼び出し側が台帳に記録する）
+func (c *IconCommission) Refund(note string, now func() time.Time) error {
+	if !c.IsOpen() {
+		return ErrIconCommissionTransition
+	}
+	c.Status = IconCommissionRefunded
+	c.Note = strings.TrimSpace(note)
+	c.touch(now)
+	return nil
+}
+
+func (c *IconCommission) touch(now func() time.Time) {
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	c.UpdatedAt = t()
+}
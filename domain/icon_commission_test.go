@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewIconCommission(t *testing.T) {
+	now := func() time.Time { return time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC) }
+
+	c, err := NewIconCommission(1, IconCommissionCost, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Status != IconCommissionPending || c.Cost != IconCommissionCost || !c.IsOpen() {
+		t.Errorf("unexpected commission: %+v", c)
+	}
+
+	if _, err := NewIconCommission(1, 1000, now); !errors.Is(err, ErrInvalidIconCommissionCost) {
+		t.Errorf("expected ErrInvalidIconCommissionCost, got %v", err)
+	}
+}
+
+func TestIconCommission_Transitions(t *testing.T) {
+	newCommission := func() *IconCommission {
+		c, _ := NewIconCommission(1, IconCommissionCost, nil)
+		return c
+	}
+
+	t.Run("pending → in_progress → delivered", func(t *testing.T) {
+		c := newCommission()
+		if err := c.Start(nil); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		if err := c.Start(nil); !errors.Is(err, ErrIconCommissionTransition) {
+			t.Errorf("expected second Start to fail, got %v", err)
+		}
+		if err := c.Deliver(9, nil); err != nil {
+			t.Fatalf("Deliver: %v", err)
+		}
+		if c.Status != IconCommissionDelivered || c.IconID == nil || *c.IconID != 9 || c.IsOpen() {
+			t.Errorf("unexpected commission: %+v", c)
+		}
+		if err := c.Refund("too late", nil); !errors.Is(err, ErrIconCommissionTransition) {
+			t.Errorf("expected refund after delivery to fail, got %v", err)
+		}
+	})
+
+	t.Run("pending can be delivered directly", func(t *testing.T) {
+		c := newCommission()
+		if err := c.Deliver(9, nil); err != nil {
+			t.Fatalf("Deliver: %v", err)
+		}
+	})
+
+	t.Run("refund keeps the note", func(t *testing.T) {
+		c := newCommission()
+		_ = c.Start(nil)
+		if err := c.Refund("  cancelled by viewer ", nil); err != nil {
+			t.Fatalf("Refund: %v", err)
+		}
+		if c.Status != IconCommissionRefunded || c.Note != "cancelled by viewer" {
+			t.Errorf("unexpected commission: %+v", c)
+		}
+		if err := c.Deliver(9, nil); !errors.Is(err, ErrIconCommissionTransition) {
+			t.Errorf("expected delivery after refund to fail, got %v", err)
+		}
+		if err := c.Refund("", nil); !errors.Is(err, ErrIconCommissionTransition) {
+			t.Errorf("expected second refund to fail, got %v", err)
+		}
+	})
+}
+
+func TestCheckPointDebit(t *testing.T) {
+	if err := CheckPointDebit(100, 100); err != nil {
+		t.Errorf("expected debit of the whole balance to succeed, got %v", err)
+	}
+	if err := CheckPointDebit(99, 100); !errors.Is(err, ErrInsufficientPoints) {
+		t.Errorf("expected ErrInsufficientPoints, got %v", err)
+	}
+	if err := CheckPointDebit(100, 0); !errors.Is(err, ErrInvalidPointAmount) {
+		t.Errorf("expected ErrInvalidPointAmount, got %v", err)
+	}
+	if _, err := NewPointEntry(1, 0, PointReasonIconCommission, "", nil); !errors.Is(err, ErrInvalidPointAmount) {
+		t.Errorf("expected ErrInvalidPointAmount for a zero entry, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "testing"
+
+func TestChooseIcon(t *testing.T) {
+	owner := int64(7)
+	other := int64(8)
+	icons := []*Icon{
+		{ID: 1, Tier: Tier1, AssetKey: "tier1-01"},
+		{ID: 2, Tier: Tier1, AssetKey: "tier1-02"},
+		{ID: 3, Tier: Tier2, AssetKey: "tier2-01"},
+		{ID: 4, Tier: Tier1, OwnerUserID: &owner, AssetKey: "exclusive-7"},
+		{ID: 5, Tier: Tier1, OwnerUserID: &other, AssetKey: "exclusive-8"},
+	}
+	last := func(n int) int { return n - 1 }
+
+	tests := []struct {
+		name   string
+		userID int64
+		tier   Tier
+		wantID int64
+	}{
+		{"shared icon of the user's tier", 1, Tier1, 2},
+		{"shared icon of another tier", 1, Tier2, 3},
+		{"exclusive icon takes priority", owner, Tier1, 4},
+		{"exclusive icon regardless of tier", owner, Tier3, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ChooseIcon(icons, tt.userID, tt.tier, last)
+			if got == nil || got.ID != tt.wantID {
+				t.Fatalf("expected icon %d, got %+v", tt.wantID, got)
+			}
+		})
+	}
+
+	if got := ChooseIcon(icons, 1, Tier3, last); got != nil {
+		t.Errorf("expected no icon for a tier without icons, got %+v", got)
+	}
+	if got := ChooseIcon(nil, 1, Tier1, last); got != nil {
+		t.Errorf("expected no icon from an empty catalog, got %+v", got)
+	}
+}
+
+func TestChooseIcon_UsesRandomSource(t *testing.T) {
+	icons := []*Icon{
+		{ID: 1, Tier: Tier1},
+		{ID: 2, Tier: Tier1},
+		{ID: 3, Tier: Tier1},
+	}
+	var gotN int
+	icon := ChooseIcon(icons, 1, Tier1, func(n int) int { gotN = n; return 1 })
+	if gotN != 3 {
+		t.Errorf("expected random source to be asked for [0, 3), got %d", gotN)
+	}
+	if icon.ID != 2 {
+		t.Errorf("expected icon 2, got %d", icon.ID)
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyIdempotencyKey        = errors.New("idempotency key must not be empty")
+	ErrIdempotencyKeyTooLong      = errors.New("idempotency key is too long")
+	ErrIdempotencyKeyNotFound     = errors.New("idempotency key not found")
+	ErrIdempotencyKeyMismatch     = errors.New("idempotency key was reused with a different request")
+	ErrIdempotencyKeyInProgress   = errors.New("request with the same idempotency key is still in progress")
+	ErrInvalidIdempotencyKeyTTL   = errors.New("invalid idempotency key ttl: must be positive")
+	ErrIdempotencyRecordCompleted = errors.New("idempotency record already completed")
+)
+
+// MaxIdempotencyKeyLength Idempotency-Key ヘッダーの最大長
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyRecord 冪等キーと、そのキーで処理したリクエストの結果を表す
+type IdempotencyRecord struct {
+	Key          string
+	RequestHash  string // メソッド・パス・ボディから算出したリクエストの指紋
+	StatusCode   int    // 処理中の場合は0
+	ResponseBody []byte
+	// ResponseHeaders ハンドラーが設定したレスポンスヘッダー（Retry-After など、再送時にも返す）
+	ResponseHeaders map[string][]string
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+}
+
+// NewIdempotencyRecord 処理中状態の冪等レコードを作成する
+// ttl の間は同じキーのリクエストに対して保存済みの結果を返す
+func NewIdempotencyRecord(key, requestHash string, ttl time.Duration, now func() time.Time) (*IdempotencyRecord, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, ErrEmptyIdempotencyKey
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, ErrIdempotencyKeyTooLong
+	}
+	if ttl <= 0 {
+		return nil, ErrInvalidIdempotencyKeyTTL
+	}
+
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	nowT := t()
+
+	return &IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   nowT,
+		ExpiresAt:   nowT.Add(ttl),
+	}, nil
+}
+
+// IsCompleted レスポンスが保存済みかを確認する
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.StatusCode != 0
+}
+
+// IsExpired 有効期限を過ぎているかを確認する
+func (r *IdempotencyRecord) IsExpired(now func() time.Time) bool {
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	return !t().Before(r.ExpiresAt)
+}
+
+// Matches 同じリクエストの再送かどうかを確認する
+func (r *IdempotencyRecord) Matches(requestHash string) bool {
+	return r.RequestHash == requestHash
+}
+
+// Complete 処理結果を記録する
+func (r *IdempotencyRecord) Complete(statusCode int, headers map[string][]string, body []byte) error {
+	if r.IsCompleted() {
+		return ErrIdempotencyRecordCompleted
+	}
+	r.StatusCode = statusCode
+	r.ResponseHeaders = headers
+	r.ResponseBody = body
+	return nil
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewIdempotencyRecord(t *testing.T) {
+	t.Run("有効なキーでレコードを作成", func(t *testing.T) {
+		record, err := NewIdempotencyRecord("  key-1  ", "hash", time.Hour, fixedTime)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if record.Key != "key-1" {
+			t.Errorf("expected trimmed key 'key-1', got %q", record.Key)
+		}
+		if !record.ExpiresAt.Equal(fixedTime().Add(time.Hour)) {
+			t.Errorf("expected ExpiresAt %v, got %v", fixedTime().Add(time.Hour), record.ExpiresAt)
+		}
+		if record.IsCompleted() {
+			t.Error("new record should not be completed")
+		}
+	})
+
+	t.Run("空のキーの場合はエラー", func(t *testing.T) {
+		if _, err := NewIdempotencyRecord("   ", "hash", time.Hour, fixedTime); err != ErrEmptyIdempotencyKey {
+			t.Errorf("expected ErrEmptyIdempotencyKey, got %v", err)
+		}
+	})
+
+	t.Run("長すぎるキーの場合はエラー", func(t *testing.T) {
+		key := strings.Repeat("a", MaxIdempotencyKeyLength+1)
+		if _, err := NewIdempotencyRecord(key, "hash", time.Hour, fixedTime); err != ErrIdempotencyKeyTooLong {
+			t.Errorf("expected ErrIdempotencyKeyTooLong, got %v", err)
+		}
+	})
+
+	t.Run("不正なTTLの場合はエラー", func(t *testing.T) {
+		if _, err := NewIdempotencyRecord("key", "hash", 0, fixedTime); err != ErrInvalidIdempotencyKeyTTL {
+			t.Errorf("expected ErrInvalidIdempotencyKeyTTL, got %v", err)
+		}
+	})
+}
+
+func TestIdempotencyRecord_Complete(t *testing.T) {
+	record, _ := NewIdempotencyRecord("key", "hash", time.Hour, fixedTime)
+
+	if err := record.Complete(200, map[string][]string{"Content-Type": {"application/json"}}, []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !record.IsCompleted() {
+		t.Error("expected record to be completed")
+	}
+	if err := record.Complete(200, nil, nil); err != ErrIdempotencyRecordCompleted {
+		t.Errorf("expected ErrIdempotencyRecordCompleted, got %v", err)
+	}
+}
+
+func TestIdempotencyRecord_IsExpired(t *testing.T) {
+	record, _ := NewIdempotencyRecord("key", "hash", time.Hour, fixedTime)
+
+	before := func() time.Time { return fixedTime().Add(59 * time.Minute) }
+	if record.IsExpired(before) {
+		t.Error("record should not be expired before ttl")
+	}
+
+	at := func() time.Time { return fixedTime().Add(time.Hour) }
+	if !record.IsExpired(at) {
+		t.Error("record should be expired at ttl boundary")
+	}
+}
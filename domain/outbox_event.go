@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyOutboxEventType = errors.New("outbox event type must not be empty")
+)
+
+const (
+	// outboxBaseBackoff 1 回目の失敗後の再試行間隔（以降は倍々に伸ばす）
+	outboxBaseBackoff = time.Second
+	// outboxMaxBackoff 再試行間隔の上限
+	outboxMaxBackoff = time.Minute
+)
+
+// OutboxEvent 状態変更と同じトランザクションで記録され、コミット後に配信されるイベント
+// ID は単調増加し、クライアントは重複配信の除去に使う
+// ID は採番順でコミット順ではないため、配信した順は DispatchSeq で表す
+type OutboxEvent struct {
+	ID           int64
+	EventType    string
+	Payload      json.RawMessage
+	Attempts     int32  // 配信に失敗した回数
+	LastError    string // 直近の配信エラー
+	CreatedAt    time.Time
+	DispatchedAt *time.Time // 配信済みの場合のみ設定される
+	DispatchSeq  int64      // 配信した順の番号（配信済みの場合のみ設定される）
+	// NextAttemptAt 次に配信を試す時刻（配信に失敗した場合のみ設定される）
+	NextAttemptAt *time.Time
+	// DeadAt 配信を諦めた時刻（デッドレターの場合のみ設定される）
+	DeadAt *time.Time
+}
+
+// NewOutboxEvent アウトボックスイベントを作成する（payload は JSON に変換して保持する）
+func NewOutboxEvent(eventType string, payload any, now func() time.Time) (*OutboxEvent, error) {
+	eventType = strings.TrimSpace(eventType)
+	if eventType == "" {
+		return nil, ErrEmptyOutboxEventType
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+
+	return &OutboxEvent{
+		EventType: eventType,
+		Payload:   b,
+		CreatedAt: t(),
+	}, nil
+}
+
+// IsDispatched 配信済みかどうか
+func (e *OutboxEvent) IsDispatched() bool {
+	return e.DispatchedAt != nil
+}
+
+// IsDead 配信を諦めたイベント（デッドレター）かどうか
+func (e *OutboxEvent) IsDead() bool {
+	return e.DeadAt != nil
+}
+
+// IsDue 配信を試してよい時刻になっているか（失敗後の再試行間隔が過ぎているか）
+func (e *OutboxEvent) IsDue(now time.Time) bool {
+	return e.NextAttemptAt == nil || !now.Before(*e.NextAttemptAt)
+}
+
+// OutboxBackoff attempts 回失敗した後の再試行間隔（1 秒から倍々、上限 1 分）
+func OutboxBackoff(attempts int32) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	backoff := outboxBaseBackoff
+	for i := int32(1); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= outboxMaxBackoff {
+			return outboxMaxBackoff
+		}
+	}
+	return backoff
+}
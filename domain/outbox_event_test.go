@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewOutboxEvent(t *testing.T) {
+	t.Run("ペイロードを JSON で保持する", func(t *testing.T) {
+		payload := struct {
+			SessionID int64 `json:"session_id"`
+		}{SessionID: 99}
+
+		event, err := NewOutboxEvent(" session_start ", payload, fixedNow)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if event.EventType != "session_start" || string(event.Payload) != `{"session_id":99}` {
+			t.Errorf("unexpected event: %+v", event)
+		}
+		if !event.CreatedAt.Equal(fixedNow()) || event.IsDispatched() {
+			t.Errorf("expected new undispatched event, got %+v", event)
+		}
+	})
+
+	t.Run("種別は必須", func(t *testing.T) {
+		if _, err := NewOutboxEvent(" ", nil, fixedNow); err != ErrEmptyOutboxEventType {
+			t.Errorf("expected ErrEmptyOutboxEventType, got %v", err)
+		}
+	})
+
+	t.Run("JSON に変換できないペイロードはエラー", func(t *testing.T) {
+		if _, err := NewOutboxEvent("session_start", make(chan int), fixedNow); err == nil {
+			t.Error("expected marshal error")
+		}
+	})
+}
+
+func TestOutboxEvent_IsDue(t *testing.T) {
+	now := fixedNow()
+	event := &OutboxEvent{}
+	if !event.IsDue(now) {
+		t.Error("expected event without next attempt to be due")
+	}
+
+	next := now.Add(time.Second)
+	event.NextAttemptAt = &next
+	if event.IsDue(now) {
+		t.Error("expected event to wait until its next attempt")
+	}
+	if !event.IsDue(next) {
+		t.Error("expected event to be due at its next attempt")
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	cases := map[int32]time.Duration{
+		0:  0,
+		1:  time.Second,
+		2:  2 * time.Second,
+		6:  32 * time.Second,
+		7:  time.Minute,
+		20: time.Minute,
+	}
+	for attempts, want := range cases {
+		if got := OutboxBackoff(attempts); got != want {
+			t.Errorf("OutboxBackoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
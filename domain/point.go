@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidPointAmount  = errors.New("invalid point amount: must not be zero")
+	ErrInsufficientPoints  = errors.New("insufficient points")
+	ErrDuplicatePointEntry = errors.New("point entry already recorded")
+)
+
+// PointReason Raziiipo が増減した理由
+type PointReason string
+
+const (
+	PointReasonIconCommission       PointReason = "icon_commission"        // 専用アイコンの作成依頼
+	PointReasonIconCommissionRefund PointReason = "icon_commission_refund" // 専用アイコンの作成依頼の取り消し
+	PointReasonChannelPoints        PointReason = "channel_points"         // Twitch のチャンネルポイント交換
+	PointReasonCheerBonus           PointReason = "cheer_bonus"            // /cheer で応援されたボーナス
+	PointReasonAdminAdjustment      PointReason = "admin_adjustment"       // 管理者による付与・取り消し
+)
+
+// DefaultChannelPointsPerRaziiipo チャンネルポイント 100pt → Raziiipo 10pt の既定の交換レート
+const DefaultChannelPointsPerRaziiipo int64 = 10
+
+// ChannelPointsToRaziiipo チャンネルポイント perRaziiipo pt につき 1pt として、交換で付与する Raziiipo を求める（端数は切り捨て）
+func ChannelPointsToRaziiipo(channelPoints, perRaziiipo int64) int64 {
+	if channelPoints <= 0 || perRaziiipo <= 0 {
+		return 0
+	}
+	return channelPoints / perRaziiipo
+}
+
+// PointEntry Raziiipo の台帳の 1 行（増減は追記のみで、残高は Delta の合計）
+// Reference は増減の元になったもの（依頼 ID など）。同じ Reason と Reference の組は 1 度しか記録できない
+type PointEntry struct {
+	ID        int64
+	UserID    int64
+	Delta     int64
+	Reason    PointReason
+	Reference string
+	CreatedAt time.Time
+}
+
+// NewPointEntry 台帳の行を作成する（Delta が正なら付与、負なら消費）
+func NewPointEntry(userID, delta int64, reason PointReason, reference string, now func() time.Time) (*PointEntry, error) {
+	if delta == 0 {
+		return nil, ErrInvalidPointAmount
+	}
+
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+
+	return &PointEntry{
+		UserID:    userID,
+		Delta:     delta,
+		Reason:    reason,
+		Reference: reference,
+		CreatedAt: t(),
+	}, nil
+}
+
+// CheckPointDebit 残高から amount を消費できるかを確認する
+func CheckPointDebit(balance, amount int64) error {
+	if amount <= 0 {
+		return ErrInvalidPointAmount
+	}
+	if balance < amount {
+		return ErrInsufficientPoints
+	}
+	return nil
+}
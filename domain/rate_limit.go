@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit 実行頻度の制限（Period あたり Count 回、最大 Count 回まで連続実行可能）
+// Count が0以下の場合は無制限
+type RateLimit struct {
+	Count  int
+	Period time.Duration
+}
+
+// Unlimited 制限なしかを確認する
+func (l RateLimit) Unlimited() bool {
+	return l.Count <= 0 || l.Period <= 0
+}
+
+// String "6/1m0s" 形式で表示する
+func (l RateLimit) String() string {
+	if l.Unlimited() {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d/%s", l.Count, l.Period)
+}
+
+// ParseRateLimit "6/1m" 形式の文字列を RateLimit に変換する（"0" または "unlimited" は無制限）
+func ParseRateLimit(s string) (RateLimit, error) {
+	s = strings.TrimSpace(s)
+	if s == "0" || strings.EqualFold(s, "unlimited") {
+		return RateLimit{}, nil
+	}
+
+	countStr, periodStr, ok := strings.Cut(s, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q: expected <count>/<period>", s)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(countStr))
+	if err != nil || count < 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit count %q", countStr)
+	}
+	period, err := time.ParseDuration(strings.TrimSpace(periodStr))
+	if err != nil || period <= 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit period %q", periodStr)
+	}
+	return RateLimit{Count: count, Period: period}, nil
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    RateLimit
+		wantErr bool
+	}{
+		{in: "6/1m", want: RateLimit{Count: 6, Period: time.Minute}},
+		{in: " 10 / 30s ", want: RateLimit{Count: 10, Period: 30 * time.Second}},
+		{in: "0", want: RateLimit{}},
+		{in: "unlimited", want: RateLimit{}},
+		{in: "6", wantErr: true},
+		{in: "x/1m", wantErr: true},
+		{in: "6/abc", wantErr: true},
+		{in: "6/0s", wantErr: true},
+		{in: "-1/1m", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseRateLimit(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRateLimit(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseRateLimit(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimit_String(t *testing.T) {
+	if got := (RateLimit{Count: 6, Period: time.Minute}).String(); got != "6/1m0s" {
+		t.Errorf("unexpected string: %q", got)
+	}
+	if got := (RateLimit{}).String(); got != "unlimited" {
+		t.Errorf("unexpected string: %q", got)
+	}
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// AuditLogFilter narrows down audit log listings (empty fields match everything)
+type AuditLogFilter struct {
+	TargetType string
+	TargetID   string
+	Limit      int32
+}
+
+// AuditLogRepository defines the interface for audit log persistence operations
+type AuditLogRepository interface {
+	// Save appends an audit log entry and sets its ID
+	Save(ctx context.Context, log *domain.AuditLog) error
+
+	// SaveWithTx appends an audit log entry within a transaction
+	SaveWithTx(ctx context.Context, tx Tx, log *domain.AuditLog) error
+
+	// List retrieves audit log entries, newest first
+	List(ctx context.Context, filter AuditLogFilter) ([]*domain.AuditLog, error)
+}
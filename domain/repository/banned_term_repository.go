@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// BannedTermRepository defines the interface for banned term persistence operations
+type BannedTermRepository interface {
+	// List retrieves all banned terms ordered by ID
+	List(ctx context.Context) ([]*domain.BannedTerm, error)
+
+	// FindByID retrieves a banned term by ID
+	FindByID(ctx context.Context, id int64) (*domain.BannedTerm, error)
+
+	// Save creates a new banned term or updates an existing one
+	Save(ctx context.Context, term *domain.BannedTerm) error
+
+	// Delete removes a banned term
+	// Returns domain.ErrBannedTermNotFound if it does not exist
+	Delete(ctx context.Context, id int64) error
+}
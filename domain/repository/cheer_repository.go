@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// CheerRepository defines the interface for cheer persistence
+type CheerRepository interface {
+	// CreateWithTx records a cheer within a transaction and sets its ID
+	// Returns domain.ErrCheerAlreadySent if the same pair already has a cheer on the same day
+	CreateWithTx(ctx context.Context, tx Tx, cheer *domain.Cheer) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// IconCommissionRepository defines the interface for icon commission persistence operations
+type IconCommissionRepository interface {
+	// List retrieves commissions, oldest first
+	// An empty status returns commissions in every status
+	List(ctx context.Context, status domain.IconCommissionStatus, limit int32) ([]*domain.IconCommission, error)
+
+	// FindByID retrieves a commission by ID
+	FindByID(ctx context.Context, id int64) (*domain.IconCommission, error)
+
+	// FindByIDWithTx retrieves a commission by ID within a transaction with row lock
+	FindByIDWithTx(ctx context.Context, tx Tx, id int64) (*domain.IconCommission, error)
+
+	// CreateWithTx creates a commission within a transaction and sets its ID
+	// Returns domain.ErrIconCommissionAlreadyOpen if the user has another pending or in-progress commission
+	CreateWithTx(ctx context.Context, tx Tx, commission *domain.IconCommission) error
+
+	// UpdateWithTx stores the status, icon and note of a commission within a transaction
+	UpdateWithTx(ctx context.Context, tx Tx, commission *domain.IconCommission) error
+}
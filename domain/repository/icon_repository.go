@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// IconRepository defines the interface for icon catalog operations
+type IconRepository interface {
+	// ListAssignable retrieves the icons a user can be given at join, ordered by ID:
+	// the user's exclusive icons and the shared icons of the given tier
+	ListAssignable(ctx context.Context, userID int64, tier domain.Tier) ([]*domain.Icon, error)
+
+	// CreateWithTx creates an icon within a transaction and sets its ID
+	// Returns domain.ErrIconAssetKeyExists if the asset key is already registered
+	CreateWithTx(ctx context.Context, tx Tx, icon *domain.Icon) error
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// IdempotencyRepository defines the interface for idempotency key persistence operations
+type IdempotencyRepository interface {
+	// Claim reserves the key of the given record
+	// Returns (nil, true, nil) when the key was newly reserved (or an expired record was reused)
+	// Returns (existing, false, nil) when a valid record already exists for the key
+	Claim(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error)
+
+	// FindByKey retrieves a record by key
+	FindByKey(ctx context.Context, key string) (*domain.IdempotencyRecord, error)
+
+	// Complete stores the response of a claimed record
+	Complete(ctx context.Context, record *domain.IdempotencyRecord) error
+
+	// Release deletes a claimed record that has not been completed so that the request can be retried
+	Release(ctx context.Context, key string) error
+
+	// DeleteExpired deletes all records that expired at or before the given time
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// OutboxRepository defines the interface for transactional outbox persistence operations
+type OutboxRepository interface {
+	// SaveWithTx records an event within the transaction that changes the state, and sets its ID
+	SaveWithTx(ctx context.Context, tx Tx, event *domain.OutboxEvent) error
+
+	// ListPending retrieves events that are neither delivered nor dead-lettered, in ID order
+	// Events waiting for a retry are included so the caller can keep later events behind them
+	ListPending(ctx context.Context, limit int32) ([]*domain.OutboxEvent, error)
+
+	// ListDispatchedAfter retrieves events delivered after the event afterID, in delivery order
+	// Used to resume event streams (events are kept until DeleteDispatchedBefore removes them).
+	// IDs are allocated before commit, so a lower ID can be delivered after a higher one;
+	// ordering by dispatch sequence keeps those events from being skipped.
+	// If afterID is gone or still being delivered, it resumes after the last event delivered with a lower ID
+	ListDispatchedAfter(ctx context.Context, afterID int64, limit int32) ([]*domain.OutboxEvent, error)
+
+	// MarkDispatched marks an event as delivered and assigns the next dispatch sequence
+	MarkDispatched(ctx context.Context, id int64, dispatchedAt time.Time) error
+
+	// MarkFailed increments the attempt count of an event, records the error and schedules the next attempt
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+
+	// MarkDead increments the attempt count of an event, records the error and gives up delivering it
+	MarkDead(ctx context.Context, id int64, lastError string, deadAt time.Time) error
+
+	// ListDead retrieves dead-lettered events, most recently dead-lettered first
+	ListDead(ctx context.Context, limit int32) ([]*domain.OutboxEvent, error)
+
+	// DeleteDispatchedBefore deletes events delivered before the given time and returns the count
+	DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// DeleteDeadBefore deletes events dead-lettered before the given time and returns the count
+	DeleteDeadBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// PointLedgerRepository defines the interface for the Raziiipo ledger (append-only)
+// Callers that debit points lock the user row first so that the balance check and the entry are serialized
+type PointLedgerRepository interface {
+	// Balance returns the sum of a user's entries (0 if there are none)
+	Balance(ctx context.Context, userID int64) (int64, error)
+
+	// BalanceWithTx returns the sum of a user's entries within a transaction
+	BalanceWithTx(ctx context.Context, tx Tx, userID int64) (int64, error)
+
+	// AppendWithTx records an entry within a transaction and sets its ID
+	// Returns domain.ErrDuplicatePointEntry if an entry with the same reason and non-empty reference exists
+	AppendWithTx(ctx context.Context, tx Tx, entry *domain.PointEntry) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// RuntimeSettingRepository defines the interface for settings changed at runtime through the admin API
+type RuntimeSettingRepository interface {
+	// List retrieves all stored settings ordered by key
+	List(ctx context.Context) ([]*domain.RuntimeSetting, error)
+
+	// ListForUpdateWithTx locks the settings until the transaction ends and retrieves all stored settings ordered by key
+	// Concurrent changes wait for the lock, so they validate against the values committed before them
+	ListForUpdateWithTx(ctx context.Context, tx Tx) ([]*domain.RuntimeSetting, error)
+
+	// SaveWithTx creates or replaces the stored value of a setting within a transaction
+	SaveWithTx(ctx context.Context, tx Tx, setting *domain.RuntimeSetting) error
+
+	// DeleteWithTx removes the stored value of a setting within a transaction
+	// Returns domain.ErrRuntimeSettingNotFound if no value is stored for the key
+	DeleteWithTx(ctx context.Context, tx Tx, key string) error
+}
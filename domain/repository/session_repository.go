@@ -35,9 +35,30 @@ type SessionRepository interface {
 	// FindActiveByUserIDWithTx retrieves the active session within a transaction
 	FindActiveByUserIDWithTx(ctx context.Context, tx Tx, userID int64) (*domain.Session, error)
 
+	// UpdateWithTx updates an existing session within a transaction
+	UpdateWithTx(ctx context.Context, tx Tx, session *domain.Session) error
+
 	// CreateWithTx creates a new session within a transaction
+	// actual_end is also stored when the session is already completed
 	CreateWithTx(ctx context.Context, tx Tx, session *domain.Session) error
 
+	// FindByIDWithTx retrieves a session by ID within a transaction with row lock
+	FindByIDWithTx(ctx context.Context, tx Tx, id int64) (*domain.Session, error)
+
+	// FindOverlappingWithTx retrieves the user's sessions overlapping [start, end) within a transaction
+	// A nil end means open-ended; active sessions are treated as open-ended. excludeID is skipped
+	FindOverlappingWithTx(ctx context.Context, tx Tx, userID, excludeID int64, start time.Time, end *time.Time) ([]*domain.Session, error)
+
+	// CorrectWithTx overwrites start_time, planned_end, actual_end and work_name within a transaction
+	CorrectWithTx(ctx context.Context, tx Tx, session *domain.Session) error
+
+	// DeleteWithTx deletes a session within a transaction
+	// Returns domain.ErrSessionNotFound if it does not exist
+	DeleteWithTx(ctx context.Context, tx Tx, id int64) error
+
 	// FindAllActive retrieves all active sessions with user information
 	FindAllActive(ctx context.Context) ([]domain.SessionInfo, error)
+
+	// CountActive counts sessions that have not ended
+	CountActive(ctx context.Context) (int64, error)
 }
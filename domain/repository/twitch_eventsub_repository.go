@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// TwitchEventSubRepository defines the interface for deduplicating Twitch EventSub notifications
+type TwitchEventSubRepository interface {
+	// MarkProcessedWithTx records a message ID within a transaction
+	// Returns domain.ErrEventSubMessageProcessed if the message was already recorded
+	MarkProcessedWithTx(ctx context.Context, tx Tx, messageID, subscriptionType string, receivedAt time.Time) error
+}
@@ -12,6 +12,11 @@ type Tx interface {
 	Rollback(ctx context.Context) error
 }
 
+// TxBeginner starts database transactions
+type TxBeginner interface {
+	BeginTx(ctx context.Context) (Tx, error)
+}
+
 // UserRepository defines the interface for user persistence operations
 type UserRepository interface {
 	// FindByName retrieves a user by name
@@ -29,6 +34,12 @@ type UserRepository interface {
 	// FindByNameWithTx retrieves a user by name within a transaction with row lock
 	FindByNameWithTx(ctx context.Context, tx Tx, name string) (*domain.User, error)
 
+	// FindByIDWithTx retrieves a user by ID within a transaction with row lock
+	FindByIDWithTx(ctx context.Context, tx Tx, id int64) (*domain.User, error)
+
 	// SaveWithTx creates a new user within a transaction
 	SaveWithTx(ctx context.Context, tx Tx, user *domain.User) error
+
+	// UpdateWithTx updates an existing user within a transaction
+	UpdateWithTx(ctx context.Context, tx Tx, user *domain.User) error
 }
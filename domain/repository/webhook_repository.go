@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// WebhookSubscriptionRepository defines the interface for webhook subscription persistence operations
+type WebhookSubscriptionRepository interface {
+	// List retrieves all subscriptions ordered by ID
+	List(ctx context.Context) ([]*domain.WebhookSubscription, error)
+
+	// FindByID retrieves a subscription by ID
+	FindByID(ctx context.Context, id int64) (*domain.WebhookSubscription, error)
+
+	// Save creates a new subscription and sets its ID
+	Save(ctx context.Context, sub *domain.WebhookSubscription) error
+
+	// Delete removes a subscription together with its queued deliveries
+	// Returns domain.ErrWebhookNotFound if it does not exist
+	Delete(ctx context.Context, id int64) error
+}
+
+// WebhookDeliveryRepository defines the interface for the persistent webhook delivery queue
+type WebhookDeliveryRepository interface {
+	// Enqueue adds a delivery and sets its ID
+	// Returns false if the same event is already queued for the subscription
+	Enqueue(ctx context.Context, delivery *domain.WebhookDelivery) (bool, error)
+
+	// ListDue retrieves pending deliveries whose next attempt is due, oldest first
+	ListDue(ctx context.Context, now time.Time, limit int32) ([]*domain.WebhookDelivery, error)
+
+	// FindByID retrieves a delivery by ID
+	FindByID(ctx context.Context, id int64) (*domain.WebhookDelivery, error)
+
+	// Update stores the status, attempt count and last result of a delivery
+	Update(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// ListByStatus retrieves deliveries in the given status, most recently updated first
+	ListByStatus(ctx context.Context, status domain.WebhookDeliveryStatus, limit int32) ([]*domain.WebhookDelivery, error)
+
+	// DeleteDeliveredBefore deletes deliveries that succeeded before the given time and returns the count
+	DeleteDeliveredBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnknownRuntimeSetting  = errors.New("unknown runtime setting")
+	ErrInvalidRuntimeSetting  = errors.New("invalid runtime setting")
+	ErrRuntimeSettingNotFound = errors.New("runtime setting not found")
+)
+
+// 配信中に変更できる設定のキー（設定ファイルのキーと同じ）
+const (
+	SettingSessionDefaultDuration   = "session.default_duration"
+	SettingMinExtensionMinutes      = "session.min_extension_minutes"
+	SettingMaxExtensionMinutes      = "session.max_extension_minutes"
+	SettingCheerBonusPoints         = "cheer.bonus_points"
+	SettingChannelPointsPerRaziiipo = "points.channel_points_per_raziiipo"
+	SettingAutoBlockDuration        = "moderation.auto_block_duration"
+)
+
+// RuntimeSettings 再起動せずに変更できる設定の値
+// 起動時の設定を基準に、管理 API で保存した値（RuntimeSetting）で上書きしたもの
+type RuntimeSettings struct {
+	// SessionDefaultDuration /in で始めるセッションの長さ
+	SessionDefaultDuration time.Duration
+	// MinExtensionMinutes, MaxExtensionMinutes /more で延長できる分数の範囲
+	MinExtensionMinutes int
+	MaxExtensionMinutes int
+	// CheerBonusPoints /cheer で応援された人に付与する Raziiipo（0 なら付与しない）
+	CheerBonusPoints int64
+	// ChannelPointsPerRaziiipo Raziiipo 1pt に交換するチャンネルポイント
+	ChannelPointsPerRaziiipo int64
+	// AutoBlockDuration 禁止ワードによる自動ブロックの期間（0なら無期限）
+	AutoBlockDuration time.Duration
+}
+
+// RuntimeSetting 管理 API で保存した設定値 1 件
+type RuntimeSetting struct {
+	Key       string
+	Value     string
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// NewRuntimeSetting 設定値を作成する（キーと値の形式を確認し、値は正規化して保持する）
+// 他の設定との範囲の整合は RuntimeSettings.Validate で確認する
+func NewRuntimeSetting(key, value, actor string, now func() time.Time) (*RuntimeSetting, error) {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return nil, ErrEmptyAuditActor
+	}
+
+	var s RuntimeSettings
+	if err := s.Set(key, value); err != nil {
+		return nil, err
+	}
+
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+
+	return &RuntimeSetting{
+		Key:       key,
+		Value:     s.Get(key),
+		UpdatedBy: actor,
+		UpdatedAt: t(),
+	}, nil
+}
+
+// RuntimeSettingError 設定値が不正（errors.Is で ErrInvalidRuntimeSetting に一致する）
+type RuntimeSettingError struct {
+	Key    string
+	Reason string
+}
+
+func (e *RuntimeSettingError) Error() string {
+	return e.Key + " " + e.Reason
+}
+
+// Is reports whether target is ErrInvalidRuntimeSetting
+func (e *RuntimeSettingError) Is(target error) bool {
+	return target == ErrInvalidRuntimeSetting
+}
+
+// runtimeSettingField 設定値 1 つの文字列との変換
+type runtimeSettingField struct {
+	key string
+	get func(s *RuntimeSettings) string
+	set func(s *RuntimeSettings, value string) error
+}
+
+var runtimeSettingFields = []runtimeSettingField{
+	durationField(SettingSessionDefaultDuration, func(s *RuntimeSettings) *time.Duration { return &s.SessionDefaultDuration }),
+	intField(SettingMinExtensionMinutes, func(s *RuntimeSettings) *int { return &s.MinExtensionMinutes }),
+	intField(SettingMaxExtensionMinutes, func(s *RuntimeSettings) *int { return &s.MaxExtensionMinutes }),
+	int64Field(SettingCheerBonusPoints, func(s *RuntimeSettings) *int64 { return &s.CheerBonusPoints }),
+	int64Field(SettingChannelPointsPerRaziiipo, func(s *RuntimeSettings) *int64 { return &s.ChannelPointsPerRaziiipo }),
+	durationField(SettingAutoBlockDuration, func(s *RuntimeSettings) *time.Duration { return &s.AutoBlockDuration }),
+}
+
+func durationField(key string, field func(s *RuntimeSettings) *time.Duration) runtimeSettingField {
+	return runtimeSettingField{
+		key: key,
+		get: func(s *RuntimeSettings) string { return field(s).String() },
+		set: func(s *RuntimeSettings, v string) error {
+			d, err := time.ParseDuration(strings.TrimSpace(v))
+			if err != nil {
+				return &RuntimeSettingError{Key: key, Reason: fmt.Sprintf("must be a duration (got %q)", v)}
+			}
+			*field(s) = d
+			return nil
+		},
+	}
+}
+
+func intField(key string, field func(s *RuntimeSettings) *int) runtimeSettingField {
+	return runtimeSettingField{
+		key: key,
+		get: func(s *RuntimeSettings) string { return strconv.Itoa(*field(s)) },
+		set: func(s *RuntimeSettings, v string) error {
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil {
+				return &RuntimeSettingError{Key: key, Reason: fmt.Sprintf("must be an integer (got %q)", v)}
+			}
+			*field(s) = n
+			return nil
+		},
+	}
+}
+
+func int64Field(key string, field func(s *RuntimeSettings) *int64) runtimeSettingField {
+	return runtimeSettingField{
+		key: key,
+		get: func(s *RuntimeSettings) string { return strconv.FormatInt(*field(s), 10) },
+		set: func(s *RuntimeSettings, v string) error {
+			n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return &RuntimeSettingError{Key: key, Reason: fmt.Sprintf("must be an integer (got %q)", v)}
+			}
+			*field(s) = n
+			return nil
+		},
+	}
+}
+
+// RuntimeSettingKeys 変更できる設定のキー（一覧の表示順）
+func RuntimeSettingKeys() []string {
+	keys := make([]string, 0, len(runtimeSettingFields))
+	for _, f := range runtimeSettingFields {
+		keys = append(keys, f.key)
+	}
+	return keys
+}
+
+func findRuntimeSettingField(key string) (runtimeSettingField, error) {
+	for _, f := range runtimeSettingFields {
+		if f.key == key {
+			return f, nil
+		}
+	}
+	return runtimeSettingField{}, fmt.Errorf("%w: %q", ErrUnknownRuntimeSetting, key)
+}
+
+// Get key の値を文字列で返す（未知のキーは空文字）
+func (s RuntimeSettings) Get(key string) string {
+	f, err := findRuntimeSettingField(key)
+	if err != nil {
+		return ""
+	}
+	return f.get(&s)
+}
+
+// Set key の値を文字列から設定する
+func (s *RuntimeSettings) Set(key, value string) error {
+	f, err := findRuntimeSettingField(key)
+	if err != nil {
+		return err
+	}
+	return f.set(s, value)
+}
+
+// Apply 保存された設定値で上書きする
+func (s *RuntimeSettings) Apply(overrides []*RuntimeSetting) error {
+	var errs []error
+	for _, o := range overrides {
+		if err := s.Set(o.Key, o.Value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate 値の範囲を確認し、問題をまとめて返す
+func (s RuntimeSettings) Validate() error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...any) {
+		if !ok {
+			errs = append(errs, &RuntimeSettingError{Key: key, Reason: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	// 24 時間を超える作業は想定しない（期限切れタイマーが残り続ける）
+	check(s.SessionDefaultDuration >= time.Minute && s.SessionDefaultDuration <= 24*time.Hour,
+		SettingSessionDefaultDuration, "must be between 1m and 24h (got %s)", s.SessionDefaultDuration)
+	check(s.MinExtensionMinutes >= 1, SettingMinExtensionMinutes, "must be at least 1 (got %d)", s.MinExtensionMinutes)
+	check(s.MaxExtensionMinutes >= s.MinExtensionMinutes && s.MaxExtensionMinutes <= 24*60,
+		SettingMaxExtensionMinutes, "must be between %s and 1440 (got %d)", SettingMinExtensionMinutes, s.MaxExtensionMinutes)
+	check(s.CheerBonusPoints >= 0, SettingCheerBonusPoints, "must not be negative (got %d)", s.CheerBonusPoints)
+	check(s.ChannelPointsPerRaziiipo >= 1, SettingChannelPointsPerRaziiipo, "must be at least 1 (got %d)", s.ChannelPointsPerRaziiipo)
+	check(s.AutoBlockDuration >= 0, SettingAutoBlockDuration, "must not be negative (got %s)", s.AutoBlockDuration)
+
+	return errors.Join(errs...)
+}
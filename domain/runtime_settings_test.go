@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func validRuntimeSettings() RuntimeSettings {
+	return RuntimeSettings{
+		SessionDefaultDuration:   time.Hour,
+		MinExtensionMinutes:      1,
+		MaxExtensionMinutes:      360,
+		CheerBonusPoints:         5,
+		ChannelPointsPerRaziiipo: DefaultChannelPointsPerRaziiipo,
+	}
+}
+
+func TestRuntimeSettings_SetGet(t *testing.T) {
+	s := validRuntimeSettings()
+
+	if err := s.Set(SettingSessionDefaultDuration, " 90m "); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Set(SettingCheerBonusPoints, "12"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.SessionDefaultDuration != 90*time.Minute || s.Get(SettingSessionDefaultDuration) != "1h30m0s" {
+		t.Errorf("unexpected duration: %s", s.Get(SettingSessionDefaultDuration))
+	}
+	if s.CheerBonusPoints != 12 || s.Get(SettingCheerBonusPoints) != "12" {
+		t.Errorf("unexpected bonus: %s", s.Get(SettingCheerBonusPoints))
+	}
+
+	if err := s.Set("session.unknown", "1"); !errors.Is(err, ErrUnknownRuntimeSetting) {
+		t.Errorf("expected ErrUnknownRuntimeSetting, got %v", err)
+	}
+	if err := s.Set(SettingMaxExtensionMinutes, "many"); !errors.Is(err, ErrInvalidRuntimeSetting) {
+		t.Errorf("expected ErrInvalidRuntimeSetting, got %v", err)
+	}
+	if s.Get("session.unknown") != "" {
+		t.Errorf("expected an empty value for an unknown key")
+	}
+}
+
+func TestRuntimeSettings_Apply(t *testing.T) {
+	s := validRuntimeSettings()
+	err := s.Apply([]*RuntimeSetting{
+		{Key: SettingChannelPointsPerRaziiipo, Value: "5"},
+		{Key: SettingAutoBlockDuration, Value: "soon"},
+	})
+
+	// 不正な値があっても、正しい値は反映される
+	if !errors.Is(err, ErrInvalidRuntimeSetting) {
+		t.Errorf("expected ErrInvalidRuntimeSetting, got %v", err)
+	}
+	if s.ChannelPointsPerRaziiipo != 5 || s.AutoBlockDuration != 0 {
+		t.Errorf("unexpected settings: %+v", s)
+	}
+}
+
+func TestRuntimeSettings_Validate(t *testing.T) {
+	if err := validRuntimeSettings().Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s := validRuntimeSettings()
+	s.MinExtensionMinutes = 30
+	s.MaxExtensionMinutes = 10
+	s.ChannelPointsPerRaziiipo = 0
+	err := s.Validate()
+	if !errors.Is(err, ErrInvalidRuntimeSetting) {
+		t.Fatalf("expected ErrInvalidRuntimeSetting, got %v", err)
+	}
+	for _, want := range []string{
+		"session.max_extension_minutes must be between session.min_extension_minutes and 1440 (got 10)",
+		"points.channel_points_per_raziiipo must be at least 1 (got 0)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in:\n%v", want, err)
+		}
+	}
+}
+
+func TestNewRuntimeSetting(t *testing.T) {
+	t.Run("値を正規化して保持する", func(t *testing.T) {
+		setting, err := NewRuntimeSetting(SettingAutoBlockDuration, "1440m", " admin ", fixedNow)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if setting.Value != "24h0m0s" || setting.UpdatedBy != "admin" || !setting.UpdatedAt.Equal(fixedNow()) {
+			t.Errorf("unexpected setting: %+v", setting)
+		}
+	})
+
+	t.Run("実行者は必須", func(t *testing.T) {
+		if _, err := NewRuntimeSetting(SettingCheerBonusPoints, "1", " ", fixedNow); err != ErrEmptyAuditActor {
+			t.Errorf("expected ErrEmptyAuditActor, got %v", err)
+		}
+	})
+
+	t.Run("未知のキーと不正な値はエラー", func(t *testing.T) {
+		if _, err := NewRuntimeSetting("cheer.unknown", "1", "admin", fixedNow); !errors.Is(err, ErrUnknownRuntimeSetting) {
+			t.Errorf("expected ErrUnknownRuntimeSetting, got %v", err)
+		}
+		if _, err := NewRuntimeSetting(SettingCheerBonusPoints, "1.5", "admin", fixedNow); !errors.Is(err, ErrInvalidRuntimeSetting) {
+			t.Errorf("expected ErrInvalidRuntimeSetting, got %v", err)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"time"
+	"unicode/utf8"
 )
 
 var (
@@ -11,8 +12,12 @@ var (
 	ErrInvalidDuration         = errors.New("invalid duration: must be positive")
 	ErrInvalidExtension        = errors.New("invalid extension: must be positive")
 	ErrUserAlreadyInSession    = errors.New("user already has an active session")
+	ErrWorkNameTooLong         = errors.New("work name is too long")
 )
 
+// WorkNameMaxLength 作業名の最大文字数（API の maxLength と同じ）
+const WorkNameMaxLength = 200
+
 // Session 作業セッションを表す
 type Session struct {
 	ID         int64
@@ -32,6 +37,10 @@ func NewSession(userID int64, workName string, duration time.Duration, now func(
 	if duration <= 0 {
 		return nil, ErrInvalidDuration
 	}
+	workName, err := NewWorkName(workName)
+	if err != nil {
+		return nil, err
+	}
 
 	t := time.Now
 	if now != nil {
@@ -105,6 +114,10 @@ func (s *Session) ChangeWorkName(workName string, now func() time.Time) error {
 	if !s.IsActive() {
 		return ErrSessionAlreadyCompleted
 	}
+	workName, err := NewWorkName(workName)
+	if err != nil {
+		return err
+	}
 
 	s.WorkName = workName
 	s.Touch(now)
@@ -119,3 +132,13 @@ func (s *Session) Touch(now func() time.Time) {
 	}
 	s.UpdatedAt = t()
 }
+
+// NewWorkName 作業名をオーバーレイに表示できる形にする（空の作業名は許可する）
+// 表示を崩す文字を取り除き、WorkNameMaxLength を超える場合は拒否する
+func NewWorkName(workName string) (string, error) {
+	workName = SanitizeComment(workName)
+	if utf8.RuneCountInString(workName) > WorkNameMaxLength {
+		return "", ErrWorkNameTooLong
+	}
+	return workName, nil
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidSessionPeriod = errors.New("invalid session period: end must be after start")
+	ErrSessionInFuture      = errors.New("session time must not be in the future")
+	ErrSessionOverlap       = errors.New("session overlaps another session of the same user")
+	ErrSessionActive        = errors.New("session is active")
+)
+
+// SessionCorrection 管理者によるセッション記録の修正内容（nil の項目は変更しない）
+type SessionCorrection struct {
+	StartTime *time.Time
+	ActualEnd *time.Time
+	WorkName  *string
+}
+
+// NewManualSession 記録漏れを補うため、終了済みのセッションを手動で作成する
+func NewManualSession(userID int64, workName string, startTime, actualEnd time.Time, now func() time.Time) (*Session, error) {
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	nowT := t()
+
+	if err := validateSessionPeriod(startTime, &actualEnd, nowT); err != nil {
+		return nil, err
+	}
+	workName, err := NewWorkName(workName)
+	if err != nil {
+		return nil, err
+	}
+
+	end := actualEnd
+	return &Session{
+		UserID:     userID,
+		WorkName:   workName,
+		StartTime:  startTime,
+		PlannedEnd: actualEnd,
+		ActualEnd:  &end,
+		CreatedAt:  nowT,
+		UpdatedAt:  nowT,
+	}, nil
+}
+
+// Correct 開始・終了時刻や作業名を修正する
+// アクティブなセッションの終了時刻は変更できない（強制終了を使う）
+func (s *Session) Correct(c SessionCorrection, now func() time.Time) error {
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	nowT := t()
+
+	if c.ActualEnd != nil && s.IsActive() {
+		return ErrSessionActive
+	}
+
+	startTime := s.StartTime
+	if c.StartTime != nil {
+		startTime = *c.StartTime
+	}
+	actualEnd := s.ActualEnd
+	if c.ActualEnd != nil {
+		end := *c.ActualEnd
+		actualEnd = &end
+	}
+	if err := validateSessionPeriod(startTime, actualEnd, nowT); err != nil {
+		return err
+	}
+	workName := s.WorkName
+	if c.WorkName != nil {
+		name, err := NewWorkName(*c.WorkName)
+		if err != nil {
+			return err
+		}
+		workName = name
+	}
+
+	s.StartTime = startTime
+	s.ActualEnd = actualEnd
+	if actualEnd != nil && s.PlannedEnd.Before(startTime) {
+		// 終了済みのセッションは予定終了時刻を実績に合わせる
+		s.PlannedEnd = *actualEnd
+	}
+	s.WorkName = workName
+	s.UpdatedAt = nowT
+	return nil
+}
+
+// CheckSessionConflicts 同じユーザーの他のセッションと矛盾しないか確認する
+// 期間の重複と、アクティブなセッションが複数になることを禁止する
+func CheckSessionConflicts(target *Session, others []*Session) error {
+	for _, other := range others {
+		if other.ID == target.ID || other.UserID != target.UserID {
+			continue
+		}
+		if target.IsActive() && other.IsActive() {
+			return ErrUserAlreadyInSession
+		}
+		if target.overlaps(other) {
+			return ErrSessionOverlap
+		}
+	}
+	return nil
+}
+
+// overlaps 2つのセッションの期間が重なるか（アクティブなセッションは終了時刻が未定として扱う）
+func (s *Session) overlaps(other *Session) bool {
+	startsBeforeOtherEnds := other.ActualEnd == nil || s.StartTime.Before(*other.ActualEnd)
+	endsAfterOtherStarts := s.ActualEnd == nil || s.ActualEnd.After(other.StartTime)
+	return startsBeforeOtherEnds && endsAfterOtherStarts
+}
+
+func validateSessionPeriod(startTime time.Time, actualEnd *time.Time, now time.Time) error {
+	if startTime.After(now) || (actualEnd != nil && actualEnd.After(now)) {
+		return ErrSessionInFuture
+	}
+	if actualEnd != nil && !actualEnd.After(startTime) {
+		return ErrInvalidSessionPeriod
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewManualSession(t *testing.T) {
+	start := fixedNow().Add(-2 * time.Hour)
+	end := fixedNow().Add(-time.Hour)
+
+	session, err := NewManualSession(1, "論文執筆", start, end, fixedNow)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.IsActive() || !session.ActualEnd.Equal(end) || !session.PlannedEnd.Equal(end) {
+		t.Errorf("expected a completed session, got %+v", session)
+	}
+
+	if _, err := NewManualSession(1, "", end, start, fixedNow); err != ErrInvalidSessionPeriod {
+		t.Errorf("expected ErrInvalidSessionPeriod, got %v", err)
+	}
+	if _, err := NewManualSession(1, "", start, start, fixedNow); err != ErrInvalidSessionPeriod {
+		t.Errorf("expected ErrInvalidSessionPeriod for zero length, got %v", err)
+	}
+	if _, err := NewManualSession(1, "", start, fixedNow().Add(time.Minute), fixedNow); err != ErrSessionInFuture {
+		t.Errorf("expected ErrSessionInFuture, got %v", err)
+	}
+}
+
+func TestSession_Correct(t *testing.T) {
+	completed := func() *Session {
+		s, _ := NewManualSession(1, "作業", fixedNow().Add(-2*time.Hour), fixedNow().Add(-time.Hour), fixedNow)
+		return s
+	}
+	at := func(d time.Duration) *time.Time {
+		t := fixedNow().Add(d)
+		return &t
+	}
+
+	t.Run("終了時刻と作業名を修正", func(t *testing.T) {
+		s := completed()
+		name := "資格勉強"
+		if err := s.Correct(SessionCorrection{ActualEnd: at(-30 * time.Minute), WorkName: &name}, fixedNow); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !s.ActualEnd.Equal(*at(-30 * time.Minute)) || s.WorkName != "資格勉強" {
+			t.Errorf("unexpected session: %+v", s)
+		}
+	})
+
+	t.Run("開始時刻を予定終了より後にすると予定終了を実績に合わせる", func(t *testing.T) {
+		s := completed()
+		s.PlannedEnd = fixedNow().Add(-90 * time.Minute)
+		if err := s.Correct(SessionCorrection{StartTime: at(-80 * time.Minute)}, fixedNow); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !s.PlannedEnd.Equal(*s.ActualEnd) {
+			t.Errorf("expected planned end to follow actual end, got %v", s.PlannedEnd)
+		}
+	})
+
+	t.Run("不正な期間は変更しない", func(t *testing.T) {
+		s := completed()
+		original := *s
+		if err := s.Correct(SessionCorrection{StartTime: at(-30 * time.Minute)}, fixedNow); err != ErrInvalidSessionPeriod {
+			t.Fatalf("expected ErrInvalidSessionPeriod, got %v", err)
+		}
+		if err := s.Correct(SessionCorrection{ActualEnd: at(time.Hour)}, fixedNow); err != ErrSessionInFuture {
+			t.Fatalf("expected ErrSessionInFuture, got %v", err)
+		}
+		if !s.StartTime.Equal(original.StartTime) || !s.ActualEnd.Equal(*original.ActualEnd) {
+			t.Errorf("failed correction should not change the session: %+v", s)
+		}
+	})
+
+	t.Run("作業名は /in と同じく検証する", func(t *testing.T) {
+		s := completed()
+		long := strings.Repeat("あ", WorkNameMaxLength+1)
+		if err := s.Correct(SessionCorrection{StartTime: at(-90 * time.Minute), WorkName: &long}, fixedNow); err != ErrWorkNameTooLong {
+			t.Fatalf("expected ErrWorkNameTooLong, got %v", err)
+		}
+		if s.WorkName != "作業" || !s.StartTime.Equal(*at(-2 * time.Hour)) {
+			t.Errorf("failed correction should not change the session: %+v", s)
+		}
+
+		name := " 資格\n勉強 "
+		if err := s.Correct(SessionCorrection{WorkName: &name}, fixedNow); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s.WorkName != "資格 勉強" {
+			t.Errorf("expected sanitized work name, got %q", s.WorkName)
+		}
+	})
+
+	t.Run("アクティブなセッションは開始時刻のみ修正できる", func(t *testing.T) {
+		s, _ := NewSession(1, "作業", time.Hour, fixedNow)
+		if err := s.Correct(SessionCorrection{ActualEnd: at(0)}, fixedNow); err != ErrSessionActive {
+			t.Fatalf("expected ErrSessionActive, got %v", err)
+		}
+		if err := s.Correct(SessionCorrection{StartTime: at(-time.Hour)}, fixedNow); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !s.IsActive() || !s.StartTime.Equal(*at(-time.Hour)) {
+			t.Errorf("unexpected session: %+v", s)
+		}
+	})
+}
+
+func TestCheckSessionConflicts(t *testing.T) {
+	manual := func(id int64, from, to time.Duration) *Session {
+		s, _ := NewManualSession(1, "", fixedNow().Add(from), fixedNow().Add(to), fixedNow)
+		s.ID = id
+		return s
+	}
+	active := func(id int64, from time.Duration) *Session {
+		s, _ := NewSession(1, "", time.Hour, func() time.Time { return fixedNow().Add(from) })
+		s.ID = id
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		target  *Session
+		others  []*Session
+		wantErr error
+	}{
+		{name: "重複なし", target: manual(1, -3*time.Hour, -2*time.Hour), others: []*Session{manual(2, -time.Hour, -30*time.Minute)}},
+		{name: "隣接は重複ではない", target: manual(1, -2*time.Hour, -time.Hour), others: []*Session{manual(2, -time.Hour, -30*time.Minute)}},
+		{name: "期間が重なる", target: manual(1, -2*time.Hour, -time.Hour), others: []*Session{manual(2, -90*time.Minute, -30*time.Minute)}, wantErr: ErrSessionOverlap},
+		{name: "アクティブなセッションの後に重なる", target: manual(1, -2*time.Hour, -time.Hour), others: []*Session{active(2, -90*time.Minute)}, wantErr: ErrSessionOverlap},
+		{name: "アクティブなセッションが2つになる", target: active(1, -time.Hour), others: []*Session{active(2, -3*time.Hour)}, wantErr: ErrUserAlreadyInSession},
+		{name: "自分自身は除外", target: manual(1, -2*time.Hour, -time.Hour), others: []*Session{manual(1, -2*time.Hour, -time.Hour)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckSessionConflicts(tt.target, tt.others); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
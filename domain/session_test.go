@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -52,6 +53,33 @@ func TestNewSession_InvalidDuration(t *testing.T) {
 	}
 }
 
+func TestNewWorkName(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr error
+	}{
+		{"そのまま", "論文執筆", "論文執筆", nil},
+		{"空は許可する", "", "", nil},
+		{"改行と前後の空白を除く", "  論文\n執筆 ", "論文 執筆", nil},
+		{"上限ちょうど", strings.Repeat("a", WorkNameMaxLength), strings.Repeat("a", WorkNameMaxLength), nil},
+		{"上限を超える", strings.Repeat("あ", WorkNameMaxLength+1), "", ErrWorkNameTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWorkName(tt.in)
+			if err != tt.wantErr || got != tt.want {
+				t.Errorf("NewWorkName(%q) = %q, %v; want %q, %v", tt.in, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := NewSession(1, strings.Repeat("あ", WorkNameMaxLength+1), time.Hour, fixedNow); err != ErrWorkNameTooLong {
+		t.Errorf("NewSession: expected ErrWorkNameTooLong, got %v", err)
+	}
+}
+
 func TestSession_Extend(t *testing.T) {
 	session, _ := NewSession(1, "work", 60*time.Minute, fixedTime)
 	originalPlannedEnd := session.PlannedEnd
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrEventSubMessageProcessed = errors.New("eventsub message already processed")
+
+// Twitch EventSub のサブスクリプション種別
+const (
+	EventSubTypeChannelPointsRedemption = "channel.channel_points_custom_reward_redemption.add"
+	EventSubTypeChannelSubscribe        = "channel.subscribe"
+)
+
+// ParseTwitchSubTier EventSub の tier（"1000" / "2000" / "3000"）をティアにする
+func ParseTwitchSubTier(s string) (Tier, error) {
+	switch s {
+	case "1000":
+		return Tier1, nil
+	case "2000":
+		return Tier2, nil
+	case "3000":
+		return Tier3, nil
+	default:
+		return TierUnknown, fmt.Errorf("invalid twitch subscription tier: %s", s)
+	}
+}
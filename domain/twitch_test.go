@@ -0,0 +1,28 @@
+package domain
+
+import "testing"
+
+func TestParseTwitchSubTier(t *testing.T) {
+	tests := map[string]Tier{"1000": Tier1, "2000": Tier2, "3000": Tier3}
+	for in, want := range tests {
+		got, err := ParseTwitchSubTier(in)
+		if err != nil || got != want {
+			t.Errorf("ParseTwitchSubTier(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseTwitchSubTier("prime"); err == nil {
+		t.Error("expected error for unknown tier")
+	}
+}
+
+func TestChannelPointsToRaziiipo(t *testing.T) {
+	tests := map[int64]int64{100: 10, 1000: 100, 105: 10, 9: 0, 0: 0, -100: 0}
+	for in, want := range tests {
+		if got := ChannelPointsToRaziiipo(in, DefaultChannelPointsPerRaziiipo); got != want {
+			t.Errorf("ChannelPointsToRaziiipo(%d) = %d, want %d", in, got, want)
+		}
+	}
+	if got := ChannelPointsToRaziiipo(100, 5); got != 20 {
+		t.Errorf("expected 20 points at a rate of 5, got %d", got)
+	}
+}
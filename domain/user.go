@@ -11,14 +11,26 @@ var (
 	ErrInvalidTier       = errors.New("invalid tier")
 	ErrUserNotFound      = errors.New("user not found")
 	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrUserBlocked       = errors.New("user is blocked")
+	ErrUserNotBlocked    = errors.New("user is not blocked")
+	ErrEmptyBlockActor   = errors.New("block actor must not be empty")
+	ErrInvalidBlockTerm  = errors.New("invalid block duration: must not be negative")
 )
 
+// PermanentBlockUntil 無期限ブロックを表す BlockedUntil の値
+var PermanentBlockUntil = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+
 type User struct {
 	ID        int64
 	Name      string
 	Tier      Tier
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// ブロック状態（BlockedUntil が nil ならブロックされていない）
+	BlockedUntil *time.Time
+	BlockReason  string
+	BlockedBy    string
 }
 
 func NewUser(name string, tier Tier, now func() time.Time) (*User, error) {
@@ -51,6 +63,71 @@ func (u *User) Touch(now func() time.Time) {
 	u.UpdatedAt = t()
 }
 
+// ChangeTier ティアを変更する（サブスクの開始・変更時）
+func (u *User) ChangeTier(tier Tier, now func() time.Time) error {
+	if !tier.Valid() {
+		return ErrInvalidTier
+	}
+	u.Tier = tier
+	u.Touch(now)
+	return nil
+}
+
+// Block ユーザーをブロックする（duration が0なら無期限）
+func (u *User) Block(reason, actor string, duration time.Duration, now func() time.Time) error {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return ErrEmptyBlockActor
+	}
+	if duration < 0 {
+		return ErrInvalidBlockTerm
+	}
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	nowT := t()
+
+	until := PermanentBlockUntil
+	if duration > 0 {
+		until = nowT.Add(duration)
+	}
+	u.BlockedUntil = &until
+	u.BlockReason = strings.TrimSpace(reason)
+	u.BlockedBy = actor
+	u.UpdatedAt = nowT
+	return nil
+}
+
+// Unblock ブロックを解除する
+func (u *User) Unblock(now func() time.Time) error {
+	if u.BlockedUntil == nil {
+		return ErrUserNotBlocked
+	}
+	u.BlockedUntil = nil
+	u.BlockReason = ""
+	u.BlockedBy = ""
+	u.Touch(now)
+	return nil
+}
+
+// IsBlocked 現在ブロック中かを確認する（期限切れのブロックは無効）
+func (u *User) IsBlocked(now func() time.Time) bool {
+	if u.BlockedUntil == nil {
+		return false
+	}
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	return t().Before(*u.BlockedUntil)
+}
+
+// IsPermanentlyBlocked 無期限ブロックかを確認する
+func (u *User) IsPermanentlyBlocked() bool {
+	return u.BlockedUntil != nil && u.BlockedUntil.Equal(PermanentBlockUntil)
+}
+
 // 追加のバリデーションが必要ならここに集約
 func (u *User) Validate() error {
 	if strings.TrimSpace(u.Name) == "" {
@@ -59,3 +59,67 @@ func TestUser_Touch(t *testing.T) {
 		t.Fatalf("updatedAt should be advanced by Touch")
 	}
 }
+
+func TestUser_Block(t *testing.T) {
+	t.Run("期間付きブロック", func(t *testing.T) {
+		u, _ := NewUser("troll", Tier1, fixedNow)
+		if err := u.Block("spam", "moderator", time.Hour, fixedNow); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !u.IsBlocked(fixedNow) {
+			t.Error("user should be blocked")
+		}
+		if u.BlockReason != "spam" || u.BlockedBy != "moderator" {
+			t.Errorf("unexpected block state: %q by %q", u.BlockReason, u.BlockedBy)
+		}
+		if u.IsPermanentlyBlocked() {
+			t.Error("timed block should not be permanent")
+		}
+		afterExpiry := func() time.Time { return fixedNow().Add(time.Hour) }
+		if u.IsBlocked(afterExpiry) {
+			t.Error("block should expire after the duration")
+		}
+	})
+
+	t.Run("期間0は無期限", func(t *testing.T) {
+		u, _ := NewUser("troll", Tier1, fixedNow)
+		if err := u.Block("", "moderator", 0, fixedNow); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !u.IsPermanentlyBlocked() {
+			t.Error("zero duration should block permanently")
+		}
+		farFuture := func() time.Time { return fixedNow().AddDate(100, 0, 0) }
+		if !u.IsBlocked(farFuture) {
+			t.Error("permanent block should not expire")
+		}
+	})
+
+	t.Run("不正な入力", func(t *testing.T) {
+		u, _ := NewUser("troll", Tier1, fixedNow)
+		if err := u.Block("spam", " ", time.Hour, fixedNow); err != ErrEmptyBlockActor {
+			t.Errorf("expected ErrEmptyBlockActor, got %v", err)
+		}
+		if err := u.Block("spam", "moderator", -time.Hour, fixedNow); err != ErrInvalidBlockTerm {
+			t.Errorf("expected ErrInvalidBlockTerm, got %v", err)
+		}
+		if u.BlockedUntil != nil {
+			t.Error("failed block should not change state")
+		}
+	})
+}
+
+func TestUser_Unblock(t *testing.T) {
+	u, _ := NewUser("troll", Tier1, fixedNow)
+	if err := u.Unblock(fixedNow); err != ErrUserNotBlocked {
+		t.Errorf("expected ErrUserNotBlocked, got %v", err)
+	}
+
+	_ = u.Block("spam", "moderator", 0, fixedNow)
+	if err := u.Unblock(fixedNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.IsBlocked(fixedNow) || u.BlockReason != "" || u.BlockedBy != "" {
+		t.Errorf("block state should be cleared, got %+v", u)
+	}
+}
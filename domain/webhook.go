@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidWebhookURL          = errors.New("webhook URL must be an absolute http or https URL")
+	ErrEmptyWebhookSecret         = errors.New("webhook secret must not be empty")
+	ErrInvalidWebhookEventType    = errors.New("invalid webhook event type")
+	ErrWebhookNotFound            = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound    = errors.New("webhook delivery not found")
+	ErrWebhookDeliveryNotRetrying = errors.New("only dead webhook deliveries can be retried")
+)
+
+const (
+	// MaxWebhookAttempts 配信を諦めてデッドレターにするまでの試行回数
+	MaxWebhookAttempts = 8
+	// webhookBaseBackoff 1 回目の失敗後の再送間隔（以降は倍々に伸ばす）
+	webhookBaseBackoff = 30 * time.Second
+	// webhookMaxBackoff 再送間隔の上限
+	webhookMaxBackoff = time.Hour
+)
+
+// WebhookDeliveryStatus Webhook 配信の状態
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // 配信待ち・再送待ち
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered" // 2xx を受け取った
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead"      // 試行回数の上限に達した（デッドレター）
+)
+
+// Valid 定義済みの状態かを確認する
+func (s WebhookDeliveryStatus) Valid() bool {
+	return s == WebhookDeliveryPending || s == WebhookDeliveryDelivered || s == WebhookDeliveryDead
+}
+
+// WebhookSubscription 管理者が登録した Webhook の送信先
+type WebhookSubscription struct {
+	ID         int64
+	URL        string
+	EventTypes []string // 空なら全イベントを送信する
+	Secret     string   // HMAC-SHA256 署名の鍵
+	CreatedBy  string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewWebhookSubscription Webhook の送信先を作成する
+func NewWebhookSubscription(rawURL string, eventTypes []string, secret, createdBy string, now func() time.Time) (*WebhookSubscription, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrInvalidWebhookURL
+	}
+	if strings.TrimSpace(secret) == "" {
+		return nil, ErrEmptyWebhookSecret
+	}
+
+	types := make([]string, 0, len(eventTypes))
+	seen := make(map[string]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if eventType == "" {
+			return nil, ErrInvalidWebhookEventType
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			types = append(types, eventType)
+		}
+	}
+
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	nowT := t()
+
+	return &WebhookSubscription{
+		URL:        rawURL,
+		EventTypes: types,
+		Secret:     secret,
+		CreatedBy:  strings.TrimSpace(createdBy),
+		CreatedAt:  nowT,
+		UpdatedAt:  nowT,
+	}, nil
+}
+
+// Accepts イベント種別が送信対象かを確認する
+func (s *WebhookSubscription) Accepts(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery 送信先ごとの 1 イベントの配信（永続化された再送キューの要素）
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventID        int64 // アウトボックスのイベントID（同じ送信先への重複配信を防ぐ）
+	EventType      string
+	Payload        json.RawMessage
+	Status         WebhookDeliveryStatus
+	Attempts       int32
+	NextAttemptAt  time.Time
+	LastError      string
+	LastStatusCode int32 // 直近の HTTP ステータス（応答がなければ 0）
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewWebhookDelivery 配信待ちの Webhook 配信を作成する
+func NewWebhookDelivery(subscriptionID, eventID int64, eventType string, payload json.RawMessage, now func() time.Time) *WebhookDelivery {
+	t := time.Now
+	if now != nil {
+		t = now
+	}
+	nowT := t()
+
+	return &WebhookDelivery{
+		SubscriptionID: subscriptionID,
+		EventID:        eventID,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  nowT,
+		CreatedAt:      nowT,
+		UpdatedAt:      nowT,
+	}
+}
+
+// MarkDelivered 配信に成功したことを記録する
+func (d *WebhookDelivery) MarkDelivered(statusCode int, now func() time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliveryDelivered
+	d.LastStatusCode = int32(statusCode)
+	d.LastError = ""
+	d.UpdatedAt = now()
+}
+
+// MarkFailed 配信の失敗を記録し、次の再送時刻を指数バックオフで決める
+// 試行回数が MaxWebhookAttempts に達したらデッドレターにする
+func (d *WebhookDelivery) MarkFailed(statusCode int, reason string, now func() time.Time) {
+	nowT := now()
+	d.Attempts++
+	d.LastStatusCode = int32(statusCode)
+	d.LastError = reason
+	d.UpdatedAt = nowT
+
+	if d.Attempts >= MaxWebhookAttempts {
+		d.Status = WebhookDeliveryDead
+		return
+	}
+	d.NextAttemptAt = nowT.Add(WebhookBackoff(d.Attempts))
+}
+
+// Requeue デッドレターの配信を再送キューに戻す
+func (d *WebhookDelivery) Requeue(now func() time.Time) error {
+	if d.Status != WebhookDeliveryDead {
+		return ErrWebhookDeliveryNotRetrying
+	}
+	nowT := now()
+	d.Status = WebhookDeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = nowT
+	d.UpdatedAt = nowT
+	return nil
+}
+
+// WebhookBackoff attempts 回失敗した後の再送間隔（30 秒から倍々、上限 1 時間）
+func WebhookBackoff(attempts int32) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	backoff := webhookBaseBackoff
+	for i := int32(1); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= webhookMaxBackoff {
+			return webhookMaxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewWebhookSubscription(t *testing.T) {
+	t.Run("URL とイベント種別を正規化する", func(t *testing.T) {
+		sub, err := NewWebhookSubscription(" https://example.com/hook ", []string{"session_start", " session_start", "session_end"}, "secret", "streamer", fixedNow)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sub.URL != "https://example.com/hook" {
+			t.Errorf("unexpected URL: %q", sub.URL)
+		}
+		if len(sub.EventTypes) != 2 || sub.EventTypes[0] != "session_start" || sub.EventTypes[1] != "session_end" {
+			t.Errorf("unexpected event types: %v", sub.EventTypes)
+		}
+	})
+
+	t.Run("不正な入力はエラー", func(t *testing.T) {
+		cases := []struct {
+			url        string
+			eventTypes []string
+			secret     string
+			want       error
+		}{
+			{"ftp://example.com", nil, "secret", ErrInvalidWebhookURL},
+			{"/relative", nil, "secret", ErrInvalidWebhookURL},
+			{"https://example.com", nil, " ", ErrEmptyWebhookSecret},
+			{"https://example.com", []string{""}, "secret", ErrInvalidWebhookEventType},
+		}
+		for _, c := range cases {
+			if _, err := NewWebhookSubscription(c.url, c.eventTypes, c.secret, "streamer", fixedNow); err != c.want {
+				t.Errorf("NewWebhookSubscription(%q, %v) = %v, want %v", c.url, c.eventTypes, err, c.want)
+			}
+		}
+	})
+}
+
+func TestWebhookSubscription_Accepts(t *testing.T) {
+	all := &WebhookSubscription{}
+	if !all.Accepts("session_start") {
+		t.Error("subscription without filter should accept every event")
+	}
+
+	filtered := &WebhookSubscription{EventTypes: []string{"session_end"}}
+	if filtered.Accepts("session_start") || !filtered.Accepts("session_end") {
+		t.Errorf("unexpected filter result for %v", filtered.EventTypes)
+	}
+}
+
+func TestWebhookDelivery_Retry(t *testing.T) {
+	d := NewWebhookDelivery(1, 10, "session_start", []byte(`{}`), fixedNow)
+	if d.Status != WebhookDeliveryPending || !d.NextAttemptAt.Equal(fixedNow()) {
+		t.Fatalf("unexpected new delivery: %+v", d)
+	}
+
+	d.MarkFailed(500, "server error", fixedNow)
+	if d.Status != WebhookDeliveryPending || d.Attempts != 1 || !d.NextAttemptAt.Equal(fixedNow().Add(30*time.Second)) {
+		t.Errorf("unexpected delivery after first failure: %+v", d)
+	}
+
+	for d.Status == WebhookDeliveryPending {
+		d.MarkFailed(0, "connection refused", fixedNow)
+	}
+	if d.Status != WebhookDeliveryDead || d.Attempts != MaxWebhookAttempts || d.LastError != "connection refused" {
+		t.Errorf("expected dead letter after %d attempts, got %+v", MaxWebhookAttempts, d)
+	}
+
+	if err := d.Requeue(fixedNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Status != WebhookDeliveryPending || d.Attempts != 0 {
+		t.Errorf("expected requeued delivery, got %+v", d)
+	}
+	if err := d.Requeue(fixedNow); err != ErrWebhookDeliveryNotRetrying {
+		t.Errorf("expected ErrWebhookDeliveryNotRetrying, got %v", err)
+	}
+
+	d.MarkDelivered(204, fixedNow)
+	if d.Status != WebhookDeliveryDelivered || d.LastStatusCode != 204 || d.LastError != "" {
+		t.Errorf("unexpected delivered state: %+v", d)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	cases := map[int32]time.Duration{
+		0:  0,
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		7:  32 * time.Minute,
+		8:  time.Hour,
+		20: time.Hour,
+	}
+	for attempts, want := range cases {
+		if got := WebhookBackoff(attempts); got != want {
+			t.Errorf("WebhookBackoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
@@ -1,30 +1,222 @@
+// Package config アプリケーションの設定を読み込む
+// 既定値・設定ファイル（YAML）・環境変数・コマンドラインフラグの順に重ね、起動時に値の範囲を検証する
 package config
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"time"
+	// 配信のタイムゾーンを tzdata のないコンテナでも読み込めるようにする
+	_ "time/tzdata"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database"
+	"github.com/yamada-ai/workspace-backend/infrastructure/telemetry"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
 )
 
 // Config holds application configuration
 type Config struct {
 	DatabaseURL string
-	ServerPort  string
+	// DatabasePool コネクションプールの大きさと接続の寿命
+	DatabasePool database.PoolOptions
+	// Port HTTP サーバーのポート
+	Port int
+	// HTTP HTTP サーバーのタイムアウトと CORS
+	HTTP HTTPConfig
+	// Session /in のセッションの長さと /more で延長できる範囲
+	Session SessionConfig
+
+	IdempotencyKeyTTL time.Duration
+	// RateLimit コマンドの実行頻度の制限
+	RateLimit RateLimitConfig
+
+	// AdminAPIToken 管理 API の Bearer トークン（空なら管理 API は無効）
+	AdminAPIToken string
+	// AutoBlockDuration 禁止ワードによる自動ブロックの期間（0なら無期限）
+	AutoBlockDuration time.Duration
+	// WSHub WebSocket Hub のキューとバッファの大きさ、送信が追いつかないクライアントへの対応（evict / drop）
+	WSHub WSHubConfig
+	// TwitchEventSubSecret EventSub の署名検証に使うシークレット（空なら EventSub の受け口は無効）
+	TwitchEventSubSecret string
+	// TwitchIRC Twitch チャットへの接続設定（Token が空ならチャットには接続しない）
+	TwitchIRC TwitchIRCConfig
+	// StreamLocation 配信のタイムゾーン（/cheer の 1 日 1 回はこの 0 時で日が変わる）
+	StreamLocation *time.Location
+	// CheerBonusPoints /cheer で応援された人に付与する Raziiipo（0 なら付与しない）
+	CheerBonusPoints int64
+	// ChannelPointsPerRaziiipo Raziiipo 1pt に交換するチャンネルポイント
+	ChannelPointsPerRaziiipo int64
+	// LogFormat ログの出力形式（text / json）
+	LogFormat logging.Format
+	// LogLevel これ以上のレベルのログだけを出力する
+	LogLevel slog.Level
+	// TracesExporter トレースの送り先（otlp / stdout / none）
+	TracesExporter telemetry.Exporter
 }
 
-// Load loads configuration from environment variables
-func Load() (*Config, error) {
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		return nil, fmt.Errorf("DATABASE_URL environment variable is required")
-	}
+// HTTPConfig HTTP サーバーの設定
+type HTTPConfig struct {
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// ShutdownTimeout 終了時に処理中のリクエストやイベントの配信を待つ時間
+	ShutdownTimeout time.Duration
+	// CORSAllowedOrigins ブラウザから API と WebSocket を使ってよいオリジン（空なら CORS ヘッダーを付けず、WebSocket はすべて受け付ける）
+	CORSAllowedOrigins []string
+}
+
+// SessionConfig セッションの設定
+type SessionConfig struct {
+	DefaultDuration     time.Duration
+	MinExtensionMinutes int
+	MaxExtensionMinutes int
+}
+
+// RateLimitConfig レート制限の設定
+type RateLimitConfig struct {
+	// PerUser ユーザー×コマンド単位の制限（ティア別）
+	PerUser map[domain.Tier]domain.RateLimit
+	// Global 全ユーザー合計でのコマンド単位の制限
+	Global domain.RateLimit
+}
+
+// WSHubConfig WebSocket Hub の設定
+type WSHubConfig struct {
+	BroadcastQueueSize int
+	ClientBufferSize   int
+	// SlowClientPolicy 送信が追いつかないクライアントへの対応（evict / drop）
+	SlowClientPolicy string
+}
+
+// TwitchIRCConfig Twitch チャットへの接続設定
+type TwitchIRCConfig struct {
+	Addr     string
+	Nick     string
+	Token    string
+	Channels []string
+	// SendLimit ボットが発言できる頻度
+	SendLimit domain.RateLimit
+}
+
+// 送信が追いつかないクライアントへの対応（presentation/ws の SlowClientPolicy と同じ値）
+const (
+	slowClientEvict = "evict"
+	slowClientDrop  = "drop"
+)
+
+// defaultStreamTimeZone 配信のタイムゾーンの既定値
+const defaultStreamTimeZone = "Asia/Tokyo"
+
+// Default 既定の設定
+func Default() *Config {
+	// tzdata を埋め込んでいるので失敗しない
+	streamLocation, _ := time.LoadLocation(defaultStreamTimeZone)
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8000"
+	// トレースの送り先は、コレクターの URL（OTEL_EXPORTER_OTLP_ENDPOINT など）があれば otlp、なければ stdout
+	tracesExporter := telemetry.ExporterStdout
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" {
+		tracesExporter = telemetry.ExporterOTLP
 	}
 
 	return &Config{
-		DatabaseURL: dbURL,
-		ServerPort:  fmt.Sprintf(":%s", port),
-	}, nil
+		DatabasePool: database.PoolOptions{
+			MaxConns:        10,
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: 30 * time.Minute,
+		},
+		Port: 8000,
+		HTTP: HTTPConfig{
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    10 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 10 * time.Second,
+		},
+		Session: SessionConfig{
+			DefaultDuration:     time.Hour,
+			MinExtensionMinutes: 1,
+			MaxExtensionMinutes: 360,
+		},
+		IdempotencyKeyTTL: 24 * time.Hour,
+		RateLimit: RateLimitConfig{
+			PerUser: map[domain.Tier]domain.RateLimit{
+				domain.Tier1: {Count: 6, Period: time.Minute},
+				domain.Tier2: {Count: 10, Period: time.Minute},
+				domain.Tier3: {Count: 15, Period: time.Minute},
+			},
+			Global: domain.RateLimit{Count: 600, Period: time.Minute},
+		},
+		WSHub: WSHubConfig{
+			BroadcastQueueSize: 256,
+			ClientBufferSize:   256,
+			SlowClientPolicy:   slowClientEvict,
+		},
+		// Twitch の通常ユーザーの上限は 30 秒に 20 件
+		TwitchIRC:                TwitchIRCConfig{SendLimit: domain.RateLimit{Count: 20, Period: 30 * time.Second}},
+		StreamLocation:           streamLocation,
+		ChannelPointsPerRaziiipo: domain.DefaultChannelPointsPerRaziiipo,
+		LogFormat:                logging.FormatText,
+		LogLevel:                 slog.LevelInfo,
+		TracesExporter:           tracesExporter,
+	}
+}
+
+// ServerAddr http.Server の Addr（":8000" など）
+func (c *Config) ServerAddr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// RuntimeSettings 配信中に変更できる設定の起動時の値（管理 API で保存した値はこれを上書きする）
+func (c *Config) RuntimeSettings() domain.RuntimeSettings {
+	return domain.RuntimeSettings{
+		SessionDefaultDuration:   c.Session.DefaultDuration,
+		MinExtensionMinutes:      c.Session.MinExtensionMinutes,
+		MaxExtensionMinutes:      c.Session.MaxExtensionMinutes,
+		CheerBonusPoints:         c.CheerBonusPoints,
+		ChannelPointsPerRaziiipo: c.ChannelPointsPerRaziiipo,
+		AutoBlockDuration:        c.AutoBlockDuration,
+	}
+}
+
+// Validate 値の範囲を確認し、問題をまとめて返す
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.DatabaseURL != "", "database.url (DATABASE_URL) is required")
+	check(c.DatabasePool.MaxConns >= 1 && c.DatabasePool.MaxConns <= 1000, "database.max_conns must be between 1 and 1000 (got %d)", c.DatabasePool.MaxConns)
+	check(c.DatabasePool.MinConns >= 0 && c.DatabasePool.MinConns <= c.DatabasePool.MaxConns, "database.min_conns must be between 0 and database.max_conns (got %d)", c.DatabasePool.MinConns)
+	check(c.DatabasePool.MaxConnLifetime > 0, "database.max_conn_lifetime must be positive (got %s)", c.DatabasePool.MaxConnLifetime)
+	check(c.DatabasePool.MaxConnIdleTime > 0, "database.max_conn_idle_time must be positive (got %s)", c.DatabasePool.MaxConnIdleTime)
+
+	check(c.Port >= 1 && c.Port <= 65535, "server.port must be between 1 and 65535 (got %d)", c.Port)
+	check(c.HTTP.ReadTimeout > 0, "server.read_timeout must be positive (got %s)", c.HTTP.ReadTimeout)
+	check(c.HTTP.WriteTimeout > 0, "server.write_timeout must be positive (got %s)", c.HTTP.WriteTimeout)
+	check(c.HTTP.IdleTimeout > 0, "server.idle_timeout must be positive (got %s)", c.HTTP.IdleTimeout)
+	check(c.HTTP.ShutdownTimeout > 0, "server.shutdown_timeout must be positive (got %s)", c.HTTP.ShutdownTimeout)
+
+	// 配信中に変更できる設定は、管理 API と同じ規則で確認する
+	if err := c.RuntimeSettings().Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	check(c.WSHub.BroadcastQueueSize >= 1 && c.WSHub.BroadcastQueueSize <= 65536, "websocket.broadcast_queue_size must be between 1 and 65536 (got %d)", c.WSHub.BroadcastQueueSize)
+	check(c.WSHub.ClientBufferSize >= 1 && c.WSHub.ClientBufferSize <= 65536, "websocket.client_buffer_size must be between 1 and 65536 (got %d)", c.WSHub.ClientBufferSize)
+	check(c.WSHub.SlowClientPolicy == slowClientEvict || c.WSHub.SlowClientPolicy == slowClientDrop,
+		"websocket.slow_client_policy must be %q or %q (got %q)", slowClientEvict, slowClientDrop, c.WSHub.SlowClientPolicy)
+
+	check(c.IdempotencyKeyTTL > 0, "idempotency.key_ttl must be positive (got %s)", c.IdempotencyKeyTTL)
+
+	// 無制限に送ると Twitch にボットごと制限される
+	check(!c.TwitchIRC.SendLimit.Unlimited(), "twitch.irc.send_limit must not be unlimited")
+	check(c.TwitchIRC.Token == "" || (c.TwitchIRC.Nick != "" && len(c.TwitchIRC.Channels) > 0),
+		"twitch.irc.nick and twitch.irc.channels are required when twitch.irc.token is set")
+
+	return errors.Join(errs...)
 }
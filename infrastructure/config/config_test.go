@@ -0,0 +1,178 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// clearEnv 実行環境の設定（CI の DATABASE_URL など）がテストに混ざらないようにする
+func clearEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv(configFileEnv, "")
+	for _, s := range settings {
+		t.Setenv(s.env, "")
+		t.Setenv(s.env+fileEnvSuffix, "")
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func parseFlags(t *testing.T, args ...string) *Flags {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	f := BindFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestLoad_Layers(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, "config.yaml", `
+database:
+  url: postgres://file@db/workspace
+  max_conns: 20
+server:
+  port: 9000
+  cors_allowed_origins:
+    - http://localhost:5173
+    - https://overlay.example
+session:
+  default_duration: 90m
+websocket:
+  slow_client_policy: drop
+rate_limit:
+  tier2: 20/1m
+`)
+	// 環境変数はファイルより、フラグは環境変数より優先する
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("PORT", "9100")
+	t.Setenv("SESSION_MAX_EXTENSION_MINUTES", "120")
+	t.Setenv("ADMIN_API_TOKEN_FILE", writeFile(t, "admin_token", "s3cret\n"))
+
+	cfg, err := Load(parseFlags(t, "-server.port=9200", "-twitch.irc.channels=raziii, other"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.DatabaseURL != "postgres://file@db/workspace" || cfg.DatabasePool.MaxConns != 20 {
+		t.Errorf("file values were not applied: %q, %d", cfg.DatabaseURL, cfg.DatabasePool.MaxConns)
+	}
+	if cfg.Port != 9200 || cfg.ServerAddr() != ":9200" {
+		t.Errorf("expected the flag to win, got port %d", cfg.Port)
+	}
+	if cfg.Session.DefaultDuration != 90*time.Minute || cfg.Session.MaxExtensionMinutes != 120 || cfg.Session.MinExtensionMinutes != 1 {
+		t.Errorf("unexpected session settings: %+v", cfg.Session)
+	}
+	if !reflect.DeepEqual(cfg.HTTP.CORSAllowedOrigins, []string{"http://localhost:5173", "https://overlay.example"}) {
+		t.Errorf("unexpected origins: %v", cfg.HTTP.CORSAllowedOrigins)
+	}
+	if !reflect.DeepEqual(cfg.TwitchIRC.Channels, []string{"raziii", "other"}) {
+		t.Errorf("unexpected channels: %v", cfg.TwitchIRC.Channels)
+	}
+	if cfg.WSHub.SlowClientPolicy != "drop" || cfg.WSHub.ClientBufferSize != 256 {
+		t.Errorf("unexpected hub options: %+v", cfg.WSHub)
+	}
+	if limit := cfg.RateLimit.PerUser[domain.Tier2]; limit.Count != 20 || limit.Period != time.Minute {
+		t.Errorf("unexpected tier2 limit: %v", limit)
+	}
+	if cfg.StreamLocation.String() != "Asia/Tokyo" {
+		t.Errorf("expected the default stream time zone, got %s", cfg.StreamLocation)
+	}
+	if cfg.AdminAPIToken != "s3cret" {
+		t.Errorf("expected the token to be read from ADMIN_API_TOKEN_FILE, got %q", cfg.AdminAPIToken)
+	}
+}
+
+func TestLoad_ReportsEveryProblem(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, "config.yaml", `
+server:
+  prot: 9000
+session:
+  min_extension_minutes: 30
+  max_extension_minutes: 10
+`)
+	t.Setenv("DATABASE_URL", "postgres://localhost/workspace")
+	t.Setenv("IDEMPOTENCY_KEY_TTL", "forever")
+	t.Setenv("STREAM_TIME_ZONE", "Mars/Olympus_Mons")
+	t.Setenv("TWITCH_IRC_TOKEN", "oauth:abc")
+	t.Setenv("TWITCH_IRC_TOKEN_FILE", "/run/secrets/twitch")
+
+	_, err := Load(parseFlags(t, "-config", path, "-websocket.client_buffer_size=0", "-websocket.slow_client_policy=block"))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{
+		`unknown key "server.prot"`,
+		"session.max_extension_minutes must be between",
+		"invalid IDEMPOTENCY_KEY_TTL",
+		"invalid STREAM_TIME_ZONE",
+		"both TWITCH_IRC_TOKEN and TWITCH_IRC_TOKEN_FILE are set",
+		"websocket.client_buffer_size must be between 1 and 65536 (got 0)",
+		`websocket.slow_client_policy must be "evict" or "drop" (got "block")`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in:\n%v", want, err)
+		}
+	}
+}
+
+func TestConfig_PrintRedactsSecretsAndRoundTrips(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DATABASE_URL", "postgres://workspace:hunter2@db:5432/workspace")
+	t.Setenv("TWITCH_EVENTSUB_SECRET", "eventsub-secret")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "http://localhost:5173")
+	t.Setenv("SESSION_DEFAULT_DURATION", "45m")
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := cfg.Print(&out); err != nil {
+		t.Fatalf("Print failed: %v", err)
+	}
+	printed := out.String()
+	if strings.Contains(printed, "hunter2") || strings.Contains(printed, "eventsub-secret") {
+		t.Fatalf("secrets were printed:\n%s", printed)
+	}
+	if !strings.Contains(printed, "postgres://workspace:xxxxx@db:5432/workspace") {
+		t.Errorf("expected the database URL with the password redacted:\n%s", printed)
+	}
+
+	// 表示した設定はそのまま設定ファイルとして読める
+	for _, env := range []string{"DATABASE_URL", "TWITCH_EVENTSUB_SECRET", "CORS_ALLOWED_ORIGINS", "SESSION_DEFAULT_DURATION"} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("CONFIG_FILE", writeFile(t, "printed.yaml", printed))
+	reloaded, err := Load(nil)
+	if err != nil {
+		t.Fatalf("printed config could not be loaded: %v", err)
+	}
+	if reloaded.Session.DefaultDuration != 45*time.Minute || !reflect.DeepEqual(reloaded.HTTP.CORSAllowedOrigins, cfg.HTTP.CORSAllowedOrigins) ||
+		reloaded.LogLevel != cfg.LogLevel || reloaded.RateLimit.Global != cfg.RateLimit.Global {
+		t.Errorf("printed config did not round-trip:\n%s", printed)
+	}
+
+	logged := cfg.LogValue().String()
+	if strings.Contains(logged, "hunter2") || strings.Contains(logged, "eventsub-secret") || !strings.Contains(logged, "[REDACTED]") {
+		t.Errorf("unexpected log value: %s", logged)
+	}
+}
@@ -0,0 +1,250 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFileEnv 設定ファイルのパスを指定する環境変数（-config が優先）
+const configFileEnv = "CONFIG_FILE"
+
+// fileEnvSuffix この接尾辞を付けた環境変数には、値の代わりに値を書いたファイルのパスを指定できる（Docker secrets 用）
+const fileEnvSuffix = "_FILE"
+
+// Flags コマンドラインで指定した設定（BindFlags で作る）
+type Flags struct {
+	configFile string
+	values     map[string]string
+}
+
+// BindFlags fs に -config と設定項目ごとのフラグ（-server.port など）を登録する
+// 値は Load の時に検証する
+func BindFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{values: map[string]string{}}
+	fs.StringVar(&f.configFile, "config", "", "YAML configuration file (default $"+configFileEnv+")")
+	for _, s := range settings {
+		fs.Func(s.key, s.usage+" [$"+s.env+"]", func(v string) error {
+			f.values[s.key] = v
+			return nil
+		})
+	}
+	return f
+}
+
+// Load 既定値・設定ファイル・環境変数・フラグの順に重ねて設定を読み込み、検証する
+// 後のものが優先される。f が nil ならフラグは使わない
+// 読み込みと検証の問題はまとめて 1 つのエラーとして返す
+func Load(f *Flags) (*Config, error) {
+	cfg := Default()
+	var errs []error
+
+	path := os.Getenv(configFileEnv)
+	if f != nil && f.configFile != "" {
+		path = f.configFile
+	}
+	if path != "" {
+		if err := loadFile(cfg, path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, s := range settings {
+		v, ok, err := lookupEnv(s.env)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := s.set(cfg, v); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s: %w", s.env, err))
+		}
+	}
+
+	if f != nil {
+		for _, s := range settings {
+			if v, ok := f.values[s.key]; ok {
+				if err := s.set(cfg, v); err != nil {
+					errs = append(errs, fmt.Errorf("invalid -%s: %w", s.key, err))
+				}
+			}
+		}
+	}
+
+	if err := errors.Join(append(errs, cfg.Validate())...); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// lookupEnv 環境変数 name の値を返す（空は未設定として扱う）
+// name_FILE があればそのファイルの内容を値にする（末尾の改行は除く）
+func lookupEnv(name string) (string, bool, error) {
+	value := os.Getenv(name)
+	path := os.Getenv(name + fileEnvSuffix)
+	if path == "" {
+		return value, value != "", nil
+	}
+	if value != "" {
+		return "", false, fmt.Errorf("both %s and %s%s are set", name, name, fileEnvSuffix)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s%s: %w", name, fileEnvSuffix, err)
+	}
+	return strings.TrimRight(string(b), "\r\n"), true, nil
+}
+
+// loadFile YAML の設定ファイルを読み込む（知らないキーはエラーにする）
+func loadFile(cfg *Config, path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil // 空のファイル
+	}
+
+	values := map[string]string{}
+	var errs []error
+	flattenYAML(doc.Content[0], "", values, &errs)
+
+	known := map[string]setting{}
+	for _, s := range settings {
+		known[s.key] = s
+	}
+	for key, v := range values {
+		s, ok := known[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown key %q", path, key))
+			continue
+		}
+		if err := s.set(cfg, v); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid %s: %w", path, key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// flattenYAML 入れ子のマッピングを "server.port" のようなキーと文字列の値にする
+// シーケンスはカンマ区切りにする（リストの項目用）
+func flattenYAML(node *yaml.Node, prefix string, values map[string]string, errs *[]error) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flattenYAML(node.Content[i+1], key, values, errs)
+		}
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				*errs = append(*errs, fmt.Errorf("line %d: %s must be a list of values", item.Line, prefix))
+				return
+			}
+			items = append(items, item.Value)
+		}
+		values[prefix] = strings.Join(items, ",")
+	case yaml.ScalarNode:
+		if prefix == "" {
+			*errs = append(*errs, fmt.Errorf("line %d: config file must be a mapping", node.Line))
+			return
+		}
+		if node.Tag == "!!null" {
+			values[prefix] = ""
+			return
+		}
+		values[prefix] = node.Value
+	default:
+		*errs = append(*errs, fmt.Errorf("line %d: unsupported value for %s", node.Line, prefix))
+	}
+}
+
+// Print 有効な設定を設定ファイルと同じ形式の YAML で書き出す（シークレットは隠す）
+func (c *Config) Print(w io.Writer) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, s := range settings {
+		parent, name := root, s.key
+		for {
+			section, rest, ok := strings.Cut(name, ".")
+			if !ok {
+				break
+			}
+			parent, name = yamlSection(parent, section), rest
+		}
+		parent.Content = append(parent.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: name},
+			yamlValue(s, s.display(c)),
+		)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// yamlSection parent の下の name のマッピングを返す（なければ作る）
+func yamlSection(parent *yaml.Node, name string) *yaml.Node {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == name {
+			return parent.Content[i+1]
+		}
+	}
+	section := &yaml.Node{Kind: yaml.MappingNode}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, section)
+	return section
+}
+
+func yamlValue(s setting, v string) *yaml.Node {
+	if !s.list {
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: v}
+	}
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	if v != "" {
+		for _, item := range strings.Split(v, ",") {
+			seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: item})
+		}
+	}
+	return seq
+}
+
+// LogValue implements slog.LogValuer: 有効な設定をセクションごとのグループにする（シークレットは隠す）
+func (c *Config) LogValue() slog.Value {
+	var attrs []slog.Attr
+	for _, s := range settings {
+		section, name, _ := strings.Cut(s.key, ".")
+		if len(attrs) == 0 || attrs[len(attrs)-1].Key != section {
+			attrs = append(attrs, slog.Group(section))
+		}
+		group := &attrs[len(attrs)-1]
+		group.Value = slog.GroupValue(append(group.Value.Group(), slog.String(name, s.display(c)))...)
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// display 表示用の値（シークレットは隠す）
+func (s setting) display(c *Config) string {
+	v := s.get(c)
+	if s.redact != nil {
+		return s.redact(v)
+	}
+	return v
+}
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/infrastructure/telemetry"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+)
+
+// setting 設定項目 1 つ（設定ファイルのキー・環境変数・フラグで同じ項目を指定できる）
+type setting struct {
+	// key 設定ファイルのキー（"." で階層を区切る）で、フラグ名にもなる
+	key   string
+	env   string
+	usage string
+	// list カンマ区切りのリスト（設定ファイルでは YAML のシーケンスでも書ける）
+	list bool
+	// redact 表示・ログに出すときに値を隠す（nil ならそのまま出す）
+	redact func(string) string
+
+	get func(c *Config) string
+	set func(c *Config, value string) error
+}
+
+// settings すべての設定項目（-print-config はこの順に表示する）
+var settings = []setting{
+	{
+		key: "database.url", env: "DATABASE_URL", usage: "PostgreSQL connection URL (required)",
+		redact: redactURL,
+		get:    func(c *Config) string { return c.DatabaseURL },
+		set:    func(c *Config, v string) error { c.DatabaseURL = v; return nil },
+	},
+	int32Setting("database.max_conns", "DB_MAX_CONNS", "maximum number of pooled connections", func(c *Config) *int32 { return &c.DatabasePool.MaxConns }),
+	int32Setting("database.min_conns", "DB_MIN_CONNS", "connections kept open even when idle", func(c *Config) *int32 { return &c.DatabasePool.MinConns }),
+	durationSetting("database.max_conn_lifetime", "DB_MAX_CONN_LIFETIME", "close connections older than this", func(c *Config) *time.Duration { return &c.DatabasePool.MaxConnLifetime }),
+	durationSetting("database.max_conn_idle_time", "DB_MAX_CONN_IDLE_TIME", "close connections idle for longer than this", func(c *Config) *time.Duration { return &c.DatabasePool.MaxConnIdleTime }),
+
+	intSetting("server.port", "PORT", "HTTP listen port", func(c *Config) *int { return &c.Port }),
+	durationSetting("server.read_timeout", "HTTP_READ_TIMEOUT", "maximum time to read a request", func(c *Config) *time.Duration { return &c.HTTP.ReadTimeout }),
+	durationSetting("server.write_timeout", "HTTP_WRITE_TIMEOUT", "maximum time to write a response", func(c *Config) *time.Duration { return &c.HTTP.WriteTimeout }),
+	durationSetting("server.idle_timeout", "HTTP_IDLE_TIMEOUT", "keep-alive timeout", func(c *Config) *time.Duration { return &c.HTTP.IdleTimeout }),
+	durationSetting("server.shutdown_timeout", "HTTP_SHUTDOWN_TIMEOUT", "time allowed for graceful shutdown", func(c *Config) *time.Duration { return &c.HTTP.ShutdownTimeout }),
+	listSetting("server.cors_allowed_origins", "CORS_ALLOWED_ORIGINS", "origins allowed to call the API and open /ws from a browser (\"*\" for any)", func(c *Config) *[]string { return &c.HTTP.CORSAllowedOrigins }),
+
+	durationSetting("session.default_duration", "SESSION_DEFAULT_DURATION", "planned length of a session started by /in", func(c *Config) *time.Duration { return &c.Session.DefaultDuration }),
+	intSetting("session.min_extension_minutes", "SESSION_MIN_EXTENSION_MINUTES", "minimum minutes accepted by /more", func(c *Config) *int { return &c.Session.MinExtensionMinutes }),
+	intSetting("session.max_extension_minutes", "SESSION_MAX_EXTENSION_MINUTES", "maximum minutes accepted by /more", func(c *Config) *int { return &c.Session.MaxExtensionMinutes }),
+
+	intSetting("websocket.broadcast_queue_size", "WS_BROADCAST_QUEUE_SIZE", "events the hub can queue before dropping", func(c *Config) *int { return &c.WSHub.BroadcastQueueSize }),
+	intSetting("websocket.client_buffer_size", "WS_CLIENT_BUFFER_SIZE", "messages buffered per client", func(c *Config) *int { return &c.WSHub.ClientBufferSize }),
+	stringSetting("websocket.slow_client_policy", "WS_SLOW_CLIENT_POLICY", "what to do with clients that fall behind (evict or drop)", func(c *Config) *string { return &c.WSHub.SlowClientPolicy }),
+
+	durationSetting("idempotency.key_ttl", "IDEMPOTENCY_KEY_TTL", "how long Idempotency-Key responses are kept", func(c *Config) *time.Duration { return &c.IdempotencyKeyTTL }),
+	tierLimitSetting("rate_limit.tier1", "RATE_LIMIT_TIER1", domain.Tier1),
+	tierLimitSetting("rate_limit.tier2", "RATE_LIMIT_TIER2", domain.Tier2),
+	tierLimitSetting("rate_limit.tier3", "RATE_LIMIT_TIER3", domain.Tier3),
+	limitSetting("rate_limit.global", "RATE_LIMIT_GLOBAL", "commands per period across all users (e.g. 600/1m, 0 for unlimited)", func(c *Config) *domain.RateLimit { return &c.RateLimit.Global }),
+
+	durationSetting("moderation.auto_block_duration", "MODERATION_AUTO_BLOCK_DURATION", "block duration for banned terms (0 blocks permanently)", func(c *Config) *time.Duration { return &c.AutoBlockDuration }),
+	secretSetting("admin.api_token", "ADMIN_API_TOKEN", "Bearer token for /api/admin (empty disables the admin API)", func(c *Config) *string { return &c.AdminAPIToken }),
+	{
+		key: "stream.time_zone", env: "STREAM_TIME_ZONE", usage: "IANA time zone of the stream; the daily /cheer limit resets at its midnight (e.g. Asia/Tokyo)",
+		get: func(c *Config) string { return c.StreamLocation.String() },
+		set: func(c *Config, v string) (err error) {
+			c.StreamLocation, err = time.LoadLocation(v)
+			return err
+		},
+	},
+	{
+		key: "cheer.bonus_points", env: "CHEER_BONUS_POINTS", usage: "points given to the user cheered by /cheer",
+		get: func(c *Config) string { return strconv.FormatInt(c.CheerBonusPoints, 10) },
+		set: func(c *Config, v string) (err error) {
+			c.CheerBonusPoints, err = strconv.ParseInt(v, 10, 64)
+			return err
+		},
+	},
+	{
+		key: domain.SettingChannelPointsPerRaziiipo, env: "POINTS_CHANNEL_POINTS_PER_RAZIIIPO", usage: "channel points exchanged for one Raziiipo",
+		get: func(c *Config) string { return strconv.FormatInt(c.ChannelPointsPerRaziiipo, 10) },
+		set: func(c *Config, v string) (err error) {
+			c.ChannelPointsPerRaziiipo, err = strconv.ParseInt(v, 10, 64)
+			return err
+		},
+	},
+
+	secretSetting("twitch.eventsub_secret", "TWITCH_EVENTSUB_SECRET", "EventSub signing secret (empty disables EventSub)", func(c *Config) *string { return &c.TwitchEventSubSecret }),
+	stringSetting("twitch.irc.addr", "TWITCH_IRC_ADDR", "Twitch chat address (empty for the default)", func(c *Config) *string { return &c.TwitchIRC.Addr }),
+	stringSetting("twitch.irc.nick", "TWITCH_IRC_NICK", "bot login name", func(c *Config) *string { return &c.TwitchIRC.Nick }),
+	secretSetting("twitch.irc.token", "TWITCH_IRC_TOKEN", "bot OAuth token (empty disables the chat connector)", func(c *Config) *string { return &c.TwitchIRC.Token }),
+	listSetting("twitch.irc.channels", "TWITCH_IRC_CHANNELS", "channels to join", func(c *Config) *[]string { return &c.TwitchIRC.Channels }),
+	limitSetting("twitch.irc.send_limit", "TWITCH_IRC_SEND_LIMIT", "messages the bot may send per period (e.g. 20/30s)", func(c *Config) *domain.RateLimit { return &c.TwitchIRC.SendLimit }),
+
+	{
+		key: "log.format", env: "LOG_FORMAT", usage: "log format (text or json)",
+		get: func(c *Config) string { return string(c.LogFormat) },
+		set: func(c *Config, v string) (err error) {
+			c.LogFormat, err = logging.ParseFormat(v)
+			return err
+		},
+	},
+	{
+		key: "log.level", env: "LOG_LEVEL", usage: "minimum log level (debug, info, warn or error)",
+		get: func(c *Config) string { return strings.ToLower(c.LogLevel.String()) },
+		set: func(c *Config, v string) (err error) {
+			c.LogLevel, err = logging.ParseLevel(v)
+			return err
+		},
+	},
+	{
+		key: "tracing.exporter", env: "OTEL_TRACES_EXPORTER", usage: "where to send traces (otlp, stdout or none)",
+		get: func(c *Config) string { return string(c.TracesExporter) },
+		set: func(c *Config, v string) (err error) {
+			c.TracesExporter, err = telemetry.ParseExporter(v)
+			return err
+		},
+	},
+}
+
+func stringSetting(key, env, usage string, field func(c *Config) *string) setting {
+	return setting{
+		key: key, env: env, usage: usage,
+		get: func(c *Config) string { return *field(c) },
+		set: func(c *Config, v string) error { *field(c) = v; return nil },
+	}
+}
+
+func secretSetting(key, env, usage string, field func(c *Config) *string) setting {
+	s := stringSetting(key, env, usage, field)
+	s.redact = redactSecret
+	return s
+}
+
+func intSetting(key, env, usage string, field func(c *Config) *int) setting {
+	return setting{
+		key: key, env: env, usage: usage,
+		get: func(c *Config) string { return strconv.Itoa(*field(c)) },
+		set: func(c *Config, v string) error {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("not an integer: %q", v)
+			}
+			*field(c) = n
+			return nil
+		},
+	}
+}
+
+func int32Setting(key, env, usage string, field func(c *Config) *int32) setting {
+	return setting{
+		key: key, env: env, usage: usage,
+		get: func(c *Config) string { return strconv.FormatInt(int64(*field(c)), 10) },
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				return fmt.Errorf("not an integer: %q", v)
+			}
+			*field(c) = int32(n)
+			return nil
+		},
+	}
+}
+
+func durationSetting(key, env, usage string, field func(c *Config) *time.Duration) setting {
+	return setting{
+		key: key, env: env, usage: usage + ` (e.g. "90s", "24h")`,
+		get: func(c *Config) string { return field(c).String() },
+		set: func(c *Config, v string) error {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("not a duration: %q", v)
+			}
+			*field(c) = d
+			return nil
+		},
+	}
+}
+
+func listSetting(key, env, usage string, field func(c *Config) *[]string) setting {
+	return setting{
+		key: key, env: env, usage: usage + " (comma separated)", list: true,
+		get: func(c *Config) string { return strings.Join(*field(c), ",") },
+		set: func(c *Config, v string) error {
+			var items []string
+			for _, item := range strings.Split(v, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			*field(c) = items
+			return nil
+		},
+	}
+}
+
+func limitSetting(key, env, usage string, field func(c *Config) *domain.RateLimit) setting {
+	return setting{
+		key: key, env: env, usage: usage,
+		get: func(c *Config) string { return field(c).String() },
+		set: func(c *Config, v string) (err error) {
+			*field(c), err = domain.ParseRateLimit(v)
+			return err
+		},
+	}
+}
+
+// tierLimitSetting ティアごとのユーザー単位のレート制限
+func tierLimitSetting(key, env string, tier domain.Tier) setting {
+	return setting{
+		key: key, env: env, usage: fmt.Sprintf("commands per period for each tier %d user (e.g. 6/1m, 0 for unlimited)", tier),
+		get: func(c *Config) string { return c.RateLimit.PerUser[tier].String() },
+		set: func(c *Config, v string) error {
+			limit, err := domain.ParseRateLimit(v)
+			if err != nil {
+				return err
+			}
+			c.RateLimit.PerUser[tier] = limit
+			return nil
+		},
+	}
+}
+
+// redactSecret 値があるかどうかだけ分かるようにする
+func redactSecret(v string) string {
+	if v == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// redactURL 接続 URL のパスワードだけを隠す（URL 形式でなければ全体を隠す）
+func redactURL(v string) string {
+	u, err := url.Parse(v)
+	if err != nil || u.Scheme == "" {
+		return redactSecret(v)
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// dumpTable エクスポート・インポートするテーブル
+type dumpTable struct {
+	name   string
+	key    string // 主キー（エクスポートする行の順）
+	serial bool   // key が SERIAL / BIGSERIAL（インポート後にシーケンスを合わせる）
+}
+
+// dumpTables エクスポート・インポートするテーブル（外部キーの参照先が先）
+// 冪等キー・アウトボックス・Webhook の配信キュー・EventSub の受信記録は一時的なデータなので含めない
+var dumpTables = []dumpTable{
+	{name: "users", key: "id", serial: true},
+	{name: "icons", key: "id", serial: true},
+	{name: "sessions", key: "id", serial: true},
+	{name: "point_ledger", key: "id", serial: true},
+	{name: "icon_commissions", key: "id", serial: true},
+	{name: "cheers", key: "id", serial: true},
+	{name: "banned_terms", key: "id", serial: true},
+	{name: "webhook_subscriptions", key: "id", serial: true},
+	{name: "audit_logs", key: "id", serial: true},
+	{name: "runtime_settings", key: "key"},
+}
+
+// Dump エクスポートしたデータ
+// 各行はテーブルの列名をキーにした JSON で、同じマイグレーションの版のデータベースにのみインポートできる
+type Dump struct {
+	MigrationVersion uint64       `json:"migration_version"`
+	ExportedAt       time.Time    `json:"exported_at"`
+	Tables           []DumpedRows `json:"tables"`
+}
+
+// DumpedRows テーブル 1 つ分の行
+type DumpedRows struct {
+	Table string            `json:"table"`
+	Rows  []json.RawMessage `json:"rows"`
+}
+
+// Export すべての対象テーブルを 1 つのスナップショットから読み出す
+func Export(ctx context.Context, pool *pgxpool.Pool) (*Dump, error) {
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	version, err := currentMigrationVersion(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	dump := &Dump{MigrationVersion: version, ExportedAt: time.Now().UTC()}
+	for _, table := range dumpTables {
+		rows, err := exportTable(ctx, tx, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", table.name, err)
+		}
+		dump.Tables = append(dump.Tables, DumpedRows{Table: table.name, Rows: rows})
+	}
+	return dump, nil
+}
+
+func exportTable(ctx context.Context, tx pgx.Tx, table dumpTable) ([]json.RawMessage, error) {
+	name, key := pgx.Identifier{table.name}.Sanitize(), pgx.Identifier{table.key}.Sanitize()
+	rows, err := tx.Query(ctx, fmt.Sprintf("SELECT to_jsonb(t)::text FROM %s t ORDER BY t.%s", name, key))
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (json.RawMessage, error) {
+		var text string
+		err := row.Scan(&text)
+		return json.RawMessage(text), err
+	})
+}
+
+// Import 対象テーブルの内容を dump で置き換え、件数をテーブルごとに返す
+// 1 つのトランザクションで行うので、途中で失敗した場合は何も変わらない
+// 実行中のサーバーの書き込みと競合するため、サーバーを止めてから実行する
+func Import(ctx context.Context, pool *pgxpool.Pool, dump *Dump) (_ map[string]int, err error) {
+	known := make(map[string]dumpTable, len(dumpTables))
+	for _, table := range dumpTables {
+		known[table.name] = table
+	}
+	rowsByTable := make(map[string][]json.RawMessage, len(dump.Tables))
+	for _, t := range dump.Tables {
+		if _, ok := known[t.Table]; !ok {
+			return nil, fmt.Errorf("unknown table %q in dump", t.Table)
+		}
+		rowsByTable[t.Table] = t.Rows
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = CheckMigrationVersion(ctx, tx, dump.MigrationVersion); err != nil {
+		return nil, err
+	}
+
+	// 参照し合うテーブルをまとめて空にする（マイグレーションで入れた初期データも置き換え、削除した Webhook の配信キューも消える）
+	names := make([]string, 0, len(dumpTables))
+	for _, table := range dumpTables {
+		names = append(names, pgx.Identifier{table.name}.Sanitize())
+	}
+	if _, err = tx.Exec(ctx, "TRUNCATE TABLE "+strings.Join(names, ", ")+" RESTART IDENTITY CASCADE"); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(dumpTables))
+	for _, table := range dumpTables {
+		rows := rowsByTable[table.name]
+		if err = importTable(ctx, tx, table, rows); err != nil {
+			return nil, fmt.Errorf("failed to import %s: %w", table.name, err)
+		}
+		counts[table.name] = len(rows)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func importTable(ctx context.Context, tx pgx.Tx, table dumpTable, rows []json.RawMessage) error {
+	name := pgx.Identifier{table.name}.Sanitize()
+	if len(rows) > 0 {
+		array, err := json.Marshal(rows)
+		if err != nil {
+			return err
+		}
+		// 列名で対応させるので、JSON にない列は NULL になる（同じ版のデータベースからのエクスポートなら全列がある）
+		if _, err := tx.Exec(ctx, fmt.Sprintf("INSERT INTO %s SELECT * FROM jsonb_populate_recordset(NULL::%s, $1::jsonb)", name, name), string(array)); err != nil {
+			return err
+		}
+	}
+	if !table.serial {
+		return nil
+	}
+	// 次に作成する行の ID がインポートした行と重ならないようにする
+	key := pgx.Identifier{table.key}.Sanitize()
+	_, err := tx.Exec(ctx, fmt.Sprintf("SELECT setval(pg_get_serial_sequence($1, $2), COALESCE(MAX(%s), 0) + 1, false) FROM %s", key, name), table.name, table.key)
+	return err
+}
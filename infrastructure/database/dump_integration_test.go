@@ -0,0 +1,88 @@
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/testutil"
+)
+
+func TestExportImport_Integration(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	testutil.CleanupTables(t, pool)
+
+	ctx := context.Background()
+	now := func() time.Time { return time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC) }
+	userRepository := repository.NewUserRepositoryWithPool(pool)
+
+	createUser := func(t *testing.T, name string) *domain.User {
+		t.Helper()
+		user, err := domain.NewUser(name, domain.Tier2, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := userRepository.Save(ctx, user); err != nil {
+			t.Fatalf("Failed to save user: %v", err)
+		}
+		return user
+	}
+
+	kept := createUser(t, "kept_user")
+	dump, err := database.Export(ctx, pool)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	// エクスポート後の変更は、インポートで元に戻る
+	createUser(t, "added_after_export")
+	// JSON を経由しても読み戻せる
+	encoded, err := json.Marshal(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded database.Dump
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := database.Import(ctx, pool, &decoded)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if counts["users"] != 1 || counts["icons"] != 30 {
+		t.Errorf("unexpected counts: %v", counts)
+	}
+
+	if _, err := userRepository.FindByName(ctx, "added_after_export"); err != domain.ErrUserNotFound {
+		t.Errorf("expected the user added after the export to be gone, got %v", err)
+	}
+	restored, err := userRepository.FindByName(ctx, "kept_user")
+	if err != nil {
+		t.Fatalf("Failed to find the exported user: %v", err)
+	}
+	if restored.ID != kept.ID || restored.Tier != domain.Tier2 {
+		t.Errorf("unexpected user: %+v", restored)
+	}
+
+	// シーケンスはインポートした行の続きから振られる
+	next := createUser(t, "created_after_import")
+	if next.ID <= kept.ID {
+		t.Errorf("expected a new ID after %d, got %d", kept.ID, next.ID)
+	}
+
+	t.Run("マイグレーションの版が違うダンプは拒否する", func(t *testing.T) {
+		stale := decoded
+		stale.MigrationVersion--
+		if _, err := database.Import(ctx, pool, &stale); err == nil {
+			t.Error("expected an error")
+		}
+		if _, err := userRepository.FindByName(ctx, "created_after_import"); err != nil {
+			t.Errorf("expected nothing to change, got %v", err)
+		}
+	})
+}
@@ -1,18 +1,88 @@
 package database
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
+	"regexp"
+	"strconv"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/yamada-ai/workspace-backend/shared/logging"
 )
 
 // RunMigrations runs database migrations
 func RunMigrations(databaseURL, migrationsPath string) error {
-	log.Println("🔄 Running database migrations...")
+	slog.Info("running database migrations", "path", migrationsPath)
+
+	return withMigrate(databaseURL, migrationsPath, func(m *migrate.Migrate) error {
+		// Run migrations
+		if err := m.Up(); err != nil {
+			if err == migrate.ErrNoChange {
+				slog.Info("no new migrations to apply")
+				return nil
+			}
+			return fmt.Errorf("failed to run migrations: %w", err)
+		}
+
+		slog.Info("migrations completed")
+		return nil
+	})
+}
+
+// RollbackMigrations 直近の steps 個のマイグレーションを戻す
+func RollbackMigrations(databaseURL, migrationsPath string, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1 (got %d)", steps)
+	}
+	slog.Info("rolling back database migrations", "path", migrationsPath, "steps", steps)
+
+	return withMigrate(databaseURL, migrationsPath, func(m *migrate.Migrate) error {
+		if err := m.Steps(-steps); err != nil {
+			return fmt.Errorf("failed to roll back migrations: %w", err)
+		}
+		slog.Info("migrations rolled back")
+		return nil
+	})
+}
+
+// MigrateTo version の状態になるまでマイグレーションを進める・戻す
+func MigrateTo(databaseURL, migrationsPath string, version uint) error {
+	slog.Info("migrating database", "path", migrationsPath, "version", version)
 
+	return withMigrate(databaseURL, migrationsPath, func(m *migrate.Migrate) error {
+		if err := m.Migrate(version); err != nil {
+			if err == migrate.ErrNoChange {
+				slog.Info("database is already at the requested version")
+				return nil
+			}
+			return fmt.Errorf("failed to migrate to %d: %w", version, err)
+		}
+		slog.Info("migrations completed")
+		return nil
+	})
+}
+
+// MigrationVersion 適用済みのマイグレーションの版と、途中で失敗しているか（dirty）を返す
+// まだ 1 つも適用していない場合は 0 を返す
+func MigrationVersion(databaseURL, migrationsPath string) (version uint, dirty bool, err error) {
+	err = withMigrate(databaseURL, migrationsPath, func(m *migrate.Migrate) error {
+		version, dirty, err = m.Version()
+		if err == migrate.ErrNilVersion {
+			return nil
+		}
+		return err
+	})
+	return version, dirty, err
+}
+
+// withMigrate migrate のインスタンスを作成して fn を実行し、閉じる
+func withMigrate(databaseURL, migrationsPath string, fn func(m *migrate.Migrate) error) error {
 	m, err := migrate.New(
 		fmt.Sprintf("file://%s", migrationsPath),
 		databaseURL,
@@ -22,19 +92,86 @@ func RunMigrations(databaseURL, migrationsPath string) error {
 	}
 	defer func() {
 		if _, err := m.Close(); err != nil {
-			log.Printf("Warning: failed to close migrate instance: %v", err)
+			slog.Warn("failed to close migrate instance", logging.Err(err))
 		}
 	}()
 
-	// Run migrations
-	if err := m.Up(); err != nil {
-		if err == migrate.ErrNoChange {
-			log.Println("✅ No new migrations to apply")
-			return nil
+	return fn(m)
+}
+
+// migrationFilePattern golang-migrate の up マイグレーションのファイル名（000012_create_cheers.up.sql）
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_.*\.up\.sql$`)
+
+// LatestMigrationVersion マイグレーションのディレクトリにある最新のバージョンを返す
+func LatestMigrationVersion(migrationsPath string) (uint64, error) {
+	entries, err := os.ReadDir(migrationsPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	var latest uint64
+	for _, entry := range entries {
+		m := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration file name %q: %w", entry.Name(), err)
 		}
-		return fmt.Errorf("failed to run migrations: %w", err)
+		latest = max(latest, version)
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations found in %s", migrationsPath)
+	}
+	return latest, nil
+}
+
+// rowQuerier 1 行を返すクエリを実行する（*pgxpool.Pool を満たす）
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// CheckMigrationVersion データベースのマイグレーションが expected の版で、途中で失敗していないことを確認する
+// データの移行など、スキーマが完全に一致している必要がある場合に使う
+func CheckMigrationVersion(ctx context.Context, db rowQuerier, expected uint64) error {
+	version, err := currentMigrationVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	if version != expected {
+		return fmt.Errorf("database is at migration %d, expected %d", version, expected)
 	}
+	return nil
+}
 
-	log.Println("✅ Migrations completed successfully")
+// CheckMigrationApplied データベースのマイグレーションが minimum 以上の版で、途中で失敗していないことを確認する
+// ローリングデプロイ中は新しい版のインスタンスが先にマイグレーションを進めるため、新しい版は準備完了として扱う
+// （マイグレーションは古い版のコードでも動くように書く）
+func CheckMigrationApplied(ctx context.Context, db rowQuerier, minimum uint64) error {
+	version, err := currentMigrationVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	if version < minimum {
+		return fmt.Errorf("database is at migration %d, expected at least %d", version, minimum)
+	}
 	return nil
 }
+
+// currentMigrationVersion 適用済みのマイグレーションの版（途中で失敗している場合はエラー）
+func currentMigrationVersion(ctx context.Context, db rowQuerier) (uint64, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	if err := db.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty); err != nil {
+		return 0, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	if dirty {
+		return 0, fmt.Errorf("migration %d failed halfway (dirty)", version)
+	}
+	if version < 0 {
+		return 0, fmt.Errorf("invalid migration version %d", version)
+	}
+	return uint64(version), nil
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestLatestMigrationVersion(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"000001_create_users.up.sql",
+		"000001_create_users.down.sql",
+		"000012_create_cheers.up.sql",
+		"000013_next.down.sql", // up がないものは数えない
+		"README.md",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := LatestMigrationVersion(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 12 {
+		t.Errorf("expected 12, got %d", got)
+	}
+
+	if _, err := LatestMigrationVersion(t.TempDir()); err == nil {
+		t.Error("expected an error for an empty directory")
+	}
+}
+
+type fakeRow struct {
+	version int64
+	dirty   bool
+	err     error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*int64) = r.version
+	*dest[1].(*bool) = r.dirty
+	return nil
+}
+
+type fakeQuerier struct{ row fakeRow }
+
+func (q fakeQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return q.row
+}
+
+func TestCheckMigrationVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		row     fakeRow
+		wantErr bool
+	}{
+		{"最新", fakeRow{version: 12}, false},
+		{"古い", fakeRow{version: 11}, true},
+		{"新しい", fakeRow{version: 13}, true},
+		{"途中で失敗", fakeRow{version: 12, dirty: true}, true},
+		{"取得できない", fakeRow{err: errors.New("relation \"schema_migrations\" does not exist")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckMigrationVersion(context.Background(), fakeQuerier{tt.row}, 12)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("wantErr=%v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckMigrationApplied(t *testing.T) {
+	tests := []struct {
+		name    string
+		row     fakeRow
+		wantErr bool
+	}{
+		{"最新", fakeRow{version: 12}, false},
+		{"新しい版のインスタンスが先に進めた", fakeRow{version: 13}, false},
+		{"古い", fakeRow{version: 11}, true},
+		{"途中で失敗", fakeRow{version: 13, dirty: true}, true},
+		{"取得できない", fakeRow{err: errors.New("relation \"schema_migrations\" does not exist")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckMigrationApplied(context.Background(), fakeQuerier{tt.row}, 12)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("wantErr=%v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+-- name: CreateAuditLog :one
+INSERT INTO audit_logs (actor, action, target_type, target_id, before_state, after_state, reason, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, actor, action, target_type, target_id, before_state, after_state, reason, created_at;
+
+-- name: ListAuditLogs :many
+SELECT id, actor, action, target_type, target_id, before_state, after_state, reason, created_at
+FROM audit_logs
+WHERE (sqlc.narg(target_type)::text IS NULL OR target_type = sqlc.narg(target_type))
+  AND (sqlc.narg(target_id)::text IS NULL OR target_id = sqlc.narg(target_id))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
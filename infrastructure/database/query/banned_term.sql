@@ -0,0 +1,25 @@
+-- name: ListBannedTerms :many
+SELECT id, term, match_type, action, auto_block, created_by, created_at, updated_at
+FROM banned_terms
+ORDER BY id;
+
+-- name: FindBannedTermByID :one
+SELECT id, term, match_type, action, auto_block, created_by, created_at, updated_at
+FROM banned_terms
+WHERE id = $1
+LIMIT 1;
+
+-- name: CreateBannedTerm :one
+INSERT INTO banned_terms (term, match_type, action, auto_block, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, term, match_type, action, auto_block, created_by, created_at, updated_at;
+
+-- name: UpdateBannedTerm :one
+UPDATE banned_terms
+SET term = $2, match_type = $3, action = $4, auto_block = $5, updated_at = $6
+WHERE id = $1
+RETURNING id, term, match_type, action, auto_block, created_by, created_at, updated_at;
+
+-- name: DeleteBannedTerm :execrows
+DELETE FROM banned_terms
+WHERE id = $1;
@@ -0,0 +1,4 @@
+-- name: CreateCheer :one
+INSERT INTO cheers (from_user_id, to_user_id, cheered_on, created_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, from_user_id, to_user_id, cheered_on, created_at;
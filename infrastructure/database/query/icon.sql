@@ -0,0 +1,11 @@
+-- name: ListAssignableIcons :many
+SELECT id, tier, owner_user_id, asset_key, created_at, updated_at
+FROM icons
+WHERE owner_user_id = $1
+   OR (owner_user_id IS NULL AND tier = $2)
+ORDER BY id;
+
+-- name: CreateIcon :one
+INSERT INTO icons (tier, owner_user_id, asset_key, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, tier, owner_user_id, asset_key, created_at, updated_at;
@@ -0,0 +1,27 @@
+-- name: CreateIconCommission :one
+INSERT INTO icon_commissions (user_id, status, cost, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, status, cost, icon_id, note, created_at, updated_at;
+
+-- name: FindIconCommissionByID :one
+SELECT id, user_id, status, cost, icon_id, note, created_at, updated_at
+FROM icon_commissions
+WHERE id = $1;
+
+-- name: FindIconCommissionByIDForUpdate :one
+SELECT id, user_id, status, cost, icon_id, note, created_at, updated_at
+FROM icon_commissions
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListIconCommissions :many
+SELECT id, user_id, status, cost, icon_id, note, created_at, updated_at
+FROM icon_commissions
+WHERE (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status))
+ORDER BY created_at, id
+LIMIT sqlc.arg(row_limit);
+
+-- name: UpdateIconCommission :exec
+UPDATE icon_commissions
+SET status = $2, icon_id = $3, note = $4, updated_at = $5
+WHERE id = $1;
@@ -0,0 +1,31 @@
+-- name: ClaimIdempotencyKey :one
+INSERT INTO idempotency_keys (key, request_hash, created_at, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    status_code = NULL,
+    response_body = NULL,
+    response_headers = NULL,
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+RETURNING key, request_hash, status_code, response_body, response_headers, created_at, expires_at;
+
+-- name: FindIdempotencyKey :one
+SELECT key, request_hash, status_code, response_body, response_headers, created_at, expires_at
+FROM idempotency_keys
+WHERE key = $1
+LIMIT 1;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status_code = $2, response_body = $3, response_headers = $4
+WHERE key = $1;
+
+-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE key = $1 AND status_code IS NULL;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= $1;
@@ -0,0 +1,55 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO outbox_events (event_type, payload, created_at)
+VALUES ($1, $2, $3)
+RETURNING id, event_type, payload, attempts, last_error, created_at, dispatched_at, dispatch_seq, next_attempt_at, dead_at;
+
+-- name: ListPendingOutboxEvents :many
+SELECT id, event_type, payload, attempts, last_error, created_at, dispatched_at, dispatch_seq, next_attempt_at, dead_at
+FROM outbox_events
+WHERE dispatched_at IS NULL
+  AND dead_at IS NULL
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListDispatchedOutboxEventsAfter :many
+SELECT id, event_type, payload, attempts, last_error, created_at, dispatched_at, dispatch_seq, next_attempt_at, dead_at
+FROM outbox_events
+WHERE dispatch_seq > COALESCE(
+    (SELECT a.dispatch_seq FROM outbox_events a WHERE a.id = sqlc.arg(after_id)),
+    (SELECT MAX(b.dispatch_seq) FROM outbox_events b WHERE b.id <= sqlc.arg(after_id)),
+    0
+)
+ORDER BY dispatch_seq
+LIMIT sqlc.arg(row_limit);
+
+-- name: MarkOutboxEventDispatched :exec
+UPDATE outbox_events
+SET dispatched_at = $2, dispatch_seq = nextval('outbox_events_dispatch_seq')
+WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE outbox_events
+SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+WHERE id = $1;
+
+-- name: MarkOutboxEventDead :exec
+UPDATE outbox_events
+SET attempts = attempts + 1, last_error = $2, dead_at = $3
+WHERE id = $1;
+
+-- name: ListDeadOutboxEvents :many
+SELECT id, event_type, payload, attempts, last_error, created_at, dispatched_at, dispatch_seq, next_attempt_at, dead_at
+FROM outbox_events
+WHERE dead_at IS NOT NULL
+ORDER BY dead_at DESC, id DESC
+LIMIT $1;
+
+-- name: DeleteDispatchedOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE dispatched_at IS NOT NULL
+  AND dispatched_at < $1;
+
+-- name: DeleteDeadOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE dead_at IS NOT NULL
+  AND dead_at < $1;
@@ -0,0 +1,9 @@
+-- name: CreatePointEntry :one
+INSERT INTO point_ledger (user_id, delta, reason, reference, created_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, delta, reason, reference, created_at;
+
+-- name: GetPointBalance :one
+SELECT COALESCE(SUM(delta), 0)::bigint AS balance
+FROM point_ledger
+WHERE user_id = $1;
@@ -0,0 +1,18 @@
+-- name: ListRuntimeSettings :many
+SELECT key, value, updated_by, updated_at
+FROM runtime_settings
+ORDER BY key;
+
+-- name: LockRuntimeSettings :exec
+SELECT pg_advisory_xact_lock(hashtext('runtime_settings'));
+
+-- name: UpsertRuntimeSetting :one
+INSERT INTO runtime_settings (key, value, updated_by, updated_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (key) DO UPDATE
+SET value = EXCLUDED.value, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+RETURNING key, value, updated_by, updated_at;
+
+-- name: DeleteRuntimeSetting :execrows
+DELETE FROM runtime_settings
+WHERE key = $1;
@@ -53,12 +53,18 @@ SELECT
   s.created_at,
   s.updated_at,
   u.name as user_name,
-  u.tier as user_tier
+  u.tier as user_tier,
+  i.asset_key as icon_asset_key
 FROM sessions s
 JOIN users u ON s.user_id = u.id
+LEFT JOIN icons i ON s.icon_id = i.id
 WHERE s.actual_end IS NULL
 ORDER BY s.start_time DESC;
 
+-- name: CountActiveSessions :one
+SELECT COUNT(*) FROM sessions
+WHERE actual_end IS NULL;
+
 -- name: ListUserSessionsForDate :many
 SELECT id, user_id, work_name, start_time, planned_end, actual_end, icon_id, created_at, updated_at
 FROM sessions
@@ -0,0 +1,24 @@
+-- name: FindSessionByIDForUpdate :one
+SELECT id, user_id, work_name, start_time, planned_end, actual_end, icon_id, created_at, updated_at
+FROM sessions
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListOverlappingSessions :many
+SELECT id, user_id, work_name, start_time, planned_end, actual_end, icon_id, created_at, updated_at
+FROM sessions
+WHERE user_id = sqlc.arg(user_id)
+  AND id <> sqlc.arg(exclude_id)
+  AND (actual_end IS NULL OR actual_end > sqlc.arg(range_start))
+  AND (sqlc.narg(range_end)::timestamp IS NULL OR start_time < sqlc.narg(range_end))
+ORDER BY start_time;
+
+-- name: CorrectSession :one
+UPDATE sessions
+SET work_name = $2, start_time = $3, planned_end = $4, actual_end = $5, updated_at = $6
+WHERE id = $1
+RETURNING id, user_id, work_name, start_time, planned_end, actual_end, icon_id, created_at, updated_at;
+
+-- name: DeleteSession :execrows
+DELETE FROM sessions
+WHERE id = $1;
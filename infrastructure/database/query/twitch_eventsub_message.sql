@@ -0,0 +1,5 @@
+-- name: CreateTwitchEventSubMessage :one
+INSERT INTO twitch_eventsub_messages (message_id, subscription_type, received_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (message_id) DO NOTHING
+RETURNING message_id;
@@ -1,11 +1,11 @@
 -- name: FindUserByName :one
-SELECT id, name, tier, created_at, updated_at
+SELECT id, name, tier, created_at, updated_at, blocked_until, block_reason, blocked_by
 FROM users
 WHERE name = $1
 LIMIT 1;
 
 -- name: FindUserByID :one
-SELECT id, name, tier, created_at, updated_at
+SELECT id, name, tier, created_at, updated_at, blocked_until, block_reason, blocked_by
 FROM users
 WHERE id = $1
 LIMIT 1;
@@ -13,17 +13,24 @@ LIMIT 1;
 -- name: CreateUser :one
 INSERT INTO users (name, tier, created_at, updated_at)
 VALUES ($1, $2, $3, $4)
-RETURNING id, name, tier, created_at, updated_at;
+RETURNING id, name, tier, created_at, updated_at, blocked_until, block_reason, blocked_by;
 
 -- name: UpdateUser :one
 UPDATE users
-SET tier = $2, updated_at = $3
+SET tier = $2, blocked_until = $3, block_reason = $4, blocked_by = $5, updated_at = $6
 WHERE id = $1
-RETURNING id, name, tier, created_at, updated_at;
+RETURNING id, name, tier, created_at, updated_at, blocked_until, block_reason, blocked_by;
 
 -- name: FindUserByNameForUpdate :one
-SELECT id, name, tier, created_at, updated_at
+SELECT id, name, tier, created_at, updated_at, blocked_until, block_reason, blocked_by
 FROM users
 WHERE name = $1
 FOR UPDATE
 LIMIT 1;
+
+-- name: FindUserByIDForUpdate :one
+SELECT id, name, tier, created_at, updated_at, blocked_until, block_reason, blocked_by
+FROM users
+WHERE id = $1
+FOR UPDATE
+LIMIT 1;
@@ -0,0 +1,54 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, event_types, secret, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, url, event_types, secret, created_by, created_at, updated_at;
+
+-- name: ListWebhookSubscriptions :many
+SELECT id, url, event_types, secret, created_by, created_at, updated_at
+FROM webhook_subscriptions
+ORDER BY id;
+
+-- name: FindWebhookSubscriptionByID :one
+SELECT id, url, event_types, secret, created_by, created_at, updated_at
+FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: EnqueueWebhookDelivery :one
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (subscription_id, event_id) DO NOTHING
+RETURNING id;
+
+-- name: ListDueWebhookDeliveries :many
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, last_status_code, created_at, updated_at
+FROM webhook_deliveries
+WHERE status = 'pending'
+  AND next_attempt_at <= $1
+ORDER BY next_attempt_at, id
+LIMIT $2;
+
+-- name: FindWebhookDeliveryByID :one
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, last_status_code, created_at, updated_at
+FROM webhook_deliveries
+WHERE id = $1;
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, last_status_code = $6, updated_at = $7
+WHERE id = $1;
+
+-- name: ListWebhookDeliveriesByStatus :many
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_error, last_status_code, created_at, updated_at
+FROM webhook_deliveries
+WHERE status = $1
+ORDER BY updated_at DESC, id DESC
+LIMIT $2;
+
+-- name: DeleteDeliveredWebhookDeliveries :execrows
+DELETE FROM webhook_deliveries
+WHERE status = 'delivered'
+  AND updated_at < $1;
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+)
+
+// Ensure auditLogRepositoryImpl implements domain.AuditLogRepository
+var _ domainRepo.AuditLogRepository = (*auditLogRepositoryImpl)(nil)
+
+// defaultAuditLogLimit 件数指定がない場合の取得件数
+const defaultAuditLogLimit = 100
+
+type auditLogRepositoryImpl struct {
+	queries *sqlc.Queries
+}
+
+// NewAuditLogRepository creates a new audit log repository implementation
+func NewAuditLogRepository(queries *sqlc.Queries) domainRepo.AuditLogRepository {
+	return &auditLogRepositoryImpl{queries: queries}
+}
+
+func (r *auditLogRepositoryImpl) Save(ctx context.Context, log *domain.AuditLog) error {
+	return r.save(ctx, r.queries, log)
+}
+
+func (r *auditLogRepositoryImpl) SaveWithTx(ctx context.Context, tx domainRepo.Tx, log *domain.AuditLog) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+	return r.save(ctx, sqlc.New(wrapper.tx), log)
+}
+
+func (r *auditLogRepositoryImpl) save(ctx context.Context, queries *sqlc.Queries, log *domain.AuditLog) error {
+	created, err := queries.CreateAuditLog(ctx, sqlc.CreateAuditLogParams{
+		Actor:       log.Actor,
+		Action:      log.Action,
+		TargetType:  log.TargetType,
+		TargetID:    log.TargetID,
+		BeforeState: log.Before,
+		AfterState:  log.After,
+		Reason:      log.Reason,
+		CreatedAt:   pgtype.Timestamp{Time: log.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	log.ID = created.ID
+	return nil
+}
+
+func (r *auditLogRepositoryImpl) List(ctx context.Context, filter domainRepo.AuditLogFilter) ([]*domain.AuditLog, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLogLimit
+	}
+
+	rows, err := r.queries.ListAuditLogs(ctx, sqlc.ListAuditLogsParams{
+		TargetType: pgtype.Text{String: filter.TargetType, Valid: filter.TargetType != ""},
+		TargetID:   pgtype.Text{String: filter.TargetID, Valid: filter.TargetID != ""},
+		RowLimit:   limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]*domain.AuditLog, 0, len(rows))
+	for _, row := range rows {
+		logs = append(logs, toDomainAuditLog(row))
+	}
+	return logs, nil
+}
+
+// toDomainAuditLog converts sqlc.AuditLog to domain.AuditLog
+func toDomainAuditLog(row sqlc.AuditLog) *domain.AuditLog {
+	return &domain.AuditLog{
+		ID:         row.ID,
+		Actor:      row.Actor,
+		Action:     row.Action,
+		TargetType: row.TargetType,
+		TargetID:   row.TargetID,
+		Before:     row.BeforeState,
+		After:      row.AfterState,
+		Reason:     row.Reason,
+		CreatedAt:  row.CreatedAt.Time,
+	}
+}
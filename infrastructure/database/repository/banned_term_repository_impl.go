@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+)
+
+// Ensure bannedTermRepositoryImpl implements domain.BannedTermRepository
+var _ domainRepo.BannedTermRepository = (*bannedTermRepositoryImpl)(nil)
+
+type bannedTermRepositoryImpl struct {
+	queries *sqlc.Queries
+}
+
+// NewBannedTermRepository creates a new banned term repository implementation
+func NewBannedTermRepository(queries *sqlc.Queries) domainRepo.BannedTermRepository {
+	return &bannedTermRepositoryImpl{queries: queries}
+}
+
+func (r *bannedTermRepositoryImpl) List(ctx context.Context) ([]*domain.BannedTerm, error) {
+	rows, err := r.queries.ListBannedTerms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := make([]*domain.BannedTerm, 0, len(rows))
+	for _, row := range rows {
+		terms = append(terms, toDomainBannedTerm(row))
+	}
+	return terms, nil
+}
+
+func (r *bannedTermRepositoryImpl) FindByID(ctx context.Context, id int64) (*domain.BannedTerm, error) {
+	row, err := r.queries.FindBannedTermByID(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrBannedTermNotFound
+		}
+		return nil, err
+	}
+	return toDomainBannedTerm(row), nil
+}
+
+func (r *bannedTermRepositoryImpl) Save(ctx context.Context, term *domain.BannedTerm) error {
+	if term.ID == 0 {
+		created, err := r.queries.CreateBannedTerm(ctx, sqlc.CreateBannedTermParams{
+			Term:      term.Term,
+			MatchType: string(term.MatchType),
+			Action:    string(term.Action),
+			AutoBlock: term.AutoBlock,
+			CreatedBy: term.CreatedBy,
+			CreatedAt: pgtype.Timestamp{Time: term.CreatedAt, Valid: true},
+			UpdatedAt: pgtype.Timestamp{Time: term.UpdatedAt, Valid: true},
+		})
+		if err != nil {
+			if isUniqueViolation(err) {
+				return domain.ErrBannedTermExists
+			}
+			return err
+		}
+		*term = *toDomainBannedTerm(created)
+		return nil
+	}
+
+	updated, err := r.queries.UpdateBannedTerm(ctx, sqlc.UpdateBannedTermParams{
+		ID:        int32(term.ID),
+		Term:      term.Term,
+		MatchType: string(term.MatchType),
+		Action:    string(term.Action),
+		AutoBlock: term.AutoBlock,
+		UpdatedAt: pgtype.Timestamp{Time: term.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrBannedTermNotFound
+		}
+		if isUniqueViolation(err) {
+			return domain.ErrBannedTermExists
+		}
+		return err
+	}
+	*term = *toDomainBannedTerm(updated)
+	return nil
+}
+
+func (r *bannedTermRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	deleted, err := r.queries.DeleteBannedTerm(ctx, int32(id))
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrBannedTermNotFound
+	}
+	return nil
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// toDomainBannedTerm converts sqlc.BannedTerm to domain.BannedTerm
+func toDomainBannedTerm(row sqlc.BannedTerm) *domain.BannedTerm {
+	return &domain.BannedTerm{
+		ID:        int64(row.ID),
+		Term:      row.Term,
+		MatchType: domain.MatchType(row.MatchType),
+		Action:    domain.ModerationAction(row.Action),
+		AutoBlock: row.AutoBlock,
+		CreatedBy: row.CreatedBy,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
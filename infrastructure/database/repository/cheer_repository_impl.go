@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+)
+
+// Ensure cheerRepositoryImpl implements domain.CheerRepository
+var _ domainRepo.CheerRepository = (*cheerRepositoryImpl)(nil)
+
+type cheerRepositoryImpl struct{}
+
+// NewCheerRepository creates a new cheer repository implementation
+func NewCheerRepository() domainRepo.CheerRepository {
+	return &cheerRepositoryImpl{}
+}
+
+func (r *cheerRepositoryImpl) CreateWithTx(ctx context.Context, tx domainRepo.Tx, cheer *domain.Cheer) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	created, err := sqlc.New(wrapper.tx).CreateCheer(ctx, sqlc.CreateCheerParams{
+		FromUserID: int32(cheer.FromUserID),
+		ToUserID:   int32(cheer.ToUserID),
+		CheeredOn:  pgtype.Date{Time: cheer.CheeredOn, Valid: true},
+		CreatedAt:  pgtype.Timestamp{Time: cheer.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		// The unique index on (from_user_id, to_user_id, cheered_on) enforces the daily limit
+		if isUniqueViolation(err) {
+			return domain.ErrCheerAlreadySent
+		}
+		return err
+	}
+	cheer.ID = created.ID
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/testutil"
+)
+
+func TestCheerRepository_Integration(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	testutil.CleanupTables(t, pool)
+
+	cheerRepository := repository.NewCheerRepository()
+	txRepository := repository.NewUserRepositoryWithPool(pool)
+	ctx := context.Background()
+	now := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+
+	create := func(t *testing.T, cheer *domain.Cheer) error {
+		t.Helper()
+		tx, err := txRepository.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		if err := cheerRepository.CreateWithTx(ctx, tx, cheer); err != nil {
+			_ = tx.Rollback(ctx)
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+		return nil
+	}
+	newCheer := func(from, to int64, at time.Time) *domain.Cheer {
+		cheer, err := domain.NewCheer(from, to, func() time.Time { return at }, time.UTC)
+		if err != nil {
+			t.Fatalf("Failed to create cheer: %v", err)
+		}
+		return cheer
+	}
+
+	t.Run("同じ相手への応援は 1 日 1 回まで", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+		alice := testutil.CreateTestUser(t, pool, "alice", 1)
+		bob := testutil.CreateTestUser(t, pool, "bob", 1)
+		carol := testutil.CreateTestUser(t, pool, "carol", 1)
+
+		first := newCheer(alice, bob, now)
+		if err := create(t, first); err != nil {
+			t.Fatalf("Failed to create cheer: %v", err)
+		}
+		if first.ID == 0 {
+			t.Errorf("Expected cheer ID to be set")
+		}
+
+		if err := create(t, newCheer(alice, bob, now.Add(time.Hour))); !errors.Is(err, domain.ErrCheerAlreadySent) {
+			t.Errorf("Expected ErrCheerAlreadySent, got %v", err)
+		}
+
+		// 逆向き・別の相手・翌日は記録できる
+		for _, cheer := range []*domain.Cheer{
+			newCheer(bob, alice, now),
+			newCheer(alice, carol, now),
+			newCheer(alice, bob, now.Add(24*time.Hour)),
+		} {
+			if err := create(t, cheer); err != nil {
+				t.Errorf("Failed to create cheer %+v: %v", cheer, err)
+			}
+		}
+	})
+
+	t.Run("ロールバックした応援は数えない", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+		alice := testutil.CreateTestUser(t, pool, "alice", 1)
+		bob := testutil.CreateTestUser(t, pool, "bob", 1)
+
+		tx, err := txRepository.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		if err := cheerRepository.CreateWithTx(ctx, tx, newCheer(alice, bob, now)); err != nil {
+			t.Fatalf("Failed to create cheer: %v", err)
+		}
+		_ = tx.Rollback(ctx)
+
+		if err := create(t, newCheer(alice, bob, now)); err != nil {
+			t.Errorf("Expected a rolled back cheer not to count, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+)
+
+// Ensure iconCommissionRepositoryImpl implements domain.IconCommissionRepository
+var _ domainRepo.IconCommissionRepository = (*iconCommissionRepositoryImpl)(nil)
+
+type iconCommissionRepositoryImpl struct {
+	queries *sqlc.Queries
+}
+
+// NewIconCommissionRepository creates a new icon commission repository implementation
+func NewIconCommissionRepository(queries *sqlc.Queries) domainRepo.IconCommissionRepository {
+	return &iconCommissionRepositoryImpl{queries: queries}
+}
+
+func (r *iconCommissionRepositoryImpl) List(ctx context.Context, status domain.IconCommissionStatus, limit int32) ([]*domain.IconCommission, error) {
+	var statusFilter pgtype.Text
+	if status != "" {
+		statusFilter = pgtype.Text{String: string(status), Valid: true}
+	}
+
+	rows, err := r.queries.ListIconCommissions(ctx, sqlc.ListIconCommissionsParams{
+		Status:   statusFilter,
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	commissions := make([]*domain.IconCommission, 0, len(rows))
+	for _, row := range rows {
+		commissions = append(commissions, toDomainIconCommission(row))
+	}
+	return commissions, nil
+}
+
+func (r *iconCommissionRepositoryImpl) FindByID(ctx context.Context, id int64) (*domain.IconCommission, error) {
+	row, err := r.queries.FindIconCommissionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrIconCommissionNotFound
+		}
+		return nil, err
+	}
+	return toDomainIconCommission(row), nil
+}
+
+func (r *iconCommissionRepositoryImpl) FindByIDWithTx(ctx context.Context, tx domainRepo.Tx, id int64) (*domain.IconCommission, error) {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return nil, errors.New("invalid transaction type")
+	}
+
+	row, err := sqlc.New(wrapper.tx).FindIconCommissionByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrIconCommissionNotFound
+		}
+		return nil, err
+	}
+	return toDomainIconCommission(row), nil
+}
+
+func (r *iconCommissionRepositoryImpl) CreateWithTx(ctx context.Context, tx domainRepo.Tx, commission *domain.IconCommission) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	created, err := sqlc.New(wrapper.tx).CreateIconCommission(ctx, sqlc.CreateIconCommissionParams{
+		UserID:    int32(commission.UserID),
+		Status:    string(commission.Status),
+		Cost:      commission.Cost,
+		CreatedAt: pgtype.Timestamp{Time: commission.CreatedAt, Valid: true},
+		UpdatedAt: pgtype.Timestamp{Time: commission.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrIconCommissionAlreadyOpen
+		}
+		return err
+	}
+	*commission = *toDomainIconCommission(created)
+	return nil
+}
+
+func (r *iconCommissionRepositoryImpl) UpdateWithTx(ctx context.Context, tx domainRepo.Tx, commission *domain.IconCommission) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	var iconID pgtype.Int4
+	if commission.IconID != nil {
+		iconID = pgtype.Int4{Int32: int32(*commission.IconID), Valid: true}
+	}
+	return sqlc.New(wrapper.tx).UpdateIconCommission(ctx, sqlc.UpdateIconCommissionParams{
+		ID:        commission.ID,
+		Status:    string(commission.Status),
+		IconID:    iconID,
+		Note:      commission.Note,
+		UpdatedAt: pgtype.Timestamp{Time: commission.UpdatedAt, Valid: true},
+	})
+}
+
+// toDomainIconCommission converts sqlc.IconCommission to domain.IconCommission
+func toDomainIconCommission(row sqlc.IconCommission) *domain.IconCommission {
+	var iconID *int64
+	if row.IconID.Valid {
+		id := int64(row.IconID.Int32)
+		iconID = &id
+	}
+	return &domain.IconCommission{
+		ID:        row.ID,
+		UserID:    int64(row.UserID),
+		Status:    domain.IconCommissionStatus(row.Status),
+		Cost:      row.Cost,
+		IconID:    iconID,
+		Note:      row.Note,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
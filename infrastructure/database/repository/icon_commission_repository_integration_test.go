@@ -0,0 +1,174 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/testutil"
+)
+
+func TestIconCommissionRepository_Integration(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	testutil.CleanupTables(t, pool)
+
+	queries := sqlc.New(pool)
+	commissionRepository := repository.NewIconCommissionRepository(queries)
+	ledgerRepository := repository.NewPointLedgerRepository(queries)
+	iconRepository := repository.NewIconRepository(queries)
+	txRepository := repository.NewUserRepositoryWithPool(pool)
+	ctx := context.Background()
+	now := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	inTx := func(t *testing.T, fn func(tx domainRepo.Tx) error) error {
+		t.Helper()
+		tx, err := txRepository.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		if err := fn(tx); err != nil {
+			_ = tx.Rollback(ctx)
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+		return nil
+	}
+
+	t.Run("台帳の合計が残高になり、同じ参照の記録は 1 度だけ", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+		userID := testutil.CreateTestUser(t, pool, "ledger_user", 1)
+
+		credit, _ := domain.NewPointEntry(userID, 1_500_000, domain.PointReasonIconCommissionRefund, "1", clock)
+		debit, _ := domain.NewPointEntry(userID, -1_000_000, domain.PointReasonIconCommission, "1", clock)
+		for _, entry := range []*domain.PointEntry{credit, debit} {
+			if err := inTx(t, func(tx domainRepo.Tx) error { return ledgerRepository.AppendWithTx(ctx, tx, entry) }); err != nil {
+				t.Fatalf("Failed to append entry: %v", err)
+			}
+			if entry.ID == 0 {
+				t.Errorf("Expected entry ID to be set")
+			}
+		}
+
+		balance, err := ledgerRepository.Balance(ctx, userID)
+		if err != nil {
+			t.Fatalf("Failed to get balance: %v", err)
+		}
+		if balance != 500_000 {
+			t.Errorf("Expected balance 500000, got %d", balance)
+		}
+
+		duplicate, _ := domain.NewPointEntry(userID, -1_000_000, domain.PointReasonIconCommission, "1", clock)
+		err = inTx(t, func(tx domainRepo.Tx) error { return ledgerRepository.AppendWithTx(ctx, tx, duplicate) })
+		if !errors.Is(err, domain.ErrDuplicatePointEntry) {
+			t.Errorf("Expected ErrDuplicatePointEntry, got %v", err)
+		}
+
+		empty, err := ledgerRepository.Balance(ctx, userID+1)
+		if err != nil || empty != 0 {
+			t.Errorf("Expected zero balance for a user without entries, got %d (%v)", empty, err)
+		}
+	})
+
+	t.Run("作成依頼を保存・更新し、未完了の依頼は 1 件まで", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+		userID := testutil.CreateTestUser(t, pool, "commission_user", 2)
+
+		commission, _ := domain.NewIconCommission(userID, domain.IconCommissionCost, clock)
+		if err := inTx(t, func(tx domainRepo.Tx) error { return commissionRepository.CreateWithTx(ctx, tx, commission) }); err != nil {
+			t.Fatalf("Failed to create commission: %v", err)
+		}
+		if commission.ID == 0 {
+			t.Fatal("Expected commission ID to be set")
+		}
+
+		second, _ := domain.NewIconCommission(userID, domain.IconCommissionCost, clock)
+		err := inTx(t, func(tx domainRepo.Tx) error { return commissionRepository.CreateWithTx(ctx, tx, second) })
+		if !errors.Is(err, domain.ErrIconCommissionAlreadyOpen) {
+			t.Fatalf("Expected ErrIconCommissionAlreadyOpen, got %v", err)
+		}
+
+		err = inTx(t, func(tx domainRepo.Tx) error {
+			locked, err := commissionRepository.FindByIDWithTx(ctx, tx, commission.ID)
+			if err != nil {
+				return err
+			}
+			icon, err := domain.NewExclusiveIcon(userID, domain.Tier2, "commission-01", clock)
+			if err != nil {
+				return err
+			}
+			if err := iconRepository.CreateWithTx(ctx, tx, icon); err != nil {
+				return err
+			}
+			if err := locked.Deliver(icon.ID, clock); err != nil {
+				return err
+			}
+			return commissionRepository.UpdateWithTx(ctx, tx, locked)
+		})
+		if err != nil {
+			t.Fatalf("Failed to deliver commission: %v", err)
+		}
+
+		got, err := commissionRepository.FindByID(ctx, commission.ID)
+		if err != nil {
+			t.Fatalf("Failed to find commission: %v", err)
+		}
+		if got.Status != domain.IconCommissionDelivered || got.IconID == nil || got.Cost != domain.IconCommissionCost {
+			t.Errorf("Unexpected commission: %+v", got)
+		}
+
+		icons, err := iconRepository.ListAssignable(ctx, userID, domain.Tier2)
+		if err != nil {
+			t.Fatalf("Failed to list icons: %v", err)
+		}
+		found := false
+		for _, icon := range icons {
+			if icon.ID == *got.IconID && icon.IsExclusive() && icon.AssetKey == "commission-01" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected delivered icon to be assignable, got %+v", icons)
+		}
+
+		// 納品後は新しい依頼を受け付ける
+		if err := inTx(t, func(tx domainRepo.Tx) error { return commissionRepository.CreateWithTx(ctx, tx, second) }); err != nil {
+			t.Fatalf("Failed to create commission after delivery: %v", err)
+		}
+
+		pending, err := commissionRepository.List(ctx, domain.IconCommissionPending, 10)
+		if err != nil {
+			t.Fatalf("Failed to list commissions: %v", err)
+		}
+		if len(pending) != 1 || pending[0].ID != second.ID {
+			t.Errorf("Expected only the second commission, got %+v", pending)
+		}
+		all, err := commissionRepository.List(ctx, "", 10)
+		if err != nil || len(all) != 2 {
+			t.Errorf("Expected 2 commissions, got %d (%v)", len(all), err)
+		}
+
+		if _, err := commissionRepository.FindByID(ctx, 9999); !errors.Is(err, domain.ErrIconCommissionNotFound) {
+			t.Errorf("Expected ErrIconCommissionNotFound, got %v", err)
+		}
+	})
+
+	t.Run("アセットキーの重複は ErrIconAssetKeyExists", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+		userID := testutil.CreateTestUser(t, pool, "asset_key_user", 1)
+		testutil.CreateTestIcon(t, pool, 1, nil, "tier1-01")
+
+		icon, _ := domain.NewExclusiveIcon(userID, domain.Tier1, "tier1-01", clock)
+		err := inTx(t, func(tx domainRepo.Tx) error { return iconRepository.CreateWithTx(ctx, tx, icon) })
+		if !errors.Is(err, domain.ErrIconAssetKeyExists) {
+			t.Errorf("Expected ErrIconAssetKeyExists, got %v", err)
+		}
+	})
+}
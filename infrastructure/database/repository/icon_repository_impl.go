@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+)
+
+// Ensure iconRepositoryImpl implements domain.IconRepository
+var _ domainRepo.IconRepository = (*iconRepositoryImpl)(nil)
+
+type iconRepositoryImpl struct {
+	queries *sqlc.Queries
+}
+
+// NewIconRepository creates a new icon repository implementation
+func NewIconRepository(queries *sqlc.Queries) domainRepo.IconRepository {
+	return &iconRepositoryImpl{queries: queries}
+}
+
+func (r *iconRepositoryImpl) ListAssignable(ctx context.Context, userID int64, tier domain.Tier) ([]*domain.Icon, error) {
+	rows, err := r.queries.ListAssignableIcons(ctx, sqlc.ListAssignableIconsParams{
+		OwnerUserID: pgtype.Int4{Int32: int32(userID), Valid: true},
+		Tier:        int32(tier),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	icons := make([]*domain.Icon, 0, len(rows))
+	for _, row := range rows {
+		icons = append(icons, toDomainIcon(row))
+	}
+	return icons, nil
+}
+
+func (r *iconRepositoryImpl) CreateWithTx(ctx context.Context, tx domainRepo.Tx, icon *domain.Icon) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	var ownerUserID pgtype.Int4
+	if icon.OwnerUserID != nil {
+		ownerUserID = pgtype.Int4{Int32: int32(*icon.OwnerUserID), Valid: true}
+	}
+	created, err := sqlc.New(wrapper.tx).CreateIcon(ctx, sqlc.CreateIconParams{
+		Tier:        int32(icon.Tier),
+		OwnerUserID: ownerUserID,
+		AssetKey:    icon.AssetKey,
+		CreatedAt:   pgtype.Timestamp{Time: icon.CreatedAt, Valid: true},
+		UpdatedAt:   pgtype.Timestamp{Time: icon.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrIconAssetKeyExists
+		}
+		return err
+	}
+	*icon = *toDomainIcon(created)
+	return nil
+}
+
+func toDomainIcon(row sqlc.Icon) *domain.Icon {
+	var ownerUserID *int64
+	if row.OwnerUserID.Valid {
+		id := int64(row.OwnerUserID.Int32)
+		ownerUserID = &id
+	}
+	return &domain.Icon{
+		ID:          int64(row.ID),
+		Tier:        domain.Tier(row.Tier),
+		OwnerUserID: ownerUserID,
+		AssetKey:    row.AssetKey,
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,75 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/testutil"
+)
+
+func TestIconRepository_Integration(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	testutil.CleanupTables(t, pool)
+
+	queries := sqlc.New(pool)
+	iconRepository := repository.NewIconRepository(queries)
+	sessionRepository := repository.NewSessionRepository(queries)
+	ctx := context.Background()
+
+	t.Run("専用アイコンとティアの共通アイコンを返す", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		owner := testutil.CreateTestUser(t, pool, "icon_owner", 1)
+		other := testutil.CreateTestUser(t, pool, "icon_other", 1)
+		shared := testutil.CreateTestIcon(t, pool, 1, nil, "tier1-01")
+		testutil.CreateTestIcon(t, pool, 2, nil, "tier2-01")
+		exclusive := testutil.CreateTestIcon(t, pool, 1, &owner, "owner-01")
+		testutil.CreateTestIcon(t, pool, 1, &other, "other-01")
+
+		icons, err := iconRepository.ListAssignable(ctx, owner, domain.Tier1)
+		if err != nil {
+			t.Fatalf("Failed to list icons: %v", err)
+		}
+		if len(icons) != 2 || icons[0].ID != shared || icons[1].ID != exclusive {
+			t.Fatalf("Expected icons [%d %d], got %+v", shared, exclusive, icons)
+		}
+		if icons[0].IsExclusive() || !icons[1].IsExclusive() || *icons[1].OwnerUserID != owner {
+			t.Errorf("Unexpected owners: %+v, %+v", icons[0], icons[1])
+		}
+		if icons[1].AssetKey != "owner-01" || icons[1].Tier != domain.Tier1 {
+			t.Errorf("Unexpected icon: %+v", icons[1])
+		}
+	})
+
+	t.Run("アクティブセッションにアイコンのアセットキーを含める", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		userID := testutil.CreateTestUser(t, pool, "icon_session_user", 1)
+		iconID := testutil.CreateTestIcon(t, pool, 1, nil, "tier1-03")
+
+		session, _ := domain.NewSession(userID, "作業", time.Hour, time.Now)
+		session.IconID = &iconID
+		if err := sessionRepository.Create(ctx, session); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+
+		sessions, err := sessionRepository.FindAllActive(ctx)
+		if err != nil {
+			t.Fatalf("Failed to find active sessions: %v", err)
+		}
+		if len(sessions) != 1 {
+			t.Fatalf("Expected 1 active session, got %d", len(sessions))
+		}
+		got := sessions[0]
+		if got.IconID == nil || *got.IconID != iconID {
+			t.Errorf("Expected icon ID %d, got %v", iconID, got.IconID)
+		}
+		if got.IconAssetKey == nil || *got.IconAssetKey != "tier1-03" {
+			t.Errorf("Expected icon asset key tier1-03, got %v", got.IconAssetKey)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+)
+
+// Ensure idempotencyRepositoryImpl implements domain.IdempotencyRepository
+var _ domainRepo.IdempotencyRepository = (*idempotencyRepositoryImpl)(nil)
+
+type idempotencyRepositoryImpl struct {
+	queries *sqlc.Queries
+}
+
+// NewIdempotencyRepository creates a new idempotency repository implementation
+func NewIdempotencyRepository(queries *sqlc.Queries) domainRepo.IdempotencyRepository {
+	return &idempotencyRepositoryImpl{queries: queries}
+}
+
+func (r *idempotencyRepositoryImpl) Claim(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error) {
+	_, err := r.queries.ClaimIdempotencyKey(ctx, sqlc.ClaimIdempotencyKeyParams{
+		Key:         record.Key,
+		RequestHash: record.RequestHash,
+		CreatedAt:   pgtype.Timestamp{Time: record.CreatedAt, Valid: true},
+		ExpiresAt:   pgtype.Timestamp{Time: record.ExpiresAt, Valid: true},
+	})
+	if err == nil {
+		return nil, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) && !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, err
+	}
+
+	// 有効なレコードが既に存在するため、ON CONFLICT の更新が行われなかった
+	existing, err := r.FindByKey(ctx, record.Key)
+	if err != nil {
+		return nil, false, err
+	}
+	return existing, false, nil
+}
+
+func (r *idempotencyRepositoryImpl) FindByKey(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	row, err := r.queries.FindIdempotencyKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrIdempotencyKeyNotFound
+		}
+		return nil, err
+	}
+	return toDomainIdempotencyRecord(row)
+}
+
+func (r *idempotencyRepositoryImpl) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	var headers []byte
+	if len(record.ResponseHeaders) > 0 {
+		var err error
+		if headers, err = json.Marshal(record.ResponseHeaders); err != nil {
+			return err
+		}
+	}
+	return r.queries.CompleteIdempotencyKey(ctx, sqlc.CompleteIdempotencyKeyParams{
+		Key:             record.Key,
+		StatusCode:      pgtype.Int4{Int32: int32(record.StatusCode), Valid: true},
+		ResponseBody:    record.ResponseBody,
+		ResponseHeaders: headers,
+	})
+}
+
+func (r *idempotencyRepositoryImpl) Release(ctx context.Context, key string) error {
+	return r.queries.ReleaseIdempotencyKey(ctx, key)
+}
+
+func (r *idempotencyRepositoryImpl) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return r.queries.DeleteExpiredIdempotencyKeys(ctx, pgtype.Timestamp{Time: now, Valid: true})
+}
+
+// toDomainIdempotencyRecord converts sqlc.IdempotencyKey to domain.IdempotencyRecord
+func toDomainIdempotencyRecord(row sqlc.IdempotencyKey) (*domain.IdempotencyRecord, error) {
+	statusCode := 0
+	if row.StatusCode.Valid {
+		statusCode = int(row.StatusCode.Int32)
+	}
+
+	var headers map[string][]string
+	if len(row.ResponseHeaders) > 0 {
+		if err := json.Unmarshal(row.ResponseHeaders, &headers); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.IdempotencyRecord{
+		Key:             row.Key,
+		RequestHash:     row.RequestHash,
+		StatusCode:      statusCode,
+		ResponseBody:    row.ResponseBody,
+		ResponseHeaders: headers,
+		CreatedAt:       row.CreatedAt.Time,
+		ExpiresAt:       row.ExpiresAt.Time,
+	}, nil
+}
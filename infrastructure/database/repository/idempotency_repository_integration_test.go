@@ -0,0 +1,118 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/testutil"
+)
+
+func TestIdempotencyRepository_Integration(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	testutil.CleanupTables(t, pool)
+
+	idempotencyRepository := repository.NewIdempotencyRepository(sqlc.New(pool))
+	ctx := context.Background()
+	now := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+
+	t.Run("新しいキーを確保して結果を保存する", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		record, _ := domain.NewIdempotencyRecord("claim-key", "hash-a", time.Hour, func() time.Time { return now })
+		existing, claimed, err := idempotencyRepository.Claim(ctx, record)
+		if err != nil {
+			t.Fatalf("Failed to claim key: %v", err)
+		}
+		if !claimed || existing != nil {
+			t.Fatalf("Expected key to be claimed, got claimed=%v existing=%+v", claimed, existing)
+		}
+
+		if err := record.Complete(200, map[string][]string{"Content-Type": {"application/json"}, "Retry-After": {"3"}}, []byte(`{"session_id":1}`)); err != nil {
+			t.Fatalf("Failed to complete record: %v", err)
+		}
+		if err := idempotencyRepository.Complete(ctx, record); err != nil {
+			t.Fatalf("Failed to store response: %v", err)
+		}
+
+		found, err := idempotencyRepository.FindByKey(ctx, "claim-key")
+		if err != nil {
+			t.Fatalf("Failed to find key: %v", err)
+		}
+		if found.StatusCode != 200 || string(found.ResponseBody) != `{"session_id":1}` {
+			t.Errorf("Unexpected stored response: %d %s", found.StatusCode, found.ResponseBody)
+		}
+		if got := found.ResponseHeaders["Retry-After"]; len(got) != 1 || got[0] != "3" {
+			t.Errorf("Unexpected stored headers: %v", found.ResponseHeaders)
+		}
+	})
+
+	t.Run("有効なキーの再確保は既存レコードを返す", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		first, _ := domain.NewIdempotencyRecord("dup-key", "hash-a", time.Hour, func() time.Time { return now })
+		if _, _, err := idempotencyRepository.Claim(ctx, first); err != nil {
+			t.Fatalf("Failed to claim key: %v", err)
+		}
+
+		second, _ := domain.NewIdempotencyRecord("dup-key", "hash-b", time.Hour, func() time.Time { return now.Add(time.Minute) })
+		existing, claimed, err := idempotencyRepository.Claim(ctx, second)
+		if err != nil {
+			t.Fatalf("Failed to claim key: %v", err)
+		}
+		if claimed {
+			t.Fatal("Expected duplicate key not to be claimed")
+		}
+		if existing.RequestHash != "hash-a" || existing.IsCompleted() {
+			t.Errorf("Unexpected existing record: %+v", existing)
+		}
+	})
+
+	t.Run("期限切れのキーは再確保できる", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		first, _ := domain.NewIdempotencyRecord("expired-key", "hash-a", time.Hour, func() time.Time { return now })
+		if _, _, err := idempotencyRepository.Claim(ctx, first); err != nil {
+			t.Fatalf("Failed to claim key: %v", err)
+		}
+
+		later, _ := domain.NewIdempotencyRecord("expired-key", "hash-b", time.Hour, func() time.Time { return now.Add(2 * time.Hour) })
+		_, claimed, err := idempotencyRepository.Claim(ctx, later)
+		if err != nil {
+			t.Fatalf("Failed to claim key: %v", err)
+		}
+		if !claimed {
+			t.Fatal("Expected expired key to be claimed again")
+		}
+	})
+
+	t.Run("未完了のキーを解放し期限切れのキーを削除する", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		pending, _ := domain.NewIdempotencyRecord("pending-key", "hash-a", time.Hour, func() time.Time { return now })
+		if _, _, err := idempotencyRepository.Claim(ctx, pending); err != nil {
+			t.Fatalf("Failed to claim key: %v", err)
+		}
+		if err := idempotencyRepository.Release(ctx, "pending-key"); err != nil {
+			t.Fatalf("Failed to release key: %v", err)
+		}
+		if _, err := idempotencyRepository.FindByKey(ctx, "pending-key"); err != domain.ErrIdempotencyKeyNotFound {
+			t.Errorf("Expected ErrIdempotencyKeyNotFound, got %v", err)
+		}
+
+		old, _ := domain.NewIdempotencyRecord("old-key", "hash-a", time.Hour, func() time.Time { return now })
+		if _, _, err := idempotencyRepository.Claim(ctx, old); err != nil {
+			t.Fatalf("Failed to claim key: %v", err)
+		}
+		deleted, err := idempotencyRepository.DeleteExpired(ctx, now.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("Failed to delete expired keys: %v", err)
+		}
+		if deleted != 1 {
+			t.Errorf("Expected 1 deleted key, got %d", deleted)
+		}
+	})
+}
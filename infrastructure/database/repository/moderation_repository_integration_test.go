@@ -0,0 +1,131 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/testutil"
+)
+
+func TestBannedTermRepository_Integration(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	testutil.CleanupTables(t, pool)
+
+	bannedTermRepository := repository.NewBannedTermRepository(sqlc.New(pool))
+	ctx := context.Background()
+	now := func() time.Time { return time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC) }
+
+	t.Run("禁止ワードの登録・更新・削除", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		term, _ := domain.NewBannedTerm("spam", domain.MatchSubstring, domain.ModerationReject, true, "admin", now)
+		if err := bannedTermRepository.Save(ctx, term); err != nil {
+			t.Fatalf("Failed to save banned term: %v", err)
+		}
+		if term.ID == 0 {
+			t.Fatal("Expected ID to be set")
+		}
+
+		_ = term.Update("spam", domain.MatchExact, domain.ModerationMask, false, now)
+		if err := bannedTermRepository.Save(ctx, term); err != nil {
+			t.Fatalf("Failed to update banned term: %v", err)
+		}
+
+		found, err := bannedTermRepository.FindByID(ctx, term.ID)
+		if err != nil {
+			t.Fatalf("Failed to find banned term: %v", err)
+		}
+		if found.MatchType != domain.MatchExact || found.Action != domain.ModerationMask || found.AutoBlock {
+			t.Errorf("Unexpected banned term: %+v", found)
+		}
+
+		if err := bannedTermRepository.Delete(ctx, term.ID); err != nil {
+			t.Fatalf("Failed to delete banned term: %v", err)
+		}
+		if _, err := bannedTermRepository.FindByID(ctx, term.ID); err != domain.ErrBannedTermNotFound {
+			t.Errorf("Expected ErrBannedTermNotFound, got %v", err)
+		}
+		if err := bannedTermRepository.Delete(ctx, term.ID); err != domain.ErrBannedTermNotFound {
+			t.Errorf("Expected ErrBannedTermNotFound on second delete, got %v", err)
+		}
+	})
+
+	t.Run("同じワードと照合方法は重複登録できない", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		first, _ := domain.NewBannedTerm("spam", domain.MatchSubstring, domain.ModerationReject, false, "admin", now)
+		if err := bannedTermRepository.Save(ctx, first); err != nil {
+			t.Fatalf("Failed to save banned term: %v", err)
+		}
+		dup, _ := domain.NewBannedTerm("spam", domain.MatchSubstring, domain.ModerationMask, false, "admin", now)
+		if err := bannedTermRepository.Save(ctx, dup); err != domain.ErrBannedTermExists {
+			t.Errorf("Expected ErrBannedTermExists, got %v", err)
+		}
+
+		other, _ := domain.NewBannedTerm("spam", domain.MatchExact, domain.ModerationMask, false, "admin", now)
+		if err := bannedTermRepository.Save(ctx, other); err != nil {
+			t.Fatalf("Different match type should be allowed: %v", err)
+		}
+
+		terms, err := bannedTermRepository.List(ctx)
+		if err != nil {
+			t.Fatalf("Failed to list banned terms: %v", err)
+		}
+		if len(terms) != 2 || terms[0].ID != first.ID {
+			t.Errorf("Expected 2 terms ordered by ID, got %+v", terms)
+		}
+	})
+}
+
+func TestAuditLogRepository_Integration(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	testutil.CleanupTables(t, pool)
+
+	auditLogRepository := repository.NewAuditLogRepository(sqlc.New(pool))
+	ctx := context.Background()
+	base := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+
+	entries := []struct {
+		targetType string
+		targetID   string
+		action     string
+	}{
+		{domain.AuditTargetBannedTerm, "1", domain.AuditActionBannedTermCreate},
+		{domain.AuditTargetUser, "42", domain.AuditActionUserBlock},
+		{domain.AuditTargetUser, "42", domain.AuditActionUserUnblock},
+	}
+	for i, e := range entries {
+		at := base.Add(time.Duration(i) * time.Minute)
+		entry, err := domain.NewAuditLog("admin", e.action, e.targetType, e.targetID, nil, map[string]int{"n": i}, "", func() time.Time { return at })
+		if err != nil {
+			t.Fatalf("Failed to create audit log: %v", err)
+		}
+		if err := auditLogRepository.Save(ctx, entry); err != nil {
+			t.Fatalf("Failed to save audit log: %v", err)
+		}
+	}
+
+	all, err := auditLogRepository.List(ctx, domainRepo.AuditLogFilter{})
+	if err != nil {
+		t.Fatalf("Failed to list audit logs: %v", err)
+	}
+	if len(all) != 3 || all[0].Action != domain.AuditActionUserUnblock {
+		t.Fatalf("Expected 3 logs newest first, got %+v", all)
+	}
+	if all[0].Before != nil || string(all[0].After) != `{"n": 2}` && string(all[0].After) != `{"n":2}` {
+		t.Errorf("Unexpected states: before=%s after=%s", all[0].Before, all[0].After)
+	}
+
+	userLogs, err := auditLogRepository.List(ctx, domainRepo.AuditLogFilter{TargetType: domain.AuditTargetUser, TargetID: "42", Limit: 1})
+	if err != nil {
+		t.Fatalf("Failed to list audit logs: %v", err)
+	}
+	if len(userLogs) != 1 || userLogs[0].Action != domain.AuditActionUserUnblock {
+		t.Errorf("Unexpected filtered logs: %+v", userLogs)
+	}
+}
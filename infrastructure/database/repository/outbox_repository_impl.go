@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+)
+
+// Ensure outboxRepositoryImpl implements domain.OutboxRepository
+var _ domainRepo.OutboxRepository = (*outboxRepositoryImpl)(nil)
+
+type outboxRepositoryImpl struct {
+	queries *sqlc.Queries
+}
+
+// NewOutboxRepository creates a new outbox repository implementation
+func NewOutboxRepository(queries *sqlc.Queries) domainRepo.OutboxRepository {
+	return &outboxRepositoryImpl{queries: queries}
+}
+
+func (r *outboxRepositoryImpl) SaveWithTx(ctx context.Context, tx domainRepo.Tx, event *domain.OutboxEvent) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	created, err := sqlc.New(wrapper.tx).CreateOutboxEvent(ctx, sqlc.CreateOutboxEventParams{
+		EventType: event.EventType,
+		Payload:   event.Payload,
+		CreatedAt: pgtype.Timestamp{Time: event.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	event.ID = created.ID
+	return nil
+}
+
+func (r *outboxRepositoryImpl) ListPending(ctx context.Context, limit int32) ([]*domain.OutboxEvent, error) {
+	rows, err := r.queries.ListPendingOutboxEvents(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.OutboxEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, toDomainOutboxEvent(row))
+	}
+	return events, nil
+}
+
+func (r *outboxRepositoryImpl) ListDispatchedAfter(ctx context.Context, afterID int64, limit int32) ([]*domain.OutboxEvent, error) {
+	rows, err := r.queries.ListDispatchedOutboxEventsAfter(ctx, sqlc.ListDispatchedOutboxEventsAfterParams{
+		AfterID:  afterID,
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.OutboxEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, toDomainOutboxEvent(row))
+	}
+	return events, nil
+}
+
+func (r *outboxRepositoryImpl) MarkDispatched(ctx context.Context, id int64, dispatchedAt time.Time) error {
+	return r.queries.MarkOutboxEventDispatched(ctx, sqlc.MarkOutboxEventDispatchedParams{
+		ID:           id,
+		DispatchedAt: pgtype.Timestamp{Time: dispatchedAt, Valid: true},
+	})
+}
+
+func (r *outboxRepositoryImpl) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	return r.queries.MarkOutboxEventFailed(ctx, sqlc.MarkOutboxEventFailedParams{
+		ID:            id,
+		LastError:     lastError,
+		NextAttemptAt: pgtype.Timestamp{Time: nextAttemptAt, Valid: true},
+	})
+}
+
+func (r *outboxRepositoryImpl) MarkDead(ctx context.Context, id int64, lastError string, deadAt time.Time) error {
+	return r.queries.MarkOutboxEventDead(ctx, sqlc.MarkOutboxEventDeadParams{
+		ID:        id,
+		LastError: lastError,
+		DeadAt:    pgtype.Timestamp{Time: deadAt, Valid: true},
+	})
+}
+
+func (r *outboxRepositoryImpl) ListDead(ctx context.Context, limit int32) ([]*domain.OutboxEvent, error) {
+	rows, err := r.queries.ListDeadOutboxEvents(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.OutboxEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, toDomainOutboxEvent(row))
+	}
+	return events, nil
+}
+
+func (r *outboxRepositoryImpl) DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.DeleteDispatchedOutboxEvents(ctx, pgtype.Timestamp{Time: before, Valid: true})
+}
+
+func (r *outboxRepositoryImpl) DeleteDeadBefore(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.DeleteDeadOutboxEvents(ctx, pgtype.Timestamp{Time: before, Valid: true})
+}
+
+// toDomainOutboxEvent converts sqlc.OutboxEvent to domain.OutboxEvent
+func toDomainOutboxEvent(row sqlc.OutboxEvent) *domain.OutboxEvent {
+	event := &domain.OutboxEvent{
+		ID:        row.ID,
+		EventType: row.EventType,
+		Payload:   row.Payload,
+		Attempts:  row.Attempts,
+		LastError: row.LastError,
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.DispatchedAt.Valid {
+		t := row.DispatchedAt.Time
+		event.DispatchedAt = &t
+	}
+	if row.DispatchSeq.Valid {
+		event.DispatchSeq = row.DispatchSeq.Int64
+	}
+	if row.NextAttemptAt.Valid {
+		t := row.NextAttemptAt.Time
+		event.NextAttemptAt = &t
+	}
+	if row.DeadAt.Valid {
+		t := row.DeadAt.Time
+		event.DeadAt = &t
+	}
+	return event
+}
@@ -0,0 +1,206 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/testutil"
+)
+
+func TestOutboxRepository_Integration(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	testutil.CleanupTables(t, pool)
+
+	outboxRepository := repository.NewOutboxRepository(sqlc.New(pool))
+	txRepository := repository.NewUserRepositoryWithPool(pool)
+	ctx := context.Background()
+	now := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+
+	record := func(t *testing.T, eventType string, commit bool) *domain.OutboxEvent {
+		t.Helper()
+		event, err := domain.NewOutboxEvent(eventType, map[string]int64{"session_id": 1}, func() time.Time { return now })
+		if err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		tx, err := txRepository.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		if err := outboxRepository.SaveWithTx(ctx, tx, event); err != nil {
+			_ = tx.Rollback(ctx)
+			t.Fatalf("Failed to save event: %v", err)
+		}
+		if commit {
+			if err := tx.Commit(ctx); err != nil {
+				t.Fatalf("Failed to commit: %v", err)
+			}
+		} else {
+			_ = tx.Rollback(ctx)
+		}
+		return event
+	}
+
+	t.Run("コミットされたイベントだけが ID 順に取得される", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		first := record(t, "session_start", true)
+		record(t, "session_extend", false)
+		second := record(t, "session_end", true)
+
+		pending, err := outboxRepository.ListPending(ctx, 100)
+		if err != nil {
+			t.Fatalf("Failed to list pending events: %v", err)
+		}
+		if len(pending) != 2 || pending[0].ID != first.ID || pending[1].ID != second.ID {
+			t.Fatalf("Expected committed events in order, got %+v", pending)
+		}
+		if pending[0].EventType != "session_start" || string(pending[0].Payload) != `{"session_id": 1}` {
+			t.Errorf("Unexpected event: %+v (payload %s)", pending[0], pending[0].Payload)
+		}
+	})
+
+	t.Run("配信済み・デッドレターのイベントは取得しない", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		dispatched := record(t, "session_start", true)
+		failed := record(t, "session_end", true)
+		dead := record(t, "session_extend", true)
+
+		if err := outboxRepository.MarkDispatched(ctx, dispatched.ID, now); err != nil {
+			t.Fatalf("Failed to mark dispatched: %v", err)
+		}
+		nextAttemptAt := now.Add(time.Second)
+		if err := outboxRepository.MarkFailed(ctx, failed.ID, "sink panicked", nextAttemptAt); err != nil {
+			t.Fatalf("Failed to mark failed: %v", err)
+		}
+		if err := outboxRepository.MarkDead(ctx, dead.ID, "unknown outbox event type", now); err != nil {
+			t.Fatalf("Failed to mark dead: %v", err)
+		}
+
+		pending, err := outboxRepository.ListPending(ctx, 100)
+		if err != nil {
+			t.Fatalf("Failed to list pending events: %v", err)
+		}
+		if len(pending) != 1 || pending[0].ID != failed.ID || pending[0].Attempts != 1 || pending[0].LastError != "sink panicked" {
+			t.Fatalf("Expected only the failed event, got %+v", pending)
+		}
+		if pending[0].NextAttemptAt == nil || !pending[0].NextAttemptAt.Equal(nextAttemptAt) {
+			t.Errorf("Expected next attempt %v, got %v", nextAttemptAt, pending[0].NextAttemptAt)
+		}
+
+		deadEvents, err := outboxRepository.ListDead(ctx, 100)
+		if err != nil {
+			t.Fatalf("Failed to list dead events: %v", err)
+		}
+		if len(deadEvents) != 1 || deadEvents[0].ID != dead.ID || !deadEvents[0].IsDead() || deadEvents[0].Attempts != 1 {
+			t.Errorf("Expected only the dead event, got %+v", deadEvents)
+		}
+	})
+
+	t.Run("古いデッドレターを削除する", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		old := record(t, "session_start", true)
+		recent := record(t, "session_end", true)
+
+		_ = outboxRepository.MarkDead(ctx, old.ID, "unknown outbox event type", now.Add(-8*24*time.Hour))
+		_ = outboxRepository.MarkDead(ctx, recent.ID, "unknown outbox event type", now)
+
+		deleted, err := outboxRepository.DeleteDeadBefore(ctx, now.Add(-7*24*time.Hour))
+		if err != nil {
+			t.Fatalf("Failed to delete dead events: %v", err)
+		}
+		if deleted != 1 {
+			t.Errorf("Expected 1 deleted event, got %d", deleted)
+		}
+
+		remaining, _ := outboxRepository.ListDead(ctx, 100)
+		if len(remaining) != 1 || remaining[0].ID != recent.ID {
+			t.Errorf("Expected recent dead event to be kept, got %+v", remaining)
+		}
+	})
+
+	t.Run("古い配信済みイベントを削除する", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		old := record(t, "session_start", true)
+		recent := record(t, "session_end", true)
+		pending := record(t, "session_extend", true)
+
+		_ = outboxRepository.MarkDispatched(ctx, old.ID, now.Add(-48*time.Hour))
+		_ = outboxRepository.MarkDispatched(ctx, recent.ID, now)
+
+		deleted, err := outboxRepository.DeleteDispatchedBefore(ctx, now.Add(-24*time.Hour))
+		if err != nil {
+			t.Fatalf("Failed to delete dispatched events: %v", err)
+		}
+		if deleted != 1 {
+			t.Errorf("Expected 1 deleted event, got %d", deleted)
+		}
+
+		remaining, _ := outboxRepository.ListPending(ctx, 100)
+		if len(remaining) != 1 || remaining[0].ID != pending.ID {
+			t.Errorf("Expected undelivered event to be kept, got %+v", remaining)
+		}
+	})
+	t.Run("後からコミットされた小さい ID のイベントも再開時に取得する", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		// first のトランザクションは second のコミット後にコミットされる
+		first, err := domain.NewOutboxEvent("session_start", map[string]int64{"session_id": 1}, func() time.Time { return now })
+		if err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		tx, err := txRepository.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		if err := outboxRepository.SaveWithTx(ctx, tx, first); err != nil {
+			_ = tx.Rollback(ctx)
+			t.Fatalf("Failed to save event: %v", err)
+		}
+		second := record(t, "session_end", true)
+		if err := outboxRepository.MarkDispatched(ctx, second.ID, now); err != nil {
+			t.Fatalf("Failed to mark dispatched: %v", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+		if err := outboxRepository.MarkDispatched(ctx, first.ID, now); err != nil {
+			t.Fatalf("Failed to mark dispatched: %v", err)
+		}
+		if first.ID >= second.ID {
+			t.Fatalf("Expected first to have the lower ID, got %d and %d", first.ID, second.ID)
+		}
+
+		// second を受け取ったクライアントが再接続すると、後から配信された first を受け取る
+		events, err := outboxRepository.ListDispatchedAfter(ctx, second.ID, 100)
+		if err != nil {
+			t.Fatalf("Failed to list dispatched events: %v", err)
+		}
+		if len(events) != 1 || events[0].ID != first.ID || events[0].DispatchSeq == 0 {
+			t.Fatalf("Expected the late event, got %+v", events)
+		}
+
+		events, err = outboxRepository.ListDispatchedAfter(ctx, first.ID, 100)
+		if err != nil {
+			t.Fatalf("Failed to list dispatched events: %v", err)
+		}
+		if len(events) != 0 {
+			t.Errorf("Expected nothing after the last delivered event, got %+v", events)
+		}
+
+		// 最初の接続（Last-Event-ID なし相当の 0）はすべてを配信した順に返す
+		events, err = outboxRepository.ListDispatchedAfter(ctx, 0, 100)
+		if err != nil {
+			t.Fatalf("Failed to list dispatched events: %v", err)
+		}
+		if len(events) != 2 || events[0].ID != second.ID || events[1].ID != first.ID {
+			t.Errorf("Expected events in delivery order, got %+v", events)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+)
+
+// Ensure pointLedgerRepositoryImpl implements domain.PointLedgerRepository
+var _ domainRepo.PointLedgerRepository = (*pointLedgerRepositoryImpl)(nil)
+
+type pointLedgerRepositoryImpl struct {
+	queries *sqlc.Queries
+}
+
+// NewPointLedgerRepository creates a new point ledger repository implementation
+func NewPointLedgerRepository(queries *sqlc.Queries) domainRepo.PointLedgerRepository {
+	return &pointLedgerRepositoryImpl{queries: queries}
+}
+
+func (r *pointLedgerRepositoryImpl) Balance(ctx context.Context, userID int64) (int64, error) {
+	return r.queries.GetPointBalance(ctx, int32(userID))
+}
+
+func (r *pointLedgerRepositoryImpl) BalanceWithTx(ctx context.Context, tx domainRepo.Tx, userID int64) (int64, error) {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return 0, errors.New("invalid transaction type")
+	}
+	return sqlc.New(wrapper.tx).GetPointBalance(ctx, int32(userID))
+}
+
+func (r *pointLedgerRepositoryImpl) AppendWithTx(ctx context.Context, tx domainRepo.Tx, entry *domain.PointEntry) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	created, err := sqlc.New(wrapper.tx).CreatePointEntry(ctx, sqlc.CreatePointEntryParams{
+		UserID:    int32(entry.UserID),
+		Delta:     entry.Delta,
+		Reason:    string(entry.Reason),
+		Reference: entry.Reference,
+		CreatedAt: pgtype.Timestamp{Time: entry.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrDuplicatePointEntry
+		}
+		return err
+	}
+	entry.ID = created.ID
+	return nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+)
+
+// Ensure runtimeSettingRepositoryImpl implements domain.RuntimeSettingRepository
+var _ domainRepo.RuntimeSettingRepository = (*runtimeSettingRepositoryImpl)(nil)
+
+type runtimeSettingRepositoryImpl struct {
+	queries *sqlc.Queries
+}
+
+// NewRuntimeSettingRepository creates a new runtime setting repository implementation
+func NewRuntimeSettingRepository(queries *sqlc.Queries) domainRepo.RuntimeSettingRepository {
+	return &runtimeSettingRepositoryImpl{queries: queries}
+}
+
+func (r *runtimeSettingRepositoryImpl) List(ctx context.Context) ([]*domain.RuntimeSetting, error) {
+	rows, err := r.queries.ListRuntimeSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make([]*domain.RuntimeSetting, 0, len(rows))
+	for _, row := range rows {
+		settings = append(settings, toDomainRuntimeSetting(row))
+	}
+	return settings, nil
+}
+
+func (r *runtimeSettingRepositoryImpl) ListForUpdateWithTx(ctx context.Context, tx domainRepo.Tx) ([]*domain.RuntimeSetting, error) {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return nil, errors.New("invalid transaction type")
+	}
+
+	// 行ロックでは新しく追加される設定を防げないため、トランザクション単位のアドバイザリロックで直列にする
+	queries := sqlc.New(wrapper.tx)
+	if err := queries.LockRuntimeSettings(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := queries.ListRuntimeSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make([]*domain.RuntimeSetting, 0, len(rows))
+	for _, row := range rows {
+		settings = append(settings, toDomainRuntimeSetting(row))
+	}
+	return settings, nil
+}
+
+func (r *runtimeSettingRepositoryImpl) SaveWithTx(ctx context.Context, tx domainRepo.Tx, setting *domain.RuntimeSetting) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	saved, err := sqlc.New(wrapper.tx).UpsertRuntimeSetting(ctx, sqlc.UpsertRuntimeSettingParams{
+		Key:       setting.Key,
+		Value:     setting.Value,
+		UpdatedBy: setting.UpdatedBy,
+		UpdatedAt: pgtype.Timestamp{Time: setting.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	*setting = *toDomainRuntimeSetting(saved)
+	return nil
+}
+
+func (r *runtimeSettingRepositoryImpl) DeleteWithTx(ctx context.Context, tx domainRepo.Tx, key string) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	deleted, err := sqlc.New(wrapper.tx).DeleteRuntimeSetting(ctx, key)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrRuntimeSettingNotFound
+	}
+	return nil
+}
+
+// toDomainRuntimeSetting converts sqlc.RuntimeSetting to domain.RuntimeSetting
+func toDomainRuntimeSetting(row sqlc.RuntimeSetting) *domain.RuntimeSetting {
+	return &domain.RuntimeSetting{
+		Key:       row.Key,
+		Value:     row.Value,
+		UpdatedBy: row.UpdatedBy,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,144 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/testutil"
+)
+
+func TestRuntimeSettingRepository_Integration(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	testutil.CleanupTables(t, pool)
+
+	settingRepository := repository.NewRuntimeSettingRepository(sqlc.New(pool))
+	txRepository := repository.NewUserRepositoryWithPool(pool)
+	ctx := context.Background()
+	now := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+
+	inTx := func(t *testing.T, fn func(tx domainRepo.Tx) error) error {
+		t.Helper()
+		tx, err := txRepository.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		if err := fn(tx); err != nil {
+			_ = tx.Rollback(ctx)
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+		return nil
+	}
+	save := func(t *testing.T, key, value, actor string, at time.Time) {
+		t.Helper()
+		setting, err := domain.NewRuntimeSetting(key, value, actor, func() time.Time { return at })
+		if err != nil {
+			t.Fatalf("Failed to create setting: %v", err)
+		}
+		if err := inTx(t, func(tx domainRepo.Tx) error { return settingRepository.SaveWithTx(ctx, tx, setting) }); err != nil {
+			t.Fatalf("Failed to save setting: %v", err)
+		}
+	}
+
+	t.Run("保存した値は上書きされ、キーの順に返る", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		save(t, domain.SettingSessionDefaultDuration, "90m", "admin", now)
+		save(t, domain.SettingCheerBonusPoints, "5", "admin", now)
+		save(t, domain.SettingSessionDefaultDuration, "2h", "mod", now.Add(time.Minute))
+
+		settings, err := settingRepository.List(ctx)
+		if err != nil {
+			t.Fatalf("Failed to list settings: %v", err)
+		}
+		if len(settings) != 2 {
+			t.Fatalf("Expected 2 settings, got %d", len(settings))
+		}
+		if settings[0].Key != domain.SettingCheerBonusPoints || settings[1].Key != domain.SettingSessionDefaultDuration {
+			t.Errorf("Expected settings ordered by key, got %s, %s", settings[0].Key, settings[1].Key)
+		}
+		if got := settings[1]; got.Value != "2h0m0s" || got.UpdatedBy != "mod" || !got.UpdatedAt.Equal(now.Add(time.Minute)) {
+			t.Errorf("Expected the latest value, got %+v", got)
+		}
+	})
+
+	t.Run("削除すると起動時の値に戻る（保存されていないキーはエラー）", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		save(t, domain.SettingCheerBonusPoints, "5", "admin", now)
+		if err := inTx(t, func(tx domainRepo.Tx) error {
+			return settingRepository.DeleteWithTx(ctx, tx, domain.SettingCheerBonusPoints)
+		}); err != nil {
+			t.Fatalf("Failed to delete setting: %v", err)
+		}
+
+		err := inTx(t, func(tx domainRepo.Tx) error {
+			return settingRepository.DeleteWithTx(ctx, tx, domain.SettingCheerBonusPoints)
+		})
+		if !errors.Is(err, domain.ErrRuntimeSettingNotFound) {
+			t.Errorf("Expected ErrRuntimeSettingNotFound, got %v", err)
+		}
+
+		settings, err := settingRepository.List(ctx)
+		if err != nil {
+			t.Fatalf("Failed to list settings: %v", err)
+		}
+		if len(settings) != 0 {
+			t.Errorf("Expected no settings, got %d", len(settings))
+		}
+	})
+	t.Run("ロック中の変更は、先のトランザクションが終わるまで待って最新の値を読む", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		first, err := txRepository.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		if _, err := settingRepository.ListForUpdateWithTx(ctx, first); err != nil {
+			_ = first.Rollback(ctx)
+			t.Fatalf("Failed to lock settings: %v", err)
+		}
+
+		second, err := txRepository.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		defer func() { _ = second.Rollback(ctx) }()
+		read := make(chan []*domain.RuntimeSetting, 1)
+		go func() {
+			settings, _ := settingRepository.ListForUpdateWithTx(ctx, second)
+			read <- settings
+		}()
+
+		select {
+		case <-read:
+			t.Fatal("Expected the second transaction to wait for the lock")
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		setting, err := domain.NewRuntimeSetting(domain.SettingCheerBonusPoints, "5", "admin", func() time.Time { return now })
+		if err != nil {
+			t.Fatalf("Failed to create setting: %v", err)
+		}
+		if err := settingRepository.SaveWithTx(ctx, first, setting); err != nil {
+			_ = first.Rollback(ctx)
+			t.Fatalf("Failed to save setting: %v", err)
+		}
+		if err := first.Commit(ctx); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+
+		settings := <-read
+		if len(settings) != 1 || settings[0].Value != "5" {
+			t.Errorf("Expected the committed value after the lock, got %+v", settings)
+		}
+	})
+}
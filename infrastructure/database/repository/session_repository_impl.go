@@ -75,6 +75,108 @@ func (r *sessionRepositoryImpl) CreateWithTx(ctx context.Context, tx domainRepo.
 	}
 
 	session.ID = int64(created.ID)
+
+	// Manually added sessions are created already completed
+	if session.ActualEnd != nil {
+		return r.CorrectWithTx(ctx, tx, session)
+	}
+	return nil
+}
+
+func (r *sessionRepositoryImpl) FindByIDWithTx(ctx context.Context, tx domainRepo.Tx, id int64) (*domain.Session, error) {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return nil, errors.New("invalid transaction type")
+	}
+
+	queries := sqlc.New(wrapper.tx)
+	session, err := queries.FindSessionByIDForUpdate(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return toDomainSession(session), nil
+}
+
+func (r *sessionRepositoryImpl) FindOverlappingWithTx(ctx context.Context, tx domainRepo.Tx, userID, excludeID int64, start time.Time, end *time.Time) ([]*domain.Session, error) {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return nil, errors.New("invalid transaction type")
+	}
+
+	var rangeEnd pgtype.Timestamp
+	if end != nil {
+		rangeEnd = pgtype.Timestamp{Time: *end, Valid: true}
+	}
+
+	queries := sqlc.New(wrapper.tx)
+	sessions, err := queries.ListOverlappingSessions(ctx, sqlc.ListOverlappingSessionsParams{
+		UserID:     int32(userID),
+		ExcludeID:  int32(excludeID),
+		RangeStart: pgtype.Timestamp{Time: start, Valid: true},
+		RangeEnd:   rangeEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.Session, len(sessions))
+	for i, s := range sessions {
+		result[i] = toDomainSession(s)
+	}
+	return result, nil
+}
+
+func (r *sessionRepositoryImpl) CorrectWithTx(ctx context.Context, tx domainRepo.Tx, session *domain.Session) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	var workName pgtype.Text
+	if session.WorkName != "" {
+		workName = pgtype.Text{String: session.WorkName, Valid: true}
+	}
+
+	var actualEnd pgtype.Timestamp
+	if session.ActualEnd != nil {
+		actualEnd = pgtype.Timestamp{Time: *session.ActualEnd, Valid: true}
+	}
+
+	queries := sqlc.New(wrapper.tx)
+	_, err := queries.CorrectSession(ctx, sqlc.CorrectSessionParams{
+		ID:         int32(session.ID),
+		WorkName:   workName,
+		StartTime:  pgtype.Timestamp{Time: session.StartTime, Valid: true},
+		PlannedEnd: pgtype.Timestamp{Time: session.PlannedEnd, Valid: true},
+		ActualEnd:  actualEnd,
+		UpdatedAt:  pgtype.Timestamp{Time: session.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrSessionNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *sessionRepositoryImpl) DeleteWithTx(ctx context.Context, tx domainRepo.Tx, id int64) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	queries := sqlc.New(wrapper.tx)
+	rows, err := queries.DeleteSession(ctx, int32(id))
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrSessionNotFound
+	}
 	return nil
 }
 
@@ -139,9 +241,21 @@ func (r *sessionRepositoryImpl) FindActiveByUserID(ctx context.Context, userID i
 }
 
 func (r *sessionRepositoryImpl) Update(ctx context.Context, session *domain.Session) error {
+	return r.update(ctx, r.queries, session)
+}
+
+func (r *sessionRepositoryImpl) UpdateWithTx(ctx context.Context, tx domainRepo.Tx, session *domain.Session) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+	return r.update(ctx, sqlc.New(wrapper.tx), session)
+}
+
+func (r *sessionRepositoryImpl) update(ctx context.Context, queries *sqlc.Queries, session *domain.Session) error {
 	// Complete session if actual_end is set
 	if session.ActualEnd != nil {
-		_, err := r.queries.CompleteSession(ctx, sqlc.CompleteSessionParams{
+		_, err := queries.CompleteSession(ctx, sqlc.CompleteSessionParams{
 			ID:        int32(session.ID),
 			ActualEnd: pgtype.Timestamp{Time: *session.ActualEnd, Valid: true},
 			UpdatedAt: pgtype.Timestamp{Time: session.UpdatedAt, Valid: true},
@@ -150,7 +264,7 @@ func (r *sessionRepositoryImpl) Update(ctx context.Context, session *domain.Sess
 	}
 
 	// For active sessions, fetch current state to determine what changed
-	current, err := r.queries.FindSessionByID(ctx, int32(session.ID))
+	current, err := queries.FindSessionByID(ctx, int32(session.ID))
 	if err != nil {
 		return err
 	}
@@ -161,7 +275,7 @@ func (r *sessionRepositoryImpl) Update(ctx context.Context, session *domain.Sess
 		currentWorkName = current.WorkName.String
 	}
 	if currentWorkName != session.WorkName {
-		_, err := r.queries.UpdateSessionWorkName(ctx, sqlc.UpdateSessionWorkNameParams{
+		_, err := queries.UpdateSessionWorkName(ctx, sqlc.UpdateSessionWorkNameParams{
 			ID:        int32(session.ID),
 			WorkName:  pgtype.Text{String: session.WorkName, Valid: true},
 			UpdatedAt: pgtype.Timestamp{Time: session.UpdatedAt, Valid: true},
@@ -173,7 +287,7 @@ func (r *sessionRepositoryImpl) Update(ctx context.Context, session *domain.Sess
 
 	// Check if planned_end changed
 	if current.PlannedEnd.Valid && !current.PlannedEnd.Time.Equal(session.PlannedEnd) {
-		_, err := r.queries.UpdateSessionPlannedEnd(ctx, sqlc.UpdateSessionPlannedEndParams{
+		_, err := queries.UpdateSessionPlannedEnd(ctx, sqlc.UpdateSessionPlannedEndParams{
 			ID:         int32(session.ID),
 			PlannedEnd: pgtype.Timestamp{Time: session.PlannedEnd, Valid: true},
 			UpdatedAt:  pgtype.Timestamp{Time: session.UpdatedAt, Valid: true},
@@ -266,18 +380,28 @@ func (r *sessionRepositoryImpl) FindAllActive(ctx context.Context) ([]domain.Ses
 			id := int64(row.IconID.Int32)
 			iconID = &id
 		}
+		var iconAssetKey *string
+		if row.IconAssetKey.Valid {
+			iconAssetKey = &row.IconAssetKey.String
+		}
 
 		result = append(result, domain.SessionInfo{
-			SessionID:  int64(row.ID),
-			UserID:     int64(row.UserID),
-			UserName:   row.UserName,
-			WorkName:   row.WorkName.String,
-			Tier:       int(row.UserTier),
-			IconID:     iconID,
-			StartTime:  row.StartTime.Time,
-			PlannedEnd: row.PlannedEnd.Time,
+			SessionID:    int64(row.ID),
+			UserID:       int64(row.UserID),
+			UserName:     row.UserName,
+			WorkName:     row.WorkName.String,
+			Tier:         int(row.UserTier),
+			IconID:       iconID,
+			IconAssetKey: iconAssetKey,
+			StartTime:    row.StartTime.Time,
+			PlannedEnd:   row.PlannedEnd.Time,
 		})
 	}
 
 	return result, nil
 }
+
+// CountActive counts sessions that have not ended
+func (r *sessionRepositoryImpl) CountActive(ctx context.Context) (int64, error) {
+	return r.queries.CountActiveSessions(ctx)
+}
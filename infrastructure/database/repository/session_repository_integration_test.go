@@ -126,6 +126,32 @@ func TestSessionRepository_Integration(t *testing.T) {
 		}
 	})
 
+	t.Run("CountActive", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		active, _ := domain.NewSession(createTestUser(t, "count_active_user", 1), "作業中", 1*time.Hour, time.Now)
+		if err := sessionRepository.Save(ctx, active); err != nil {
+			t.Fatalf("Failed to save session: %v", err)
+		}
+		completed, _ := domain.NewSession(createTestUser(t, "count_completed_user", 1), "完了済み", 1*time.Hour, time.Now)
+		if err := sessionRepository.Save(ctx, completed); err != nil {
+			t.Fatalf("Failed to save session: %v", err)
+		}
+		actualEnd := time.Now()
+		completed.ActualEnd = &actualEnd
+		if err := sessionRepository.Save(ctx, completed); err != nil {
+			t.Fatalf("Failed to complete session: %v", err)
+		}
+
+		count, err := sessionRepository.CountActive(ctx)
+		if err != nil {
+			t.Fatalf("Failed to count active sessions: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("Expected 1 active session, got %d", count)
+		}
+	})
+
 	t.Run("Save_UpdateExistingSession", func(t *testing.T) {
 		testutil.CleanupTables(t, pool)
 
@@ -220,4 +246,98 @@ func TestSessionRepository_Integration(t *testing.T) {
 			t.Errorf("Expected ErrSessionNotFound, got %v", err)
 		}
 	})
+
+	t.Run("Manual session correction", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		userID := createTestUser(t, "correction_test_user", 1)
+		txRepository := repository.NewUserRepositoryWithPool(pool)
+
+		base := time.Now().UTC().Truncate(time.Second).Add(-5 * time.Hour)
+		first, err := domain.NewManualSession(userID, "午前の作業", base, base.Add(time.Hour), time.Now)
+		if err != nil {
+			t.Fatalf("Failed to create manual session: %v", err)
+		}
+		second, err := domain.NewManualSession(userID, "午後の作業", base.Add(2*time.Hour), base.Add(3*time.Hour), time.Now)
+		if err != nil {
+			t.Fatalf("Failed to create manual session: %v", err)
+		}
+
+		tx, err := txRepository.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		if _, err := txRepository.FindByIDWithTx(ctx, tx, userID); err != nil {
+			_ = tx.Rollback(ctx)
+			t.Fatalf("Failed to lock user: %v", err)
+		}
+		for _, s := range []*domain.Session{first, second} {
+			if err := sessionRepository.CreateWithTx(ctx, tx, s); err != nil {
+				_ = tx.Rollback(ctx)
+				t.Fatalf("Failed to create session: %v", err)
+			}
+		}
+
+		// [base+30m, base+2h30m) は両方のセッションと重なる
+		end := base.Add(150 * time.Minute)
+		overlapping, err := sessionRepository.FindOverlappingWithTx(ctx, tx, userID, 0, base.Add(30*time.Minute), &end)
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			t.Fatalf("Failed to find overlapping sessions: %v", err)
+		}
+		if len(overlapping) != 2 {
+			t.Errorf("Expected 2 overlapping sessions, got %d", len(overlapping))
+		}
+
+		// 自分自身は除外される
+		overlapping, err = sessionRepository.FindOverlappingWithTx(ctx, tx, userID, second.ID, base.Add(2*time.Hour), nil)
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			t.Fatalf("Failed to find overlapping sessions: %v", err)
+		}
+		if len(overlapping) != 0 {
+			t.Errorf("Expected no overlapping sessions, got %d", len(overlapping))
+		}
+
+		newStart := base.Add(90 * time.Minute)
+		if err := second.Correct(domain.SessionCorrection{StartTime: &newStart}, time.Now); err != nil {
+			_ = tx.Rollback(ctx)
+			t.Fatalf("Failed to correct session: %v", err)
+		}
+		if err := sessionRepository.CorrectWithTx(ctx, tx, second); err != nil {
+			_ = tx.Rollback(ctx)
+			t.Fatalf("Failed to save correction: %v", err)
+		}
+		if err := sessionRepository.DeleteWithTx(ctx, tx, first.ID); err != nil {
+			_ = tx.Rollback(ctx)
+			t.Fatalf("Failed to delete session: %v", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+
+		found, err := sessionRepository.FindByID(ctx, second.ID)
+		if err != nil {
+			t.Fatalf("Failed to find corrected session: %v", err)
+		}
+		if !found.StartTime.Equal(newStart) {
+			t.Errorf("Expected start_time %v, got %v", newStart, found.StartTime)
+		}
+		if found.ActualEnd == nil || !found.ActualEnd.Equal(base.Add(3*time.Hour)) {
+			t.Errorf("Expected actual_end to be kept, got %v", found.ActualEnd)
+		}
+		if _, err := sessionRepository.FindByID(ctx, first.ID); err != domain.ErrSessionNotFound {
+			t.Errorf("Expected deleted session to be gone, got %v", err)
+		}
+		testutil.AssertSessionCount(t, pool, userID, 1)
+
+		tx, err = txRepository.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+		if err := sessionRepository.DeleteWithTx(ctx, tx, first.ID); err != domain.ErrSessionNotFound {
+			t.Errorf("Expected ErrSessionNotFound, got %v", err)
+		}
+	})
 }
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+)
+
+// Ensure twitchEventSubRepositoryImpl implements domain.TwitchEventSubRepository
+var _ domainRepo.TwitchEventSubRepository = (*twitchEventSubRepositoryImpl)(nil)
+
+type twitchEventSubRepositoryImpl struct{}
+
+// NewTwitchEventSubRepository creates a new Twitch EventSub repository implementation
+func NewTwitchEventSubRepository() domainRepo.TwitchEventSubRepository {
+	return &twitchEventSubRepositoryImpl{}
+}
+
+func (r *twitchEventSubRepositoryImpl) MarkProcessedWithTx(ctx context.Context, tx domainRepo.Tx, messageID, subscriptionType string, receivedAt time.Time) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	// ON CONFLICT DO NOTHING returns no row for a duplicate message ID
+	_, err := sqlc.New(wrapper.tx).CreateTwitchEventSubMessage(ctx, sqlc.CreateTwitchEventSubMessageParams{
+		MessageID:        messageID,
+		SubscriptionType: subscriptionType,
+		ReceivedAt:       pgtype.Timestamp{Time: receivedAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrEventSubMessageProcessed
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/testutil"
+)
+
+func TestTwitchEventSubRepository_Integration(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	testutil.CleanupTables(t, pool)
+
+	eventSubRepository := repository.NewTwitchEventSubRepository()
+	txRepository := repository.NewUserRepositoryWithPool(pool)
+	ctx := context.Background()
+	now := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+
+	markProcessed := func(t *testing.T, messageID string, commit bool) error {
+		t.Helper()
+		tx, err := txRepository.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		err = eventSubRepository.MarkProcessedWithTx(ctx, tx, messageID, domain.EventSubTypeChannelSubscribe, now)
+		if err != nil || !commit {
+			_ = tx.Rollback(ctx)
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+		return nil
+	}
+
+	t.Run("同じ Message-Id は 1 度だけ記録できる", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		if err := markProcessed(t, "msg-1", true); err != nil {
+			t.Fatalf("Failed to mark message: %v", err)
+		}
+		if err := markProcessed(t, "msg-1", true); !errors.Is(err, domain.ErrEventSubMessageProcessed) {
+			t.Errorf("Expected ErrEventSubMessageProcessed, got %v", err)
+		}
+		if err := markProcessed(t, "msg-2", true); err != nil {
+			t.Errorf("Failed to mark another message: %v", err)
+		}
+	})
+
+	t.Run("ロールバックした記録は残らない", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		if err := markProcessed(t, "msg-1", false); err != nil {
+			t.Fatalf("Failed to mark message: %v", err)
+		}
+		if err := markProcessed(t, "msg-1", true); err != nil {
+			t.Errorf("Expected a rolled back message to be processed again, got %v", err)
+		}
+	})
+}
@@ -92,11 +92,7 @@ func (r *userRepositoryImpl) Save(ctx context.Context, user *domain.User) error
 	}
 
 	// Update existing user
-	updated, err := r.queries.UpdateUser(ctx, sqlc.UpdateUserParams{
-		ID:        int32(user.ID),
-		Tier:      int32(user.Tier.Int()),
-		UpdatedAt: pgtype.Timestamp{Time: user.UpdatedAt, Valid: true},
-	})
+	updated, err := r.queries.UpdateUser(ctx, toUpdateUserParams(user))
 	if err != nil {
 		return err
 	}
@@ -133,6 +129,23 @@ func (r *userRepositoryImpl) FindByNameWithTx(ctx context.Context, tx domainRepo
 	return toDomainUser(user), nil
 }
 
+func (r *userRepositoryImpl) FindByIDWithTx(ctx context.Context, tx domainRepo.Tx, id int64) (*domain.User, error) {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return nil, errors.New("invalid transaction type")
+	}
+
+	queries := sqlc.New(wrapper.tx)
+	user, err := queries.FindUserByIDForUpdate(ctx, int32(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return toDomainUser(user), nil
+}
+
 func (r *userRepositoryImpl) SaveWithTx(ctx context.Context, tx domainRepo.Tx, user *domain.User) error {
 	wrapper, ok := tx.(*txWrapper)
 	if !ok {
@@ -158,6 +171,40 @@ func (r *userRepositoryImpl) SaveWithTx(ctx context.Context, tx domainRepo.Tx, u
 	return nil
 }
 
+func (r *userRepositoryImpl) UpdateWithTx(ctx context.Context, tx domainRepo.Tx, user *domain.User) error {
+	wrapper, ok := tx.(*txWrapper)
+	if !ok {
+		return errors.New("invalid transaction type")
+	}
+
+	queries := sqlc.New(wrapper.tx)
+	updated, err := queries.UpdateUser(ctx, toUpdateUserParams(user))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrUserNotFound
+		}
+		return err
+	}
+
+	*user = *toDomainUser(updated)
+	return nil
+}
+
+// toUpdateUserParams converts domain.User to sqlc.UpdateUserParams
+func toUpdateUserParams(user *domain.User) sqlc.UpdateUserParams {
+	params := sqlc.UpdateUserParams{
+		ID:          int32(user.ID),
+		Tier:        int32(user.Tier.Int()),
+		BlockReason: pgtype.Text{String: user.BlockReason, Valid: user.BlockedUntil != nil},
+		BlockedBy:   pgtype.Text{String: user.BlockedBy, Valid: user.BlockedUntil != nil},
+		UpdatedAt:   pgtype.Timestamp{Time: user.UpdatedAt, Valid: true},
+	}
+	if user.BlockedUntil != nil {
+		params.BlockedUntil = pgtype.Timestamp{Time: *user.BlockedUntil, Valid: true}
+	}
+	return params
+}
+
 // toDomainUser converts sqlc.User to domain.User
 func toDomainUser(user sqlc.User) *domain.User {
 	// Convert int32 tier to domain.Tier
@@ -167,11 +214,18 @@ func toDomainUser(user sqlc.User) *domain.User {
 		tier = domain.Tier1
 	}
 
-	return &domain.User{
+	u := &domain.User{
 		ID:        int64(user.ID),
 		Name:      user.Name,
 		Tier:      tier,
 		CreatedAt: user.CreatedAt.Time,
 		UpdatedAt: user.UpdatedAt.Time,
 	}
+	if user.BlockedUntil.Valid {
+		blockedUntil := user.BlockedUntil.Time
+		u.BlockedUntil = &blockedUntil
+		u.BlockReason = user.BlockReason.String
+		u.BlockedBy = user.BlockedBy.String
+	}
+	return u
 }
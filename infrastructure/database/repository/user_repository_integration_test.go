@@ -147,4 +147,37 @@ func TestUserRepository_Integration(t *testing.T) {
 			t.Errorf("Expected tier 3, got %d", found.Tier)
 		}
 	})
+
+	t.Run("UpdateWithTx_Block", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		txRepository := repository.NewUserRepositoryWithPool(pool)
+		user, _ := domain.NewUser("block_test", 1, time.Now)
+		if err := txRepository.Save(ctx, user); err != nil {
+			t.Fatalf("Failed to save user: %v", err)
+		}
+		if err := user.Block("spam", "moderator", 0, time.Now); err != nil {
+			t.Fatalf("Failed to block user: %v", err)
+		}
+
+		tx, err := txRepository.BeginTx(ctx)
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		if err := txRepository.UpdateWithTx(ctx, tx, user); err != nil {
+			_ = tx.Rollback(ctx)
+			t.Fatalf("Failed to update user: %v", err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+
+		found, err := txRepository.FindByName(ctx, "block_test")
+		if err != nil {
+			t.Fatalf("Failed to find user: %v", err)
+		}
+		if !found.IsPermanentlyBlocked() || found.BlockReason != "spam" || found.BlockedBy != "moderator" {
+			t.Errorf("Expected block to be persisted, got %+v", found)
+		}
+	})
 }
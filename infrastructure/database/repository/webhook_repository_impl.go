@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	domainRepo "github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+)
+
+// Ensure the implementations satisfy the domain repository interfaces
+var (
+	_ domainRepo.WebhookSubscriptionRepository = (*webhookSubscriptionRepositoryImpl)(nil)
+	_ domainRepo.WebhookDeliveryRepository     = (*webhookDeliveryRepositoryImpl)(nil)
+)
+
+type webhookSubscriptionRepositoryImpl struct {
+	queries *sqlc.Queries
+}
+
+// NewWebhookSubscriptionRepository creates a new webhook subscription repository implementation
+func NewWebhookSubscriptionRepository(queries *sqlc.Queries) domainRepo.WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepositoryImpl{queries: queries}
+}
+
+func (r *webhookSubscriptionRepositoryImpl) List(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	rows, err := r.queries.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subs := make([]*domain.WebhookSubscription, 0, len(rows))
+	for _, row := range rows {
+		subs = append(subs, toDomainWebhookSubscription(row))
+	}
+	return subs, nil
+}
+
+func (r *webhookSubscriptionRepositoryImpl) FindByID(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	row, err := r.queries.FindWebhookSubscriptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return toDomainWebhookSubscription(row), nil
+}
+
+func (r *webhookSubscriptionRepositoryImpl) Save(ctx context.Context, sub *domain.WebhookSubscription) error {
+	eventTypes := sub.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	created, err := r.queries.CreateWebhookSubscription(ctx, sqlc.CreateWebhookSubscriptionParams{
+		URL:        sub.URL,
+		EventTypes: eventTypes,
+		Secret:     sub.Secret,
+		CreatedBy:  sub.CreatedBy,
+		CreatedAt:  pgtype.Timestamp{Time: sub.CreatedAt, Valid: true},
+		UpdatedAt:  pgtype.Timestamp{Time: sub.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	sub.ID = created.ID
+	return nil
+}
+
+func (r *webhookSubscriptionRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	deleted, err := r.queries.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+type webhookDeliveryRepositoryImpl struct {
+	queries *sqlc.Queries
+}
+
+// NewWebhookDeliveryRepository creates a new webhook delivery repository implementation
+func NewWebhookDeliveryRepository(queries *sqlc.Queries) domainRepo.WebhookDeliveryRepository {
+	return &webhookDeliveryRepositoryImpl{queries: queries}
+}
+
+func (r *webhookDeliveryRepositoryImpl) Enqueue(ctx context.Context, delivery *domain.WebhookDelivery) (bool, error) {
+	id, err := r.queries.EnqueueWebhookDelivery(ctx, sqlc.EnqueueWebhookDeliveryParams{
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		NextAttemptAt:  pgtype.Timestamp{Time: delivery.NextAttemptAt, Valid: true},
+		CreatedAt:      pgtype.Timestamp{Time: delivery.CreatedAt, Valid: true},
+		UpdatedAt:      pgtype.Timestamp{Time: delivery.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		// ON CONFLICT DO NOTHING: the event is already queued for this subscription
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	delivery.ID = id
+	return true, nil
+}
+
+func (r *webhookDeliveryRepositoryImpl) ListDue(ctx context.Context, now time.Time, limit int32) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.queries.ListDueWebhookDeliveries(ctx, sqlc.ListDueWebhookDeliveriesParams{
+		NextAttemptAt: pgtype.Timestamp{Time: now, Valid: true},
+		Limit:         limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDomainWebhookDeliveries(rows), nil
+}
+
+func (r *webhookDeliveryRepositoryImpl) FindByID(ctx context.Context, id int64) (*domain.WebhookDelivery, error) {
+	row, err := r.queries.FindWebhookDeliveryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return toDomainWebhookDelivery(row), nil
+}
+
+func (r *webhookDeliveryRepositoryImpl) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return r.queries.UpdateWebhookDelivery(ctx, sqlc.UpdateWebhookDeliveryParams{
+		ID:             delivery.ID,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  pgtype.Timestamp{Time: delivery.NextAttemptAt, Valid: true},
+		LastError:      delivery.LastError,
+		LastStatusCode: delivery.LastStatusCode,
+		UpdatedAt:      pgtype.Timestamp{Time: delivery.UpdatedAt, Valid: true},
+	})
+}
+
+func (r *webhookDeliveryRepositoryImpl) ListByStatus(ctx context.Context, status domain.WebhookDeliveryStatus, limit int32) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.queries.ListWebhookDeliveriesByStatus(ctx, sqlc.ListWebhookDeliveriesByStatusParams{
+		Status: string(status),
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDomainWebhookDeliveries(rows), nil
+}
+
+func (r *webhookDeliveryRepositoryImpl) DeleteDeliveredBefore(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.DeleteDeliveredWebhookDeliveries(ctx, pgtype.Timestamp{Time: before, Valid: true})
+}
+
+// toDomainWebhookSubscription converts sqlc.WebhookSubscription to domain.WebhookSubscription
+func toDomainWebhookSubscription(row sqlc.WebhookSubscription) *domain.WebhookSubscription {
+	return &domain.WebhookSubscription{
+		ID:         row.ID,
+		URL:        row.URL,
+		EventTypes: row.EventTypes,
+		Secret:     row.Secret,
+		CreatedBy:  row.CreatedBy,
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+}
+
+// toDomainWebhookDelivery converts sqlc.WebhookDelivery to domain.WebhookDelivery
+func toDomainWebhookDelivery(row sqlc.WebhookDelivery) *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:             row.ID,
+		SubscriptionID: row.SubscriptionID,
+		EventID:        row.EventID,
+		EventType:      row.EventType,
+		Payload:        row.Payload,
+		Status:         domain.WebhookDeliveryStatus(row.Status),
+		Attempts:       row.Attempts,
+		NextAttemptAt:  row.NextAttemptAt.Time,
+		LastError:      row.LastError,
+		LastStatusCode: row.LastStatusCode,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}
+}
+
+func toDomainWebhookDeliveries(rows []sqlc.WebhookDelivery) []*domain.WebhookDelivery {
+	deliveries := make([]*domain.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, toDomainWebhookDelivery(row))
+	}
+	return deliveries
+}
@@ -0,0 +1,157 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
+	"github.com/yamada-ai/workspace-backend/infrastructure/database/testutil"
+)
+
+func TestWebhookRepository_Integration(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	testutil.CleanupTables(t, pool)
+
+	queries := sqlc.New(pool)
+	subscriptionRepository := repository.NewWebhookSubscriptionRepository(queries)
+	deliveryRepository := repository.NewWebhookDeliveryRepository(queries)
+	ctx := context.Background()
+	now := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	createSubscription := func(t *testing.T, eventTypes []string) *domain.WebhookSubscription {
+		t.Helper()
+		sub, err := domain.NewWebhookSubscription("https://example.com/hook", eventTypes, "secret", "admin", clock)
+		if err != nil {
+			t.Fatalf("Failed to create subscription: %v", err)
+		}
+		if err := subscriptionRepository.Save(ctx, sub); err != nil {
+			t.Fatalf("Failed to save subscription: %v", err)
+		}
+		return sub
+	}
+
+	t.Run("送信先の登録・取得・削除", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		sub := createSubscription(t, []string{"session_start"})
+		if sub.ID == 0 {
+			t.Fatal("Expected ID to be set")
+		}
+
+		found, err := subscriptionRepository.FindByID(ctx, sub.ID)
+		if err != nil {
+			t.Fatalf("Failed to find subscription: %v", err)
+		}
+		if found.URL != sub.URL || len(found.EventTypes) != 1 || found.EventTypes[0] != "session_start" || found.Secret != "secret" {
+			t.Errorf("Unexpected subscription: %+v", found)
+		}
+
+		if err := subscriptionRepository.Delete(ctx, sub.ID); err != nil {
+			t.Fatalf("Failed to delete subscription: %v", err)
+		}
+		if err := subscriptionRepository.Delete(ctx, sub.ID); !errors.Is(err, domain.ErrWebhookNotFound) {
+			t.Errorf("Expected ErrWebhookNotFound, got %v", err)
+		}
+		if _, err := subscriptionRepository.FindByID(ctx, sub.ID); !errors.Is(err, domain.ErrWebhookNotFound) {
+			t.Errorf("Expected ErrWebhookNotFound, got %v", err)
+		}
+	})
+
+	t.Run("同じイベントは送信先ごとに 1 件だけ積まれる", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		sub := createSubscription(t, nil)
+		payload := json.RawMessage(`{"event_id":1,"type":"session_start","data":{}}`)
+
+		delivery := domain.NewWebhookDelivery(sub.ID, 1, "session_start", payload, clock)
+		created, err := deliveryRepository.Enqueue(ctx, delivery)
+		if err != nil || !created {
+			t.Fatalf("Expected delivery to be queued, got created=%v err=%v", created, err)
+		}
+		created, err = deliveryRepository.Enqueue(ctx, domain.NewWebhookDelivery(sub.ID, 1, "session_start", payload, clock))
+		if err != nil || created {
+			t.Fatalf("Expected duplicate to be ignored, got created=%v err=%v", created, err)
+		}
+
+		due, err := deliveryRepository.ListDue(ctx, now, 10)
+		if err != nil {
+			t.Fatalf("Failed to list due deliveries: %v", err)
+		}
+		if len(due) != 1 || due[0].ID != delivery.ID {
+			t.Fatalf("Expected 1 due delivery, got %+v", due)
+		}
+	})
+
+	t.Run("失敗した配信は再送時刻まで取得されず、上限でデッドレターになる", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		sub := createSubscription(t, nil)
+		delivery := domain.NewWebhookDelivery(sub.ID, 2, "session_end", json.RawMessage(`{}`), clock)
+		if _, err := deliveryRepository.Enqueue(ctx, delivery); err != nil {
+			t.Fatalf("Failed to enqueue delivery: %v", err)
+		}
+
+		delivery.MarkFailed(502, "bad gateway", clock)
+		if err := deliveryRepository.Update(ctx, delivery); err != nil {
+			t.Fatalf("Failed to update delivery: %v", err)
+		}
+		due, err := deliveryRepository.ListDue(ctx, now, 10)
+		if err != nil {
+			t.Fatalf("Failed to list due deliveries: %v", err)
+		}
+		if len(due) != 0 {
+			t.Fatalf("Expected no due deliveries before backoff, got %+v", due)
+		}
+
+		for delivery.Status != domain.WebhookDeliveryDead {
+			delivery.MarkFailed(502, "bad gateway", clock)
+		}
+		if err := deliveryRepository.Update(ctx, delivery); err != nil {
+			t.Fatalf("Failed to update delivery: %v", err)
+		}
+
+		dead, err := deliveryRepository.ListByStatus(ctx, domain.WebhookDeliveryDead, 10)
+		if err != nil {
+			t.Fatalf("Failed to list dead deliveries: %v", err)
+		}
+		if len(dead) != 1 || dead[0].Attempts != domain.MaxWebhookAttempts || dead[0].LastStatusCode != 502 {
+			t.Fatalf("Expected 1 dead delivery, got %+v", dead)
+		}
+
+		// 送信先を削除すると配信も削除される
+		if err := subscriptionRepository.Delete(ctx, sub.ID); err != nil {
+			t.Fatalf("Failed to delete subscription: %v", err)
+		}
+		if _, err := deliveryRepository.FindByID(ctx, delivery.ID); !errors.Is(err, domain.ErrWebhookDeliveryNotFound) {
+			t.Errorf("Expected ErrWebhookDeliveryNotFound, got %v", err)
+		}
+	})
+
+	t.Run("古い配信済みの記録を削除する", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+
+		sub := createSubscription(t, nil)
+		delivery := domain.NewWebhookDelivery(sub.ID, 3, "session_start", json.RawMessage(`{}`), clock)
+		if _, err := deliveryRepository.Enqueue(ctx, delivery); err != nil {
+			t.Fatalf("Failed to enqueue delivery: %v", err)
+		}
+		delivery.MarkDelivered(200, clock)
+		if err := deliveryRepository.Update(ctx, delivery); err != nil {
+			t.Fatalf("Failed to update delivery: %v", err)
+		}
+
+		deleted, err := deliveryRepository.DeleteDeliveredBefore(ctx, now.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("Failed to purge deliveries: %v", err)
+		}
+		if deleted != 1 {
+			t.Errorf("Expected 1 deleted delivery, got %d", deleted)
+		}
+	})
+}
@@ -8,6 +8,96 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AuditLog struct {
+	ID          int64            `json:"id"`
+	Actor       string           `json:"actor"`
+	Action      string           `json:"action"`
+	TargetType  string           `json:"target_type"`
+	TargetID    string           `json:"target_id"`
+	BeforeState []byte           `json:"before_state"`
+	AfterState  []byte           `json:"after_state"`
+	Reason      string           `json:"reason"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+}
+
+type BannedTerm struct {
+	ID        int32            `json:"id"`
+	Term      string           `json:"term"`
+	MatchType string           `json:"match_type"`
+	Action    string           `json:"action"`
+	AutoBlock bool             `json:"auto_block"`
+	CreatedBy string           `json:"created_by"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
+type Cheer struct {
+	ID         int64            `json:"id"`
+	FromUserID int32            `json:"from_user_id"`
+	ToUserID   int32            `json:"to_user_id"`
+	CheeredOn  pgtype.Date      `json:"cheered_on"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
+type Icon struct {
+	ID          int32            `json:"id"`
+	Tier        int32            `json:"tier"`
+	OwnerUserID pgtype.Int4      `json:"owner_user_id"`
+	AssetKey    string           `json:"asset_key"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+}
+
+type IconCommission struct {
+	ID        int64            `json:"id"`
+	UserID    int32            `json:"user_id"`
+	Status    string           `json:"status"`
+	Cost      int64            `json:"cost"`
+	IconID    pgtype.Int4      `json:"icon_id"`
+	Note      string           `json:"note"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
+type IdempotencyKey struct {
+	Key             string           `json:"key"`
+	RequestHash     string           `json:"request_hash"`
+	StatusCode      pgtype.Int4      `json:"status_code"`
+	ResponseBody    []byte           `json:"response_body"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	ExpiresAt       pgtype.Timestamp `json:"expires_at"`
+	ResponseHeaders []byte           `json:"response_headers"`
+}
+
+type OutboxEvent struct {
+	ID            int64            `json:"id"`
+	EventType     string           `json:"event_type"`
+	Payload       []byte           `json:"payload"`
+	Attempts      int32            `json:"attempts"`
+	LastError     string           `json:"last_error"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+	DispatchedAt  pgtype.Timestamp `json:"dispatched_at"`
+	DispatchSeq   pgtype.Int8      `json:"dispatch_seq"`
+	NextAttemptAt pgtype.Timestamp `json:"next_attempt_at"`
+	DeadAt        pgtype.Timestamp `json:"dead_at"`
+}
+
+type PointLedger struct {
+	ID        int64            `json:"id"`
+	UserID    int32            `json:"user_id"`
+	Delta     int64            `json:"delta"`
+	Reason    string           `json:"reason"`
+	Reference string           `json:"reference"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+type RuntimeSetting struct {
+	Key       string           `json:"key"`
+	Value     string           `json:"value"`
+	UpdatedBy string           `json:"updated_by"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
 type Session struct {
 	ID         int32            `json:"id"`
 	UserID     int32            `json:"user_id"`
@@ -20,10 +110,44 @@ type Session struct {
 	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
 }
 
+type TwitchEventsubMessage struct {
+	MessageID        string           `json:"message_id"`
+	SubscriptionType string           `json:"subscription_type"`
+	ReceivedAt       pgtype.Timestamp `json:"received_at"`
+}
+
 type User struct {
-	ID        int32            `json:"id"`
-	Name      string           `json:"name"`
-	Tier      int32            `json:"tier"`
-	CreatedAt pgtype.Timestamp `json:"created_at"`
-	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+	ID           int32            `json:"id"`
+	Name         string           `json:"name"`
+	Tier         int32            `json:"tier"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	BlockedUntil pgtype.Timestamp `json:"blocked_until"`
+	BlockReason  pgtype.Text      `json:"block_reason"`
+	BlockedBy    pgtype.Text      `json:"blocked_by"`
+}
+
+type WebhookDelivery struct {
+	ID             int64            `json:"id"`
+	SubscriptionID int64            `json:"subscription_id"`
+	EventID        int64            `json:"event_id"`
+	EventType      string           `json:"event_type"`
+	Payload        []byte           `json:"payload"`
+	Status         string           `json:"status"`
+	Attempts       int32            `json:"attempts"`
+	NextAttemptAt  pgtype.Timestamp `json:"next_attempt_at"`
+	LastError      string           `json:"last_error"`
+	LastStatusCode int32            `json:"last_status_code"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+}
+
+type WebhookSubscription struct {
+	ID         int64            `json:"id"`
+	URL        string           `json:"url"`
+	EventTypes []string         `json:"event_types"`
+	Secret     string           `json:"secret"`
+	CreatedBy  string           `json:"created_by"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
 }
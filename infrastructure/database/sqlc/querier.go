@@ -6,23 +6,77 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (IdempotencyKey, error)
+	CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error
 	CompleteSession(ctx context.Context, arg CompleteSessionParams) (Session, error)
+	CorrectSession(ctx context.Context, arg CorrectSessionParams) (Session, error)
+	CountActiveSessions(ctx context.Context) (int64, error)
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
+	CreateBannedTerm(ctx context.Context, arg CreateBannedTermParams) (BannedTerm, error)
+	CreateCheer(ctx context.Context, arg CreateCheerParams) (Cheer, error)
+	CreateIcon(ctx context.Context, arg CreateIconParams) (Icon, error)
+	CreateIconCommission(ctx context.Context, arg CreateIconCommissionParams) (IconCommission, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (OutboxEvent, error)
+	CreatePointEntry(ctx context.Context, arg CreatePointEntryParams) (PointLedger, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateTwitchEventSubMessage(ctx context.Context, arg CreateTwitchEventSubMessageParams) (string, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
+	DeleteBannedTerm(ctx context.Context, id int32) (int64, error)
+	DeleteDeadOutboxEvents(ctx context.Context, deadAt pgtype.Timestamp) (int64, error)
+	DeleteDeliveredWebhookDeliveries(ctx context.Context, updatedAt pgtype.Timestamp) (int64, error)
+	DeleteDispatchedOutboxEvents(ctx context.Context, dispatchedAt pgtype.Timestamp) (int64, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt pgtype.Timestamp) (int64, error)
+	DeleteRuntimeSetting(ctx context.Context, key string) (int64, error)
+	DeleteSession(ctx context.Context, id int32) (int64, error)
+	DeleteWebhookSubscription(ctx context.Context, id int64) (int64, error)
+	EnqueueWebhookDelivery(ctx context.Context, arg EnqueueWebhookDeliveryParams) (int64, error)
 	FindActiveSessionByUserID(ctx context.Context, userID int32) (Session, error)
+	FindBannedTermByID(ctx context.Context, id int32) (BannedTerm, error)
+	FindIconCommissionByID(ctx context.Context, id int64) (IconCommission, error)
+	FindIconCommissionByIDForUpdate(ctx context.Context, id int64) (IconCommission, error)
+	FindIdempotencyKey(ctx context.Context, key string) (IdempotencyKey, error)
 	FindSessionByID(ctx context.Context, id int32) (Session, error)
+	FindSessionByIDForUpdate(ctx context.Context, id int32) (Session, error)
 	FindUserByID(ctx context.Context, id int32) (User, error)
+	FindUserByIDForUpdate(ctx context.Context, id int32) (User, error)
 	FindUserByName(ctx context.Context, name string) (User, error)
 	FindUserByNameForUpdate(ctx context.Context, name string) (User, error)
+	FindWebhookDeliveryByID(ctx context.Context, id int64) (WebhookDelivery, error)
+	FindWebhookSubscriptionByID(ctx context.Context, id int64) (WebhookSubscription, error)
 	GetActiveSessions(ctx context.Context) ([]GetActiveSessionsRow, error)
+	GetPointBalance(ctx context.Context, userID int32) (int64, error)
+	ListAssignableIcons(ctx context.Context, arg ListAssignableIconsParams) ([]Icon, error)
+	ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([]AuditLog, error)
+	ListBannedTerms(ctx context.Context) ([]BannedTerm, error)
+	ListDeadOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error)
+	ListDispatchedOutboxEventsAfter(ctx context.Context, arg ListDispatchedOutboxEventsAfterParams) ([]OutboxEvent, error)
+	ListDueWebhookDeliveries(ctx context.Context, arg ListDueWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListIconCommissions(ctx context.Context, arg ListIconCommissionsParams) ([]IconCommission, error)
+	ListOverlappingSessions(ctx context.Context, arg ListOverlappingSessionsParams) ([]Session, error)
+	ListPendingOutboxEvents(ctx context.Context, rowLimit int32) ([]OutboxEvent, error)
+	ListRuntimeSettings(ctx context.Context) ([]RuntimeSetting, error)
 	ListUserSessions(ctx context.Context, arg ListUserSessionsParams) ([]Session, error)
 	ListUserSessionsForDate(ctx context.Context, arg ListUserSessionsForDateParams) ([]Session, error)
+	ListWebhookDeliveriesByStatus(ctx context.Context, arg ListWebhookDeliveriesByStatusParams) ([]WebhookDelivery, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	LockRuntimeSettings(ctx context.Context) error
+	MarkOutboxEventDead(ctx context.Context, arg MarkOutboxEventDeadParams) error
+	MarkOutboxEventDispatched(ctx context.Context, arg MarkOutboxEventDispatchedParams) error
+	MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+	UpdateBannedTerm(ctx context.Context, arg UpdateBannedTermParams) (BannedTerm, error)
+	UpdateIconCommission(ctx context.Context, arg UpdateIconCommissionParams) error
 	UpdateSessionPlannedEnd(ctx context.Context, arg UpdateSessionPlannedEndParams) (Session, error)
 	UpdateSessionWorkName(ctx context.Context, arg UpdateSessionWorkNameParams) (Session, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) error
+	UpsertRuntimeSetting(ctx context.Context, arg UpsertRuntimeSettingParams) (RuntimeSetting, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -41,6 +41,18 @@ func (q *Queries) CompleteSession(ctx context.Context, arg CompleteSessionParams
 	return i, err
 }
 
+const countActiveSessions = `-- name: CountActiveSessions :one
+SELECT COUNT(*) FROM sessions
+WHERE actual_end IS NULL
+`
+
+func (q *Queries) CountActiveSessions(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveSessions)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO sessions (user_id, work_name, start_time, planned_end, icon_id, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
@@ -143,25 +155,28 @@ SELECT
   s.created_at,
   s.updated_at,
   u.name as user_name,
-  u.tier as user_tier
+  u.tier as user_tier,
+  i.asset_key as icon_asset_key
 FROM sessions s
 JOIN users u ON s.user_id = u.id
+LEFT JOIN icons i ON s.icon_id = i.id
 WHERE s.actual_end IS NULL
 ORDER BY s.start_time DESC
 `
 
 type GetActiveSessionsRow struct {
-	ID         int32            `json:"id"`
-	UserID     int32            `json:"user_id"`
-	WorkName   pgtype.Text      `json:"work_name"`
-	StartTime  pgtype.Timestamp `json:"start_time"`
-	PlannedEnd pgtype.Timestamp `json:"planned_end"`
-	ActualEnd  pgtype.Timestamp `json:"actual_end"`
-	IconID     pgtype.Int4      `json:"icon_id"`
-	CreatedAt  pgtype.Timestamp `json:"created_at"`
-	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
-	UserName   string           `json:"user_name"`
-	UserTier   int32            `json:"user_tier"`
+	ID           int32            `json:"id"`
+	UserID       int32            `json:"user_id"`
+	WorkName     pgtype.Text      `json:"work_name"`
+	StartTime    pgtype.Timestamp `json:"start_time"`
+	PlannedEnd   pgtype.Timestamp `json:"planned_end"`
+	ActualEnd    pgtype.Timestamp `json:"actual_end"`
+	IconID       pgtype.Int4      `json:"icon_id"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+	UserName     string           `json:"user_name"`
+	UserTier     int32            `json:"user_tier"`
+	IconAssetKey pgtype.Text      `json:"icon_asset_key"`
 }
 
 func (q *Queries) GetActiveSessions(ctx context.Context) ([]GetActiveSessionsRow, error) {
@@ -185,6 +200,7 @@ func (q *Queries) GetActiveSessions(ctx context.Context) ([]GetActiveSessionsRow
 			&i.UpdatedAt,
 			&i.UserName,
 			&i.UserTier,
+			&i.IconAssetKey,
 		); err != nil {
 			return nil, err
 		}
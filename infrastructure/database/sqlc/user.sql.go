@@ -14,7 +14,7 @@ import (
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (name, tier, created_at, updated_at)
 VALUES ($1, $2, $3, $4)
-RETURNING id, name, tier, created_at, updated_at
+RETURNING id, name, tier, created_at, updated_at, blocked_until, block_reason, blocked_by
 `
 
 type CreateUserParams struct {
@@ -38,12 +38,15 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Tier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BlockedUntil,
+		&i.BlockReason,
+		&i.BlockedBy,
 	)
 	return i, err
 }
 
 const findUserByID = `-- name: FindUserByID :one
-SELECT id, name, tier, created_at, updated_at
+SELECT id, name, tier, created_at, updated_at, blocked_until, block_reason, blocked_by
 FROM users
 WHERE id = $1
 LIMIT 1
@@ -58,12 +61,39 @@ func (q *Queries) FindUserByID(ctx context.Context, id int32) (User, error) {
 		&i.Tier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BlockedUntil,
+		&i.BlockReason,
+		&i.BlockedBy,
+	)
+	return i, err
+}
+
+const findUserByIDForUpdate = `-- name: FindUserByIDForUpdate :one
+SELECT id, name, tier, created_at, updated_at, blocked_until, block_reason, blocked_by
+FROM users
+WHERE id = $1
+FOR UPDATE
+LIMIT 1
+`
+
+func (q *Queries) FindUserByIDForUpdate(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRow(ctx, findUserByIDForUpdate, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Tier,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.BlockedUntil,
+		&i.BlockReason,
+		&i.BlockedBy,
 	)
 	return i, err
 }
 
 const findUserByName = `-- name: FindUserByName :one
-SELECT id, name, tier, created_at, updated_at
+SELECT id, name, tier, created_at, updated_at, blocked_until, block_reason, blocked_by
 FROM users
 WHERE name = $1
 LIMIT 1
@@ -78,12 +108,15 @@ func (q *Queries) FindUserByName(ctx context.Context, name string) (User, error)
 		&i.Tier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BlockedUntil,
+		&i.BlockReason,
+		&i.BlockedBy,
 	)
 	return i, err
 }
 
 const findUserByNameForUpdate = `-- name: FindUserByNameForUpdate :one
-SELECT id, name, tier, created_at, updated_at
+SELECT id, name, tier, created_at, updated_at, blocked_until, block_reason, blocked_by
 FROM users
 WHERE name = $1
 FOR UPDATE
@@ -99,25 +132,38 @@ func (q *Queries) FindUserByNameForUpdate(ctx context.Context, name string) (Use
 		&i.Tier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BlockedUntil,
+		&i.BlockReason,
+		&i.BlockedBy,
 	)
 	return i, err
 }
 
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
-SET tier = $2, updated_at = $3
+SET tier = $2, blocked_until = $3, block_reason = $4, blocked_by = $5, updated_at = $6
 WHERE id = $1
-RETURNING id, name, tier, created_at, updated_at
+RETURNING id, name, tier, created_at, updated_at, blocked_until, block_reason, blocked_by
 `
 
 type UpdateUserParams struct {
-	ID        int32            `json:"id"`
-	Tier      int32            `json:"tier"`
-	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+	ID           int32            `json:"id"`
+	Tier         int32            `json:"tier"`
+	BlockedUntil pgtype.Timestamp `json:"blocked_until"`
+	BlockReason  pgtype.Text      `json:"block_reason"`
+	BlockedBy    pgtype.Text      `json:"blocked_by"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
-	row := q.db.QueryRow(ctx, updateUser, arg.ID, arg.Tier, arg.UpdatedAt)
+	row := q.db.QueryRow(ctx, updateUser,
+		arg.ID,
+		arg.Tier,
+		arg.BlockedUntil,
+		arg.BlockReason,
+		arg.BlockedBy,
+		arg.UpdatedAt,
+	)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -125,6 +171,9 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Tier,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BlockedUntil,
+		&i.BlockReason,
+		&i.BlockedBy,
 	)
 	return i, err
 }
@@ -41,7 +41,7 @@ func SetupTestDB(t *testing.T) *pgxpool.Pool {
 	}
 
 	// Create connection pool
-	pool, err := database.NewPool(ctx, dbURL)
+	pool, err := database.NewPool(ctx, dbURL, database.PoolOptions{})
 	if err != nil {
 		t.Fatalf("Failed to connect to test database: %v", err)
 	}
@@ -62,6 +62,18 @@ func CleanupTables(t *testing.T, pool *pgxpool.Pool) {
 	queries := []string{
 		"TRUNCATE TABLE sessions RESTART IDENTITY CASCADE",
 		"TRUNCATE TABLE users RESTART IDENTITY CASCADE",
+		"TRUNCATE TABLE icons RESTART IDENTITY CASCADE",
+		"TRUNCATE TABLE point_ledger RESTART IDENTITY",
+		"TRUNCATE TABLE icon_commissions RESTART IDENTITY",
+		"TRUNCATE TABLE twitch_eventsub_messages",
+		"TRUNCATE TABLE cheers RESTART IDENTITY",
+		"TRUNCATE TABLE idempotency_keys",
+		"TRUNCATE TABLE banned_terms RESTART IDENTITY",
+		"TRUNCATE TABLE audit_logs RESTART IDENTITY",
+		"TRUNCATE TABLE outbox_events RESTART IDENTITY",
+		"TRUNCATE TABLE webhook_subscriptions RESTART IDENTITY CASCADE",
+		"TRUNCATE TABLE webhook_deliveries RESTART IDENTITY",
+		"TRUNCATE TABLE runtime_settings",
 	}
 
 	for _, query := range queries {
@@ -89,6 +101,25 @@ func CreateTestUser(t *testing.T, pool *pgxpool.Pool, name string, tier int32) i
 	return id
 }
 
+// CreateTestIcon creates an icon and returns the ID
+// A nil ownerUserID creates a shared icon of the tier
+func CreateTestIcon(t *testing.T, pool *pgxpool.Pool, tier int32, ownerUserID *int64, assetKey string) int64 {
+	t.Helper()
+
+	ctx := context.Background()
+	var id int64
+	err := pool.QueryRow(ctx,
+		"INSERT INTO icons (tier, owner_user_id, asset_key) VALUES ($1, $2, $3) RETURNING id",
+		tier, ownerUserID, assetKey,
+	).Scan(&id)
+
+	if err != nil {
+		t.Fatalf("Failed to create test icon: %v", err)
+	}
+
+	return id
+}
+
 // AssertUserExists checks if a user exists with the given name
 func AssertUserExists(t *testing.T, pool *pgxpool.Pool, name string) int64 {
 	t.Helper()
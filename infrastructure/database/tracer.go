@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
+)
+
+// QueryTracer pgx のクエリ 1 つを 1 つのスパンにする（pgx.QueryTracer）
+// HTTP リクエストや期限切れ処理などのスパンの中で実行したクエリだけを記録し、
+// 親のないクエリ（メトリクスの取得や定期的なポーリング）はトレースを増やさないよう記録しない
+type QueryTracer struct {
+	tracer trace.Tracer // nil なら tracing.Tracer()
+}
+
+var _ pgx.QueryTracer = QueryTracer{}
+
+type querySpanKey struct{}
+
+// TraceQueryStart implements pgx.QueryTracer
+func (t QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	tracer := t.tracer
+	if tracer == nil {
+		tracer = tracing.Tracer()
+	}
+	ctx, span := tracer.Start(ctx, queryName(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBQueryText(data.SQL),
+		),
+	)
+	return context.WithValue(ctx, querySpanKey{}, span)
+}
+
+// TraceQueryEnd implements pgx.QueryTracer
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, ok := ctx.Value(querySpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	// 行がないのはリポジトリが NotFound として扱う正常な結果
+	if data.Err != nil && data.Err != pgx.ErrNoRows {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+	span.End()
+}
+
+// queryName スパン名にするクエリ名
+// sqlc のクエリは先頭の "-- name: FindUserByNameForUpdate :one" から名前を取り、それ以外は先頭の語（SELECT・BEGIN など）を使う
+func queryName(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if rest, ok := strings.CutPrefix(sql, "-- name: "); ok {
+		if name, _, found := strings.Cut(rest, " "); found {
+			return name
+		}
+	}
+	// pgx の Ping は "-- ping" を送る
+	fields := strings.Fields(strings.TrimPrefix(sql, "--"))
+	if len(fields) == 0 {
+		return "query"
+	}
+	return strings.ToUpper(fields[0])
+}
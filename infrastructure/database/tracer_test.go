@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestQueryName(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{"-- name: FindUserByNameForUpdate :one\nSELECT id FROM users WHERE name = $1 FOR UPDATE", "FindUserByNameForUpdate"},
+		{"begin", "BEGIN"},
+		{"  SELECT version, dirty FROM schema_migrations LIMIT 1", "SELECT"},
+		{"-- ping", "PING"},
+		{"", "query"},
+	}
+	for _, tt := range tests {
+		if got := queryName(tt.sql); got != tt.want {
+			t.Errorf("queryName(%q) = %q, want %q", tt.sql, got, tt.want)
+		}
+	}
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := QueryTracer{tracer: provider.Tracer("test")}
+
+	// 親スパンのないクエリは記録しない
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	if n := len(recorder.Ended()); n != 0 {
+		t.Fatalf("expected no spans without a parent, got %d", n)
+	}
+
+	parent, span := provider.Tracer("test").Start(context.Background(), "JoinCommandUseCase.Execute")
+	ctx = tracer.TraceQueryStart(parent, nil, pgx.TraceQueryStartData{SQL: "-- name: CreateSession :one\nINSERT INTO sessions"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("INSERT 0 1"), Err: errors.New("deadlock detected")})
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	query := spans[0]
+	if query.Name() != "CreateSession" || query.Parent().SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("unexpected query span %q (parent %s)", query.Name(), query.Parent().SpanID())
+	}
+	if query.Status().Code != codes.Error {
+		t.Errorf("failed query should be an error span")
+	}
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+)
+
+// Handler g のメトリクスを Prometheus の形式で返す（GET /metrics）
+// 値を取得できなかったメトリクスはログに残して省略し、他のメトリクスは返す
+func Handler(g prometheus.Gatherer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		promhttp.HandlerFor(g, promhttp.HandlerOpts{
+			ErrorLog:      errorLog{ctx: r.Context()},
+			ErrorHandling: promhttp.ContinueOnError,
+		}).ServeHTTP(w, r)
+	})
+}
+
+// errorLog promhttp のエラーをリクエストのロガーに書く
+type errorLog struct {
+	ctx context.Context
+}
+
+func (l errorLog) Println(v ...any) {
+	logging.FromContext(l.ctx).Warn("failed to collect metrics", "error", fmt.Sprint(v...))
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// failingCollector 値を取得できないメトリクス
+type failingCollector struct {
+	desc *prometheus.Desc
+}
+
+func (c failingCollector) Describe(ch chan<- *prometheus.Desc) { ch <- c.desc }
+
+func (c failingCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.NewInvalidMetric(c.desc, errors.New("database is down"))
+}
+
+func TestHandler_OmitsFailedMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: "test_up", Help: "Up."}, func() float64 { return 1 }),
+		failingCollector{desc: prometheus.NewDesc("test_broken", "Broken.", nil, nil)},
+	)
+
+	rec := httptest.NewRecorder()
+	Handler(reg).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	if !strings.Contains(string(body), "test_up 1\n") {
+		t.Errorf("missing test_up in:\n%s", body)
+	}
+	if strings.Contains(string(body), "test_broken") {
+		t.Errorf("failed metric should be omitted:\n%s", body)
+	}
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/usecase/ratelimit"
+)
+
+// ResultOK コマンドが成功したときの result ラベル
+const ResultOK = "ok"
+
+// Metrics HTTP リクエストとコマンドの結果を数えて /metrics で公開する
+// command.CommandObserver と middleware.HTTPRecorder を実装する
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	commands     *prometheus.CounterVec
+}
+
+// New creates the application metrics on a new registry
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "workspace_http_requests_total",
+			Help: "HTTP requests by route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "workspace_http_request_duration_seconds",
+			Help:    "Time spent serving HTTP requests.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		commands: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "workspace_commands_total",
+			Help: "Command executions by source (http or chat) and result (ok or the error type).",
+		}, []string{"source", "command", "result"}),
+	}
+	m.Registry.MustRegister(m.httpRequests, m.httpDuration, m.commands)
+	return m
+}
+
+// ObserveHTTPRequest implements middleware.HTTPRecorder
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, elapsed time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(elapsed.Seconds())
+}
+
+// ObserveCommand implements command.CommandObserver
+func (m *Metrics) ObserveCommand(source, command string, err error) {
+	m.commands.WithLabelValues(source, command, ErrorType(err)).Inc()
+}
+
+// errorTypes コマンドが返す既知のエラーと result ラベルの対応
+// 一覧にないエラーは internal にまとめ、ラベルの種類が増え続けないようにする
+var errorTypes = []struct {
+	err   error
+	label string
+}{
+	{ratelimit.ErrRateLimited, "rate_limited"},
+	{domain.ErrBannedTermDetected, "banned_term"},
+	{domain.ErrUserBlocked, "user_blocked"},
+	{domain.ErrEmptyUserName, "invalid_input"},
+	{domain.ErrInvalidDuration, "invalid_input"},
+	{domain.ErrInvalidExtension, "invalid_input"},
+	{domain.ErrEmptyComment, "invalid_input"},
+	{domain.ErrUserNotFound, "user_not_found"},
+	{domain.ErrSessionNotFound, "session_not_found"},
+	{domain.ErrUserAlreadyInSession, "already_in_session"},
+	{domain.ErrSessionAlreadyCompleted, "session_completed"},
+	{domain.ErrCannotCheerSelf, "cheer_self"},
+	{domain.ErrCheerAlreadySent, "cheer_already_sent"},
+	{domain.ErrCheerTargetNotInRoom, "cheer_target_not_in_room"},
+	{domain.ErrIconCommissionAlreadyOpen, "commission_already_open"},
+	{domain.ErrInsufficientPoints, "insufficient_points"},
+	{context.Canceled, "canceled"},
+	{context.DeadlineExceeded, "timeout"},
+}
+
+// ErrorType エラーを result ラベルの値に変換する（nil は ok）
+func ErrorType(err error) string {
+	if err == nil {
+		return ResultOK
+	}
+	for _, t := range errorTypes {
+		if errors.Is(err, t.err) {
+			return t.label
+		}
+	}
+	return "internal"
+}
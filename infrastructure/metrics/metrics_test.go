@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/usecase/ratelimit"
+)
+
+func TestErrorType(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ResultOK},
+		{domain.ErrUserAlreadyInSession, "already_in_session"},
+		{fmt.Errorf("join: %w", domain.ErrBannedTermDetected), "banned_term"},
+		{&ratelimit.LimitError{Scope: ratelimit.ScopeUser, Command: "in", RetryAfter: time.Second}, "rate_limited"},
+		{context.DeadlineExceeded, "timeout"},
+		// 一覧にないエラーは internal にまとめる
+		{errors.New("connection refused"), "internal"},
+	}
+	for _, tt := range tests {
+		if got := ErrorType(tt.err); got != tt.want {
+			t.Errorf("ErrorType(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestMetrics_Observe(t *testing.T) {
+	m := New()
+	m.ObserveCommand("chat", "in", nil)
+	m.ObserveCommand("chat", "in", domain.ErrUserAlreadyInSession)
+	m.ObserveHTTPRequest("POST", "/api/commands/join", 409, 30*time.Millisecond)
+
+	for _, tt := range []struct {
+		counter prometheus.Counter
+		want    float64
+	}{
+		{m.commands.WithLabelValues("chat", "in", "ok"), 1},
+		{m.commands.WithLabelValues("chat", "in", "already_in_session"), 1},
+		{m.httpRequests.WithLabelValues("POST", "/api/commands/join", "409"), 1},
+	} {
+		if got := testutil.ToFloat64(tt.counter); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.counter.Desc(), got, tt.want)
+		}
+	}
+	if got := testutil.CollectAndCount(m.httpDuration); got != 1 {
+		t.Errorf("workspace_http_request_duration_seconds series = %d, want 1", got)
+	}
+}
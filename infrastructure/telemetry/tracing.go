@@ -0,0 +1,85 @@
+// Package telemetry OpenTelemetry のエクスポーターと TracerProvider を設定する
+// スパンを作る側は shared/tracing を使い、この package はプロセスの起動時に 1 回だけ呼ぶ
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// DefaultServiceName OTEL_SERVICE_NAME がないときのサービス名
+const DefaultServiceName = "work-tracker"
+
+// Exporter スパンの送り先
+type Exporter string
+
+const (
+	// ExporterOTLP OTLP/HTTP でコレクターに送る（送り先は OTEL_EXPORTER_OTLP_ENDPOINT など標準の環境変数で指定する）
+	ExporterOTLP Exporter = "otlp"
+	// ExporterStdout 1 スパン 1 行の JSON を標準出力に書く（コレクターがない開発環境用）
+	ExporterStdout Exporter = "stdout"
+	// ExporterNone スパンを送らない
+	ExporterNone Exporter = "none"
+)
+
+// ParseExporter 設定値を Exporter に変換する（OTEL_TRACES_EXPORTER の "console" は stdout として扱う）
+func ParseExporter(s string) (Exporter, error) {
+	switch e := Exporter(strings.ToLower(s)); e {
+	case ExporterOTLP, ExporterStdout, ExporterNone:
+		return e, nil
+	case "console":
+		return ExporterStdout, nil
+	default:
+		return "", fmt.Errorf("unknown traces exporter %q (want %q, %q or %q)", s, ExporterOTLP, ExporterStdout, ExporterNone)
+	}
+}
+
+// SetupTracing exporter に送る TracerProvider と W3C Trace Context の伝播をグローバルに設定する
+// 返り値の shutdown は終了時に呼び、バッファに残ったスパンを送り切る
+// サンプリングは OTEL_TRACES_SAMPLER / OTEL_TRACES_SAMPLER_ARG で変えられる（既定はすべて記録）
+func SetupTracing(ctx context.Context, exporter Exporter, stdout io.Writer) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var spanExporter sdktrace.SpanExporter
+	switch exporter {
+	case ExporterOTLP:
+		spanExporter, err = otlptracehttp.New(ctx)
+	case ExporterStdout:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(stdout))
+	default:
+		return nil, fmt.Errorf("unknown traces exporter %q", exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", exporter, err)
+	}
+
+	// 後の検出結果が優先されるので、OTEL_SERVICE_NAME・OTEL_RESOURCE_ATTRIBUTES が既定のサービス名を上書きする
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(DefaultServiceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,52 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+)
+
+func TestParseExporter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Exporter
+	}{
+		{"otlp", ExporterOTLP},
+		{"STDOUT", ExporterStdout},
+		{"console", ExporterStdout},
+		{"none", ExporterNone},
+	}
+	for _, tt := range tests {
+		got, err := ParseExporter(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseExporter(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseExporter("jaeger"); err == nil {
+		t.Error("expected an error for an unknown exporter")
+	}
+}
+
+func TestSetupTracing_Stdout(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "work-tracker-test")
+	var buf bytes.Buffer
+	shutdown, err := SetupTracing(context.Background(), ExporterStdout, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, span := otel.Tracer("test").Start(context.Background(), "JoinCommandUseCase.Execute")
+	span.End()
+	// Shutdown でバッファのスパンが書き出される
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `"Name":"JoinCommandUseCase.Execute"`) || !strings.Contains(out, "work-tracker-test") {
+		t.Errorf("unexpected exporter output:\n%s", out)
+	}
+}
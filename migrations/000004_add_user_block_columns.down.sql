@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS blocked_by,
+    DROP COLUMN IF EXISTS block_reason,
+    DROP COLUMN IF EXISTS blocked_until;
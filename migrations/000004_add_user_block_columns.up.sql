@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS blocked_until TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS block_reason TEXT,
+    ADD COLUMN IF NOT EXISTS blocked_by TEXT;
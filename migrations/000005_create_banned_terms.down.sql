@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_banned_terms_term_match_type;
+DROP TABLE IF EXISTS banned_terms;
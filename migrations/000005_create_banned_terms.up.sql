@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS banned_terms (
+    id SERIAL PRIMARY KEY,
+    term TEXT NOT NULL,
+    match_type TEXT NOT NULL CHECK (match_type IN ('exact', 'substring', 'regex')),
+    action TEXT NOT NULL CHECK (action IN ('reject', 'mask')),
+    auto_block BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_banned_terms_term_match_type ON banned_terms(term, match_type);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_audit_logs_created_at;
+DROP INDEX IF EXISTS idx_audit_logs_target;
+DROP TABLE IF EXISTS audit_logs;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    before_state JSONB,
+    after_state JSONB,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_logs_target ON audit_logs(target_type, target_id, created_at DESC);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at DESC);
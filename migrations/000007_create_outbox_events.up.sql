@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    dispatched_at TIMESTAMP
+);
+
+-- 未配信イベントの取得用（配信済みの行は含めない）
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE dispatched_at IS NULL;
+CREATE INDEX idx_outbox_events_dispatched_at ON outbox_events(dispatched_at) WHERE dispatched_at IS NOT NULL;
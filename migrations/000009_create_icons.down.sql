@@ -0,0 +1,4 @@
+ALTER TABLE sessions DROP CONSTRAINT IF EXISTS sessions_icon_id_fkey;
+DROP INDEX IF EXISTS idx_icons_owner_user_id;
+DROP INDEX IF EXISTS idx_icons_tier;
+DROP TABLE IF EXISTS icons;
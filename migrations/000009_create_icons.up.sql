@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS icons (
+    id SERIAL PRIMARY KEY,
+    tier INTEGER NOT NULL,
+    -- 専用アイコンの持ち主（NULL ならティアの共通アイコン）
+    owner_user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    asset_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_icons_tier ON icons(tier) WHERE owner_user_id IS NULL;
+CREATE INDEX idx_icons_owner_user_id ON icons(owner_user_id) WHERE owner_user_id IS NOT NULL;
+
+-- 共通アイコン（スプライトの命名規則 tier{tier}-{番号} に合わせる）
+INSERT INTO icons (tier, asset_key)
+SELECT tier, 'tier' || tier || '-' || lpad(n::text, 2, '0')
+FROM generate_series(1, 3) AS tier, generate_series(1, 10) AS n
+ON CONFLICT (asset_key) DO NOTHING;
+
+ALTER TABLE sessions
+    ADD CONSTRAINT sessions_icon_id_fkey FOREIGN KEY (icon_id) REFERENCES icons(id) ON DELETE SET NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS icon_commissions_open_user_key;
+DROP INDEX IF EXISTS idx_icon_commissions_status;
+DROP TABLE IF EXISTS icon_commissions;
+DROP INDEX IF EXISTS point_ledger_reason_reference_key;
+DROP INDEX IF EXISTS idx_point_ledger_user_id;
+DROP TABLE IF EXISTS point_ledger;
@@ -0,0 +1,30 @@
+-- Raziiipo の増減の記録（残高は合計で求める）
+CREATE TABLE IF NOT EXISTS point_ledger (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    delta BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    reference TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT point_ledger_delta_check CHECK (delta <> 0)
+);
+
+CREATE INDEX idx_point_ledger_user_id ON point_ledger(user_id, id);
+-- 同じ理由・参照の二重計上を防ぐ（返金の二重実行、外部イベントの再送など）
+CREATE UNIQUE INDEX point_ledger_reason_reference_key ON point_ledger(reason, reference) WHERE reference <> '';
+
+CREATE TABLE IF NOT EXISTS icon_commissions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'pending',
+    cost BIGINT NOT NULL,
+    icon_id INTEGER REFERENCES icons(id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT icon_commissions_status_check CHECK (status IN ('pending', 'in_progress', 'delivered', 'refunded'))
+);
+
+CREATE INDEX idx_icon_commissions_status ON icon_commissions(status, created_at);
+-- 未完了の依頼は 1 ユーザー 1 件まで
+CREATE UNIQUE INDEX icon_commissions_open_user_key ON icon_commissions(user_id) WHERE status IN ('pending', 'in_progress');
@@ -0,0 +1,6 @@
+-- 処理済みの Twitch EventSub 通知（Twitch は同じ Message-Id で再送するため、重複を除く）
+CREATE TABLE IF NOT EXISTS twitch_eventsub_messages (
+    message_id TEXT PRIMARY KEY,
+    subscription_type TEXT NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,12 @@
+-- /cheer @ユーザー名 の記録（同じ相手への応援は 1 日 1 回まで）
+CREATE TABLE IF NOT EXISTS cheers (
+    id BIGSERIAL PRIMARY KEY,
+    from_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    cheered_on DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT cheers_not_self_check CHECK (from_user_id <> to_user_id)
+);
+
+CREATE UNIQUE INDEX cheers_daily_pair_key ON cheers(from_user_id, to_user_id, cheered_on);
+CREATE INDEX idx_cheers_to_user_id ON cheers(to_user_id, cheered_on);
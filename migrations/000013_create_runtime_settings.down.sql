@@ -0,0 +1 @@
+DROP TABLE IF EXISTS runtime_settings;
@@ -0,0 +1,7 @@
+-- 配信中に管理 API から変更した設定（起動時の設定を上書きする。行がなければ起動時の値を使う）
+CREATE TABLE IF NOT EXISTS runtime_settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_by TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_outbox_events_dispatch_seq;
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS dispatch_seq;
+DROP SEQUENCE IF EXISTS outbox_events_dispatch_seq;
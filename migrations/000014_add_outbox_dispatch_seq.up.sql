@@ -0,0 +1,12 @@
+-- 配信した順の番号（Last-Event-ID からの再開に使う）
+-- BIGSERIAL の id は採番順で、コミット順とは限らない。後からコミットされた小さい id のイベントを
+-- id > Last-Event-ID で探すと取りこぼすため、配信済みにするときに採番する
+CREATE SEQUENCE IF NOT EXISTS outbox_events_dispatch_seq;
+ALTER TABLE outbox_events ADD COLUMN dispatch_seq BIGINT;
+ALTER SEQUENCE outbox_events_dispatch_seq OWNED BY outbox_events.dispatch_seq;
+
+-- 既存の配信済みイベントは id を番号にする（再接続するクライアントの Last-Event-ID がそのまま使える）
+UPDATE outbox_events SET dispatch_seq = id WHERE dispatched_at IS NOT NULL;
+SELECT setval('outbox_events_dispatch_seq', COALESCE((SELECT MAX(id) FROM outbox_events), 0) + 1, false);
+
+CREATE UNIQUE INDEX idx_outbox_events_dispatch_seq ON outbox_events(dispatch_seq) WHERE dispatch_seq IS NOT NULL;
@@ -0,0 +1,11 @@
+-- 再試行の予定時刻（配信に失敗したイベントは、この時刻まで後続のイベントごと配信を待つ）
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP;
+-- 配信を諦めた時刻（デッドレター。配信待ちから外し、保持期間が過ぎたら削除する）
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS dead_at TIMESTAMP;
+
+-- 試行回数の上限（10 回）に達して取り残されていたイベントはデッドレターにする
+UPDATE outbox_events SET dead_at = NOW() WHERE dispatched_at IS NULL AND attempts >= 10;
+
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE dispatched_at IS NULL AND dead_at IS NULL;
+CREATE INDEX idx_outbox_events_dead_at ON outbox_events(dead_at) WHERE dead_at IS NOT NULL;
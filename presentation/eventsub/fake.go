@@ -0,0 +1,107 @@
+package eventsub
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Fake Twitch の代わりに署名付きの通知を送る（ローカルでの動作確認・テスト用）
+type Fake struct {
+	URL    string // コールバック URL（例: http://localhost:8000/api/twitch/eventsub）
+	Secret string
+	Client *http.Client // nil の場合は http.DefaultClient
+	Now    func() time.Time
+}
+
+// Send 通知を 1 件送る。messageID が空なら生成する
+// payload は envelope（subscription・event・challenge）として JSON にする
+func (f *Fake) Send(ctx context.Context, messageID, messageType string, payload any) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	if messageID == "" {
+		messageID = newMessageID()
+	}
+	now := time.Now
+	if f.Now != nil {
+		now = f.Now
+	}
+	timestamp := now().UTC().Format(time.RFC3339Nano)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderMessageID, messageID)
+	req.Header.Set(HeaderMessageTimestamp, timestamp)
+	req.Header.Set(HeaderMessageSignature, Sign(f.Secret, messageID, timestamp, body))
+	req.Header.Set(HeaderMessageType, messageType)
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// Notify subscriptionType の通知を送る
+func (f *Fake) Notify(ctx context.Context, messageID, subscriptionType string, event any) (*http.Response, error) {
+	return f.Send(ctx, messageID, MessageTypeNotification, map[string]any{
+		"subscription": fakeSubscription(subscriptionType, "enabled"),
+		"event":        event,
+	})
+}
+
+// Verify コールバック URL の確認（challenge）を送る
+func (f *Fake) Verify(ctx context.Context, subscriptionType, challenge string) (*http.Response, error) {
+	return f.Send(ctx, "", MessageTypeVerification, map[string]any{
+		"challenge":    challenge,
+		"subscription": fakeSubscription(subscriptionType, "webhook_callback_verification_pending"),
+	})
+}
+
+// RedemptionEvent チャンネルポイント交換の event を作る
+func RedemptionEvent(redemptionID, userName string, cost int64) map[string]any {
+	return map[string]any{
+		"id":         redemptionID,
+		"user_login": userName,
+		"user_name":  userName,
+		"status":     "unfulfilled",
+		"reward":     map[string]any{"id": "fake-reward", "title": "Raziiipo", "cost": cost},
+	}
+}
+
+// SubscribeEvent サブスク開始の event を作る（tier は "1000" / "2000" / "3000"）
+func SubscribeEvent(userName, tier string) map[string]any {
+	return map[string]any{
+		"user_login": userName,
+		"user_name":  userName,
+		"tier":       tier,
+		"is_gift":    false,
+	}
+}
+
+func fakeSubscription(subscriptionType, status string) map[string]any {
+	return map[string]any{
+		"id":      "fake-" + subscriptionType,
+		"type":    subscriptionType,
+		"version": "1",
+		"status":  status,
+	}
+}
+
+func newMessageID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("fake-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,218 @@
+// Package eventsub は Twitch EventSub（Webhook トランスポート）の通知を受け取る
+//
+// 署名の検証・コールバック確認（challenge）・Message-Id による重複排除を行い、
+// チャンネルポイント交換とサブスクを usecase/twitch に渡す
+package eventsub
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/usecase/twitch"
+)
+
+// Twitch が通知に付けるヘッダー
+const (
+	HeaderMessageID        = "Twitch-Eventsub-Message-Id"
+	HeaderMessageTimestamp = "Twitch-Eventsub-Message-Timestamp"
+	HeaderMessageSignature = "Twitch-Eventsub-Message-Signature"
+	HeaderMessageType      = "Twitch-Eventsub-Message-Type"
+
+	// SignaturePrefix 署名ヘッダーの値の接頭辞
+	SignaturePrefix = "sha256="
+)
+
+// Twitch-Eventsub-Message-Type の値
+const (
+	MessageTypeNotification = "notification"
+	MessageTypeVerification = "webhook_callback_verification"
+	MessageTypeRevocation   = "revocation"
+)
+
+const (
+	// MaxMessageAge これより古いタイムスタンプの通知はリプレイとして拒否する（Twitch の推奨値）
+	MaxMessageAge = 10 * time.Minute
+	// maxBodyBytes 通知の本文の最大長
+	maxBodyBytes = 1 << 20
+)
+
+// Sign EventSub の署名を計算する
+// 署名対象は Message-Id・Message-Timestamp・本文をこの順に連結したもの
+func Sign(secret, messageID, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(messageID))
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+	return SignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Processor 通知の処理（twitch.Service）
+type Processor interface {
+	CreditRedemption(ctx context.Context, redemption twitch.Redemption) (int64, error)
+	ApplySubscription(ctx context.Context, subscription twitch.Subscription) (*domain.User, error)
+}
+
+// Handler EventSub のコールバック URL
+type Handler struct {
+	secret    string
+	processor Processor
+	now       func() time.Time
+}
+
+// NewHandler creates a new EventSub handler
+// secret は EventSub のサブスクリプション作成時に指定したシークレット
+func NewHandler(secret string, processor Processor) *Handler {
+	return &Handler{
+		secret:    secret,
+		processor: processor,
+		now:       time.Now,
+	}
+}
+
+// envelope 通知の本文
+type envelope struct {
+	Challenge    string `json:"challenge"`
+	Subscription struct {
+		ID     string `json:"id"`
+		Type   string `json:"type"`
+		Status string `json:"status"`
+	} `json:"subscription"`
+	Event json.RawMessage `json:"event"`
+}
+
+// redemptionEvent channel.channel_points_custom_reward_redemption.add の event
+type redemptionEvent struct {
+	ID       string `json:"id"`
+	UserName string `json:"user_name"`
+	Reward   struct {
+		Cost int64 `json:"cost"`
+	} `json:"reward"`
+}
+
+// subscribeEvent channel.subscribe の event
+type subscribeEvent struct {
+	UserName string `json:"user_name"`
+	Tier     string `json:"tier"`
+}
+
+// ServeHTTP handles POST /api/twitch/eventsub
+//
+// 処理済み・対象外の通知にも 2xx を返す（Twitch は 2xx 以外を再送し、失敗が続くとサブスクリプションを無効にする）
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxBodyBytes {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	messageID := r.Header.Get(HeaderMessageID)
+	timestamp := r.Header.Get(HeaderMessageTimestamp)
+	if !h.verify(messageID, timestamp, r.Header.Get(HeaderMessageSignature), body) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	var msg envelope
+	if err := json.Unmarshal(body, &msg); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Header.Get(HeaderMessageType) {
+	case MessageTypeVerification:
+		// コールバック URL の確認: challenge をそのまま返す
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(msg.Challenge))
+	case MessageTypeRevocation:
+		logging.FromContext(r.Context()).Warn("eventsub: subscription revoked",
+			"subscription_id", msg.Subscription.ID, "subscription_type", msg.Subscription.Type, "status", msg.Subscription.Status)
+		w.WriteHeader(http.StatusNoContent)
+	case MessageTypeNotification:
+		h.handleNotification(r.Context(), w, messageID, msg)
+	default:
+		http.Error(w, "unknown message type", http.StatusBadRequest)
+	}
+}
+
+func (h *Handler) handleNotification(ctx context.Context, w http.ResponseWriter, messageID string, msg envelope) {
+	var err error
+	switch msg.Subscription.Type {
+	case domain.EventSubTypeChannelPointsRedemption:
+		var event redemptionEvent
+		if err = json.Unmarshal(msg.Event, &event); err != nil {
+			http.Error(w, "invalid event", http.StatusBadRequest)
+			return
+		}
+		_, err = h.processor.CreditRedemption(ctx, twitch.Redemption{
+			MessageID:    messageID,
+			RedemptionID: event.ID,
+			UserName:     event.UserName,
+			Cost:         event.Reward.Cost,
+		})
+	case domain.EventSubTypeChannelSubscribe:
+		var event subscribeEvent
+		if err = json.Unmarshal(msg.Event, &event); err != nil {
+			http.Error(w, "invalid event", http.StatusBadRequest)
+			return
+		}
+		tier, parseErr := domain.ParseTwitchSubTier(event.Tier)
+		if parseErr != nil {
+			http.Error(w, parseErr.Error(), http.StatusBadRequest)
+			return
+		}
+		_, err = h.processor.ApplySubscription(ctx, twitch.Subscription{
+			MessageID: messageID,
+			UserName:  event.UserName,
+			Tier:      tier,
+		})
+	default:
+		// 購読していない種別は無視する
+		logging.FromContext(ctx).Info("eventsub: ignoring notification", "subscription_type", msg.Subscription.Type)
+	}
+
+	switch {
+	case err == nil, errors.Is(err, domain.ErrEventSubMessageProcessed):
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, domain.ErrEmptyUserName):
+		http.Error(w, "user_name is required", http.StatusBadRequest)
+	default:
+		logging.FromContext(ctx).Error("eventsub: failed to process message", "message_id", messageID, logging.Err(err))
+		http.Error(w, "failed to process notification", http.StatusInternalServerError)
+	}
+}
+
+// verify 署名とタイムスタンプを確認する
+func (h *Handler) verify(messageID, timestamp, signature string, body []byte) bool {
+	if messageID == "" || timestamp == "" || signature == "" {
+		return false
+	}
+	sent, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return false
+	}
+	if age := h.now().Sub(sent); age > MaxMessageAge || age < -MaxMessageAge {
+		return false
+	}
+	expected := Sign(h.secret, messageID, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
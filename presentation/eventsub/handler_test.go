@@ -0,0 +1,182 @@
+package eventsub
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/usecase/twitch"
+)
+
+const testSecret = "s3cret"
+
+type fakeProcessor struct {
+	redemptions   []twitch.Redemption
+	subscriptions []twitch.Subscription
+	err           error
+}
+
+func (p *fakeProcessor) CreditRedemption(ctx context.Context, redemption twitch.Redemption) (int64, error) {
+	p.redemptions = append(p.redemptions, redemption)
+	return domain.ChannelPointsToRaziiipo(redemption.Cost, domain.DefaultChannelPointsPerRaziiipo), p.err
+}
+
+func (p *fakeProcessor) ApplySubscription(ctx context.Context, subscription twitch.Subscription) (*domain.User, error) {
+	p.subscriptions = append(p.subscriptions, subscription)
+	return &domain.User{Name: subscription.UserName, Tier: subscription.Tier}, p.err
+}
+
+func newTestServer(t *testing.T, processor Processor) *Fake {
+	t.Helper()
+	server := httptest.NewServer(NewHandler(testSecret, processor))
+	t.Cleanup(server.Close)
+	return &Fake{URL: server.URL, Secret: testSecret, Client: server.Client()}
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	return string(body)
+}
+
+func TestHandler_Verification(t *testing.T) {
+	fake := newTestServer(t, &fakeProcessor{})
+
+	resp, err := fake.Verify(context.Background(), domain.EventSubTypeChannelSubscribe, "pogchamp-kappa-360noscope-vohiyo")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if body := readBody(t, resp); resp.StatusCode != http.StatusOK || body != "pogchamp-kappa-360noscope-vohiyo" {
+		t.Fatalf("expected the challenge, got %d %q", resp.StatusCode, body)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("expected text/plain, got %q", ct)
+	}
+}
+
+func TestHandler_RejectsInvalidMessages(t *testing.T) {
+	tests := []struct {
+		name string
+		fake func(f *Fake)
+	}{
+		{"署名が違う", func(f *Fake) { f.Secret = "wrong" }},
+		{"古いタイムスタンプ", func(f *Fake) { f.Now = func() time.Time { return time.Now().Add(-MaxMessageAge - time.Minute) } }},
+		{"未来のタイムスタンプ", func(f *Fake) { f.Now = func() time.Time { return time.Now().Add(MaxMessageAge + time.Minute) } }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &fakeProcessor{}
+			fake := newTestServer(t, processor)
+			tt.fake(fake)
+
+			resp, err := fake.Notify(context.Background(), "", domain.EventSubTypeChannelPointsRedemption, RedemptionEvent("r1", "yamada", 100))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			readBody(t, resp)
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("expected 403, got %d", resp.StatusCode)
+			}
+			if len(processor.redemptions) != 0 {
+				t.Errorf("processor must not be called")
+			}
+		})
+	}
+}
+
+func TestHandler_Redemption(t *testing.T) {
+	processor := &fakeProcessor{}
+	fake := newTestServer(t, processor)
+
+	resp, err := fake.Notify(context.Background(), "msg-1", domain.EventSubTypeChannelPointsRedemption, RedemptionEvent("r1", "yamada", 500))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	readBody(t, resp)
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", resp.StatusCode)
+	}
+	want := twitch.Redemption{MessageID: "msg-1", RedemptionID: "r1", UserName: "yamada", Cost: 500}
+	if len(processor.redemptions) != 1 || processor.redemptions[0] != want {
+		t.Errorf("unexpected redemptions: %+v", processor.redemptions)
+	}
+}
+
+func TestHandler_Subscribe(t *testing.T) {
+	processor := &fakeProcessor{}
+	fake := newTestServer(t, processor)
+	ctx := context.Background()
+
+	resp, err := fake.Notify(ctx, "msg-1", domain.EventSubTypeChannelSubscribe, SubscribeEvent("yamada", "2000"))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	readBody(t, resp)
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", resp.StatusCode)
+	}
+	if len(processor.subscriptions) != 1 || processor.subscriptions[0].Tier != domain.Tier2 || processor.subscriptions[0].MessageID != "msg-1" {
+		t.Errorf("unexpected subscriptions: %+v", processor.subscriptions)
+	}
+
+	resp, err = fake.Notify(ctx, "msg-2", domain.EventSubTypeChannelSubscribe, SubscribeEvent("yamada", "prime"))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	readBody(t, resp)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown tier, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_ProcessedMessageIsAcknowledged(t *testing.T) {
+	fake := newTestServer(t, &fakeProcessor{err: domain.ErrEventSubMessageProcessed})
+
+	resp, err := fake.Notify(context.Background(), "msg-1", domain.EventSubTypeChannelPointsRedemption, RedemptionEvent("r1", "yamada", 100))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	readBody(t, resp)
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected 204 so that Twitch stops retrying, got %d", resp.StatusCode)
+	}
+}
+
+func TestHandler_UnknownSubscriptionType(t *testing.T) {
+	processor := &fakeProcessor{}
+	fake := newTestServer(t, processor)
+
+	resp, err := fake.Notify(context.Background(), "msg-1", "channel.follow", map[string]any{"user_name": "yamada"})
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	readBody(t, resp)
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", resp.StatusCode)
+	}
+	if len(processor.redemptions) != 0 || len(processor.subscriptions) != 0 {
+		t.Errorf("processor must not be called")
+	}
+}
+
+func TestHandler_ProcessorError(t *testing.T) {
+	fake := newTestServer(t, &fakeProcessor{err: context.DeadlineExceeded})
+
+	resp, err := fake.Notify(context.Background(), "msg-1", domain.EventSubTypeChannelPointsRedemption, RedemptionEvent("r1", "yamada", 100))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	readBody(t, resp)
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected 500 so that Twitch retries, got %d", resp.StatusCode)
+	}
+}
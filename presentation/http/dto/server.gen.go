@@ -4,6 +4,7 @@
 package dto
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -12,11 +13,178 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
+const (
+	AdminTokenScopes = "adminToken.Scopes"
+)
+
+// Defines values for BannedTermAction.
+const (
+	BannedTermActionMask   BannedTermAction = "mask"
+	BannedTermActionReject BannedTermAction = "reject"
+)
+
+// Defines values for BannedTermMatchType.
+const (
+	BannedTermMatchTypeExact     BannedTermMatchType = "exact"
+	BannedTermMatchTypeRegex     BannedTermMatchType = "regex"
+	BannedTermMatchTypeSubstring BannedTermMatchType = "substring"
+)
+
+// Defines values for BannedTermRequestAction.
+const (
+	BannedTermRequestActionMask   BannedTermRequestAction = "mask"
+	BannedTermRequestActionReject BannedTermRequestAction = "reject"
+)
+
+// Defines values for BannedTermRequestMatchType.
+const (
+	BannedTermRequestMatchTypeExact     BannedTermRequestMatchType = "exact"
+	BannedTermRequestMatchTypeRegex     BannedTermRequestMatchType = "regex"
+	BannedTermRequestMatchTypeSubstring BannedTermRequestMatchType = "substring"
+)
+
+// Defines values for IconCommissionStatus.
+const (
+	IconCommissionStatusDelivered  IconCommissionStatus = "delivered"
+	IconCommissionStatusInProgress IconCommissionStatus = "in_progress"
+	IconCommissionStatusPending    IconCommissionStatus = "pending"
+	IconCommissionStatusRefunded   IconCommissionStatus = "refunded"
+)
+
+// Defines values for ReadinessCheckResultStatus.
+const (
+	ReadinessCheckResultStatusFailed ReadinessCheckResultStatus = "failed"
+	ReadinessCheckResultStatusOk     ReadinessCheckResultStatus = "ok"
+)
+
+// Defines values for ReadinessResponseStatus.
+const (
+	ReadinessResponseStatusOk          ReadinessResponseStatus = "ok"
+	ReadinessResponseStatusUnavailable ReadinessResponseStatus = "unavailable"
+)
+
+// Defines values for WebhookCreateRequestEventTypes.
+const (
+	SessionEnd      WebhookCreateRequestEventTypes = "session_end"
+	SessionExtend   WebhookCreateRequestEventTypes = "session_extend"
+	SessionStart    WebhookCreateRequestEventTypes = "session_start"
+	SettingsChanged WebhookCreateRequestEventTypes = "settings_changed"
+	WorkNameChange  WebhookCreateRequestEventTypes = "work_name_change"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "dead"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+)
+
+// Defines values for ListIconCommissionsParamsStatus.
+const (
+	ListIconCommissionsParamsStatusDelivered  ListIconCommissionsParamsStatus = "delivered"
+	ListIconCommissionsParamsStatusInProgress ListIconCommissionsParamsStatus = "in_progress"
+	ListIconCommissionsParamsStatusPending    ListIconCommissionsParamsStatus = "pending"
+	ListIconCommissionsParamsStatusRefunded   ListIconCommissionsParamsStatus = "refunded"
+)
+
+// Defines values for ListWebhookDeliveriesParamsStatus.
+const (
+	ListWebhookDeliveriesParamsStatusDead      ListWebhookDeliveriesParamsStatus = "dead"
+	ListWebhookDeliveriesParamsStatusDelivered ListWebhookDeliveriesParamsStatus = "delivered"
+	ListWebhookDeliveriesParamsStatusPending   ListWebhookDeliveriesParamsStatus = "pending"
+)
+
 // ActiveSessionsResponse defines model for ActiveSessionsResponse.
 type ActiveSessionsResponse struct {
 	Sessions []SessionInfo `json:"sessions"`
 }
 
+// AdminActionRequest defines model for AdminActionRequest.
+type AdminActionRequest struct {
+	// Reason Reason recorded in the audit log
+	Reason *string `json:"reason,omitempty"`
+}
+
+// AuditLog defines model for AuditLog.
+type AuditLog struct {
+	// Action Operation performed (e.g. user.block, banned_term.create)
+	Action string `json:"action"`
+
+	// Actor Moderator name or `system:<component>` for automatic actions
+	Actor string `json:"actor"`
+
+	// After Target state after the operation
+	After *map[string]interface{} `json:"after"`
+
+	// Before Target state before the operation
+	Before     *map[string]interface{} `json:"before"`
+	CreatedAt  time.Time               `json:"created_at"`
+	Id         int64                   `json:"id"`
+	Reason     string                  `json:"reason"`
+	TargetId   string                  `json:"target_id"`
+	TargetType string                  `json:"target_type"`
+}
+
+// AuditLogListResponse defines model for AuditLogListResponse.
+type AuditLogListResponse struct {
+	Logs []AuditLog `json:"logs"`
+}
+
+// BannedTerm defines model for BannedTerm.
+type BannedTerm struct {
+	Action    BannedTermAction `json:"action"`
+	AutoBlock bool             `json:"auto_block"`
+	CreatedAt time.Time        `json:"created_at"`
+
+	// CreatedBy Actor who registered the term
+	CreatedBy string              `json:"created_by"`
+	Id        int64               `json:"id"`
+	MatchType BannedTermMatchType `json:"match_type"`
+	Term      string              `json:"term"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// BannedTermAction defines model for BannedTerm.Action.
+type BannedTermAction string
+
+// BannedTermMatchType defines model for BannedTerm.MatchType.
+type BannedTermMatchType string
+
+// BannedTermListResponse defines model for BannedTermListResponse.
+type BannedTermListResponse struct {
+	Terms []BannedTerm `json:"terms"`
+}
+
+// BannedTermRequest defines model for BannedTermRequest.
+type BannedTermRequest struct {
+	// Action Reject the command, or accept it with the matched part masked by `*`
+	Action BannedTermRequestAction `json:"action"`
+
+	// AutoBlock Automatically block the user who used the term
+	AutoBlock *bool `json:"auto_block,omitempty"`
+
+	// MatchType How the term is matched against normalized text
+	MatchType BannedTermRequestMatchType `json:"match_type"`
+
+	// Term Term or regular expression (regex is applied to normalized text, case-insensitive)
+	Term string `json:"term"`
+}
+
+// BannedTermRequestAction Reject the command, or accept it with the matched part masked by `*`
+type BannedTermRequestAction string
+
+// BannedTermRequestMatchType How the term is matched against normalized text
+type BannedTermRequestMatchType string
+
+// BlockUserRequest defines model for BlockUserRequest.
+type BlockUserRequest struct {
+	// DurationMinutes Block duration in minutes; omitted or 0 blocks permanently
+	DurationMinutes *int `json:"duration_minutes,omitempty"`
+
+	// Reason Reason shown in the block state and the audit log
+	Reason *string `json:"reason,omitempty"`
+}
+
 // ChangeCommandRequest defines model for ChangeCommandRequest.
 type ChangeCommandRequest struct {
 	// NewWorkName New work name (can be empty)
@@ -38,12 +206,143 @@ type ChangeCommandResponse struct {
 	WorkName string `json:"work_name"`
 }
 
+// CheerCommandRequest defines model for CheerCommandRequest.
+type CheerCommandRequest struct {
+	// TargetName User to cheer (a leading @ is ignored)
+	TargetName string `json:"target_name"`
+
+	// UserName User name from Twitch/YouTube
+	UserName string `json:"user_name"`
+}
+
+// CheerCommandResponse defines model for CheerCommandResponse.
+type CheerCommandResponse struct {
+	// BonusPoints Raziiipo credited to the cheered user (0 when the bonus is disabled)
+	BonusPoints int64 `json:"bonus_points"`
+
+	// CheerId Cheer ID
+	CheerId int64 `json:"cheer_id"`
+
+	// TargetName Name of the cheered user
+	TargetName string `json:"target_name"`
+
+	// TargetUserId User ID of the cheered user
+	TargetUserId int64 `json:"target_user_id"`
+
+	// UserId User ID of the cheering user
+	UserId int64 `json:"user_id"`
+}
+
+// CommentCommandRequest defines model for CommentCommandRequest.
+type CommentCommandRequest struct {
+	// Text Chat message
+	Text string `json:"text"`
+
+	// UserName User name from Twitch/YouTube
+	UserName string `json:"user_name"`
+}
+
+// CommentCommandResponse defines model for CommentCommandResponse.
+type CommentCommandResponse struct {
+	// Text Text shown in the comment bubble
+	Text string `json:"text"`
+
+	// UserId User ID
+	UserId int64 `json:"user_id"`
+}
+
+// DeadOutboxEvent defines model for DeadOutboxEvent.
+type DeadOutboxEvent struct {
+	Attempts  int32     `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+	DeadAt    time.Time `json:"dead_at"`
+	EventType string    `json:"event_type"`
+	Id        int64     `json:"id"`
+	LastError string    `json:"last_error"`
+
+	// Payload Event payload as recorded in the outbox
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// DeadOutboxEventListResponse defines model for DeadOutboxEventListResponse.
+type DeadOutboxEventListResponse struct {
+	Events []DeadOutboxEvent `json:"events"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
+	// Code Machine-readable error code (e.g. rate_limited)
+	Code *string `json:"code,omitempty"`
+
 	// Error Error message
 	Error string `json:"error"`
 }
 
+// ForceOutResponse defines model for ForceOutResponse.
+type ForceOutResponse struct {
+	ActualEnd time.Time `json:"actual_end"`
+	SessionId int64     `json:"session_id"`
+	UserId    int64     `json:"user_id"`
+}
+
+// IconCommission defines model for IconCommission.
+type IconCommission struct {
+	// Cost Raziiipo debited (and credited back on refund)
+	Cost      int64     `json:"cost"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// IconId Exclusive icon registered on delivery
+	IconId *int64 `json:"icon_id"`
+	Id     int64  `json:"id"`
+
+	// Note Refund reason
+	Note      string               `json:"note"`
+	Status    IconCommissionStatus `json:"status"`
+	UpdatedAt time.Time            `json:"updated_at"`
+	UserId    int64                `json:"user_id"`
+}
+
+// IconCommissionStatus defines model for IconCommission.Status.
+type IconCommissionStatus string
+
+// IconCommissionDeliverRequest defines model for IconCommissionDeliverRequest.
+type IconCommissionDeliverRequest struct {
+	// AssetKey Sprite file name prefix of the uploaded icon
+	AssetKey string `json:"asset_key"`
+
+	// Reason Reason recorded in the audit log
+	Reason *string `json:"reason,omitempty"`
+}
+
+// IconCommissionListResponse defines model for IconCommissionListResponse.
+type IconCommissionListResponse struct {
+	Commissions []IconCommission `json:"commissions"`
+}
+
+// IconCreationCommandRequest defines model for IconCreationCommandRequest.
+type IconCreationCommandRequest struct {
+	// Points Raziiipo to spend (must equal the commission cost, 1000000)
+	Points int64 `json:"points"`
+
+	// UserName User name from Twitch/YouTube
+	UserName string `json:"user_name"`
+}
+
+// IconCreationCommandResponse defines model for IconCreationCommandResponse.
+type IconCreationCommandResponse struct {
+	// Balance Raziiipo left after the debit
+	Balance int64 `json:"balance"`
+
+	// CommissionId Icon commission ID
+	CommissionId int64 `json:"commission_id"`
+
+	// Cost Raziiipo debited
+	Cost int64 `json:"cost"`
+
+	// UserId User ID
+	UserId int64 `json:"user_id"`
+}
+
 // JoinCommandRequest defines model for JoinCommandRequest.
 type JoinCommandRequest struct {
 	// UserName User name from Twitch/YouTube
@@ -71,6 +370,15 @@ type JoinCommandResponse struct {
 	WorkName *string `json:"work_name,omitempty"`
 }
 
+// KickAllResponse defines model for KickAllResponse.
+type KickAllResponse struct {
+	// Failed Number of sessions that could not be ended
+	Failed int `json:"failed"`
+
+	// SessionIds Sessions ended by this request
+	SessionIds []int64 `json:"session_ids"`
+}
+
 // MoreCommandRequest defines model for MoreCommandRequest.
 type MoreCommandRequest struct {
 	// Minutes Extension duration in minutes (1-360)
@@ -88,170 +396,1476 @@ type MoreCommandResponse struct {
 	// PlannedEnd New planned end time after extension
 	PlannedEnd time.Time `json:"planned_end"`
 
-	// SessionId Extended session ID
-	SessionId int64 `json:"session_id"`
+	// SessionId Extended session ID
+	SessionId int64 `json:"session_id"`
+
+	// UserId User ID
+	UserId int64 `json:"user_id"`
+}
+
+// OutCommandRequest defines model for OutCommandRequest.
+type OutCommandRequest struct {
+	// UserName User name from Twitch/YouTube
+	UserName string `json:"user_name"`
+}
+
+// OutCommandResponse defines model for OutCommandResponse.
+type OutCommandResponse struct {
+	// ActualEnd Session actual end time
+	ActualEnd time.Time `json:"actual_end"`
+
+	// SessionId Ended session ID
+	SessionId int64 `json:"session_id"`
+
+	// UserId User ID
+	UserId int64 `json:"user_id"`
+}
+
+// ReadinessCheckResult defines model for ReadinessCheckResult.
+type ReadinessCheckResult struct {
+	// DurationMs Time the check took
+	DurationMs int64 `json:"duration_ms"`
+
+	// Error Why the check failed
+	Error  *string                    `json:"error,omitempty"`
+	Status ReadinessCheckResultStatus `json:"status"`
+}
+
+// ReadinessCheckResultStatus defines model for ReadinessCheckResult.Status.
+type ReadinessCheckResultStatus string
+
+// ReadinessResponse defines model for ReadinessResponse.
+type ReadinessResponse struct {
+	// Checks Result of each check by name (database, migrations, websocket_hub, session_expiration)
+	Checks map[string]ReadinessCheckResult `json:"checks"`
+	Status ReadinessResponseStatus         `json:"status"`
+}
+
+// ReadinessResponseStatus defines model for ReadinessResponse.Status.
+type ReadinessResponseStatus string
+
+// RuntimeSetting defines model for RuntimeSetting.
+type RuntimeSetting struct {
+	// Default Value from the startup configuration
+	Default string `json:"default"`
+	Key     string `json:"key"`
+
+	// UpdatedAt When the value was changed (omitted when the startup value is used)
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+
+	// UpdatedBy Moderator who changed the value (omitted when the startup value is used)
+	UpdatedBy *string `json:"updated_by,omitempty"`
+
+	// Value Value in effect
+	Value string `json:"value"`
+}
+
+// RuntimeSettingListResponse defines model for RuntimeSettingListResponse.
+type RuntimeSettingListResponse struct {
+	Settings []RuntimeSetting `json:"settings"`
+}
+
+// RuntimeSettingsUpdateRequest defines model for RuntimeSettingsUpdateRequest.
+type RuntimeSettingsUpdateRequest struct {
+	// Settings New values by key
+	Settings map[string]string `json:"settings"`
+}
+
+// SessionInfo defines model for SessionInfo.
+type SessionInfo struct {
+	// IconAssetKey Asset key of the icon (sprite file name prefix, e.g. tier1-01)
+	IconAssetKey *string `json:"icon_asset_key"`
+
+	// IconId Icon ID (optional)
+	IconId *int64 `json:"icon_id"`
+
+	// PlannedEnd Planned end time
+	PlannedEnd time.Time `json:"planned_end"`
+
+	// SessionId Session ID
+	SessionId int64 `json:"session_id"`
+
+	// StartTime Session start time
+	StartTime time.Time `json:"start_time"`
+
+	// Tier User tier (1, 2, or 3)
+	Tier int `json:"tier"`
+
+	// UserId User ID
+	UserId int64 `json:"user_id"`
+
+	// UserName User name
+	UserName string `json:"user_name"`
+
+	// WorkName Work description
+	WorkName string `json:"work_name"`
+}
+
+// SessionRecord defines model for SessionRecord.
+type SessionRecord struct {
+	// ActualEnd Null while the session is active
+	ActualEnd  *time.Time `json:"actual_end"`
+	Id         int64      `json:"id"`
+	PlannedEnd time.Time  `json:"planned_end"`
+	StartTime  time.Time  `json:"start_time"`
+	UserId     int64      `json:"user_id"`
+	WorkName   string     `json:"work_name"`
+}
+
+// SessionRecordCorrectionRequest defines model for SessionRecordCorrectionRequest.
+type SessionRecordCorrectionRequest struct {
+	ActualEnd *time.Time `json:"actual_end,omitempty"`
+
+	// Reason Reason recorded in the audit log
+	Reason    *string    `json:"reason,omitempty"`
+	StartTime *time.Time `json:"start_time,omitempty"`
+	WorkName  *string    `json:"work_name,omitempty"`
+}
+
+// SessionRecordCreateRequest defines model for SessionRecordCreateRequest.
+type SessionRecordCreateRequest struct {
+	ActualEnd time.Time `json:"actual_end"`
+
+	// Reason Reason recorded in the audit log
+	Reason    *string   `json:"reason,omitempty"`
+	StartTime time.Time `json:"start_time"`
+	UserName  string    `json:"user_name"`
+	WorkName  *string   `json:"work_name,omitempty"`
+}
+
+// UserBlockResponse defines model for UserBlockResponse.
+type UserBlockResponse struct {
+	BlockReason string `json:"block_reason"`
+	Blocked     bool   `json:"blocked"`
+	BlockedBy   string `json:"blocked_by"`
+
+	// BlockedUntil Block expiry (9999-12-31 for permanent blocks)
+	BlockedUntil *time.Time `json:"blocked_until"`
+	UserId       int64      `json:"user_id"`
+	UserName     string     `json:"user_name"`
+}
+
+// UserInfoResponse defines model for UserInfoResponse.
+type UserInfoResponse struct {
+	// LifetimeTotalMinutes Total work minutes across all time
+	LifetimeTotalMinutes int `json:"lifetime_total_minutes"`
+
+	// RemainingMinutes Minutes remaining until planned end time
+	RemainingMinutes int `json:"remaining_minutes"`
+
+	// TodayTotalMinutes Total work minutes for today
+	TodayTotalMinutes int `json:"today_total_minutes"`
+
+	// UserId User ID
+	UserId int64 `json:"user_id"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	CreatedAt  time.Time `json:"created_at"`
+	CreatedBy  string    `json:"created_by"`
+	EventTypes []string  `json:"event_types"`
+	Id         int64     `json:"id"`
+
+	// Secret Signing secret (only returned when the subscription is created)
+	Secret    *string   `json:"secret,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Url       string    `json:"url"`
+}
+
+// WebhookCreateRequest defines model for WebhookCreateRequest.
+type WebhookCreateRequest struct {
+	// EventTypes Event types to send (empty or omitted means all events)
+	EventTypes *[]WebhookCreateRequestEventTypes `json:"event_types,omitempty"`
+
+	// Secret Signing secret (generated when omitted)
+	Secret *string `json:"secret,omitempty"`
+
+	// Url Absolute http or https URL that receives the events
+	Url string `json:"url"`
+}
+
+// WebhookCreateRequestEventTypes defines model for WebhookCreateRequest.EventTypes.
+type WebhookCreateRequestEventTypes string
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts  int32     `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// EventId Outbox event ID (same as `event_id` in the payload)
+	EventId   int64  `json:"event_id"`
+	EventType string `json:"event_type"`
+	Id        int64  `json:"id"`
+	LastError string `json:"last_error"`
+
+	// LastStatusCode HTTP status of the last attempt (0 when no response was received)
+	LastStatusCode int32                 `json:"last_status_code"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	WebhookId      int64                 `json:"webhook_id"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// WebhookDeliveryListResponse defines model for WebhookDeliveryListResponse.
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+// WebhookListResponse defines model for WebhookListResponse.
+type WebhookListResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = ErrorResponse
+
+// ListAuditLogsParams defines parameters for ListAuditLogs.
+type ListAuditLogsParams struct {
+	// TargetType Filter by target type (e.g. user, banned_term)
+	TargetType *string `form:"target_type,omitempty" json:"target_type,omitempty"`
+
+	// TargetId Filter by target ID
+	TargetId *string `form:"target_id,omitempty" json:"target_id,omitempty"`
+
+	// Limit Maximum number of entries (default 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListIconCommissionsParams defines parameters for ListIconCommissions.
+type ListIconCommissionsParams struct {
+	// Status Commission status
+	Status *ListIconCommissionsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Limit Maximum number of commissions (default 50)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListIconCommissionsParamsStatus defines parameters for ListIconCommissions.
+type ListIconCommissionsParamsStatus string
+
+// ListDeadOutboxEventsParams defines parameters for ListDeadOutboxEvents.
+type ListDeadOutboxEventsParams struct {
+	// Limit Maximum number of events (default 50)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// DeleteSessionRecordParams defines parameters for DeleteSessionRecord.
+type DeleteSessionRecordParams struct {
+	// Reason Reason recorded in the audit log
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	// Status Delivery status (default dead)
+	Status *ListWebhookDeliveriesParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Limit Maximum number of deliveries (default 50)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListWebhookDeliveriesParamsStatus defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParamsStatus string
+
+// CreateBannedTermJSONRequestBody defines body for CreateBannedTerm for application/json ContentType.
+type CreateBannedTermJSONRequestBody = BannedTermRequest
+
+// UpdateBannedTermJSONRequestBody defines body for UpdateBannedTerm for application/json ContentType.
+type UpdateBannedTermJSONRequestBody = BannedTermRequest
+
+// DeliverIconCommissionJSONRequestBody defines body for DeliverIconCommission for application/json ContentType.
+type DeliverIconCommissionJSONRequestBody = IconCommissionDeliverRequest
+
+// RefundIconCommissionJSONRequestBody defines body for RefundIconCommission for application/json ContentType.
+type RefundIconCommissionJSONRequestBody = AdminActionRequest
+
+// StartIconCommissionJSONRequestBody defines body for StartIconCommission for application/json ContentType.
+type StartIconCommissionJSONRequestBody = AdminActionRequest
+
+// CreateSessionRecordJSONRequestBody defines body for CreateSessionRecord for application/json ContentType.
+type CreateSessionRecordJSONRequestBody = SessionRecordCreateRequest
+
+// KickAllSessionsJSONRequestBody defines body for KickAllSessions for application/json ContentType.
+type KickAllSessionsJSONRequestBody = AdminActionRequest
+
+// CorrectSessionRecordJSONRequestBody defines body for CorrectSessionRecord for application/json ContentType.
+type CorrectSessionRecordJSONRequestBody = SessionRecordCorrectionRequest
+
+// ForceOutSessionJSONRequestBody defines body for ForceOutSession for application/json ContentType.
+type ForceOutSessionJSONRequestBody = AdminActionRequest
+
+// UpdateRuntimeSettingsJSONRequestBody defines body for UpdateRuntimeSettings for application/json ContentType.
+type UpdateRuntimeSettingsJSONRequestBody = RuntimeSettingsUpdateRequest
+
+// BlockUserJSONRequestBody defines body for BlockUser for application/json ContentType.
+type BlockUserJSONRequestBody = BlockUserRequest
+
+// UnblockUserJSONRequestBody defines body for UnblockUser for application/json ContentType.
+type UnblockUserJSONRequestBody = AdminActionRequest
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = WebhookCreateRequest
+
+// ChangeCommandJSONRequestBody defines body for ChangeCommand for application/json ContentType.
+type ChangeCommandJSONRequestBody = ChangeCommandRequest
+
+// CheerCommandJSONRequestBody defines body for CheerCommand for application/json ContentType.
+type CheerCommandJSONRequestBody = CheerCommandRequest
+
+// CommentCommandJSONRequestBody defines body for CommentCommand for application/json ContentType.
+type CommentCommandJSONRequestBody = CommentCommandRequest
+
+// IconCreationCommandJSONRequestBody defines body for IconCreationCommand for application/json ContentType.
+type IconCreationCommandJSONRequestBody = IconCreationCommandRequest
+
+// JoinCommandJSONRequestBody defines body for JoinCommand for application/json ContentType.
+type JoinCommandJSONRequestBody = JoinCommandRequest
+
+// MoreCommandJSONRequestBody defines body for MoreCommand for application/json ContentType.
+type MoreCommandJSONRequestBody = MoreCommandRequest
+
+// OutCommandJSONRequestBody defines body for OutCommand for application/json ContentType.
+type OutCommandJSONRequestBody = OutCommandRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List audit log entries
+	// (GET /api/admin/audit-logs)
+	ListAuditLogs(w http.ResponseWriter, r *http.Request, params ListAuditLogsParams)
+	// List banned terms
+	// (GET /api/admin/banned-terms)
+	ListBannedTerms(w http.ResponseWriter, r *http.Request)
+	// Register a banned term
+	// (POST /api/admin/banned-terms)
+	CreateBannedTerm(w http.ResponseWriter, r *http.Request)
+	// Delete a banned term
+	// (DELETE /api/admin/banned-terms/{id})
+	DeleteBannedTerm(w http.ResponseWriter, r *http.Request, id int64)
+	// Update a banned term
+	// (PUT /api/admin/banned-terms/{id})
+	UpdateBannedTerm(w http.ResponseWriter, r *http.Request, id int64)
+	// List icon commissions
+	// (GET /api/admin/icon-commissions)
+	ListIconCommissions(w http.ResponseWriter, r *http.Request, params ListIconCommissionsParams)
+	// Deliver an icon commission
+	// (POST /api/admin/icon-commissions/{id}/deliver)
+	DeliverIconCommission(w http.ResponseWriter, r *http.Request, id int64)
+	// Refund an icon commission
+	// (POST /api/admin/icon-commissions/{id}/refund)
+	RefundIconCommission(w http.ResponseWriter, r *http.Request, id int64)
+	// Start working on an icon commission
+	// (POST /api/admin/icon-commissions/{id}/start)
+	StartIconCommission(w http.ResponseWriter, r *http.Request, id int64)
+	// List dead-lettered outbox events
+	// (GET /api/admin/outbox/dead-events)
+	ListDeadOutboxEvents(w http.ResponseWriter, r *http.Request, params ListDeadOutboxEventsParams)
+	// Add a missing session record
+	// (POST /api/admin/sessions)
+	CreateSessionRecord(w http.ResponseWriter, r *http.Request)
+	// Force-end all active sessions
+	// (POST /api/admin/sessions/kick-all)
+	KickAllSessions(w http.ResponseWriter, r *http.Request)
+	// Delete a session record
+	// (DELETE /api/admin/sessions/{id})
+	DeleteSessionRecord(w http.ResponseWriter, r *http.Request, id int64, params DeleteSessionRecordParams)
+	// Correct a session record
+	// (PATCH /api/admin/sessions/{id})
+	CorrectSessionRecord(w http.ResponseWriter, r *http.Request, id int64)
+	// Force-end a session
+	// (POST /api/admin/sessions/{id}/force-out)
+	ForceOutSession(w http.ResponseWriter, r *http.Request, id int64)
+	// List runtime settings
+	// (GET /api/admin/settings)
+	ListRuntimeSettings(w http.ResponseWriter, r *http.Request)
+	// Change runtime settings
+	// (PATCH /api/admin/settings)
+	UpdateRuntimeSettings(w http.ResponseWriter, r *http.Request)
+	// Reset a runtime setting
+	// (DELETE /api/admin/settings/{key})
+	ResetRuntimeSetting(w http.ResponseWriter, r *http.Request, key string)
+	// Block a user
+	// (POST /api/admin/users/{user_name}/block)
+	BlockUser(w http.ResponseWriter, r *http.Request, userName string)
+	// Unblock a user
+	// (POST /api/admin/users/{user_name}/unblock)
+	UnblockUser(w http.ResponseWriter, r *http.Request, userName string)
+	// List webhook subscriptions
+	// (GET /api/admin/webhooks)
+	ListWebhooks(w http.ResponseWriter, r *http.Request)
+	// Register a webhook subscription
+	// (POST /api/admin/webhooks)
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	// List webhook deliveries
+	// (GET /api/admin/webhooks/deliveries)
+	ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, params ListWebhookDeliveriesParams)
+	// Retry a dead webhook delivery
+	// (POST /api/admin/webhooks/deliveries/{id}/retry)
+	RetryWebhookDelivery(w http.ResponseWriter, r *http.Request, id int64)
+	// Delete a webhook subscription
+	// (DELETE /api/admin/webhooks/{id})
+	DeleteWebhook(w http.ResponseWriter, r *http.Request, id int64)
+	// Change command (/change)
+	// (POST /api/commands/change)
+	ChangeCommand(w http.ResponseWriter, r *http.Request)
+	// Cheer command (/cheer @user)
+	// (POST /api/commands/cheer)
+	CheerCommand(w http.ResponseWriter, r *http.Request)
+	// Comment (chat message shown in the overlay)
+	// (POST /api/commands/comment)
+	CommentCommand(w http.ResponseWriter, r *http.Request)
+	// Icon creation command (/icon_creation)
+	// (POST /api/commands/icon_creation)
+	IconCreationCommand(w http.ResponseWriter, r *http.Request)
+	// Join command (/in)
+	// (POST /api/commands/join)
+	JoinCommand(w http.ResponseWriter, r *http.Request)
+	// More command (/more)
+	// (POST /api/commands/more)
+	MoreCommand(w http.ResponseWriter, r *http.Request)
+	// Out command (/out)
+	// (POST /api/commands/out)
+	OutCommand(w http.ResponseWriter, r *http.Request)
+	// Get all active sessions
+	// (GET /api/sessions/active)
+	GetActiveSessions(w http.ResponseWriter, r *http.Request)
+	// Get user info (/info)
+	// (GET /api/users/{user_name}/info)
+	GetUserInfo(w http.ResponseWriter, r *http.Request, userName string)
+	// Health check endpoint
+	// (GET /health)
+	HealthCheck(w http.ResponseWriter, r *http.Request)
+	// Liveness probe
+	// (GET /livez)
+	Livez(w http.ResponseWriter, r *http.Request)
+	// Readiness probe
+	// (GET /readyz)
+	Readyz(w http.ResponseWriter, r *http.Request)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// List audit log entries
+// (GET /api/admin/audit-logs)
+func (_ Unimplemented) ListAuditLogs(w http.ResponseWriter, r *http.Request, params ListAuditLogsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List banned terms
+// (GET /api/admin/banned-terms)
+func (_ Unimplemented) ListBannedTerms(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Register a banned term
+// (POST /api/admin/banned-terms)
+func (_ Unimplemented) CreateBannedTerm(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a banned term
+// (DELETE /api/admin/banned-terms/{id})
+func (_ Unimplemented) DeleteBannedTerm(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a banned term
+// (PUT /api/admin/banned-terms/{id})
+func (_ Unimplemented) UpdateBannedTerm(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List icon commissions
+// (GET /api/admin/icon-commissions)
+func (_ Unimplemented) ListIconCommissions(w http.ResponseWriter, r *http.Request, params ListIconCommissionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Deliver an icon commission
+// (POST /api/admin/icon-commissions/{id}/deliver)
+func (_ Unimplemented) DeliverIconCommission(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Refund an icon commission
+// (POST /api/admin/icon-commissions/{id}/refund)
+func (_ Unimplemented) RefundIconCommission(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start working on an icon commission
+// (POST /api/admin/icon-commissions/{id}/start)
+func (_ Unimplemented) StartIconCommission(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List dead-lettered outbox events
+// (GET /api/admin/outbox/dead-events)
+func (_ Unimplemented) ListDeadOutboxEvents(w http.ResponseWriter, r *http.Request, params ListDeadOutboxEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add a missing session record
+// (POST /api/admin/sessions)
+func (_ Unimplemented) CreateSessionRecord(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Force-end all active sessions
+// (POST /api/admin/sessions/kick-all)
+func (_ Unimplemented) KickAllSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a session record
+// (DELETE /api/admin/sessions/{id})
+func (_ Unimplemented) DeleteSessionRecord(w http.ResponseWriter, r *http.Request, id int64, params DeleteSessionRecordParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Correct a session record
+// (PATCH /api/admin/sessions/{id})
+func (_ Unimplemented) CorrectSessionRecord(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Force-end a session
+// (POST /api/admin/sessions/{id}/force-out)
+func (_ Unimplemented) ForceOutSession(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List runtime settings
+// (GET /api/admin/settings)
+func (_ Unimplemented) ListRuntimeSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change runtime settings
+// (PATCH /api/admin/settings)
+func (_ Unimplemented) UpdateRuntimeSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reset a runtime setting
+// (DELETE /api/admin/settings/{key})
+func (_ Unimplemented) ResetRuntimeSetting(w http.ResponseWriter, r *http.Request, key string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Block a user
+// (POST /api/admin/users/{user_name}/block)
+func (_ Unimplemented) BlockUser(w http.ResponseWriter, r *http.Request, userName string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Unblock a user
+// (POST /api/admin/users/{user_name}/unblock)
+func (_ Unimplemented) UnblockUser(w http.ResponseWriter, r *http.Request, userName string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List webhook subscriptions
+// (GET /api/admin/webhooks)
+func (_ Unimplemented) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Register a webhook subscription
+// (POST /api/admin/webhooks)
+func (_ Unimplemented) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List webhook deliveries
+// (GET /api/admin/webhooks/deliveries)
+func (_ Unimplemented) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, params ListWebhookDeliveriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Retry a dead webhook delivery
+// (POST /api/admin/webhooks/deliveries/{id}/retry)
+func (_ Unimplemented) RetryWebhookDelivery(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a webhook subscription
+// (DELETE /api/admin/webhooks/{id})
+func (_ Unimplemented) DeleteWebhook(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change command (/change)
+// (POST /api/commands/change)
+func (_ Unimplemented) ChangeCommand(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cheer command (/cheer @user)
+// (POST /api/commands/cheer)
+func (_ Unimplemented) CheerCommand(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Comment (chat message shown in the overlay)
+// (POST /api/commands/comment)
+func (_ Unimplemented) CommentCommand(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Icon creation command (/icon_creation)
+// (POST /api/commands/icon_creation)
+func (_ Unimplemented) IconCreationCommand(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Join command (/in)
+// (POST /api/commands/join)
+func (_ Unimplemented) JoinCommand(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// More command (/more)
+// (POST /api/commands/more)
+func (_ Unimplemented) MoreCommand(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Out command (/out)
+// (POST /api/commands/out)
+func (_ Unimplemented) OutCommand(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get all active sessions
+// (GET /api/sessions/active)
+func (_ Unimplemented) GetActiveSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user info (/info)
+// (GET /api/users/{user_name}/info)
+func (_ Unimplemented) GetUserInfo(w http.ResponseWriter, r *http.Request, userName string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Health check endpoint
+// (GET /health)
+func (_ Unimplemented) HealthCheck(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Liveness probe
+// (GET /livez)
+func (_ Unimplemented) Livez(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Readiness probe
+// (GET /readyz)
+func (_ Unimplemented) Readyz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListAuditLogs operation middleware
+func (siw *ServerInterfaceWrapper) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuditLogsParams
+
+	// ------------- Optional query parameter "target_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target_type", r.URL.Query(), &params.TargetType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target_type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "target_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target_id", r.URL.Query(), &params.TargetId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAuditLogs(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListBannedTerms operation middleware
+func (siw *ServerInterfaceWrapper) ListBannedTerms(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBannedTerms(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateBannedTerm operation middleware
+func (siw *ServerInterfaceWrapper) CreateBannedTerm(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBannedTerm(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBannedTerm operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBannedTerm(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBannedTerm(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateBannedTerm operation middleware
+func (siw *ServerInterfaceWrapper) UpdateBannedTerm(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateBannedTerm(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListIconCommissions operation middleware
+func (siw *ServerInterfaceWrapper) ListIconCommissions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListIconCommissionsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListIconCommissions(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeliverIconCommission operation middleware
+func (siw *ServerInterfaceWrapper) DeliverIconCommission(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeliverIconCommission(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RefundIconCommission operation middleware
+func (siw *ServerInterfaceWrapper) RefundIconCommission(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefundIconCommission(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StartIconCommission operation middleware
+func (siw *ServerInterfaceWrapper) StartIconCommission(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartIconCommission(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListDeadOutboxEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListDeadOutboxEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDeadOutboxEventsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDeadOutboxEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateSessionRecord operation middleware
+func (siw *ServerInterfaceWrapper) CreateSessionRecord(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSessionRecord(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// KickAllSessions operation middleware
+func (siw *ServerInterfaceWrapper) KickAllSessions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.KickAllSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteSessionRecord operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSessionRecord(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteSessionRecordParams
+
+	// ------------- Optional query parameter "reason" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reason", r.URL.Query(), &params.Reason)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reason", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSessionRecord(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CorrectSessionRecord operation middleware
+func (siw *ServerInterfaceWrapper) CorrectSessionRecord(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CorrectSessionRecord(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ForceOutSession operation middleware
+func (siw *ServerInterfaceWrapper) ForceOutSession(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ForceOutSession(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListRuntimeSettings operation middleware
+func (siw *ServerInterfaceWrapper) ListRuntimeSettings(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRuntimeSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateRuntimeSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateRuntimeSettings(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateRuntimeSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ResetRuntimeSetting operation middleware
+func (siw *ServerInterfaceWrapper) ResetRuntimeSetting(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "key" -------------
+	var key string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key", chi.URLParam(r, "key"), &key, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetRuntimeSetting(w, r, key)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BlockUser operation middleware
+func (siw *ServerInterfaceWrapper) BlockUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_name" -------------
+	var userName string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_name", chi.URLParam(r, "user_name"), &userName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BlockUser(w, r, userName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnblockUser operation middleware
+func (siw *ServerInterfaceWrapper) UnblockUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_name" -------------
+	var userName string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_name", chi.URLParam(r, "user_name"), &userName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnblockUser(w, r, userName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhooks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWebhook(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookDeliveries(w, r, params)
+	}))
 
-	// UserId User ID
-	UserId int64 `json:"user_id"`
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// OutCommandRequest defines model for OutCommandRequest.
-type OutCommandRequest struct {
-	// UserName User name from Twitch/YouTube
-	UserName string `json:"user_name"`
+	handler.ServeHTTP(w, r)
 }
 
-// OutCommandResponse defines model for OutCommandResponse.
-type OutCommandResponse struct {
-	// ActualEnd Session actual end time
-	ActualEnd time.Time `json:"actual_end"`
-
-	// SessionId Ended session ID
-	SessionId int64 `json:"session_id"`
+// RetryWebhookDelivery operation middleware
+func (siw *ServerInterfaceWrapper) RetryWebhookDelivery(w http.ResponseWriter, r *http.Request) {
 
-	// UserId User ID
-	UserId int64 `json:"user_id"`
-}
+	var err error
 
-// SessionInfo defines model for SessionInfo.
-type SessionInfo struct {
-	// IconId Icon ID (optional)
-	IconId *int64 `json:"icon_id"`
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-	// PlannedEnd Planned end time
-	PlannedEnd time.Time `json:"planned_end"`
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-	// SessionId Session ID
-	SessionId int64 `json:"session_id"`
+	ctx := r.Context()
 
-	// StartTime Session start time
-	StartTime time.Time `json:"start_time"`
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
 
-	// Tier User tier (1, 2, or 3)
-	Tier int `json:"tier"`
+	r = r.WithContext(ctx)
 
-	// UserId User ID
-	UserId int64 `json:"user_id"`
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RetryWebhookDelivery(w, r, id)
+	}))
 
-	// UserName User name
-	UserName string `json:"user_name"`
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	// WorkName Work description
-	WorkName string `json:"work_name"`
+	handler.ServeHTTP(w, r)
 }
 
-// UserInfoResponse defines model for UserInfoResponse.
-type UserInfoResponse struct {
-	// LifetimeTotalMinutes Total work minutes across all time
-	LifetimeTotalMinutes int `json:"lifetime_total_minutes"`
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
 
-	// RemainingMinutes Minutes remaining until planned end time
-	RemainingMinutes int `json:"remaining_minutes"`
+	var err error
 
-	// TodayTotalMinutes Total work minutes for today
-	TodayTotalMinutes int `json:"today_total_minutes"`
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-	// UserId User ID
-	UserId int64 `json:"user_id"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-// ChangeCommandJSONRequestBody defines body for ChangeCommand for application/json ContentType.
-type ChangeCommandJSONRequestBody = ChangeCommandRequest
+	ctx := r.Context()
 
-// JoinCommandJSONRequestBody defines body for JoinCommand for application/json ContentType.
-type JoinCommandJSONRequestBody = JoinCommandRequest
+	ctx = context.WithValue(ctx, AdminTokenScopes, []string{})
 
-// MoreCommandJSONRequestBody defines body for MoreCommand for application/json ContentType.
-type MoreCommandJSONRequestBody = MoreCommandRequest
+	r = r.WithContext(ctx)
 
-// OutCommandJSONRequestBody defines body for OutCommand for application/json ContentType.
-type OutCommandJSONRequestBody = OutCommandRequest
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhook(w, r, id)
+	}))
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Change command (/change)
-	// (POST /api/commands/change)
-	ChangeCommand(w http.ResponseWriter, r *http.Request)
-	// Join command (/in)
-	// (POST /api/commands/join)
-	JoinCommand(w http.ResponseWriter, r *http.Request)
-	// More command (/more)
-	// (POST /api/commands/more)
-	MoreCommand(w http.ResponseWriter, r *http.Request)
-	// Out command (/out)
-	// (POST /api/commands/out)
-	OutCommand(w http.ResponseWriter, r *http.Request)
-	// Get all active sessions
-	// (GET /api/sessions/active)
-	GetActiveSessions(w http.ResponseWriter, r *http.Request)
-	// Get user info (/info)
-	// (GET /api/users/{user_name}/info)
-	GetUserInfo(w http.ResponseWriter, r *http.Request, userName string)
-	// Health check endpoint
-	// (GET /health)
-	HealthCheck(w http.ResponseWriter, r *http.Request)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+// ChangeCommand operation middleware
+func (siw *ServerInterfaceWrapper) ChangeCommand(w http.ResponseWriter, r *http.Request) {
 
-type Unimplemented struct{}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ChangeCommand(w, r)
+	}))
 
-// Change command (/change)
-// (POST /api/commands/change)
-func (_ Unimplemented) ChangeCommand(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// Join command (/in)
-// (POST /api/commands/join)
-func (_ Unimplemented) JoinCommand(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+	handler.ServeHTTP(w, r)
 }
 
-// More command (/more)
-// (POST /api/commands/more)
-func (_ Unimplemented) MoreCommand(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// CheerCommand operation middleware
+func (siw *ServerInterfaceWrapper) CheerCommand(w http.ResponseWriter, r *http.Request) {
 
-// Out command (/out)
-// (POST /api/commands/out)
-func (_ Unimplemented) OutCommand(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CheerCommand(w, r)
+	}))
 
-// Get all active sessions
-// (GET /api/sessions/active)
-func (_ Unimplemented) GetActiveSessions(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// Get user info (/info)
-// (GET /api/users/{user_name}/info)
-func (_ Unimplemented) GetUserInfo(w http.ResponseWriter, r *http.Request, userName string) {
-	w.WriteHeader(http.StatusNotImplemented)
+	handler.ServeHTTP(w, r)
 }
 
-// Health check endpoint
-// (GET /health)
-func (_ Unimplemented) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// CommentCommand operation middleware
+func (siw *ServerInterfaceWrapper) CommentCommand(w http.ResponseWriter, r *http.Request) {
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CommentCommand(w, r)
+	}))
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// ChangeCommand operation middleware
-func (siw *ServerInterfaceWrapper) ChangeCommand(w http.ResponseWriter, r *http.Request) {
+	handler.ServeHTTP(w, r)
+}
+
+// IconCreationCommand operation middleware
+func (siw *ServerInterfaceWrapper) IconCreationCommand(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ChangeCommand(w, r)
+		siw.Handler.IconCreationCommand(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -356,6 +1970,34 @@ func (siw *ServerInterfaceWrapper) HealthCheck(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
+// Livez operation middleware
+func (siw *ServerInterfaceWrapper) Livez(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Livez(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Readyz operation middleware
+func (siw *ServerInterfaceWrapper) Readyz(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Readyz(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -469,9 +2111,93 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/audit-logs", wrapper.ListAuditLogs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/banned-terms", wrapper.ListBannedTerms)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/banned-terms", wrapper.CreateBannedTerm)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/banned-terms/{id}", wrapper.DeleteBannedTerm)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/admin/banned-terms/{id}", wrapper.UpdateBannedTerm)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/icon-commissions", wrapper.ListIconCommissions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/icon-commissions/{id}/deliver", wrapper.DeliverIconCommission)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/icon-commissions/{id}/refund", wrapper.RefundIconCommission)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/icon-commissions/{id}/start", wrapper.StartIconCommission)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/outbox/dead-events", wrapper.ListDeadOutboxEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/sessions", wrapper.CreateSessionRecord)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/sessions/kick-all", wrapper.KickAllSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/sessions/{id}", wrapper.DeleteSessionRecord)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/admin/sessions/{id}", wrapper.CorrectSessionRecord)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/sessions/{id}/force-out", wrapper.ForceOutSession)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/settings", wrapper.ListRuntimeSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/admin/settings", wrapper.UpdateRuntimeSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/settings/{key}", wrapper.ResetRuntimeSetting)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/users/{user_name}/block", wrapper.BlockUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/users/{user_name}/unblock", wrapper.UnblockUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/webhooks", wrapper.ListWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/webhooks", wrapper.CreateWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/webhooks/deliveries", wrapper.ListWebhookDeliveries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/webhooks/deliveries/{id}/retry", wrapper.RetryWebhookDelivery)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/webhooks/{id}", wrapper.DeleteWebhook)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/commands/change", wrapper.ChangeCommand)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/commands/cheer", wrapper.CheerCommand)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/commands/comment", wrapper.CommentCommand)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/commands/icon_creation", wrapper.IconCreationCommand)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/commands/join", wrapper.JoinCommand)
 	})
@@ -490,6 +2216,12 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/health", wrapper.HealthCheck)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/livez", wrapper.Livez)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/readyz", wrapper.Readyz)
+	})
 
 	return r
 }
@@ -0,0 +1,864 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/presentation/http/dto"
+	"github.com/yamada-ai/workspace-backend/presentation/http/middleware"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+	"github.com/yamada-ai/workspace-backend/usecase/moderation"
+	"github.com/yamada-ai/workspace-backend/usecase/query"
+	"github.com/yamada-ai/workspace-backend/usecase/settings"
+	"github.com/yamada-ai/workspace-backend/usecase/webhook"
+)
+
+// AdminHandler handles admin (moderation) requests
+// Authentication is done by middleware.AdminAuthMiddleware
+type AdminHandler struct {
+	moderationService    *moderation.Service
+	listAuditLogsUseCase *query.ListAuditLogsUseCase
+	forceOutUseCase      *command.ForceOutCommandUseCase
+	kickAllUseCase       *command.KickAllCommandUseCase
+	correctionUseCase    *command.SessionCorrectionUseCase
+	webhookService       *webhook.Service
+	iconUseCase          *command.IconCommissionUseCase
+	settingsService      *settings.Service
+	deadEventsUseCase    *query.ListDeadOutboxEventsUseCase
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(
+	moderationService *moderation.Service,
+	listAuditLogsUseCase *query.ListAuditLogsUseCase,
+	forceOutUseCase *command.ForceOutCommandUseCase,
+	kickAllUseCase *command.KickAllCommandUseCase,
+	correctionUseCase *command.SessionCorrectionUseCase,
+	webhookService *webhook.Service,
+	iconUseCase *command.IconCommissionUseCase,
+	settingsService *settings.Service,
+	deadEventsUseCase *query.ListDeadOutboxEventsUseCase,
+) *AdminHandler {
+	return &AdminHandler{
+		moderationService:    moderationService,
+		listAuditLogsUseCase: listAuditLogsUseCase,
+		forceOutUseCase:      forceOutUseCase,
+		kickAllUseCase:       kickAllUseCase,
+		correctionUseCase:    correctionUseCase,
+		webhookService:       webhookService,
+		iconUseCase:          iconUseCase,
+		settingsService:      settingsService,
+		deadEventsUseCase:    deadEventsUseCase,
+	}
+}
+
+// ListBannedTerms handles GET /api/admin/banned-terms
+func (h *AdminHandler) ListBannedTerms(w http.ResponseWriter, r *http.Request) {
+	terms, err := h.moderationService.ListTerms(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list banned terms: "+err.Error())
+		return
+	}
+
+	resp := dto.BannedTermListResponse{
+		Terms: make([]dto.BannedTerm, 0, len(terms)),
+	}
+	for _, term := range terms {
+		resp.Terms = append(resp.Terms, toBannedTermDTO(term))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CreateBannedTerm handles POST /api/admin/banned-terms
+func (h *AdminHandler) CreateBannedTerm(w http.ResponseWriter, r *http.Request) {
+	var req dto.BannedTermRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	term, err := h.moderationService.CreateTerm(r.Context(), toTermInput(req), actor)
+	if err != nil {
+		writeBannedTermError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toBannedTermDTO(term))
+}
+
+// UpdateBannedTerm handles PUT /api/admin/banned-terms/{id}
+func (h *AdminHandler) UpdateBannedTerm(w http.ResponseWriter, r *http.Request, id int64) {
+	var req dto.BannedTermRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	term, err := h.moderationService.UpdateTerm(r.Context(), id, toTermInput(req), actor)
+	if err != nil {
+		writeBannedTermError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toBannedTermDTO(term))
+}
+
+// DeleteBannedTerm handles DELETE /api/admin/banned-terms/{id}
+func (h *AdminHandler) DeleteBannedTerm(w http.ResponseWriter, r *http.Request, id int64) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	if err := h.moderationService.DeleteTerm(r.Context(), id, actor); err != nil {
+		writeBannedTermError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAuditLogs handles GET /api/admin/audit-logs
+func (h *AdminHandler) ListAuditLogs(w http.ResponseWriter, r *http.Request, params dto.ListAuditLogsParams) {
+	input := query.ListAuditLogsInput{}
+	if params.TargetType != nil {
+		input.TargetType = *params.TargetType
+	}
+	if params.TargetId != nil {
+		input.TargetID = *params.TargetId
+	}
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > query.MaxAuditLogLimit {
+			writeError(w, http.StatusBadRequest, "limit must be between 1 and 1000")
+			return
+		}
+		input.Limit = *params.Limit
+	}
+
+	output, err := h.listAuditLogsUseCase.Execute(r.Context(), input)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list audit logs: "+err.Error())
+		return
+	}
+
+	resp := dto.AuditLogListResponse{
+		Logs: make([]dto.AuditLog, 0, len(output.Logs)),
+	}
+	for _, log := range output.Logs {
+		resp.Logs = append(resp.Logs, toAuditLogDTO(log))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// BlockUser handles POST /api/admin/users/{user_name}/block
+func (h *AdminHandler) BlockUser(w http.ResponseWriter, r *http.Request, userName string) {
+	var req dto.BlockUserRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+
+	var duration time.Duration
+	if req.DurationMinutes != nil {
+		if *req.DurationMinutes < 0 {
+			writeError(w, http.StatusBadRequest, "duration_minutes must not be negative")
+			return
+		}
+		duration = time.Duration(*req.DurationMinutes) * time.Minute
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	user, err := h.moderationService.BlockUser(r.Context(), userName, stringValue(req.Reason), duration, actor)
+	if err != nil {
+		writeUserBlockError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toUserBlockDTO(user))
+}
+
+// UnblockUser handles POST /api/admin/users/{user_name}/unblock
+func (h *AdminHandler) UnblockUser(w http.ResponseWriter, r *http.Request, userName string) {
+	var req dto.AdminActionRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	user, err := h.moderationService.UnblockUser(r.Context(), userName, stringValue(req.Reason), actor)
+	if err != nil {
+		writeUserBlockError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toUserBlockDTO(user))
+}
+
+// ForceOutSession handles POST /api/admin/sessions/{id}/force-out
+func (h *AdminHandler) ForceOutSession(w http.ResponseWriter, r *http.Request, id int64) {
+	var req dto.AdminActionRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+
+	output, err := h.forceOutUseCase.Execute(r.Context(), command.ForceOutCommandInput{
+		SessionID: id,
+		Actor:     middleware.AdminActorFromContext(r.Context()),
+		Reason:    stringValue(req.Reason),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSessionNotFound):
+			writeError(w, http.StatusNotFound, "セッションが見つかりません。")
+		case errors.Is(err, domain.ErrSessionAlreadyCompleted):
+			writeError(w, http.StatusConflict, "既に完了したセッションです。")
+		default:
+			writeError(w, http.StatusInternalServerError, "Failed to end session: "+err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.ForceOutResponse{
+		SessionId: output.SessionID,
+		UserId:    output.UserID,
+		ActualEnd: output.ActualEnd,
+	})
+}
+
+// KickAllSessions handles POST /api/admin/sessions/kick-all
+func (h *AdminHandler) KickAllSessions(w http.ResponseWriter, r *http.Request) {
+	var req dto.AdminActionRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+
+	output, err := h.kickAllUseCase.Execute(r.Context(), command.KickAllCommandInput{
+		Actor:  middleware.AdminActorFromContext(r.Context()),
+		Reason: stringValue(req.Reason),
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to end sessions: "+err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.KickAllResponse{
+		SessionIds: output.SessionIDs,
+		Failed:     output.Failed,
+	})
+}
+
+// CreateSessionRecord handles POST /api/admin/sessions
+func (h *AdminHandler) CreateSessionRecord(w http.ResponseWriter, r *http.Request) {
+	var req dto.SessionRecordCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if req.UserName == "" {
+		writeError(w, http.StatusBadRequest, "user_name is required")
+		return
+	}
+
+	session, err := h.correctionUseCase.Create(r.Context(), command.CreateManualSessionInput{
+		UserName:  req.UserName,
+		WorkName:  stringValue(req.WorkName),
+		StartTime: req.StartTime.UTC(),
+		ActualEnd: req.ActualEnd.UTC(),
+		Actor:     middleware.AdminActorFromContext(r.Context()),
+		Reason:    stringValue(req.Reason),
+	})
+	if err != nil {
+		writeSessionCorrectionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toSessionRecordDTO(session))
+}
+
+// CorrectSessionRecord handles PATCH /api/admin/sessions/{id}
+func (h *AdminHandler) CorrectSessionRecord(w http.ResponseWriter, r *http.Request, id int64) {
+	var req dto.SessionRecordCorrectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	session, err := h.correctionUseCase.Correct(r.Context(), command.CorrectSessionInput{
+		SessionID: id,
+		StartTime: utcTime(req.StartTime),
+		ActualEnd: utcTime(req.ActualEnd),
+		WorkName:  req.WorkName,
+		Actor:     middleware.AdminActorFromContext(r.Context()),
+		Reason:    stringValue(req.Reason),
+	})
+	if err != nil {
+		writeSessionCorrectionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toSessionRecordDTO(session))
+}
+
+// DeleteSessionRecord handles DELETE /api/admin/sessions/{id}
+func (h *AdminHandler) DeleteSessionRecord(w http.ResponseWriter, r *http.Request, id int64, params dto.DeleteSessionRecordParams) {
+	err := h.correctionUseCase.Delete(r.Context(), command.DeleteSessionInput{
+		SessionID: id,
+		Actor:     middleware.AdminActorFromContext(r.Context()),
+		Reason:    stringValue(params.Reason),
+	})
+	if err != nil {
+		writeSessionCorrectionError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhooks handles GET /api/admin/webhooks
+func (h *AdminHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.webhookService.ListSubscriptions(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list webhooks: "+err.Error())
+		return
+	}
+
+	resp := dto.WebhookListResponse{
+		Webhooks: make([]dto.Webhook, 0, len(subs)),
+	}
+	for _, sub := range subs {
+		resp.Webhooks = append(resp.Webhooks, toWebhookDTO(sub))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CreateWebhook handles POST /api/admin/webhooks
+func (h *AdminHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req dto.WebhookCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	input := webhook.SubscriptionInput{
+		URL:    req.Url,
+		Secret: stringValue(req.Secret),
+	}
+	if req.EventTypes != nil {
+		for _, eventType := range *req.EventTypes {
+			input.EventTypes = append(input.EventTypes, string(eventType))
+		}
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	sub, err := h.webhookService.CreateSubscription(r.Context(), input, actor)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	// シークレットは登録時のレスポンスでのみ返す
+	resp := toWebhookDTO(sub)
+	resp.Secret = &sub.Secret
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// DeleteWebhook handles DELETE /api/admin/webhooks/{id}
+func (h *AdminHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request, id int64) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	if err := h.webhookService.DeleteSubscription(r.Context(), id, actor); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries handles GET /api/admin/webhooks/deliveries
+func (h *AdminHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, params dto.ListWebhookDeliveriesParams) {
+	status := domain.WebhookDeliveryDead
+	if params.Status != nil {
+		status = domain.WebhookDeliveryStatus(*params.Status)
+		if !status.Valid() {
+			writeError(w, http.StatusBadRequest, "status must be one of pending, delivered, dead")
+			return
+		}
+	}
+	var limit int32
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > webhook.MaxListLimit {
+			writeError(w, http.StatusBadRequest, "limit must be between 1 and 500")
+			return
+		}
+		limit = int32(*params.Limit)
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), status, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list webhook deliveries: "+err.Error())
+		return
+	}
+
+	resp := dto.WebhookDeliveryListResponse{
+		Deliveries: make([]dto.WebhookDelivery, 0, len(deliveries)),
+	}
+	for _, delivery := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, toWebhookDeliveryDTO(delivery))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// RetryWebhookDelivery handles POST /api/admin/webhooks/deliveries/{id}/retry
+func (h *AdminHandler) RetryWebhookDelivery(w http.ResponseWriter, r *http.Request, id int64) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	delivery, err := h.webhookService.RetryDelivery(r.Context(), id, actor)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toWebhookDeliveryDTO(delivery))
+}
+
+// ListDeadOutboxEvents handles GET /api/admin/outbox/dead-events
+func (h *AdminHandler) ListDeadOutboxEvents(w http.ResponseWriter, r *http.Request, params dto.ListDeadOutboxEventsParams) {
+	input := query.ListDeadOutboxEventsInput{}
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > query.MaxDeadOutboxEventLimit {
+			writeError(w, http.StatusBadRequest, "limit must be between 1 and 500")
+			return
+		}
+		input.Limit = *params.Limit
+	}
+
+	output, err := h.deadEventsUseCase.Execute(r.Context(), input)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list dead outbox events: "+err.Error())
+		return
+	}
+
+	resp := dto.DeadOutboxEventListResponse{
+		Events: make([]dto.DeadOutboxEvent, 0, len(output.Events)),
+	}
+	for _, event := range output.Events {
+		resp.Events = append(resp.Events, toDeadOutboxEventDTO(event))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ListIconCommissions handles GET /api/admin/icon-commissions
+func (h *AdminHandler) ListIconCommissions(w http.ResponseWriter, r *http.Request, params dto.ListIconCommissionsParams) {
+	var status domain.IconCommissionStatus
+	if params.Status != nil {
+		status = domain.IconCommissionStatus(*params.Status)
+		if !status.Valid() {
+			writeError(w, http.StatusBadRequest, "status must be one of pending, in_progress, delivered, refunded")
+			return
+		}
+	}
+	var limit int32
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > command.MaxIconCommissionListLimit {
+			writeError(w, http.StatusBadRequest, "limit must be between 1 and 500")
+			return
+		}
+		limit = int32(*params.Limit)
+	}
+
+	commissions, err := h.iconUseCase.List(r.Context(), status, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list icon commissions: "+err.Error())
+		return
+	}
+
+	resp := dto.IconCommissionListResponse{
+		Commissions: make([]dto.IconCommission, 0, len(commissions)),
+	}
+	for _, commission := range commissions {
+		resp.Commissions = append(resp.Commissions, toIconCommissionDTO(commission))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// StartIconCommission handles POST /api/admin/icon-commissions/{id}/start
+func (h *AdminHandler) StartIconCommission(w http.ResponseWriter, r *http.Request, id int64) {
+	var req dto.AdminActionRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+
+	commission, err := h.iconUseCase.Start(r.Context(), command.StartIconCommissionInput{
+		CommissionID: id,
+		Actor:        middleware.AdminActorFromContext(r.Context()),
+		Reason:       stringValue(req.Reason),
+	})
+	if err != nil {
+		writeIconCommissionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toIconCommissionDTO(commission))
+}
+
+// DeliverIconCommission handles POST /api/admin/icon-commissions/{id}/deliver
+func (h *AdminHandler) DeliverIconCommission(w http.ResponseWriter, r *http.Request, id int64) {
+	var req dto.IconCommissionDeliverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	commission, err := h.iconUseCase.Deliver(r.Context(), command.DeliverIconCommissionInput{
+		CommissionID: id,
+		AssetKey:     req.AssetKey,
+		Actor:        middleware.AdminActorFromContext(r.Context()),
+		Reason:       stringValue(req.Reason),
+	})
+	if err != nil {
+		writeIconCommissionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toIconCommissionDTO(commission))
+}
+
+// RefundIconCommission handles POST /api/admin/icon-commissions/{id}/refund
+func (h *AdminHandler) RefundIconCommission(w http.ResponseWriter, r *http.Request, id int64) {
+	var req dto.AdminActionRequest
+	if !decodeOptionalBody(w, r, &req) {
+		return
+	}
+
+	commission, err := h.iconUseCase.Refund(r.Context(), command.RefundIconCommissionInput{
+		CommissionID: id,
+		Actor:        middleware.AdminActorFromContext(r.Context()),
+		Reason:       stringValue(req.Reason),
+	})
+	if err != nil {
+		writeIconCommissionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toIconCommissionDTO(commission))
+}
+
+// ListRuntimeSettings handles GET /api/admin/settings
+func (h *AdminHandler) ListRuntimeSettings(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.settingsService.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to list settings: "+err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRuntimeSettingListDTO(entries))
+}
+
+// UpdateRuntimeSettings handles PATCH /api/admin/settings
+func (h *AdminHandler) UpdateRuntimeSettings(w http.ResponseWriter, r *http.Request) {
+	var req dto.RuntimeSettingsUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	entries, err := h.settingsService.Update(r.Context(), req.Settings, actor)
+	if err != nil {
+		writeRuntimeSettingError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRuntimeSettingListDTO(entries))
+}
+
+// ResetRuntimeSetting handles DELETE /api/admin/settings/{key}
+func (h *AdminHandler) ResetRuntimeSetting(w http.ResponseWriter, r *http.Request, key string) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	entries, err := h.settingsService.Reset(r.Context(), key, actor)
+	if err != nil {
+		writeRuntimeSettingError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRuntimeSettingListDTO(entries))
+}
+
+func writeBannedTermError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrBannedTermNotFound):
+		writeError(w, http.StatusNotFound, "禁止ワードが見つかりません。")
+	case errors.Is(err, domain.ErrBannedTermExists):
+		writeError(w, http.StatusConflict, "同じ禁止ワードが既に登録されています。")
+	case errors.Is(err, domain.ErrEmptyBannedTerm),
+		errors.Is(err, domain.ErrBannedTermTooLong),
+		errors.Is(err, domain.ErrInvalidMatchType),
+		errors.Is(err, domain.ErrInvalidModerationMode),
+		errors.Is(err, domain.ErrInvalidBannedTermRegex):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "Failed to update banned terms: "+err.Error())
+	}
+}
+
+func toTermInput(req dto.BannedTermRequest) moderation.TermInput {
+	input := moderation.TermInput{
+		Term:      req.Term,
+		MatchType: domain.MatchType(req.MatchType),
+		Action:    domain.ModerationAction(req.Action),
+	}
+	if req.AutoBlock != nil {
+		input.AutoBlock = *req.AutoBlock
+	}
+	return input
+}
+
+func toBannedTermDTO(term *domain.BannedTerm) dto.BannedTerm {
+	return dto.BannedTerm{
+		Id:        term.ID,
+		Term:      term.Term,
+		MatchType: dto.BannedTermMatchType(term.MatchType),
+		Action:    dto.BannedTermAction(term.Action),
+		AutoBlock: term.AutoBlock,
+		CreatedBy: term.CreatedBy,
+		CreatedAt: term.CreatedAt,
+		UpdatedAt: term.UpdatedAt,
+	}
+}
+
+func toAuditLogDTO(log *domain.AuditLog) dto.AuditLog {
+	return dto.AuditLog{
+		Id:         log.ID,
+		Actor:      log.Actor,
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetId:   log.TargetID,
+		Before:     toJSONObject(log.Before),
+		After:      toJSONObject(log.After),
+		Reason:     log.Reason,
+		CreatedAt:  log.CreatedAt,
+	}
+}
+
+func toDeadOutboxEventDTO(event *domain.OutboxEvent) dto.DeadOutboxEvent {
+	resp := dto.DeadOutboxEvent{
+		Id:        event.ID,
+		EventType: event.EventType,
+		Payload:   map[string]interface{}{},
+		Attempts:  event.Attempts,
+		LastError: event.LastError,
+		CreatedAt: event.CreatedAt,
+	}
+	if payload := toJSONObject(event.Payload); payload != nil {
+		resp.Payload = *payload
+	}
+	if event.DeadAt != nil {
+		resp.DeadAt = *event.DeadAt
+	}
+	return resp
+}
+
+// toJSONObject 保存済みの JSON をレスポンス用のオブジェクトに変換する
+func toJSONObject(raw json.RawMessage) *map[string]interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil
+	}
+	return &obj
+}
+
+func writeUserBlockError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		writeError(w, http.StatusNotFound, "ユーザーが見つかりません。")
+	case errors.Is(err, domain.ErrUserNotBlocked):
+		writeError(w, http.StatusConflict, "このユーザーはブロックされていません。")
+	case errors.Is(err, domain.ErrEmptyUserName),
+		errors.Is(err, domain.ErrInvalidBlockTerm):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "Failed to update user block: "+err.Error())
+	}
+}
+
+// decodeOptionalBody 任意のリクエストボディを読み込む（空のボディは許可する）
+func decodeOptionalBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func toUserBlockDTO(user *domain.User) dto.UserBlockResponse {
+	return dto.UserBlockResponse{
+		UserId:       user.ID,
+		UserName:     user.Name,
+		Blocked:      user.IsBlocked(nil),
+		BlockedUntil: user.BlockedUntil,
+		BlockReason:  user.BlockReason,
+		BlockedBy:    user.BlockedBy,
+	}
+}
+
+func writeSessionCorrectionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSessionNotFound):
+		writeError(w, http.StatusNotFound, "セッションが見つかりません。")
+	case errors.Is(err, domain.ErrUserNotFound):
+		writeError(w, http.StatusNotFound, "ユーザーが見つかりません。")
+	case errors.Is(err, domain.ErrInvalidSessionPeriod):
+		writeError(w, http.StatusBadRequest, "終了時刻は開始時刻より後にしてください。")
+	case errors.Is(err, domain.ErrSessionInFuture):
+		writeError(w, http.StatusBadRequest, "未来の時刻は指定できません。")
+	case errors.Is(err, domain.ErrSessionOverlap):
+		writeError(w, http.StatusConflict, "同じユーザーの他のセッションと期間が重なっています。")
+	case errors.Is(err, domain.ErrUserAlreadyInSession):
+		writeError(w, http.StatusConflict, "同じユーザーのアクティブなセッションが既にあります。")
+	case errors.Is(err, domain.ErrSessionActive):
+		writeError(w, http.StatusConflict, "アクティブなセッションです。先に強制終了してください。")
+	case errors.Is(err, domain.ErrWorkNameTooLong):
+		writeError(w, http.StatusBadRequest, workNameTooLongMessage)
+	default:
+		writeError(w, http.StatusInternalServerError, "Failed to correct session: "+err.Error())
+	}
+}
+
+// utcTime リクエストの時刻を UTC に揃える（sessions は UTC の TIMESTAMP で保存している）
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+func toSessionRecordDTO(session *domain.Session) dto.SessionRecord {
+	return dto.SessionRecord{
+		Id:         session.ID,
+		UserId:     session.UserID,
+		WorkName:   session.WorkName,
+		StartTime:  session.StartTime,
+		PlannedEnd: session.PlannedEnd,
+		ActualEnd:  session.ActualEnd,
+	}
+}
+
+func writeWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		writeError(w, http.StatusNotFound, "Webhook が見つかりません。")
+	case errors.Is(err, domain.ErrWebhookDeliveryNotFound):
+		writeError(w, http.StatusNotFound, "Webhook の配信が見つかりません。")
+	case errors.Is(err, domain.ErrWebhookDeliveryNotRetrying):
+		writeError(w, http.StatusConflict, "再送できるのは配信に失敗した（dead）配信のみです。")
+	case errors.Is(err, domain.ErrInvalidWebhookURL),
+		errors.Is(err, domain.ErrEmptyWebhookSecret),
+		errors.Is(err, domain.ErrInvalidWebhookEventType):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "Failed to update webhooks: "+err.Error())
+	}
+}
+
+// toWebhookDTO シークレットを含めずに変換する
+func toWebhookDTO(sub *domain.WebhookSubscription) dto.Webhook {
+	eventTypes := sub.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return dto.Webhook{
+		Id:         sub.ID,
+		Url:        sub.URL,
+		EventTypes: eventTypes,
+		CreatedBy:  sub.CreatedBy,
+		CreatedAt:  sub.CreatedAt,
+		UpdatedAt:  sub.UpdatedAt,
+	}
+}
+
+func toWebhookDeliveryDTO(delivery *domain.WebhookDelivery) dto.WebhookDelivery {
+	return dto.WebhookDelivery{
+		Id:             delivery.ID,
+		WebhookId:      delivery.SubscriptionID,
+		EventId:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         dto.WebhookDeliveryStatus(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastError:      delivery.LastError,
+		LastStatusCode: delivery.LastStatusCode,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+func writeIconCommissionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrIconCommissionNotFound):
+		writeError(w, http.StatusNotFound, "アイコンの作成依頼が見つかりません。")
+	case errors.Is(err, domain.ErrUserNotFound):
+		writeError(w, http.StatusNotFound, "ユーザーが見つかりません。")
+	case errors.Is(err, domain.ErrIconCommissionTransition):
+		writeError(w, http.StatusConflict, "この依頼は現在の状態から変更できません。")
+	case errors.Is(err, domain.ErrIconAssetKeyExists):
+		writeError(w, http.StatusConflict, "このアセットキーは既に使われています。")
+	case errors.Is(err, domain.ErrEmptyIconAssetKey):
+		writeError(w, http.StatusBadRequest, "asset_key is required")
+	default:
+		writeError(w, http.StatusInternalServerError, "Failed to update icon commission: "+err.Error())
+	}
+}
+
+func toIconCommissionDTO(commission *domain.IconCommission) dto.IconCommission {
+	return dto.IconCommission{
+		Id:        commission.ID,
+		UserId:    commission.UserID,
+		Status:    dto.IconCommissionStatus(commission.Status),
+		Cost:      commission.Cost,
+		IconId:    commission.IconID,
+		Note:      commission.Note,
+		CreatedAt: commission.CreatedAt,
+		UpdatedAt: commission.UpdatedAt,
+	}
+}
+
+func writeRuntimeSettingError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrRuntimeSettingNotFound):
+		writeError(w, http.StatusNotFound, "この設定は変更されていません。")
+	case errors.Is(err, domain.ErrUnknownRuntimeSetting),
+		errors.Is(err, domain.ErrInvalidRuntimeSetting):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "Failed to update settings: "+err.Error())
+	}
+}
+
+func toRuntimeSettingListDTO(entries []settings.Entry) dto.RuntimeSettingListResponse {
+	resp := dto.RuntimeSettingListResponse{
+		Settings: make([]dto.RuntimeSetting, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		setting := dto.RuntimeSetting{
+			Key:       entry.Key,
+			Value:     entry.Value,
+			Default:   entry.Default,
+			UpdatedAt: entry.UpdatedAt,
+		}
+		if entry.UpdatedAt != nil {
+			setting.UpdatedBy = &entry.UpdatedBy
+		}
+		resp.Settings = append(resp.Settings, setting)
+	}
+	return resp
+}
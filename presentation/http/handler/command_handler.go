@@ -2,33 +2,64 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/yamada-ai/workspace-backend/domain"
 	"github.com/yamada-ai/workspace-backend/presentation/http/dto"
 	"github.com/yamada-ai/workspace-backend/usecase/command"
+	"github.com/yamada-ai/workspace-backend/usecase/ratelimit"
+)
+
+// ErrorResponse.code に設定するエラーコード
+const (
+	// ErrorCodeRateLimited コマンドの実行頻度が制限を超えた
+	ErrorCodeRateLimited = "rate_limited"
+	// ErrorCodeBannedTerm ユーザー名・作業名に禁止ワードが含まれている
+	ErrorCodeBannedTerm = "banned_term"
+	// ErrorCodeUserBlocked ユーザーがブロックされている
+	ErrorCodeUserBlocked = "user_blocked"
 )
 
 // CommandHandler handles command-related HTTP requests
 type CommandHandler struct {
-	joinUseCase   *command.JoinCommandUseCase
-	outUseCase    *command.OutCommandUseCase
-	moreUseCase   *command.MoreCommandUseCase
-	changeUseCase *command.ChangeCommandUseCase
+	joinUseCase    *command.JoinCommandUseCase
+	outUseCase     *command.OutCommandUseCase
+	moreUseCase    *command.MoreCommandUseCase
+	changeUseCase  *command.ChangeCommandUseCase
+	iconUseCase    *command.IconCommissionUseCase
+	cheerUseCase   *command.CheerCommandUseCase
+	commentUseCase *command.CommentCommandUseCase
+	observer       command.CommandObserver
 }
 
 // NewCommandHandler creates a new command handler
+// observer records the outcome of each command (nil disables it)
 func NewCommandHandler(
 	joinUseCase *command.JoinCommandUseCase,
 	outUseCase *command.OutCommandUseCase,
 	moreUseCase *command.MoreCommandUseCase,
 	changeUseCase *command.ChangeCommandUseCase,
+	iconUseCase *command.IconCommissionUseCase,
+	cheerUseCase *command.CheerCommandUseCase,
+	commentUseCase *command.CommentCommandUseCase,
+	observer command.CommandObserver,
 ) *CommandHandler {
+	if observer == nil {
+		observer = command.NoOpCommandObserver{}
+	}
 	return &CommandHandler{
-		joinUseCase:   joinUseCase,
-		outUseCase:    outUseCase,
-		moreUseCase:   moreUseCase,
-		changeUseCase: changeUseCase,
+		joinUseCase:    joinUseCase,
+		outUseCase:     outUseCase,
+		moreUseCase:    moreUseCase,
+		changeUseCase:  changeUseCase,
+		iconUseCase:    iconUseCase,
+		cheerUseCase:   cheerUseCase,
+		commentUseCase: commentUseCase,
+		observer:       observer,
 	}
 }
 
@@ -60,12 +91,26 @@ func (h *CommandHandler) JoinCommand(w http.ResponseWriter, r *http.Request) {
 
 	// Execute usecase
 	output, err := h.joinUseCase.Execute(r.Context(), input)
+	h.observer.ObserveCommand(command.SourceHTTP, command.CommandJoin, err)
 	if err != nil {
 		// Handle already in session error
 		if err == domain.ErrUserAlreadyInSession {
 			writeError(w, http.StatusConflict, "既に作業セッション中です。先に /out で終了してください。")
 			return
 		}
+		if errors.Is(err, domain.ErrWorkNameTooLong) {
+			writeError(w, http.StatusBadRequest, workNameTooLongMessage)
+			return
+		}
+		// Handle moderation errors (banned term, blocked user)
+		if writeModerationError(w, err) {
+			return
+		}
+		// Handle rate limit error
+		if errors.Is(err, ratelimit.ErrRateLimited) {
+			writeRateLimited(w, err)
+			return
+		}
 		writeError(w, http.StatusInternalServerError, "Failed to join: "+err.Error())
 		return
 	}
@@ -106,6 +151,7 @@ func (h *CommandHandler) OutCommand(w http.ResponseWriter, r *http.Request) {
 
 	// Execute usecase
 	output, err := h.outUseCase.Execute(r.Context(), input)
+	h.observer.ObserveCommand(command.SourceHTTP, command.CommandOut, err)
 	if err != nil {
 		// Handle user not found error
 		if err == domain.ErrUserNotFound {
@@ -117,6 +163,15 @@ func (h *CommandHandler) OutCommand(w http.ResponseWriter, r *http.Request) {
 			writeError(w, http.StatusNotFound, "有効なセッションが見つかりません。")
 			return
 		}
+		// Handle blocked user error
+		if writeModerationError(w, err) {
+			return
+		}
+		// Handle rate limit error
+		if errors.Is(err, ratelimit.ErrRateLimited) {
+			writeRateLimited(w, err)
+			return
+		}
 		writeError(w, http.StatusInternalServerError, "Failed to leave: "+err.Error())
 		return
 	}
@@ -146,12 +201,6 @@ func (h *CommandHandler) MoreCommand(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate minutes
-	if req.Minutes < command.MinExtensionMinutes || req.Minutes > command.MaxExtensionMinutes {
-		writeError(w, http.StatusBadRequest, "minutes must be between 1 and 360")
-		return
-	}
-
 	// Prepare usecase input
 	input := command.MoreCommandInput{
 		UserName: req.UserName,
@@ -160,6 +209,7 @@ func (h *CommandHandler) MoreCommand(w http.ResponseWriter, r *http.Request) {
 
 	// Execute usecase
 	output, err := h.moreUseCase.Execute(r.Context(), input)
+	h.observer.ObserveCommand(command.SourceHTTP, command.CommandMore, err)
 	if err != nil {
 		// Handle user not found error
 		if err == domain.ErrUserNotFound {
@@ -171,11 +221,25 @@ func (h *CommandHandler) MoreCommand(w http.ResponseWriter, r *http.Request) {
 			writeError(w, http.StatusNotFound, "有効なセッションが見つかりません。")
 			return
 		}
-		// Handle invalid extension error
-		if err == domain.ErrInvalidExtension {
+		// Handle invalid extension error (the range is configurable, so the use case validates minutes)
+		var rangeErr *command.ExtensionRangeError
+		if errors.As(err, &rangeErr) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("minutes must be between %d and %d", rangeErr.Min, rangeErr.Max))
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidExtension) {
 			writeError(w, http.StatusBadRequest, "無効な延長時間です。")
 			return
 		}
+		// Handle blocked user error
+		if writeModerationError(w, err) {
+			return
+		}
+		// Handle rate limit error
+		if errors.Is(err, ratelimit.ErrRateLimited) {
+			writeRateLimited(w, err)
+			return
+		}
 		writeError(w, http.StatusInternalServerError, "Failed to extend session: "+err.Error())
 		return
 	}
@@ -214,6 +278,7 @@ func (h *CommandHandler) ChangeCommand(w http.ResponseWriter, r *http.Request) {
 
 	// Execute usecase
 	output, err := h.changeUseCase.Execute(r.Context(), input)
+	h.observer.ObserveCommand(command.SourceHTTP, command.CommandChange, err)
 	if err != nil {
 		// Handle user not found error
 		if err == domain.ErrUserNotFound {
@@ -230,6 +295,19 @@ func (h *CommandHandler) ChangeCommand(w http.ResponseWriter, r *http.Request) {
 			writeError(w, http.StatusBadRequest, "既に完了したセッションです。")
 			return
 		}
+		if errors.Is(err, domain.ErrWorkNameTooLong) {
+			writeError(w, http.StatusBadRequest, workNameTooLongMessage)
+			return
+		}
+		// Handle moderation errors (banned term, blocked user)
+		if writeModerationError(w, err) {
+			return
+		}
+		// Handle rate limit error
+		if errors.Is(err, ratelimit.ErrRateLimited) {
+			writeRateLimited(w, err)
+			return
+		}
 		writeError(w, http.StatusInternalServerError, "Failed to change work name: "+err.Error())
 		return
 	}
@@ -244,6 +322,151 @@ func (h *CommandHandler) ChangeCommand(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// IconCreationCommand handles POST /api/commands/icon_creation
+func (h *CommandHandler) IconCreationCommand(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var req dto.IconCreationCommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Validate user_name
+	if req.UserName == "" {
+		writeError(w, http.StatusBadRequest, "user_name is required")
+		return
+	}
+
+	// Execute usecase
+	output, err := h.iconUseCase.Request(r.Context(), command.RequestIconCommissionInput{
+		UserName: req.UserName,
+		Points:   req.Points,
+	})
+	h.observer.ObserveCommand(command.SourceHTTP, command.CommandIconCreation, err)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			writeError(w, http.StatusNotFound, "ユーザーが見つかりません。")
+		case errors.Is(err, domain.ErrInvalidIconCommissionCost):
+			writeError(w, http.StatusBadRequest, "専用アイコンの作成には "+strconv.FormatInt(domain.IconCommissionCost, 10)+" Raziiipo が必要です。")
+		case errors.Is(err, domain.ErrInsufficientPoints):
+			writeError(w, http.StatusConflict, "Raziiipo が足りません。")
+		case errors.Is(err, domain.ErrIconCommissionAlreadyOpen):
+			writeError(w, http.StatusConflict, "作成中の専用アイコンの依頼が既にあります。")
+		case writeModerationError(w, err):
+		case errors.Is(err, ratelimit.ErrRateLimited):
+			writeRateLimited(w, err)
+		default:
+			writeError(w, http.StatusInternalServerError, "Failed to request icon: "+err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, dto.IconCreationCommandResponse{
+		CommissionId: output.Commission.ID,
+		UserId:       output.Commission.UserID,
+		Cost:         output.Commission.Cost,
+		Balance:      output.Balance,
+	})
+}
+
+// CheerCommand handles POST /api/commands/cheer
+func (h *CommandHandler) CheerCommand(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var req dto.CheerCommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Validate user_name
+	if req.UserName == "" {
+		writeError(w, http.StatusBadRequest, "user_name is required")
+		return
+	}
+
+	// Execute usecase
+	output, err := h.cheerUseCase.Execute(r.Context(), command.CheerCommandInput{
+		UserName:   req.UserName,
+		TargetName: req.TargetName,
+	})
+	h.observer.ObserveCommand(command.SourceHTTP, command.CommandCheer, err)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmptyUserName):
+			writeError(w, http.StatusBadRequest, "target_name is required")
+		case errors.Is(err, domain.ErrCannotCheerSelf):
+			writeError(w, http.StatusBadRequest, "自分自身は応援できません。")
+		case errors.Is(err, domain.ErrUserNotFound):
+			writeError(w, http.StatusNotFound, "ユーザーが見つかりません。")
+		case errors.Is(err, domain.ErrSessionNotFound):
+			writeError(w, http.StatusNotFound, "有効なセッションが見つかりません。")
+		case errors.Is(err, domain.ErrCheerTargetNotInRoom):
+			writeError(w, http.StatusNotFound, "応援する相手が入室していません。")
+		case errors.Is(err, domain.ErrCheerAlreadySent):
+			writeError(w, http.StatusConflict, "今日は既にこのユーザーを応援しています。")
+		case writeModerationError(w, err):
+		case errors.Is(err, ratelimit.ErrRateLimited):
+			writeRateLimited(w, err)
+		default:
+			writeError(w, http.StatusInternalServerError, "Failed to cheer: "+err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.CheerCommandResponse{
+		CheerId:      output.CheerID,
+		UserId:       output.UserID,
+		TargetUserId: output.TargetUserID,
+		TargetName:   output.TargetName,
+		BonusPoints:  output.BonusPoints,
+	})
+}
+
+// CommentCommand handles POST /api/commands/comment
+func (h *CommandHandler) CommentCommand(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var req dto.CommentCommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// Validate user_name
+	if req.UserName == "" {
+		writeError(w, http.StatusBadRequest, "user_name is required")
+		return
+	}
+
+	// Execute usecase
+	output, err := h.commentUseCase.Execute(r.Context(), command.CommentCommandInput{
+		UserName: req.UserName,
+		Text:     req.Text,
+	})
+	h.observer.ObserveCommand(command.SourceHTTP, command.CommandComment, err)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmptyComment):
+			writeError(w, http.StatusBadRequest, "text is required")
+		case errors.Is(err, domain.ErrUserNotFound):
+			writeError(w, http.StatusNotFound, "ユーザーが見つかりません。")
+		case errors.Is(err, domain.ErrSessionNotFound):
+			writeError(w, http.StatusNotFound, "有効なセッションが見つかりません。")
+		case writeModerationError(w, err):
+		case errors.Is(err, ratelimit.ErrRateLimited):
+			writeRateLimited(w, err)
+		default:
+			writeError(w, http.StatusInternalServerError, "Failed to post comment: "+err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dto.CommentCommandResponse{
+		UserId: output.UserID,
+		Text:   output.Text,
+	})
+}
+
 // HealthCheck handles GET /health
 func (h *CommandHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain")
@@ -267,3 +490,38 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 func writeError(w http.ResponseWriter, status int, message string) {
 	writeJSON(w, status, dto.ErrorResponse{Error: message})
 }
+
+func writeErrorWithCode(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, dto.ErrorResponse{Error: message, Code: &code})
+}
+
+// writeRateLimited 429 と Retry-After ヘッダー（秒、切り上げ）を返す
+func writeRateLimited(w http.ResponseWriter, err error) {
+	retryAfter := 1
+	var limitErr *ratelimit.LimitError
+	if errors.As(err, &limitErr) {
+		if secs := int(math.Ceil(limitErr.RetryAfter.Seconds())); secs > retryAfter {
+			retryAfter = secs
+		}
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	writeErrorWithCode(w, http.StatusTooManyRequests, ErrorCodeRateLimited,
+		"コマンドの実行間隔が短すぎます。"+strconv.Itoa(retryAfter)+"秒後に再度お試しください。")
+}
+
+// workNameTooLongMessage 作業名が domain.WorkNameMaxLength を超えたときのメッセージ
+var workNameTooLongMessage = "作業名は" + strconv.Itoa(domain.WorkNameMaxLength) + "文字以内にしてください。"
+
+// writeModerationError 禁止ワード・ブロックのエラーであればレスポンスを返して true を返す
+func writeModerationError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrUserBlocked):
+		writeErrorWithCode(w, http.StatusForbidden, ErrorCodeUserBlocked, "このユーザーはブロックされています。")
+		return true
+	case errors.Is(err, domain.ErrBannedTermDetected):
+		writeErrorWithCode(w, http.StatusUnprocessableEntity, ErrorCodeBannedTerm, "禁止ワードが含まれているため受け付けられません。")
+		return true
+	}
+	return false
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/yamada-ai/workspace-backend/domain"
 	"github.com/yamada-ai/workspace-backend/infrastructure/database/repository"
 	"github.com/yamada-ai/workspace-backend/infrastructure/database/sqlc"
 	"github.com/yamada-ai/workspace-backend/infrastructure/database/testutil"
@@ -35,18 +36,18 @@ func TestCommandHandler_JoinCommand_E2E(t *testing.T) {
 	// Create dependencies
 	userRepo := repository.NewUserRepositoryWithPool(pool)
 	sessionRepo := repository.NewSessionRepository(sqlc.New(pool))
-	completeService := session.NewCompleteSessionService(sessionRepo, command.NoOpBroadcaster{})
+	completeService := session.NewCompleteSessionService(userRepo, sessionRepo, command.NoOpEventOutbox{})
 	expirationManager := session.NewSessionExpirationManager(sessionRepo, completeService)
-	joinUseCase := command.NewJoinCommandUseCase(userRepo, sessionRepo, command.NoOpBroadcaster{}, expirationManager)
-	outUseCase := command.NewOutCommandUseCase(userRepo, sessionRepo, completeService, expirationManager)
-	moreUseCase := command.NewMoreCommandUseCase(userRepo, sessionRepo, command.NoOpBroadcaster{}, expirationManager)
+	joinUseCase := command.NewJoinCommandUseCase(userRepo, sessionRepo, command.NoOpEventOutbox{}, expirationManager, command.NoOpRateLimiter{}, command.NoOpTextModerator{}, command.NoOpIconPicker{}, command.DefaultSessionSettings())
+	outUseCase := command.NewOutCommandUseCase(userRepo, sessionRepo, completeService, expirationManager, command.NoOpRateLimiter{})
+	moreUseCase := command.NewMoreCommandUseCase(userRepo, sessionRepo, command.NoOpEventOutbox{}, expirationManager, command.NoOpRateLimiter{}, command.DefaultSessionSettings())
 	getActiveSessionsUseCase := query.NewGetActiveSessionsUseCase(sessionRepo)
 	getUserInfoUseCase := query.NewGetUserInfoUseCase(userRepo, sessionRepo)
 
-	changeUseCase := command.NewChangeCommandUseCase(userRepo, sessionRepo, command.NoOpBroadcaster{})
-	commandHandler := handler.NewCommandHandler(joinUseCase, outUseCase, moreUseCase, changeUseCase)
+	changeUseCase := command.NewChangeCommandUseCase(userRepo, sessionRepo, command.NoOpEventOutbox{}, command.NoOpRateLimiter{}, command.NoOpTextModerator{})
+	commandHandler := handler.NewCommandHandler(joinUseCase, outUseCase, moreUseCase, changeUseCase, nil, nil, nil, nil)
 	queryHandler := handler.NewQueryHandler(getActiveSessionsUseCase, getUserInfoUseCase)
-	unifiedHandler := handler.NewHandler(commandHandler, queryHandler)
+	unifiedHandler := handler.NewHandler(commandHandler, queryHandler, handler.NewAdminHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil), handler.NewHealthHandler(0))
 
 	// Setup router
 	r := chi.NewRouter()
@@ -367,18 +368,18 @@ func TestCommandHandler_InfoCommand_E2E(t *testing.T) {
 	// Create dependencies
 	userRepo := repository.NewUserRepositoryWithPool(pool)
 	sessionRepo := repository.NewSessionRepository(sqlc.New(pool))
-	completeService := session.NewCompleteSessionService(sessionRepo, command.NoOpBroadcaster{})
+	completeService := session.NewCompleteSessionService(userRepo, sessionRepo, command.NoOpEventOutbox{})
 	expirationManager := session.NewSessionExpirationManager(sessionRepo, completeService)
-	joinUseCase := command.NewJoinCommandUseCase(userRepo, sessionRepo, command.NoOpBroadcaster{}, expirationManager)
-	outUseCase := command.NewOutCommandUseCase(userRepo, sessionRepo, completeService, expirationManager)
-	moreUseCase := command.NewMoreCommandUseCase(userRepo, sessionRepo, command.NoOpBroadcaster{}, expirationManager)
-	changeUseCase := command.NewChangeCommandUseCase(userRepo, sessionRepo, command.NoOpBroadcaster{})
+	joinUseCase := command.NewJoinCommandUseCase(userRepo, sessionRepo, command.NoOpEventOutbox{}, expirationManager, command.NoOpRateLimiter{}, command.NoOpTextModerator{}, command.NoOpIconPicker{}, command.DefaultSessionSettings())
+	outUseCase := command.NewOutCommandUseCase(userRepo, sessionRepo, completeService, expirationManager, command.NoOpRateLimiter{})
+	moreUseCase := command.NewMoreCommandUseCase(userRepo, sessionRepo, command.NoOpEventOutbox{}, expirationManager, command.NoOpRateLimiter{}, command.DefaultSessionSettings())
+	changeUseCase := command.NewChangeCommandUseCase(userRepo, sessionRepo, command.NoOpEventOutbox{}, command.NoOpRateLimiter{}, command.NoOpTextModerator{})
 	getActiveSessionsUseCase := query.NewGetActiveSessionsUseCase(sessionRepo)
 	getUserInfoUseCase := query.NewGetUserInfoUseCase(userRepo, sessionRepo)
 
-	commandHandler := handler.NewCommandHandler(joinUseCase, outUseCase, moreUseCase, changeUseCase)
+	commandHandler := handler.NewCommandHandler(joinUseCase, outUseCase, moreUseCase, changeUseCase, nil, nil, nil, nil)
 	queryHandler := handler.NewQueryHandler(getActiveSessionsUseCase, getUserInfoUseCase)
-	unifiedHandler := handler.NewHandler(commandHandler, queryHandler)
+	unifiedHandler := handler.NewHandler(commandHandler, queryHandler, handler.NewAdminHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil), handler.NewHealthHandler(0))
 
 	// Setup router
 	r := chi.NewRouter()
@@ -505,18 +506,18 @@ func TestCommandHandler_Integration_FullFlow(t *testing.T) {
 	// Create dependencies
 	userRepo := repository.NewUserRepositoryWithPool(pool)
 	sessionRepo := repository.NewSessionRepository(sqlc.New(pool))
-	completeService := session.NewCompleteSessionService(sessionRepo, command.NoOpBroadcaster{})
+	completeService := session.NewCompleteSessionService(userRepo, sessionRepo, command.NoOpEventOutbox{})
 	expirationManager := session.NewSessionExpirationManager(sessionRepo, completeService)
-	joinUseCase := command.NewJoinCommandUseCase(userRepo, sessionRepo, command.NoOpBroadcaster{}, expirationManager)
-	outUseCase := command.NewOutCommandUseCase(userRepo, sessionRepo, completeService, expirationManager)
-	moreUseCase := command.NewMoreCommandUseCase(userRepo, sessionRepo, command.NoOpBroadcaster{}, expirationManager)
+	joinUseCase := command.NewJoinCommandUseCase(userRepo, sessionRepo, command.NoOpEventOutbox{}, expirationManager, command.NoOpRateLimiter{}, command.NoOpTextModerator{}, command.NoOpIconPicker{}, command.DefaultSessionSettings())
+	outUseCase := command.NewOutCommandUseCase(userRepo, sessionRepo, completeService, expirationManager, command.NoOpRateLimiter{})
+	moreUseCase := command.NewMoreCommandUseCase(userRepo, sessionRepo, command.NoOpEventOutbox{}, expirationManager, command.NoOpRateLimiter{}, command.DefaultSessionSettings())
 	getActiveSessionsUseCase := query.NewGetActiveSessionsUseCase(sessionRepo)
 	getUserInfoUseCase := query.NewGetUserInfoUseCase(userRepo, sessionRepo)
 
-	changeUseCase := command.NewChangeCommandUseCase(userRepo, sessionRepo, command.NoOpBroadcaster{})
-	commandHandler := handler.NewCommandHandler(joinUseCase, outUseCase, moreUseCase, changeUseCase)
+	changeUseCase := command.NewChangeCommandUseCase(userRepo, sessionRepo, command.NoOpEventOutbox{}, command.NoOpRateLimiter{}, command.NoOpTextModerator{})
+	commandHandler := handler.NewCommandHandler(joinUseCase, outUseCase, moreUseCase, changeUseCase, nil, nil, nil, nil)
 	queryHandler := handler.NewQueryHandler(getActiveSessionsUseCase, getUserInfoUseCase)
-	unifiedHandler := handler.NewHandler(commandHandler, queryHandler)
+	unifiedHandler := handler.NewHandler(commandHandler, queryHandler, handler.NewAdminHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil), handler.NewHealthHandler(0))
 
 	// Setup router
 	r := chi.NewRouter()
@@ -596,18 +597,18 @@ func TestCommandHandler_MoreCommand_E2E(t *testing.T) {
 	// Create dependencies
 	userRepo := repository.NewUserRepositoryWithPool(pool)
 	sessionRepo := repository.NewSessionRepository(sqlc.New(pool))
-	completeService := session.NewCompleteSessionService(sessionRepo, command.NoOpBroadcaster{})
+	completeService := session.NewCompleteSessionService(userRepo, sessionRepo, command.NoOpEventOutbox{})
 	expirationManager := session.NewSessionExpirationManager(sessionRepo, completeService)
-	joinUseCase := command.NewJoinCommandUseCase(userRepo, sessionRepo, command.NoOpBroadcaster{}, expirationManager)
-	outUseCase := command.NewOutCommandUseCase(userRepo, sessionRepo, completeService, expirationManager)
-	moreUseCase := command.NewMoreCommandUseCase(userRepo, sessionRepo, command.NoOpBroadcaster{}, expirationManager)
-	changeUseCase := command.NewChangeCommandUseCase(userRepo, sessionRepo, command.NoOpBroadcaster{})
+	joinUseCase := command.NewJoinCommandUseCase(userRepo, sessionRepo, command.NoOpEventOutbox{}, expirationManager, command.NoOpRateLimiter{}, command.NoOpTextModerator{}, command.NoOpIconPicker{}, command.DefaultSessionSettings())
+	outUseCase := command.NewOutCommandUseCase(userRepo, sessionRepo, completeService, expirationManager, command.NoOpRateLimiter{})
+	moreUseCase := command.NewMoreCommandUseCase(userRepo, sessionRepo, command.NoOpEventOutbox{}, expirationManager, command.NoOpRateLimiter{}, command.DefaultSessionSettings())
+	changeUseCase := command.NewChangeCommandUseCase(userRepo, sessionRepo, command.NoOpEventOutbox{}, command.NoOpRateLimiter{}, command.NoOpTextModerator{})
 	getActiveSessionsUseCase := query.NewGetActiveSessionsUseCase(sessionRepo)
 	getUserInfoUseCase := query.NewGetUserInfoUseCase(userRepo, sessionRepo)
 
-	commandHandler := handler.NewCommandHandler(joinUseCase, outUseCase, moreUseCase, changeUseCase)
+	commandHandler := handler.NewCommandHandler(joinUseCase, outUseCase, moreUseCase, changeUseCase, nil, nil, nil, nil)
 	queryHandler := handler.NewQueryHandler(getActiveSessionsUseCase, getUserInfoUseCase)
-	unifiedHandler := handler.NewHandler(commandHandler, queryHandler)
+	unifiedHandler := handler.NewHandler(commandHandler, queryHandler, handler.NewAdminHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil), handler.NewHealthHandler(0))
 
 	// Setup router
 	r := chi.NewRouter()
@@ -750,6 +751,109 @@ func TestCommandHandler_MoreCommand_E2E(t *testing.T) {
 	})
 }
 
+func TestCommandHandler_IconCreationCommand_E2E(t *testing.T) {
+	// Skip integration tests when running with -short flag
+	if testing.Short() {
+		t.Skip("Skipping E2E test")
+	}
+
+	// Setup test database
+	pool := testutil.SetupTestDB(t)
+	testutil.CleanupTables(t, pool)
+
+	// Create dependencies
+	queries := sqlc.New(pool)
+	userRepo := repository.NewUserRepositoryWithPool(pool)
+	ledgerRepo := repository.NewPointLedgerRepository(queries)
+	iconUseCase := command.NewIconCommissionUseCase(
+		userRepo,
+		repository.NewIconCommissionRepository(queries),
+		ledgerRepo,
+		repository.NewIconRepository(queries),
+		repository.NewAuditLogRepository(queries),
+		command.NoOpRateLimiter{},
+	)
+	commandHandler := handler.NewCommandHandler(nil, nil, nil, nil, iconUseCase, nil, nil, nil)
+	unifiedHandler := handler.NewHandler(commandHandler, handler.NewQueryHandler(nil, nil), handler.NewAdminHandler(nil, nil, nil, nil, nil, nil, iconUseCase, nil, nil), handler.NewHealthHandler(0))
+
+	server := httptest.NewServer(dto.HandlerFromMux(unifiedHandler, chi.NewRouter()))
+	defer server.Close()
+
+	post := func(t *testing.T, path string, body interface{}) *http.Response {
+		t.Helper()
+		bodyBytes, _ := json.Marshal(body)
+		resp, err := http.Post(server.URL+path, "application/json", bytes.NewReader(bodyBytes))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		return resp
+	}
+	credit := func(t *testing.T, userID, points int64) {
+		t.Helper()
+		tx, err := userRepo.BeginTx(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to begin tx: %v", err)
+		}
+		entry, _ := domain.NewPointEntry(userID, points, "test_credit", "", nil)
+		if err := ledgerRepo.AppendWithTx(context.Background(), tx, entry); err != nil {
+			_ = tx.Rollback(context.Background())
+			t.Fatalf("Failed to credit points: %v", err)
+		}
+		if err := tx.Commit(context.Background()); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+	}
+	request := dto.IconCreationCommandRequest{UserName: "icon_user", Points: domain.IconCommissionCost}
+
+	t.Run("InsufficientPoints", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+		userID := testutil.CreateTestUser(t, pool, "icon_user", 1)
+		credit(t, userID, domain.IconCommissionCost-1)
+
+		resp := post(t, "/api/commands/icon_creation", request)
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("RequestAndRefund", func(t *testing.T) {
+		testutil.CleanupTables(t, pool)
+		userID := testutil.CreateTestUser(t, pool, "icon_user", 1)
+		credit(t, userID, domain.IconCommissionCost+500)
+
+		resp := post(t, "/api/commands/icon_creation", request)
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d", resp.StatusCode)
+		}
+		var created dto.IconCreationCommandResponse
+		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if created.UserId != userID || created.Cost != domain.IconCommissionCost || created.Balance != 500 {
+			t.Errorf("Unexpected response: %+v", created)
+		}
+
+		// A second request is refused while the first one is open
+		again := post(t, "/api/commands/icon_creation", request)
+		defer func() { _ = again.Body.Close() }()
+		if again.StatusCode != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", again.StatusCode)
+		}
+
+		refund := post(t, fmt.Sprintf("/api/admin/icon-commissions/%d/refund", created.CommissionId), dto.AdminActionRequest{Reason: stringPtr("cancelled")})
+		defer func() { _ = refund.Body.Close() }()
+		if refund.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", refund.StatusCode)
+		}
+		balance, err := ledgerRepo.Balance(context.Background(), userID)
+		if err != nil || balance != domain.IconCommissionCost+500 {
+			t.Errorf("Expected points to be credited back, got %d (%v)", balance, err)
+		}
+	})
+}
+
 // Helper function
 func stringPtr(s string) *string {
 	return &s
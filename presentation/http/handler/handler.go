@@ -2,20 +2,26 @@ package handler
 
 import "net/http"
 
-// Handler combines all HTTP handlers (commands and queries)
+// Handler combines all HTTP handlers (commands, queries, admin and health probes)
 type Handler struct {
 	*CommandHandler
 	*QueryHandler
+	*AdminHandler
+	*HealthHandler
 }
 
 // NewHandler creates a unified handler that implements dto.ServerInterface
 func NewHandler(
 	commandHandler *CommandHandler,
 	queryHandler *QueryHandler,
+	adminHandler *AdminHandler,
+	healthHandler *HealthHandler,
 ) *Handler {
 	return &Handler{
 		CommandHandler: commandHandler,
 		QueryHandler:   queryHandler,
+		AdminHandler:   adminHandler,
+		HealthHandler:  healthHandler,
 	}
 }
 
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/presentation/http/dto"
+)
+
+// DefaultReadinessTimeout 1 つのチェックを待つ時間の既定値
+const DefaultReadinessTimeout = 2 * time.Second
+
+// ReadinessCheck readiness の判定に使う依存先 1 つのチェック（nil を返せば正常）
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// HealthHandler liveness（/livez）と readiness（/readyz）のプローブ
+// /livez はプロセスが応答できるかだけを返し、/readyz は依存先をすべて確認する
+type HealthHandler struct {
+	checks  []ReadinessCheck
+	timeout time.Duration
+}
+
+// NewHealthHandler creates a new health handler (timeout <= 0 falls back to DefaultReadinessTimeout)
+func NewHealthHandler(timeout time.Duration, checks ...ReadinessCheck) *HealthHandler {
+	if timeout <= 0 {
+		timeout = DefaultReadinessTimeout
+	}
+	return &HealthHandler{checks: checks, timeout: timeout}
+}
+
+// Livez handles GET /livez
+func (h *HealthHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+// Readyz handles GET /readyz
+// チェックは並行して実行し、1 つでも失敗・タイムアウトすれば 503 を返す
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	results := make(map[string]dto.ReadinessCheckResult, len(h.checks))
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, c := range h.checks {
+		wg.Add(1)
+		go func(c ReadinessCheck) {
+			defer wg.Done()
+			result := h.run(r.Context(), c)
+			mu.Lock()
+			results[c.Name] = result
+			mu.Unlock()
+		}(c)
+	}
+	wg.Wait()
+
+	response := dto.ReadinessResponse{Status: dto.ReadinessResponseStatusOk, Checks: results}
+	status := http.StatusOK
+	for _, result := range results {
+		if result.Status != dto.ReadinessCheckResultStatusOk {
+			response.Status = dto.ReadinessResponseStatusUnavailable
+			status = http.StatusServiceUnavailable
+		}
+	}
+	writeJSON(w, status, response)
+}
+
+// run チェックを 1 つ実行する
+// context を見ないチェックが詰まっても、タイムアウトで失敗として返す
+func (h *HealthHandler) run(ctx context.Context, c ReadinessCheck) dto.ReadinessCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", h.timeout)
+	}
+
+	result := dto.ReadinessCheckResult{
+		Status:     dto.ReadinessCheckResultStatusOk,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		message := err.Error()
+		result.Status = dto.ReadinessCheckResultStatusFailed
+		result.Error = &message
+	}
+	return result
+}
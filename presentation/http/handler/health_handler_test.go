@@ -0,0 +1,77 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/presentation/http/dto"
+	"github.com/yamada-ai/workspace-backend/presentation/http/handler"
+)
+
+func TestHealthHandler_Readyz(t *testing.T) {
+	ok := handler.ReadinessCheck{Name: "database", Check: func(ctx context.Context) error { return nil }}
+	failing := handler.ReadinessCheck{Name: "migrations", Check: func(ctx context.Context) error {
+		return errors.New("database is at migration 11, expected 12")
+	}}
+	// context を見ずに詰まるチェック
+	stuck := handler.ReadinessCheck{Name: "websocket_hub", Check: func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}}
+
+	tests := []struct {
+		name       string
+		checks     []handler.ReadinessCheck
+		wantStatus int
+		wantFailed map[string]bool
+	}{
+		{"すべて正常", []handler.ReadinessCheck{ok}, http.StatusOK, map[string]bool{"database": false}},
+		{"失敗したチェックがある", []handler.ReadinessCheck{ok, failing}, http.StatusServiceUnavailable, map[string]bool{"database": false, "migrations": true}},
+		{"タイムアウト", []handler.ReadinessCheck{ok, stuck}, http.StatusServiceUnavailable, map[string]bool{"database": false, "websocket_hub": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handler.NewHealthHandler(50*time.Millisecond, tt.checks...)
+			rec := httptest.NewRecorder()
+			h.Readyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			var resp dto.ReadinessResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+			wantStatus := dto.ReadinessResponseStatusOk
+			if tt.wantStatus != http.StatusOK {
+				wantStatus = dto.ReadinessResponseStatusUnavailable
+			}
+			if resp.Status != wantStatus || len(resp.Checks) != len(tt.wantFailed) {
+				t.Fatalf("unexpected response: %+v", resp)
+			}
+			for name, wantFailed := range tt.wantFailed {
+				result := resp.Checks[name]
+				if failed := result.Status == dto.ReadinessCheckResultStatusFailed; failed != wantFailed || failed != (result.Error != nil) {
+					t.Errorf("check %s: unexpected result %+v", name, result)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthHandler_Livez(t *testing.T) {
+	// 依存先が落ちていても liveness は失敗させない
+	h := handler.NewHealthHandler(0, handler.ReadinessCheck{Name: "database", Check: func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}})
+	rec := httptest.NewRecorder()
+	h.Livez(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+		t.Errorf("unexpected response: %d %q", rec.Code, rec.Body.String())
+	}
+}
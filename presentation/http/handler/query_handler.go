@@ -40,14 +40,15 @@ func (h *QueryHandler) GetActiveSessions(w http.ResponseWriter, r *http.Request)
 	sessions := make([]dto.SessionInfo, 0, len(output.Sessions))
 	for _, s := range output.Sessions {
 		sessions = append(sessions, dto.SessionInfo{
-			SessionId:  s.SessionID,
-			UserId:     s.UserID,
-			UserName:   s.UserName,
-			WorkName:   s.WorkName,
-			Tier:       s.Tier,
-			IconId:     s.IconID,
-			StartTime:  s.StartTime,
-			PlannedEnd: s.PlannedEnd,
+			SessionId:    s.SessionID,
+			UserId:       s.UserID,
+			UserName:     s.UserName,
+			WorkName:     s.WorkName,
+			Tier:         s.Tier,
+			IconId:       s.IconID,
+			IconAssetKey: s.IconAssetKey,
+			StartTime:    s.StartTime,
+			PlannedEnd:   s.PlannedEnd,
 		})
 	}
 
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+const (
+	// AdminActorHeader 操作した管理者名を指定するヘッダー（監査ログに記録される）
+	AdminActorHeader = "X-Admin-Actor"
+	// DefaultAdminActor ヘッダーがない場合の管理者名
+	DefaultAdminActor = "admin"
+
+	// adminPathPrefix 管理者トークンを要求するパスのプレフィックス
+	adminPathPrefix = "/api/admin/"
+	// maxAdminActorLength 管理者名の最大長
+	maxAdminActorLength = 100
+)
+
+type adminActorKey struct{}
+
+// AdminAuthMiddleware /api/admin/* へのリクエストを Bearer トークンで認証する
+// トークンが未設定の場合、管理 API は無効になる
+type AdminAuthMiddleware struct {
+	token string
+}
+
+// NewAdminAuthMiddleware 管理者認証ミドルウェアを作成する
+func NewAdminAuthMiddleware(token string) *AdminAuthMiddleware {
+	return &AdminAuthMiddleware{token: token}
+}
+
+// Handler chi の r.Use に渡すミドルウェア関数
+func (m *AdminAuthMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, adminPathPrefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if m.token == "" {
+			writeError(w, http.StatusForbidden, "Admin API is disabled: ADMIN_API_TOKEN is not configured")
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(m.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			writeError(w, http.StatusUnauthorized, "Invalid admin token")
+			return
+		}
+
+		actor := strings.TrimSpace(r.Header.Get(AdminActorHeader))
+		if actor == "" {
+			actor = DefaultAdminActor
+		}
+		if len(actor) > maxAdminActorLength {
+			writeError(w, http.StatusBadRequest, AdminActorHeader+" is too long")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithAdminActor(r.Context(), actor)))
+	})
+}
+
+// WithAdminActor 管理者名をコンテキストに設定する
+func WithAdminActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, adminActorKey{}, actor)
+}
+
+// AdminActorFromContext コンテキストから管理者名を取得する
+func AdminActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(adminActorKey{}).(string); ok {
+		return actor
+	}
+	return DefaultAdminActor
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	var gotActor string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotActor = AdminActorFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		token      string
+		path       string
+		header     map[string]string
+		wantStatus int
+		wantActor  string
+	}{
+		{name: "管理 API 以外は素通り", token: "", path: "/api/sessions", wantStatus: http.StatusOK, wantActor: DefaultAdminActor},
+		{name: "トークン未設定なら無効", token: "", path: "/api/admin/banned-terms", header: map[string]string{"Authorization": "Bearer "}, wantStatus: http.StatusForbidden},
+		{name: "トークンなし", token: "secret", path: "/api/admin/banned-terms", wantStatus: http.StatusUnauthorized},
+		{name: "トークン不一致", token: "secret", path: "/api/admin/banned-terms", header: map[string]string{"Authorization": "Bearer wrong"}, wantStatus: http.StatusUnauthorized},
+		{name: "正しいトークン", token: "secret", path: "/api/admin/banned-terms", header: map[string]string{"Authorization": "Bearer secret"}, wantStatus: http.StatusOK, wantActor: DefaultAdminActor},
+		{name: "管理者名を指定", token: "secret", path: "/api/admin/audit-logs", header: map[string]string{"Authorization": "Bearer secret", AdminActorHeader: " moderator "}, wantStatus: http.StatusOK, wantActor: "moderator"},
+		{name: "管理者名が長すぎる", token: "secret", path: "/api/admin/audit-logs", header: map[string]string{"Authorization": "Bearer secret", AdminActorHeader: strings.Repeat("a", maxAdminActorLength+1)}, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotActor = ""
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+
+			NewAdminAuthMiddleware(tt.token).Handler(next).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if gotActor != tt.wantActor {
+				t.Errorf("expected actor %q, got %q", tt.wantActor, gotActor)
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("401 response should include WWW-Authenticate")
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	// corsAllowedMethods プリフライトに返すメソッド
+	corsAllowedMethods = "GET, POST, PUT, PATCH, DELETE"
+	// corsAllowedHeaders プリフライトに返すリクエストヘッダー
+	corsAllowedHeaders = "Authorization, Content-Type, Idempotency-Key, Last-Event-ID, " + AdminActorHeader
+	// corsExposedHeaders ブラウザのスクリプトから読めるレスポンスヘッダー
+	corsExposedHeaders = "Retry-After"
+	// corsMaxAge プリフライトの結果をキャッシュしてよい秒数
+	corsMaxAge = "600"
+)
+
+// CORSMiddleware 許可したオリジンのブラウザからの API 呼び出しに CORS ヘッダーを付ける
+// 許可するオリジンが空なら何もしない（同一オリジン・nginx 経由のみ）。"*" はすべてのオリジンを許可する
+type CORSMiddleware struct {
+	origins []string
+}
+
+// NewCORSMiddleware CORS ミドルウェアを作成する
+func NewCORSMiddleware(allowedOrigins []string) *CORSMiddleware {
+	return &CORSMiddleware{origins: allowedOrigins}
+}
+
+// Handler chi の r.Use に渡すミドルウェア関数
+func (m *CORSMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || len(m.origins) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+		if !m.allowed(origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Expose-Headers", corsExposedHeaders)
+		// プリフライトはハンドラーまで通さない
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", corsAllowedMethods)
+			w.Header().Set("Access-Control-Allow-Headers", corsAllowedHeaders)
+			w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CheckOrigin WebSocket の接続を受け付けるか（websocket.Upgrader.CheckOrigin に渡す）
+// Origin のないクライアント（ブラウザ以外）と、許可するオリジンが空の場合は受け付ける
+func (m *CORSMiddleware) CheckOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	return origin == "" || len(m.origins) == 0 || m.allowed(origin)
+}
+
+func (m *CORSMiddleware) allowed(origin string) bool {
+	return slices.ContainsFunc(m.origins, func(o string) bool {
+		return o == "*" || strings.EqualFold(o, origin)
+	})
+}
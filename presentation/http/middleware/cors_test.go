@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	m := NewCORSMiddleware([]string{"http://localhost:5173"})
+	called := false
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	// 許可したオリジンのプリフライトはハンドラーまで通さない
+	req := httptest.NewRequest(http.MethodOptions, "/api/commands/join", nil)
+	req.Header.Set("Origin", "http://localhost:5173")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent || called {
+		t.Fatalf("expected preflight to be answered with 204, got %d (handler called: %v)", rec.Code, called)
+	}
+	if rec.Header().Get("Access-Control-Allow-Origin") != "http://localhost:5173" || rec.Header().Get("Access-Control-Allow-Headers") == "" {
+		t.Errorf("unexpected preflight headers: %v", rec.Header())
+	}
+
+	// 許可していないオリジンには CORS ヘッダーを付けない
+	req = httptest.NewRequest(http.MethodGet, "/api/sessions/active", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if !called || rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("expected the request to pass without CORS headers, got %v", rec.Header())
+	}
+	if m.CheckOrigin(req) {
+		t.Errorf("expected websocket from a disallowed origin to be rejected")
+	}
+	if !m.CheckOrigin(httptest.NewRequest(http.MethodGet, "/ws", nil)) {
+		t.Errorf("expected websocket without Origin to be accepted")
+	}
+}
+
+func TestCORSMiddleware_NoOrigins(t *testing.T) {
+	m := NewCORSMiddleware(nil)
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	req.Header.Set("Origin", "https://anywhere.example")
+	if !m.CheckOrigin(req) {
+		t.Errorf("expected every origin to be accepted when none are configured")
+	}
+	rec := httptest.NewRecorder()
+	m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rec, req)
+	if rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("expected no CORS headers, got %v", rec.Header())
+	}
+}
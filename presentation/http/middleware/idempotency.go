@@ -0,0 +1,256 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/presentation/http/dto"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+)
+
+const (
+	// IdempotencyKeyHeader クライアントが冪等キーを指定するヘッダー
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader 保存済みレスポンスの再送であることを示すヘッダー
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// DefaultIdempotencyKeyTTL 冪等キーの既定の有効期間
+	DefaultIdempotencyKeyTTL = 24 * time.Hour
+
+	// idempotentPathPrefix 冪等キーを受け付けるパスのプレフィックス
+	idempotentPathPrefix = "/api/commands/"
+	// maxIdempotentRequestBodyBytes 冪等キー付きリクエストのボディの上限（超えた場合は 413）
+	maxIdempotentRequestBodyBytes = 1 << 20
+)
+
+// IdempotencyMiddleware POST /api/commands/* のリクエストを Idempotency-Key で重複排除する
+// 同じキーの再送には保存済みのレスポンスを返し、別のボディでの再利用は拒否する
+type IdempotencyMiddleware struct {
+	idempotencyRepository repository.IdempotencyRepository
+	ttl                   time.Duration
+	now                   func() time.Time
+}
+
+// NewIdempotencyMiddleware 冪等キーミドルウェアを作成する
+func NewIdempotencyMiddleware(
+	idempotencyRepository repository.IdempotencyRepository,
+	ttl time.Duration,
+) *IdempotencyMiddleware {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyKeyTTL
+	}
+	return &IdempotencyMiddleware{
+		idempotencyRepository: idempotencyRepository,
+		ttl:                   ttl,
+		now:                   func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Handler chi の r.Use に渡すミドルウェア関数
+func (m *IdempotencyMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" || r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, idempotentPathPrefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// ボディを読み込んで指紋を計算し、後続のハンドラー用に戻す
+		// 上限を超えるボディは切り詰めずに拒否する（一部だけを指紋にすると別のリクエストと衝突する）
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentRequestBodyBytes+1))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+			return
+		}
+		if len(body) > maxIdempotentRequestBodyBytes {
+			writeError(w, http.StatusRequestEntityTooLarge, "Request body is too large")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		record, err := domain.NewIdempotencyRecord(key, requestHash(r, body), m.ttl, m.now)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid Idempotency-Key: "+err.Error())
+			return
+		}
+
+		existing, claimed, err := m.idempotencyRepository.Claim(r.Context(), record)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to claim idempotency key: "+err.Error())
+			return
+		}
+
+		if !claimed {
+			m.replay(w, existing, record.RequestHash)
+			return
+		}
+
+		// クライアントが切断してもレコードの状態は確定させる
+		storeCtx := context.WithoutCancel(r.Context())
+
+		// ハンドラーが panic した場合もキーを解放し、panic は外側の Recoverer に任せる
+		defer func() {
+			if p := recover(); p != nil {
+				m.release(storeCtx, record.Key)
+				panic(p)
+			}
+		}()
+
+		recorder := newResponseRecorder(w)
+		next.ServeHTTP(recorder, r)
+
+		// サーバーエラーとレート制限（429）は待てば回復するため、キーを解放して同じキーでの再実行を許可する
+		if recorder.status >= http.StatusInternalServerError || recorder.status == http.StatusTooManyRequests {
+			m.release(storeCtx, record.Key)
+			return
+		}
+
+		if err := record.Complete(recorder.status, recorder.handlerHeader(), recorder.body.Bytes()); err != nil {
+			logging.FromContext(storeCtx).Error("failed to complete idempotency record", "key", record.Key, logging.Err(err))
+			return
+		}
+		if err := m.idempotencyRepository.Complete(storeCtx, record); err != nil {
+			logging.FromContext(storeCtx).Error("failed to store idempotent response", "key", record.Key, logging.Err(err))
+		}
+	})
+}
+
+// release 処理中のキーを解放して同じキーでの再実行を許可する
+func (m *IdempotencyMiddleware) release(ctx context.Context, key string) {
+	if err := m.idempotencyRepository.Release(ctx, key); err != nil {
+		logging.FromContext(ctx).Error("failed to release idempotency key", "key", key, logging.Err(err))
+	}
+}
+
+// replay 既存レコードの状態に応じて保存済みレスポンスの再送またはエラーを返す
+func (m *IdempotencyMiddleware) replay(w http.ResponseWriter, existing *domain.IdempotencyRecord, hash string) {
+	if !existing.Matches(hash) {
+		writeError(w, http.StatusUnprocessableEntity, "Idempotency-Key が別のリクエストで使用されています。")
+		return
+	}
+	if !existing.IsCompleted() {
+		writeError(w, http.StatusConflict, "同じ Idempotency-Key のリクエストを処理中です。")
+		return
+	}
+
+	// ハンドラーが設定したヘッダーも元のレスポンスと同じにする（ヘッダーを保存する前のレコードは JSON とみなす）
+	for key, values := range existing.ResponseHeaders {
+		w.Header()[key] = values
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(existing.StatusCode)
+	_, _ = w.Write(existing.ResponseBody)
+}
+
+// PurgeExpired 期限切れの冪等キーを削除する
+func (m *IdempotencyMiddleware) PurgeExpired(ctx context.Context) (int64, error) {
+	return m.idempotencyRepository.DeleteExpired(ctx, m.now())
+}
+
+// RunPurge interval ごとに期限切れの冪等キーを削除する（ctx がキャンセルされるまでブロックする）
+func (m *IdempotencyMiddleware) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := m.PurgeExpired(ctx)
+			if err != nil {
+				if !errors.Is(err, context.Canceled) {
+					logging.FromContext(ctx).Error("failed to purge expired idempotency keys", logging.Err(err))
+				}
+				continue
+			}
+			if deleted > 0 {
+				logging.FromContext(ctx).Info("purged expired idempotency keys", "deleted", deleted)
+			}
+		}
+	}
+}
+
+// requestHash メソッド・パス・ボディからリクエストの指紋を計算する
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte(" "))
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte("\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder ハンドラーのレスポンスをクライアントに返しつつ記録する
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+	// before ハンドラーを呼ぶ前のヘッダー（CORS など外側のミドルウェアが設定したもの）
+	before http.Header
+	// header ハンドラーが設定したヘッダー（レスポンスを書き始めた時点のもの）
+	header http.Header
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{ResponseWriter: w, status: http.StatusOK, before: w.Header().Clone()}
+}
+
+func (rr *responseRecorder) WriteHeader(status int) {
+	if !rr.wroteHeader {
+		rr.status = status
+		rr.wroteHeader = true
+		rr.captureHeader()
+	}
+	rr.ResponseWriter.WriteHeader(status)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	if !rr.wroteHeader {
+		rr.wroteHeader = true
+		rr.captureHeader()
+	}
+	rr.body.Write(b)
+	return rr.ResponseWriter.Write(b)
+}
+
+// captureHeader ハンドラーが追加・変更したヘッダーだけを記録する
+// 外側のミドルウェアのヘッダーは再送時にもそのリクエストに合わせて設定されるため保存しない
+func (rr *responseRecorder) captureHeader() {
+	rr.header = make(http.Header)
+	for key, values := range rr.ResponseWriter.Header() {
+		if !slices.Equal(rr.before[key], values) {
+			rr.header[key] = slices.Clone(values)
+		}
+	}
+}
+
+// handlerHeader 保存するヘッダー（ハンドラーが何も書かなかった場合は終了時点のもの）
+func (rr *responseRecorder) handlerHeader() map[string][]string {
+	if rr.header == nil {
+		rr.captureHeader()
+	}
+	return rr.header
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(dto.ErrorResponse{Error: message})
+}
@@ -0,0 +1,276 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// インメモリの冪等キーリポジトリ
+type fakeIdempotencyRepository struct {
+	mu      sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+}
+
+func newFakeIdempotencyRepository() *fakeIdempotencyRepository {
+	return &fakeIdempotencyRepository{records: make(map[string]*domain.IdempotencyRecord)}
+}
+
+func (f *fakeIdempotencyRepository) Claim(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if existing, ok := f.records[record.Key]; ok && existing.ExpiresAt.After(record.CreatedAt) {
+		copied := *existing
+		return &copied, false, nil
+	}
+	copied := *record
+	f.records[record.Key] = &copied
+	return nil, true, nil
+}
+
+func (f *fakeIdempotencyRepository) FindByKey(ctx context.Context, key string) (*domain.IdempotencyRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if existing, ok := f.records[key]; ok {
+		copied := *existing
+		return &copied, nil
+	}
+	return nil, domain.ErrIdempotencyKeyNotFound
+}
+
+func (f *fakeIdempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *record
+	f.records[record.Key] = &copied
+	return nil
+}
+
+func (f *fakeIdempotencyRepository) Release(ctx context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if existing, ok := f.records[key]; ok && !existing.IsCompleted() {
+		delete(f.records, key)
+	}
+	return nil
+}
+
+func (f *fakeIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var deleted int64
+	for key, record := range f.records {
+		if !record.ExpiresAt.After(now) {
+			delete(f.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// countingHandler 呼び出し回数を数え、指定ステータスでボディを返すハンドラー
+func countingHandler(calls *int, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, `{"call":%d}`, *calls)
+	})
+}
+
+func doRequest(h http.Handler, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	t.Run("同じキーの再送は保存済みレスポンスを返す", func(t *testing.T) {
+		calls := 0
+		m := NewIdempotencyMiddleware(newFakeIdempotencyRepository(), time.Hour)
+		h := m.Handler(countingHandler(&calls, http.StatusOK))
+
+		first := doRequest(h, "/api/commands/more", "retry-1", `{"user_name":"yamada","minutes":120}`)
+		second := doRequest(h, "/api/commands/more", "retry-1", `{"user_name":"yamada","minutes":120}`)
+
+		if calls != 1 {
+			t.Fatalf("expected handler to be called once, got %d", calls)
+		}
+		if second.Code != first.Code || second.Body.String() != first.Body.String() {
+			t.Errorf("expected replayed response %d %q, got %d %q", first.Code, first.Body.String(), second.Code, second.Body.String())
+		}
+		if second.Header().Get(IdempotentReplayedHeader) != "true" {
+			t.Error("expected replayed header to be set")
+		}
+	})
+
+	t.Run("同じキーを別のボディで再利用した場合はエラー", func(t *testing.T) {
+		calls := 0
+		m := NewIdempotencyMiddleware(newFakeIdempotencyRepository(), time.Hour)
+		h := m.Handler(countingHandler(&calls, http.StatusOK))
+
+		doRequest(h, "/api/commands/more", "retry-2", `{"user_name":"yamada","minutes":120}`)
+		rec := doRequest(h, "/api/commands/more", "retry-2", `{"user_name":"yamada","minutes":30}`)
+
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected status 422, got %d", rec.Code)
+		}
+		if calls != 1 {
+			t.Errorf("expected handler to be called once, got %d", calls)
+		}
+	})
+
+	t.Run("サーバーエラーの場合はキーを解放して再実行を許可する", func(t *testing.T) {
+		calls := 0
+		m := NewIdempotencyMiddleware(newFakeIdempotencyRepository(), time.Hour)
+		h := m.Handler(countingHandler(&calls, http.StatusInternalServerError))
+
+		doRequest(h, "/api/commands/join", "retry-3", `{"user_name":"yamada"}`)
+		doRequest(h, "/api/commands/join", "retry-3", `{"user_name":"yamada"}`)
+
+		if calls != 2 {
+			t.Errorf("expected handler to be called twice, got %d", calls)
+		}
+	})
+
+	t.Run("レート制限（429）の場合はキーを解放して再実行を許可する", func(t *testing.T) {
+		calls := 0
+		m := NewIdempotencyMiddleware(newFakeIdempotencyRepository(), time.Hour)
+		limited := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+
+		rec := doRequest(limited, "/api/commands/join", "retry-9", `{"user_name":"yamada"}`)
+		if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "3" {
+			t.Fatalf("expected 429 with Retry-After, got %d %v", rec.Code, rec.Header())
+		}
+
+		// Retry-After の後に同じキーで再送すれば実行される
+		rec = doRequest(m.Handler(countingHandler(&calls, http.StatusOK)), "/api/commands/join", "retry-9", `{"user_name":"yamada"}`)
+		if rec.Code != http.StatusOK || calls != 2 || rec.Header().Get(IdempotentReplayedHeader) != "" {
+			t.Errorf("expected the retry to run, got status %d after %d calls", rec.Code, calls)
+		}
+	})
+
+	t.Run("再送はハンドラーが設定したヘッダーも返す", func(t *testing.T) {
+		calls := 0
+		m := NewIdempotencyMiddleware(newFakeIdempotencyRepository(), time.Hour)
+		// 外側のミドルウェアが設定するヘッダーは保存しない
+		outer := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Vary", "Origin")
+				next.ServeHTTP(w, r)
+			})
+		}
+		h := outer(m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Content-Type", "text/plain")
+			w.Header().Set("Location", "/api/sessions/1")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("created"))
+		})))
+
+		doRequest(h, "/api/commands/join", "retry-10", `{"user_name":"yamada"}`)
+		rec := doRequest(h, "/api/commands/join", "retry-10", `{"user_name":"yamada"}`)
+
+		if calls != 1 || rec.Code != http.StatusCreated || rec.Body.String() != "created" {
+			t.Fatalf("expected the stored response, got %d %q after %d calls", rec.Code, rec.Body.String(), calls)
+		}
+		if rec.Header().Get("Content-Type") != "text/plain" || rec.Header().Get("Location") != "/api/sessions/1" {
+			t.Errorf("expected the original headers, got %v", rec.Header())
+		}
+		if got := rec.Header().Values("Vary"); len(got) != 1 {
+			t.Errorf("expected the outer header once, got %v", got)
+		}
+	})
+
+	t.Run("ハンドラーが panic した場合もキーを解放する", func(t *testing.T) {
+		calls := 0
+		m := NewIdempotencyMiddleware(newFakeIdempotencyRepository(), time.Hour)
+		panicking := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			panic("boom")
+		}))
+
+		func() {
+			defer func() {
+				if p := recover(); p != "boom" {
+					t.Errorf("expected the panic to propagate, got %v", p)
+				}
+			}()
+			doRequest(panicking, "/api/commands/join", "retry-6", `{"user_name":"yamada"}`)
+		}()
+
+		rec := doRequest(m.Handler(countingHandler(&calls, http.StatusOK)), "/api/commands/join", "retry-6", `{"user_name":"yamada"}`)
+		if rec.Code != http.StatusOK || calls != 2 {
+			t.Errorf("expected the retry to run, got status %d after %d calls", rec.Code, calls)
+		}
+	})
+
+	t.Run("上限を超えるボディは切り詰めずに拒否する", func(t *testing.T) {
+		calls := 0
+		repo := newFakeIdempotencyRepository()
+		m := NewIdempotencyMiddleware(repo, time.Hour)
+		h := m.Handler(countingHandler(&calls, http.StatusOK))
+
+		rec := doRequest(h, "/api/commands/join", "retry-7", strings.Repeat("a", maxIdempotentRequestBodyBytes+1))
+		if rec.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected status 413, got %d", rec.Code)
+		}
+		if calls != 0 {
+			t.Errorf("expected handler not to be called, got %d", calls)
+		}
+		if _, err := repo.FindByKey(context.Background(), "retry-7"); err != domain.ErrIdempotencyKeyNotFound {
+			t.Errorf("expected the key not to be claimed, got %v", err)
+		}
+
+		rec = doRequest(h, "/api/commands/join", "retry-8", strings.Repeat("a", maxIdempotentRequestBodyBytes))
+		if rec.Code != http.StatusOK || calls != 1 {
+			t.Errorf("expected a body at the limit to pass, got status %d", rec.Code)
+		}
+	})
+
+	t.Run("TTLを過ぎたキーは再実行される", func(t *testing.T) {
+		calls := 0
+		now := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+		m := NewIdempotencyMiddleware(newFakeIdempotencyRepository(), time.Hour)
+		m.now = func() time.Time { return now }
+		h := m.Handler(countingHandler(&calls, http.StatusOK))
+
+		doRequest(h, "/api/commands/out", "retry-4", `{"user_name":"yamada"}`)
+		now = now.Add(2 * time.Hour)
+		doRequest(h, "/api/commands/out", "retry-4", `{"user_name":"yamada"}`)
+
+		if calls != 2 {
+			t.Errorf("expected handler to be called twice, got %d", calls)
+		}
+	})
+
+	t.Run("キーなしやコマンド以外のパスはそのまま処理する", func(t *testing.T) {
+		calls := 0
+		m := NewIdempotencyMiddleware(newFakeIdempotencyRepository(), time.Hour)
+		h := m.Handler(countingHandler(&calls, http.StatusOK))
+
+		doRequest(h, "/api/commands/more", "", `{"user_name":"yamada","minutes":120}`)
+		doRequest(h, "/api/commands/more", "", `{"user_name":"yamada","minutes":120}`)
+		doRequest(h, "/api/other", "retry-5", `{}`)
+		doRequest(h, "/api/other", "retry-5", `{}`)
+
+		if calls != 4 {
+			t.Errorf("expected handler to be called 4 times, got %d", calls)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// unmatchedRoute どのルートにも一致しなかったリクエストの route ラベル
+const unmatchedRoute = "unmatched"
+
+// HTTPRecorder HTTP リクエストの結果を記録する
+type HTTPRecorder interface {
+	ObserveHTTPRequest(method, route string, status int, elapsed time.Duration)
+}
+
+// MetricsMiddleware リクエストごとにルートのパターン・ステータス・処理時間を記録する
+// ラベルには実際のパスではなくパターン（/api/users/{user_name} など）を使う
+type MetricsMiddleware struct {
+	recorder HTTPRecorder
+	now      func() time.Time
+}
+
+// NewMetricsMiddleware HTTP メトリクスのミドルウェアを作成する
+func NewMetricsMiddleware(recorder HTTPRecorder) *MetricsMiddleware {
+	return &MetricsMiddleware{recorder: recorder, now: time.Now}
+}
+
+// Handler chi の r.Use に渡すミドルウェア関数
+func (m *MetricsMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := m.now()
+		// Hijacker・Flusher を引き継ぐので /ws と SSE もそのまま動く
+		ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		// ルートのパターンはルーティングの後でないと分からない
+		m.recorder.ObserveHTTPRequest(r.Method, routePattern(r), responseStatus(ww), m.now().Sub(start))
+	})
+}
+
+// routePattern リクエストが一致したルートのパターン（どのルートにも一致しなければ unmatchedRoute）
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return unmatchedRoute
+}
+
+// responseStatus 書き込んだステータスコード
+// WriteHeader を呼ばずに書いた、または何も書かなかった場合は 200
+func responseStatus(ww chiMiddleware.WrapResponseWriter) int {
+	if status := ww.Status(); status != 0 {
+		return status
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type recordedRequest struct {
+	method, route string
+	status        int
+	elapsed       time.Duration
+}
+
+type recordingHTTPRecorder struct {
+	requests []recordedRequest
+}
+
+func (r *recordingHTTPRecorder) ObserveHTTPRequest(method, route string, status int, elapsed time.Duration) {
+	r.requests = append(r.requests, recordedRequest{method, route, status, elapsed})
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	recorder := &recordingHTTPRecorder{}
+	m := NewMetricsMiddleware(recorder)
+	t0 := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+	calls := 0
+	m.now = func() time.Time {
+		calls++
+		return t0.Add(time.Duration(calls) * 100 * time.Millisecond)
+	}
+
+	r := chi.NewRouter()
+	r.Use(m.Handler)
+	r.Get("/api/users/{user_name}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	r.Post("/api/commands/join", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/users/yamada", nil),
+		httptest.NewRequest(http.MethodPost, "/api/commands/join", nil),
+		httptest.NewRequest(http.MethodGet, "/nothing/here", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	want := []recordedRequest{
+		// パスではなくルートのパターンで数える
+		{http.MethodGet, "/api/users/{user_name}", http.StatusOK, 100 * time.Millisecond},
+		{http.MethodPost, "/api/commands/join", http.StatusConflict, 100 * time.Millisecond},
+		{http.MethodGet, unmatchedRoute, http.StatusNotFound, 100 * time.Millisecond},
+	}
+	if len(recorder.requests) != len(want) {
+		t.Fatalf("expected %d requests, got %+v", len(want), recorder.requests)
+	}
+	for i, got := range recorder.requests {
+		if got != want[i] {
+			t.Errorf("request %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
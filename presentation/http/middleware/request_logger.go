@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+)
+
+// quietPaths 監視から定期的に呼ばれるパス（アクセスログは Debug で書く）
+var quietPaths = map[string]bool{
+	"/health":  true,
+	"/livez":   true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// RequestLogger リクエスト ID を付けたロガーを context に入れ、終了時にアクセスログを 1 行書く
+// handler・usecase は logging.FromContext でこのロガーを取り出すので、同じリクエストのログを request_id で追える
+// chi の RequestID ミドルウェアの後に置く
+type RequestLogger struct {
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewRequestLogger リクエストのロガーのミドルウェアを作成する
+func NewRequestLogger(logger *slog.Logger) *RequestLogger {
+	return &RequestLogger{logger: logger, now: time.Now}
+}
+
+// Handler chi の r.Use に渡すミドルウェア関数
+func (m *RequestLogger) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := m.now()
+		logger := m.logger.With(logging.KeyRequestID, chiMiddleware.GetReqID(r.Context()))
+		r = r.WithContext(logging.WithLogger(r.Context(), logger))
+
+		ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := responseStatus(ww)
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case quietPaths[r.URL.Path]:
+			level = slog.LevelDebug
+		}
+		logger.LogAttrs(r.Context(), level, "http request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", status),
+			slog.Int("bytes", ww.BytesWritten()),
+			slog.Int64("duration_ms", m.now().Sub(start).Milliseconds()),
+			slog.String("remote_addr", r.RemoteAddr),
+		)
+	})
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+)
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestRequestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.FormatJSON, slog.LevelInfo)
+
+	r := chi.NewRouter()
+	r.Use(chiMiddleware.RequestID)
+	r.Use(NewRequestLogger(logger).Handler)
+	r.Post("/api/commands/join", func(w http.ResponseWriter, r *http.Request) {
+		// usecase 側のログにもリクエスト ID が付く
+		logging.FromContext(r.Context()).Info("session started")
+		w.WriteHeader(http.StatusCreated)
+	})
+	r.Get("/readyz", func(w http.ResponseWriter, r *http.Request) {})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/commands/join", nil)
+	req.Header.Set(chiMiddleware.RequestIDHeader, "req-123")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	// プローブのアクセスログは Debug なので出ない
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	lines := decodeLogLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d:\n%s", len(lines), buf.String())
+	}
+	if lines[0]["msg"] != "session started" || lines[0][logging.KeyRequestID] != "req-123" {
+		t.Errorf("use case log is not correlated: %v", lines[0])
+	}
+	access := lines[1]
+	if access["msg"] != "http request" || access[logging.KeyRequestID] != "req-123" {
+		t.Errorf("unexpected access log: %v", access)
+	}
+	if access["method"] != "POST" || access["path"] != "/api/commands/join" || access["status"] != float64(http.StatusCreated) {
+		t.Errorf("unexpected access log fields: %v", access)
+	}
+}
+
+func TestRequestLogger_ServerErrorLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.FormatJSON, slog.LevelInfo)
+
+	handler := NewRequestLogger(logger).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/sessions", nil))
+
+	lines := decodeLogLines(t, &buf)
+	if len(lines) != 1 || lines[0]["level"] != "ERROR" {
+		t.Errorf("expected one ERROR access log, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
+)
+
+// TracingMiddleware リクエストごとにサーバースパンを作る
+// traceparent ヘッダーがあれば呼び出し元のトレースを引き継ぎ、ログにも trace_id を付ける
+// 監視のプローブ（quietPaths）はトレースしない
+// RequestLogger の後に置く（前に置くと、RequestLogger が入れるロガーに trace_id が付かない）
+type TracingMiddleware struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracingMiddleware トレースのミドルウェアを作成する（otel のグローバルな TracerProvider・伝播を使う）
+func NewTracingMiddleware() *TracingMiddleware {
+	return &TracingMiddleware{tracer: tracing.Tracer(), propagator: otel.GetTextMapPropagator()}
+}
+
+// Handler chi の r.Use に渡すミドルウェア関数
+func (m *TracingMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if quietPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := m.propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := m.tracer.Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+			),
+		)
+		defer span.End()
+		if traceID := tracing.TraceID(ctx); traceID != "" {
+			ctx = logging.With(ctx, logging.KeyTraceID, traceID)
+		}
+
+		ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		// ルートのパターンはルーティングの後でないと分からない
+		route := routePattern(r)
+		status := responseStatus(ww)
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	})
+}
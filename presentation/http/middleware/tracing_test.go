@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
+)
+
+func newTestTracingMiddleware() (*TracingMiddleware, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	m := NewTracingMiddleware()
+	m.tracer = provider.Tracer("test")
+	m.propagator = propagation.TraceContext{}
+	return m, recorder
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	m, recorder := newTestTracingMiddleware()
+
+	var traceID string
+	r := chi.NewRouter()
+	r.Use(m.Handler)
+	r.Get("/api/users/{user_name}", func(w http.ResponseWriter, r *http.Request) {
+		traceID = tracing.TraceID(r.Context())
+		w.WriteHeader(http.StatusNotFound)
+	})
+	r.Get("/readyz", func(w http.ResponseWriter, r *http.Request) {})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/yamada", nil)
+	// 呼び出し元のトレースを引き継ぐ
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	// プローブはトレースしない
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "GET /api/users/{user_name}" {
+		t.Errorf("unexpected span name %q", span.Name())
+	}
+	if got := span.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" || traceID != got {
+		t.Errorf("trace was not propagated: span %s, handler %s", got, traceID)
+	}
+	attrs := map[string]any{}
+	for _, kv := range span.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	if attrs[string(semconv.HTTPRouteKey)] != "/api/users/{user_name}" || attrs[string(semconv.HTTPResponseStatusCodeKey)] != int64(http.StatusNotFound) {
+		t.Errorf("unexpected attributes: %v", attrs)
+	}
+	// 4xx はクライアントの誤りなのでエラーにしない
+	if span.Status().Code == codes.Error {
+		t.Errorf("4xx should not mark the span as an error")
+	}
+}
+
+func TestTracingMiddleware_ServerError(t *testing.T) {
+	m, recorder := newTestTracingMiddleware()
+
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/commands/join", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Status().Code != codes.Error {
+		t.Fatalf("expected one error span, got %v", spans)
+	}
+	if spans[0].Name() != "POST unmatched" {
+		t.Errorf("unexpected span name %q", spans[0].Name())
+	}
+}
@@ -0,0 +1,69 @@
+package twitchirc
+
+import (
+	"strings"
+)
+
+// ChatMessage チャンネルへの発言（PRIVMSG）
+type ChatMessage struct {
+	ID          string // タグ id（返信の reply-parent-msg-id に使う）
+	Channel     string // "#" を除いたチャンネル名
+	UserID      string // タグ user-id（Twitch のユーザー ID）
+	Login       string // ログイン名（小文字）
+	DisplayName string // タグ display-name（なければログイン名）
+	Text        string
+	Badges      map[string]string // タグ badges（例: broadcaster → "1"、subscriber → "12"）
+}
+
+// UserName コマンドに渡すユーザー名（EventSub の user_name と同じく表示名）
+func (m *ChatMessage) UserName() string {
+	if m.DisplayName != "" {
+		return m.DisplayName
+	}
+	return m.Login
+}
+
+// HasBadge バッジを持っているか
+func (m *ChatMessage) HasBadge(name string) bool {
+	_, ok := m.Badges[name]
+	return ok
+}
+
+// IsModerator 配信者またはモデレーターか
+func (m *ChatMessage) IsModerator() bool {
+	return m.HasBadge("broadcaster") || m.HasBadge("moderator")
+}
+
+// newChatMessage PRIVMSG を ChatMessage にする（PRIVMSG でなければ nil）
+func newChatMessage(msg *Message) *ChatMessage {
+	if msg.Command != "PRIVMSG" || len(msg.Params) < 2 {
+		return nil
+	}
+	chat := &ChatMessage{
+		ID:          msg.Tags["id"],
+		Channel:     strings.TrimPrefix(msg.Param(0), "#"),
+		UserID:      msg.Tags["user-id"],
+		Login:       msg.Nick(),
+		DisplayName: msg.Tags["display-name"],
+		Text:        msg.Param(1),
+		Badges:      parseBadges(msg.Tags["badges"]),
+	}
+	// /me の発言（CTCP ACTION）は本文だけを取り出す
+	if action, ok := strings.CutPrefix(chat.Text, "\x01ACTION "); ok {
+		chat.Text = strings.TrimSuffix(action, "\x01")
+	}
+	return chat
+}
+
+// parseBadges "broadcaster/1,subscriber/12" 形式のバッジを解析する
+func parseBadges(s string) map[string]string {
+	badges := make(map[string]string)
+	for _, badge := range strings.Split(s, ",") {
+		if badge == "" {
+			continue
+		}
+		name, version, _ := strings.Cut(badge, "/")
+		badges[name] = version
+	}
+	return badges
+}
@@ -0,0 +1,376 @@
+package twitchirc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/usecase/ratelimit"
+)
+
+// ErrAuthenticationFailed トークンが無効などでログインできなかった（再接続しても直らないので Run を終える）
+var ErrAuthenticationFailed = errors.New("twitch irc authentication failed")
+
+// errServerReconnect サーバーから RECONNECT を受け取った（メンテナンス前に送られる）
+var errServerReconnect = errors.New("server requested reconnect")
+
+const (
+	// MinBackoff 切断後、最初の再接続までの待ち時間（以降は倍々に伸ばす）
+	MinBackoff = time.Second
+	// MaxBackoff 再接続の待ち時間の上限
+	MaxBackoff = 2 * time.Minute
+	// MaxMessageLength Twitch が受け付ける発言の最大長（文字数）
+	MaxMessageLength = 500
+
+	// loginTimeout 接続してからログインの完了（001）を待つ時間
+	loginTimeout = 15 * time.Second
+	// pingInterval こちらから PING を送る間隔（Twitch からも約 5 分ごとに PING が来る）
+	pingInterval = 4 * time.Minute
+	// pongTimeout PING を送ってから何も受信しなければ切断とみなすまでの時間
+	pongTimeout = 30 * time.Second
+	// incomingQueueSize 処理待ちの発言の最大数（超えた発言は捨てる）
+	incomingQueueSize = 64
+	// outgoingQueueSize 送信待ちの返信の最大数（超えた返信は捨てる）
+	outgoingQueueSize = 32
+	// sendLimitKey 送信のトークンバケットのキー
+	sendLimitKey = "twitchirc:privmsg"
+)
+
+// DefaultSendLimit 返信の送信レート（Twitch の通常ユーザーの上限は 30 秒に 20 件）
+var DefaultSendLimit = domain.RateLimit{Count: 20, Period: 30 * time.Second}
+
+// Handler 受け取った発言を処理し、返信する文を返す（空なら返信しない）
+type Handler interface {
+	HandleChat(ctx context.Context, msg *ChatMessage) string
+}
+
+// Config Twitch チャットへの接続設定
+type Config struct {
+	Addr      string   // 空なら DefaultAddr
+	Nick      string   // ボットのログイン名
+	Token     string   // OAuth トークン（"oauth:" は省略可、chat:read と chat:edit が必要）
+	Channels  []string // 参加するチャンネル（"#" は省略可）
+	SendLimit domain.RateLimit
+}
+
+// sendLimiter 返信の送信レートを制限するトークンバケット（ratelimit.MemoryStore が実装する）
+type sendLimiter interface {
+	Take(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (ratelimit.Decision, error)
+}
+
+// Client Twitch チャットのクライアント
+// 発言は 1 つのゴルーチンで受信順に Handler に渡すので、同じユーザーの !in と !change の順序は入れ替わらない
+type Client struct {
+	cfg        Config
+	handler    Handler
+	sendLimit  sendLimiter
+	out        chan string
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	pingEvery  time.Duration
+	now        func() time.Time
+}
+
+// NewClient creates a new Twitch chat client
+func NewClient(cfg Config, handler Handler) *Client {
+	if cfg.Addr == "" {
+		cfg.Addr = DefaultAddr
+	}
+	if cfg.SendLimit == (domain.RateLimit{}) {
+		cfg.SendLimit = DefaultSendLimit
+	}
+	cfg.Nick = strings.ToLower(cfg.Nick)
+	if !strings.HasPrefix(cfg.Token, "oauth:") {
+		cfg.Token = "oauth:" + cfg.Token
+	}
+	channels := make([]string, 0, len(cfg.Channels))
+	for _, ch := range cfg.Channels {
+		if ch = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(ch), "#")); ch != "" {
+			channels = append(channels, ch)
+		}
+	}
+	cfg.Channels = channels
+
+	return &Client{
+		cfg:        cfg,
+		handler:    handler,
+		sendLimit:  ratelimit.NewMemoryStore(),
+		out:        make(chan string, outgoingQueueSize),
+		minBackoff: MinBackoff,
+		maxBackoff: MaxBackoff,
+		pingEvery:  pingInterval,
+		now:        time.Now,
+	}
+}
+
+// Run ctx が終わるまで接続を保ち、切断されたらバックオフを挟んで再接続する
+// ログインに失敗した場合だけ ErrAuthenticationFailed を返す
+func (c *Client) Run(ctx context.Context) error {
+	failures := 0
+	for {
+		loggedIn, err := c.session(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, ErrAuthenticationFailed) {
+			return err
+		}
+		if loggedIn {
+			failures = 0
+		}
+		delay := c.backoff(failures)
+		failures++
+		logging.FromContext(ctx).Warn("twitchirc: disconnected", "reconnect_in", delay.String(), logging.Err(err))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// Say チャンネルに発言する（parentID を指定するとその発言への返信になる）
+// 送信はレート制限に従って順に行い、送信待ちが溢れた場合は捨てて false を返す（ctx のロガーに記録する）
+func (c *Client) Say(ctx context.Context, channel, parentID, text string) bool {
+	msg := &Message{
+		Command: "PRIVMSG",
+		Params:  []string{"#" + strings.TrimPrefix(channel, "#"), sanitizeText(text)},
+	}
+	if parentID != "" {
+		msg.Tags = map[string]string{"reply-parent-msg-id": parentID}
+	}
+	select {
+	case c.out <- msg.String():
+		return true
+	default:
+		logging.FromContext(ctx).Warn("twitchirc: send queue is full; dropping message", "channel", channel)
+		return false
+	}
+}
+
+// backoff failures 回連続で失敗した後の待ち時間
+func (c *Client) backoff(failures int) time.Duration {
+	d := c.minBackoff
+	for i := 0; i < failures; i++ {
+		d *= 2
+		if d >= c.maxBackoff {
+			return c.maxBackoff
+		}
+	}
+	return d
+}
+
+// session 1 回の接続。ログインまで進んだかと、切断の理由を返す
+func (c *Client) session(ctx context.Context) (bool, error) {
+	conn, err := dial(ctx, c.cfg.Addr)
+	if err != nil {
+		return false, err
+	}
+	sessionCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+
+	// ctx が終わったら読み込み中の ReadLine を抜けさせる
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-sessionCtx.Done()
+		_ = conn.Close()
+	}()
+
+	if err := c.login(conn); err != nil {
+		return false, err
+	}
+
+	incoming := make(chan *ChatMessage, incomingQueueSize)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		c.dispatch(ctx, incoming)
+	}()
+	go func() {
+		defer wg.Done()
+		c.writeLoop(sessionCtx, conn)
+	}()
+	go func() {
+		defer wg.Done()
+		c.keepalive(sessionCtx, conn)
+	}()
+
+	err = c.readLoop(ctx, conn, incoming)
+	close(incoming)
+	return true, err
+}
+
+// login CAP・PASS・NICK を送り、001 を受け取ったらチャンネルに参加する
+func (c *Client) login(conn conn) error {
+	for _, line := range []string{
+		"CAP REQ :twitch.tv/tags twitch.tv/commands",
+		"PASS " + c.cfg.Token,
+		"NICK " + c.cfg.Nick,
+	} {
+		if err := conn.WriteLine(line); err != nil {
+			return err
+		}
+	}
+
+	if err := conn.SetReadDeadline(c.now().Add(loginTimeout)); err != nil {
+		return err
+	}
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return fmt.Errorf("waiting for login: %w", err)
+		}
+		msg, err := ParseMessage(line)
+		if err != nil {
+			continue
+		}
+		switch msg.Command {
+		case "001":
+			if len(c.cfg.Channels) == 0 {
+				return nil
+			}
+			return conn.WriteLine("JOIN #" + strings.Join(c.cfg.Channels, ",#"))
+		case "PING":
+			if err := conn.WriteLine("PONG :" + msg.Param(0)); err != nil {
+				return err
+			}
+		case "NOTICE":
+			if isAuthFailure(msg.Param(1)) {
+				return fmt.Errorf("%w: %s", ErrAuthenticationFailed, msg.Param(1))
+			}
+		}
+	}
+}
+
+// readLoop 切断されるまで受信する
+func (c *Client) readLoop(ctx context.Context, conn conn, incoming chan<- *ChatMessage) error {
+	logger := logging.FromContext(ctx)
+	for {
+		// こちらの PING への応答が来るはずなので、それより長く何も届かなければ切断とみなす
+		if err := conn.SetReadDeadline(c.now().Add(c.pingEvery + pongTimeout)); err != nil {
+			return err
+		}
+		line, err := conn.ReadLine()
+		if err != nil {
+			return err
+		}
+		msg, err := ParseMessage(line)
+		if err != nil {
+			continue
+		}
+
+		switch msg.Command {
+		case "PING":
+			if err := conn.WriteLine("PONG :" + msg.Param(0)); err != nil {
+				return err
+			}
+		case "RECONNECT":
+			return errServerReconnect
+		case "NOTICE":
+			if isAuthFailure(msg.Param(1)) {
+				return fmt.Errorf("%w: %s", ErrAuthenticationFailed, msg.Param(1))
+			}
+			logger.Info("twitchirc: notice", "channel", msg.Param(0), "notice", msg.Param(1))
+		case "PRIVMSG":
+			chat := newChatMessage(msg)
+			if chat == nil || chat.Login == c.cfg.Nick {
+				continue
+			}
+			select {
+			case incoming <- chat:
+			default:
+				logger.Warn("twitchirc: incoming queue is full; dropping message", "login", chat.Login)
+			}
+		}
+	}
+}
+
+// dispatch 発言を受信順に Handler に渡し、返信する
+// コマンドの途中で切断されても処理は最後まで行うため、接続ではなく Run の ctx を使う
+func (c *Client) dispatch(ctx context.Context, incoming <-chan *ChatMessage) {
+	for msg := range incoming {
+		// HTTP のリクエスト ID の代わりに、チャットの発言 ID でログを関連付ける
+		msgCtx := logging.With(ctx, logging.KeyRequestID, msg.ID, logging.KeyUserName, msg.UserName())
+		if reply := c.handler.HandleChat(msgCtx, msg); reply != "" {
+			c.Say(msgCtx, msg.Channel, msg.ID, reply)
+		}
+	}
+}
+
+// writeLoop 送信待ちの返信をレート制限に従って送る
+func (c *Client) writeLoop(ctx context.Context, conn conn) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case line := <-c.out:
+			if !c.waitSendToken(ctx) {
+				return
+			}
+			if err := conn.WriteLine(line); err != nil {
+				logging.FromContext(ctx).Error("twitchirc: failed to send message", logging.Err(err))
+				return
+			}
+		}
+	}
+}
+
+// waitSendToken 送信のトークンが取れるまで待つ（ctx が終わったら false）
+func (c *Client) waitSendToken(ctx context.Context) bool {
+	for {
+		decision, err := c.sendLimit.Take(ctx, sendLimitKey, c.cfg.SendLimit, c.now())
+		if err != nil || decision.Allowed {
+			return true
+		}
+		timer := time.NewTimer(decision.RetryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+	}
+}
+
+// keepalive 定期的に PING を送る
+func (c *Client) keepalive(ctx context.Context, conn conn) {
+	ticker := time.NewTicker(c.pingEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := conn.WriteLine("PING :tmi.twitch.tv"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// isAuthFailure ログイン失敗の NOTICE か
+func isAuthFailure(text string) bool {
+	return strings.Contains(text, "Login authentication failed") || strings.Contains(text, "Improperly formatted auth")
+}
+
+// sanitizeText 改行を空白にし、Twitch の上限の長さに切り詰める
+func sanitizeText(text string) string {
+	text = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(text)
+	if utf8.RuneCountInString(text) <= MaxMessageLength {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:MaxMessageLength-1]) + "…"
+}
@@ -0,0 +1,218 @@
+package twitchirc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+const expectTimeout = 2 * time.Second
+
+// startClient fake に接続するクライアントを起動する（テストの終わりに止める）
+func startClient(t *testing.T, fake *FakeServer, cfg Config, handler Handler) (*Client, <-chan error) {
+	t.Helper()
+	cfg.Addr = fake.Addr()
+	if cfg.Nick == "" {
+		cfg.Nick = "WorkBot"
+	}
+	if cfg.Token == "" {
+		cfg.Token = "token"
+	}
+	client := NewClient(cfg, handler)
+	client.minBackoff = 10 * time.Millisecond
+	client.maxBackoff = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		done <- client.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case <-stopped:
+		case <-time.After(expectTimeout):
+			t.Error("client did not stop")
+		}
+	})
+	return client, done
+}
+
+func newFakeServer(t *testing.T) *FakeServer {
+	t.Helper()
+	fake, err := NewFakeServer()
+	if err != nil {
+		t.Fatalf("failed to start fake irc server: %v", err)
+	}
+	t.Cleanup(fake.Close)
+	return fake
+}
+
+func expect(t *testing.T, fake *FakeServer, prefix string) string {
+	t.Helper()
+	line, err := fake.Expect(prefix, expectTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return line
+}
+
+func expectContains(t *testing.T, fake *FakeServer, substr string) string {
+	t.Helper()
+	line, err := fake.ExpectContains(substr, expectTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return line
+}
+
+// handlerFunc テスト用の Handler
+type handlerFunc func(ctx context.Context, msg *ChatMessage) string
+
+func (f handlerFunc) HandleChat(ctx context.Context, msg *ChatMessage) string {
+	return f(ctx, msg)
+}
+
+func TestClient_LoginAndReply(t *testing.T) {
+	fake := newFakeServer(t)
+	fake.Token = "s3cret"
+	commands, calls := newTestCommands(nil)
+	startClient(t, fake, Config{Nick: "WorkBot", Token: "s3cret", Channels: []string{"#Yamada_Ch", "other"}}, commands)
+
+	expect(t, fake, "CAP REQ :twitch.tv/tags twitch.tv/commands")
+	expect(t, fake, "PASS oauth:s3cret")
+	expect(t, fake, "NICK workbot")
+	expect(t, fake, "JOIN #yamada_ch,#other")
+
+	fake.Privmsg("yamada_ch", "viewer", "msg-1", "!in 資料作成", "subscriber/3")
+	line := expectContains(t, fake, "PRIVMSG")
+	if want := "@reply-parent-msg-id=msg-1 PRIVMSG #yamada_ch :@viewer 資料作成を開始しました！"; line != want {
+		t.Errorf("reply = %q, want %q", line, want)
+	}
+	if len(calls.calls) != 1 || calls.calls[0] != "in:viewer:資料作成" {
+		t.Errorf("unexpected calls: %v", calls.calls)
+	}
+}
+
+func TestClient_AnswersPing(t *testing.T) {
+	fake := newFakeServer(t)
+	startClient(t, fake, Config{Channels: []string{"ch"}}, handlerFunc(func(ctx context.Context, msg *ChatMessage) string { return "" }))
+
+	expect(t, fake, "JOIN #ch")
+	fake.Send("PING :tmi.twitch.tv")
+	expect(t, fake, "PONG :tmi.twitch.tv")
+}
+
+func TestClient_IgnoresOwnMessages(t *testing.T) {
+	fake := newFakeServer(t)
+	var mu sync.Mutex
+	var seen []string
+	startClient(t, fake, Config{Nick: "workbot", Channels: []string{"ch"}}, handlerFunc(func(ctx context.Context, msg *ChatMessage) string {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, msg.Login)
+		return "ok " + msg.Login
+	}))
+
+	expect(t, fake, "JOIN #ch")
+	fake.Privmsg("ch", "workbot", "msg-1", "!ping")
+	fake.Privmsg("ch", "viewer", "msg-2", "!ping")
+	expectContains(t, fake, "PRIVMSG #ch :ok viewer")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) != 1 || seen[0] != "viewer" {
+		t.Errorf("handler saw %v", seen)
+	}
+}
+
+func TestClient_Reconnects(t *testing.T) {
+	fake := newFakeServer(t)
+	startClient(t, fake, Config{Channels: []string{"ch"}}, handlerFunc(func(ctx context.Context, msg *ChatMessage) string { return "pong" }))
+	expect(t, fake, "JOIN #ch")
+
+	t.Run("切断されたら再接続する", func(t *testing.T) {
+		fake.Disconnect()
+		expect(t, fake, "PASS ")
+		expect(t, fake, "JOIN #ch")
+	})
+
+	t.Run("RECONNECT を受け取ったら再接続する", func(t *testing.T) {
+		fake.Send(":tmi.twitch.tv RECONNECT")
+		expect(t, fake, "PASS ")
+		expect(t, fake, "JOIN #ch")
+	})
+
+	if got := fake.Accepted(); got != 3 {
+		t.Errorf("expected 3 connections, got %d", got)
+	}
+
+	// 再接続後も返信できる
+	fake.Privmsg("ch", "viewer", "msg-1", "!ping")
+	expectContains(t, fake, "PRIVMSG #ch :pong")
+}
+
+func TestClient_AuthenticationFailure(t *testing.T) {
+	fake := newFakeServer(t)
+	fake.Token = "s3cret"
+	_, done := startClient(t, fake, Config{Token: "wrong"}, handlerFunc(func(ctx context.Context, msg *ChatMessage) string { return "" }))
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrAuthenticationFailed) {
+			t.Fatalf("expected ErrAuthenticationFailed, got %v", err)
+		}
+	case <-time.After(expectTimeout):
+		t.Fatal("client should stop after an authentication failure")
+	}
+	if got := fake.Accepted(); got != 1 {
+		t.Errorf("client must not retry a rejected token, got %d connections", got)
+	}
+}
+
+func TestClient_SendRateLimit(t *testing.T) {
+	fake := newFakeServer(t)
+	startClient(t, fake, Config{Channels: []string{"ch"}, SendLimit: domain.RateLimit{Count: 2, Period: time.Hour}},
+		handlerFunc(func(ctx context.Context, msg *ChatMessage) string { return "reply " + msg.ID }))
+	expect(t, fake, "JOIN #ch")
+
+	for _, id := range []string{"m1", "m2", "m3"} {
+		fake.Privmsg("ch", "viewer", id, "hello")
+	}
+	expectContains(t, fake, "PRIVMSG #ch :reply m1")
+	expectContains(t, fake, "PRIVMSG #ch :reply m2")
+	if line, err := fake.ExpectContains("PRIVMSG", 200*time.Millisecond); err == nil {
+		t.Errorf("third reply should wait for the rate limit, got %q", line)
+	}
+}
+
+func TestSanitizeText(t *testing.T) {
+	if got := sanitizeText("a\r\nb\nc"); got != "a b c" {
+		t.Errorf("got %q", got)
+	}
+	long := strings.Repeat("あ", MaxMessageLength+10)
+	got := []rune(sanitizeText(long))
+	if len(got) != MaxMessageLength || got[len(got)-1] != '…' {
+		t.Errorf("expected %d runes ending with an ellipsis, got %d", MaxMessageLength, len(got))
+	}
+}
+
+func TestClient_Backoff(t *testing.T) {
+	c := NewClient(Config{}, nil)
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}
+	for i, w := range want {
+		if got := c.backoff(i); got != w {
+			t.Errorf("backoff(%d) = %s, want %s", i, got, w)
+		}
+	}
+	if got := c.backoff(30); got != MaxBackoff {
+		t.Errorf("backoff should be capped at %s, got %s", MaxBackoff, got)
+	}
+}
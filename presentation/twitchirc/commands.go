@@ -0,0 +1,265 @@
+package twitchirc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+	"github.com/yamada-ai/workspace-backend/usecase/query"
+	"github.com/yamada-ai/workspace-backend/usecase/ratelimit"
+)
+
+// CommandPrefix チャットのコマンドの接頭辞（"/" は Twitch のクライアントが使うため "!"）
+const CommandPrefix = "!"
+
+// 各コマンドの usecase（*command.JoinCommandUseCase などが満たす）
+type (
+	JoinExecutor interface {
+		Execute(ctx context.Context, input command.JoinCommandInput) (*command.JoinCommandOutput, error)
+	}
+	OutExecutor interface {
+		Execute(ctx context.Context, input command.OutCommandInput) (*command.OutCommandOutput, error)
+	}
+	MoreExecutor interface {
+		Execute(ctx context.Context, input command.MoreCommandInput) (*command.MoreCommandOutput, error)
+	}
+	ChangeExecutor interface {
+		Execute(ctx context.Context, input command.ChangeCommandInput) (*command.ChangeCommandOutput, error)
+	}
+	UserInfoExecutor interface {
+		Execute(ctx context.Context, input query.GetUserInfoInput) (*query.GetUserInfoOutput, error)
+	}
+	CheerExecutor interface {
+		Execute(ctx context.Context, input command.CheerCommandInput) (*command.CheerCommandOutput, error)
+	}
+	CommentExecutor interface {
+		Execute(ctx context.Context, input command.CommentCommandInput) (*command.CommentCommandOutput, error)
+	}
+)
+
+// Commands チャットのコマンド（!in・!out・!more・!change・!info・!cheer・!ping）を usecase に渡し、返信の文を作る
+// HTTP の /api/commands/* と同じ usecase を呼ぶので、レート制限・禁止ワード・ブロックも同じく適用される
+// コマンド以外の発言は入室中のユーザーのコメントとしてコメント欄に出す（返信はしない）
+type Commands struct {
+	join     JoinExecutor
+	out      OutExecutor
+	more     MoreExecutor
+	change   ChangeExecutor
+	userInfo UserInfoExecutor
+	cheer    CheerExecutor
+	comment  CommentExecutor
+	observer command.CommandObserver
+}
+
+// NewCommands creates a new chat command dispatcher
+// observer records the outcome of each command (nil disables it)
+func NewCommands(
+	join JoinExecutor,
+	out OutExecutor,
+	more MoreExecutor,
+	change ChangeExecutor,
+	userInfo UserInfoExecutor,
+	cheer CheerExecutor,
+	comment CommentExecutor,
+	observer command.CommandObserver,
+) *Commands {
+	if observer == nil {
+		observer = command.NoOpCommandObserver{}
+	}
+	return &Commands{
+		join:     join,
+		out:      out,
+		more:     more,
+		change:   change,
+		userInfo: userInfo,
+		cheer:    cheer,
+		comment:  comment,
+		observer: observer,
+	}
+}
+
+// HandleChat implements Handler
+func (c *Commands) HandleChat(ctx context.Context, msg *ChatMessage) string {
+	user := msg.UserName()
+	name, arg, ok := parseCommand(msg.Text)
+	if !ok {
+		c.handleComment(ctx, user, msg.Text)
+		return ""
+	}
+
+	var (
+		reply string
+		err   error
+	)
+	switch name {
+	case "ping":
+		return "pong"
+	case "in":
+		reply, err = c.handleIn(ctx, user, arg)
+	case "out":
+		reply, err = c.handleOut(ctx, user)
+	case "more":
+		reply, err = c.handleMore(ctx, user, arg)
+	case "change":
+		reply, err = c.handleChange(ctx, user, arg)
+	case "info":
+		reply, err = c.handleInfo(ctx, user)
+	case "cheer":
+		// 応援の返信は「{ユーザー名}さんが{対象}さんを応援しています」で、メンションを付けない
+		if reply, err = c.handleCheer(ctx, user, arg); err == nil && arg != "" {
+			return reply
+		}
+	default:
+		return ""
+	}
+	if err != nil {
+		reply = errorReply(ctx, name, err)
+		if reply == "" {
+			return ""
+		}
+	}
+	return "@" + user + " " + reply
+}
+
+func (c *Commands) handleIn(ctx context.Context, user, workName string) (string, error) {
+	output, err := c.join.Execute(ctx, command.JoinCommandInput{UserName: user, WorkName: workName})
+	c.observer.ObserveCommand(command.SourceChat, command.CommandJoin, err)
+	if err != nil {
+		return "", err
+	}
+	if output.WorkName == "" {
+		return "作業を開始しました！", nil
+	}
+	return output.WorkName + "を開始しました！", nil
+}
+
+func (c *Commands) handleOut(ctx context.Context, user string) (string, error) {
+	_, err := c.out.Execute(ctx, command.OutCommandInput{UserName: user})
+	c.observer.ObserveCommand(command.SourceChat, command.CommandOut, err)
+	if err != nil {
+		return "", err
+	}
+	return "作業を終了しました。お疲れ様でした！", nil
+}
+
+func (c *Commands) handleMore(ctx context.Context, user, arg string) (string, error) {
+	if arg == "" {
+		return fmt.Sprintf("延長時間（分）を指定してください。例: %smore 30", CommandPrefix), nil
+	}
+	minutes, err := strconv.Atoi(strings.Fields(arg)[0])
+	if err != nil {
+		return fmt.Sprintf("延長時間は数値で指定してください。例: %smore 30", CommandPrefix), nil
+	}
+	output, err := c.more.Execute(ctx, command.MoreCommandInput{UserName: user, Minutes: minutes})
+	c.observer.ObserveCommand(command.SourceChat, command.CommandMore, err)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("作業時間を%d分延長しました！", output.Minutes), nil
+}
+
+func (c *Commands) handleChange(ctx context.Context, user, workName string) (string, error) {
+	output, err := c.change.Execute(ctx, command.ChangeCommandInput{UserName: user, NewWorkName: workName})
+	c.observer.ObserveCommand(command.SourceChat, command.CommandChange, err)
+	if err != nil {
+		return "", err
+	}
+	if output.WorkName == "" {
+		return "作業を開始しました", nil
+	}
+	return fmt.Sprintf("%qを開始しました", output.WorkName), nil
+}
+
+func (c *Commands) handleInfo(ctx context.Context, user string) (string, error) {
+	output, err := c.userInfo.Execute(ctx, query.GetUserInfoInput{UserName: user})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("退出まで:%d分/今日の累計作業時間:%d分/累計作業時間:%d分",
+		output.RemainingMinutes, output.TodayTotalMinutes, output.LifetimeTotalMinutes), nil
+}
+
+func (c *Commands) handleCheer(ctx context.Context, user, arg string) (string, error) {
+	if arg == "" {
+		return fmt.Sprintf("応援する相手を指定してください。例: %scheer @ユーザー名", CommandPrefix), nil
+	}
+	output, err := c.cheer.Execute(ctx, command.CheerCommandInput{UserName: user, TargetName: strings.Fields(arg)[0]})
+	c.observer.ObserveCommand(command.SourceChat, command.CommandCheer, err)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%sさんが%sさんを応援しています", output.UserName, output.TargetName), nil
+}
+
+// handleComment 発言をコメント欄に出す
+// 入室していない視聴者の発言や、レート制限・禁止ワードで受け付けなかった発言は黙って捨てる
+func (c *Commands) handleComment(ctx context.Context, user, text string) {
+	_, err := c.comment.Execute(ctx, command.CommentCommandInput{UserName: user, Text: text})
+	c.observer.ObserveCommand(command.SourceChat, command.CommandComment, err)
+	switch {
+	case err == nil,
+		errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrSessionNotFound),
+		errors.Is(err, domain.ErrEmptyComment),
+		errors.Is(err, domain.ErrUserBlocked),
+		errors.Is(err, domain.ErrBannedTermDetected),
+		errors.Is(err, ratelimit.ErrRateLimited):
+	default:
+		logging.FromContext(ctx).Error("twitchirc: comment failed", logging.KeyUserName, user, logging.Err(err))
+	}
+}
+
+// errorReply usecase のエラーを返信の文にする（空なら返信しない）
+func errorReply(ctx context.Context, name string, err error) string {
+	var rangeErr *command.ExtensionRangeError
+	switch {
+	case errors.Is(err, domain.ErrUserBlocked):
+		// ブロック中のユーザーには反応しない
+		return ""
+	case errors.Is(err, ratelimit.ErrRateLimited):
+		retryAfter := 1
+		var limitErr *ratelimit.LimitError
+		if errors.As(err, &limitErr) {
+			retryAfter = int(math.Max(1, math.Ceil(limitErr.RetryAfter.Seconds())))
+		}
+		return "コマンドの実行間隔が短すぎます。" + strconv.Itoa(retryAfter) + "秒後に再度お試しください。"
+	case errors.Is(err, domain.ErrBannedTermDetected):
+		return "禁止ワードが含まれているため受け付けられません。"
+	case errors.Is(err, domain.ErrUserAlreadyInSession):
+		return fmt.Sprintf("既に作業セッション中です。先に %sout で終了してください。", CommandPrefix)
+	case errors.Is(err, domain.ErrWorkNameTooLong):
+		return fmt.Sprintf("作業名は%d文字以内にしてください。", domain.WorkNameMaxLength)
+	case errors.As(err, &rangeErr):
+		return fmt.Sprintf("延長時間は%d〜%d分の範囲で指定してください。", rangeErr.Min, rangeErr.Max)
+	case errors.Is(err, domain.ErrInvalidExtension):
+		return "延長時間が正しくありません。"
+	case errors.Is(err, domain.ErrCannotCheerSelf):
+		return "自分自身は応援できません。"
+	case errors.Is(err, domain.ErrCheerTargetNotInRoom):
+		return "応援する相手が入室していません。"
+	case errors.Is(err, domain.ErrCheerAlreadySent):
+		return "今日は既にこのユーザーを応援しています。"
+	case errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrSessionNotFound):
+		return "入室していません"
+	default:
+		logging.FromContext(ctx).Error("twitchirc: command failed", "command", name, logging.Err(err))
+		return "コマンドの処理に失敗しました。"
+	}
+}
+
+// parseCommand "!in 資料作成" を ("in", "資料作成") にする
+func parseCommand(text string) (name, arg string, ok bool) {
+	text = strings.TrimSpace(text)
+	rest, ok := strings.CutPrefix(text, CommandPrefix)
+	if !ok {
+		return "", "", false
+	}
+	name, arg, _ = strings.Cut(rest, " ")
+	return strings.ToLower(name), strings.TrimSpace(arg), name != ""
+}
@@ -0,0 +1,190 @@
+package twitchirc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+	"github.com/yamada-ai/workspace-backend/usecase/query"
+	"github.com/yamada-ai/workspace-backend/usecase/ratelimit"
+)
+
+// fakeUseCases 全コマンドの usecase の代わり（呼び出しを記録し、err を返す）
+// CommandObserver として記録された結果も保持する
+type fakeUseCases struct {
+	calls    []string
+	observed []string
+	err      error
+}
+
+func (f *fakeUseCases) ObserveCommand(source, name string, err error) {
+	result := "ok"
+	if err != nil {
+		result = err.Error()
+	}
+	f.observed = append(f.observed, source+":"+name+":"+result)
+}
+
+type fakeJoin struct{ *fakeUseCases }
+type fakeOut struct{ *fakeUseCases }
+type fakeMore struct{ *fakeUseCases }
+type fakeChange struct{ *fakeUseCases }
+type fakeUserInfo struct{ *fakeUseCases }
+type fakeCheer struct{ *fakeUseCases }
+type fakeComment struct{ *fakeUseCases }
+
+func (f fakeJoin) Execute(ctx context.Context, input command.JoinCommandInput) (*command.JoinCommandOutput, error) {
+	f.calls = append(f.calls, "in:"+input.UserName+":"+input.WorkName)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &command.JoinCommandOutput{WorkName: input.WorkName}, nil
+}
+
+func (f fakeOut) Execute(ctx context.Context, input command.OutCommandInput) (*command.OutCommandOutput, error) {
+	f.calls = append(f.calls, "out:"+input.UserName)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &command.OutCommandOutput{}, nil
+}
+
+func (f fakeMore) Execute(ctx context.Context, input command.MoreCommandInput) (*command.MoreCommandOutput, error) {
+	f.calls = append(f.calls, "more:"+input.UserName)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &command.MoreCommandOutput{Minutes: input.Minutes}, nil
+}
+
+func (f fakeChange) Execute(ctx context.Context, input command.ChangeCommandInput) (*command.ChangeCommandOutput, error) {
+	f.calls = append(f.calls, "change:"+input.UserName+":"+input.NewWorkName)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &command.ChangeCommandOutput{WorkName: input.NewWorkName}, nil
+}
+
+func (f fakeUserInfo) Execute(ctx context.Context, input query.GetUserInfoInput) (*query.GetUserInfoOutput, error) {
+	f.calls = append(f.calls, "info:"+input.UserName)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &query.GetUserInfoOutput{RemainingMinutes: 30, TodayTotalMinutes: 90, LifetimeTotalMinutes: 1200}, nil
+}
+
+func (f fakeCheer) Execute(ctx context.Context, input command.CheerCommandInput) (*command.CheerCommandOutput, error) {
+	f.calls = append(f.calls, "cheer:"+input.UserName+":"+input.TargetName)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &command.CheerCommandOutput{UserName: input.UserName, TargetName: strings.TrimPrefix(input.TargetName, "@")}, nil
+}
+
+func (f fakeComment) Execute(ctx context.Context, input command.CommentCommandInput) (*command.CommentCommandOutput, error) {
+	f.calls = append(f.calls, "comment:"+input.UserName+":"+input.Text)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &command.CommentCommandOutput{Text: input.Text}, nil
+}
+
+func newTestCommands(err error) (*Commands, *fakeUseCases) {
+	f := &fakeUseCases{err: err}
+	return NewCommands(fakeJoin{f}, fakeOut{f}, fakeMore{f}, fakeChange{f}, fakeUserInfo{f}, fakeCheer{f}, fakeComment{f}, f), f
+}
+
+func TestCommands_HandleChat(t *testing.T) {
+	tests := []struct {
+		text      string
+		wantReply string
+		wantCall  string
+	}{
+		{"!in", "@Yamada 作業を開始しました！", "in:Yamada:"},
+		{"!in 資料 作成", "@Yamada 資料 作成を開始しました！", "in:Yamada:資料 作成"},
+		{"  !IN   論文 ", "@Yamada 論文を開始しました！", "in:Yamada:論文"},
+		{"!out", "@Yamada 作業を終了しました。お疲れ様でした！", "out:Yamada"},
+		{"!more 30", "@Yamada 作業時間を30分延長しました！", "more:Yamada"},
+		{"!more", "@Yamada 延長時間（分）を指定してください。例: !more 30", ""},
+		{"!more abc", "@Yamada 延長時間は数値で指定してください。例: !more 30", ""},
+		{"!change 読書", `@Yamada "読書"を開始しました`, "change:Yamada:読書"},
+		{"!info", "@Yamada 退出まで:30分/今日の累計作業時間:90分/累計作業時間:1200分", "info:Yamada"},
+		{"!cheer @raziii03", "Yamadaさんがraziii03さんを応援しています", "cheer:Yamada:@raziii03"},
+		{"!cheer", "@Yamada 応援する相手を指定してください。例: !cheer @ユーザー名", ""},
+		{"!ping", "pong", ""},
+		{"!unknown", "", ""},
+		{"こんにちは", "", "comment:Yamada:こんにちは"},
+		{"!", "", "comment:Yamada:!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			commands, f := newTestCommands(nil)
+			reply := commands.HandleChat(context.Background(), &ChatMessage{Login: "yamada", DisplayName: "Yamada", Text: tt.text})
+			if reply != tt.wantReply {
+				t.Errorf("reply = %q, want %q", reply, tt.wantReply)
+			}
+			if tt.wantCall == "" && len(f.calls) != 0 {
+				t.Errorf("expected no use case call, got %v", f.calls)
+			}
+			if tt.wantCall != "" && (len(f.calls) != 1 || f.calls[0] != tt.wantCall) {
+				t.Errorf("calls = %v, want [%s]", f.calls, tt.wantCall)
+			}
+		})
+	}
+}
+
+func TestCommands_ErrorReplies(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		err       error
+		wantReply string
+	}{
+		{"セッション中", "!in", domain.ErrUserAlreadyInSession, "@yamada 既に作業セッション中です。先に !out で終了してください。"},
+		{"未入室", "!change 読書", domain.ErrSessionNotFound, "@yamada 入室していません"},
+		{"未登録", "!out", domain.ErrUserNotFound, "@yamada 入室していません"},
+		{"延長時間の範囲外", "!more 999", &command.ExtensionRangeError{Minutes: 999, Min: 1, Max: 360}, "@yamada 延長時間は1〜360分の範囲で指定してください。"},
+		{"自分を応援", "!cheer @yamada", domain.ErrCannotCheerSelf, "@yamada 自分自身は応援できません。"},
+		{"応援相手が未入室", "!cheer @raziii03", domain.ErrCheerTargetNotInRoom, "@yamada 応援する相手が入室していません。"},
+		{"今日は応援済み", "!cheer @raziii03", domain.ErrCheerAlreadySent, "@yamada 今日は既にこのユーザーを応援しています。"},
+		{"未入室のコメントには返信しない", "こんにちは", domain.ErrSessionNotFound, ""},
+		{"禁止ワードのコメントには返信しない", "ひどい言葉", domain.ErrBannedTermDetected, ""},
+		{"禁止ワード", "!in ひどい言葉", domain.ErrBannedTermDetected, "@yamada 禁止ワードが含まれているため受け付けられません。"},
+		{"レート制限", "!in", &ratelimit.LimitError{Scope: ratelimit.ScopeUser, Command: "in", RetryAfter: 2500 * time.Millisecond}, "@yamada コマンドの実行間隔が短すぎます。3秒後に再度お試しください。"},
+		{"ブロック中は返信しない", "!in", domain.ErrUserBlocked, ""},
+		{"その他", "!info", errors.New("db is down"), "@yamada コマンドの処理に失敗しました。"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, _ := newTestCommands(tt.err)
+			reply := commands.HandleChat(context.Background(), &ChatMessage{Login: "yamada", Text: tt.text})
+			if reply != tt.wantReply {
+				t.Errorf("reply = %q, want %q", reply, tt.wantReply)
+			}
+		})
+	}
+}
+
+func TestCommands_ObservesCommands(t *testing.T) {
+	c, f := newTestCommands(nil)
+	ctx := context.Background()
+	for _, text := range []string{"!in", "!more", "!more 30", "!info", "!ping", "こんにちは"} {
+		c.HandleChat(ctx, &ChatMessage{Login: "yamada", DisplayName: "Yamada", Text: text})
+	}
+
+	// 使い方を返しただけのもの・クエリ・ping は数えない
+	want := []string{"chat:in:ok", "chat:more:ok", "chat:comment:ok"}
+	if strings.Join(f.observed, ",") != strings.Join(want, ",") {
+		t.Errorf("observed = %v, want %v", f.observed, want)
+	}
+
+	c, f = newTestCommands(domain.ErrSessionNotFound)
+	c.HandleChat(ctx, &ChatMessage{Login: "yamada", DisplayName: "Yamada", Text: "!out"})
+	if len(f.observed) != 1 || f.observed[0] != "chat:out:"+domain.ErrSessionNotFound.Error() {
+		t.Errorf("failed command should be observed with its error, got %v", f.observed)
+	}
+}
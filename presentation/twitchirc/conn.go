@@ -0,0 +1,149 @@
+package twitchirc
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Twitch チャットの接続先
+const (
+	// DefaultAddr TLS の IRC（TCP）
+	DefaultAddr = "ircs://irc.chat.twitch.tv:6697"
+	// WebSocketAddr WebSocket 経由の IRC（TCP の 6697 番が使えない環境向け）
+	WebSocketAddr = "wss://irc-ws.chat.twitch.tv:443"
+)
+
+// writeTimeout 1 行の送信を待つ時間の上限
+const writeTimeout = 10 * time.Second
+
+// conn IRC の行単位の送受信（TCP と WebSocket で共通）
+type conn interface {
+	ReadLine() (string, error)
+	WriteLine(line string) error
+	SetReadDeadline(t time.Time) error
+	Close() error
+}
+
+// dial addr に接続する
+// addr は irc://host:port（平文）、ircs://host:port（TLS）、ws:// または wss://（WebSocket）
+// スキームがなければ平文の TCP とみなす
+func dial(ctx context.Context, addr string) (conn, error) {
+	scheme, hostport, ok := strings.Cut(addr, "://")
+	if !ok {
+		scheme, hostport = "irc", addr
+	}
+
+	switch scheme {
+	case "irc", "ircs":
+		var d net.Dialer
+		c, err := d.DialContext(ctx, "tcp", hostport)
+		if err != nil {
+			return nil, err
+		}
+		if scheme == "ircs" {
+			host, _, _ := net.SplitHostPort(hostport)
+			tlsConn := tls.Client(c, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+			if err := tlsConn.HandshakeContext(ctx); err != nil {
+				_ = c.Close()
+				return nil, err
+			}
+			c = tlsConn
+		}
+		return &tcpConn{conn: c, reader: bufio.NewReader(c)}, nil
+	case "ws", "wss":
+		u, err := url.Parse(addr)
+		if err != nil {
+			return nil, err
+		}
+		ws, _, err := websocket.DefaultDialer.DialContext(ctx, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return &wsConn{conn: ws}, nil
+	default:
+		return nil, fmt.Errorf("unsupported irc address scheme: %s", scheme)
+	}
+}
+
+// tcpConn CRLF 区切りの IRC（TCP・TLS）
+type tcpConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	mu     sync.Mutex
+}
+
+func (c *tcpConn) ReadLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *tcpConn) WriteLine(line string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	_, err := c.conn.Write([]byte(line + "\r\n"))
+	return err
+}
+
+func (c *tcpConn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+func (c *tcpConn) Close() error {
+	return c.conn.Close()
+}
+
+// wsConn WebSocket の IRC（1 つのテキストメッセージに複数行が入ることがある）
+type wsConn struct {
+	conn    *websocket.Conn
+	pending []string
+	mu      sync.Mutex
+}
+
+func (c *wsConn) ReadLine() (string, error) {
+	for len(c.pending) == 0 {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return "", err
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimRight(line, "\r"); line != "" {
+				c.pending = append(c.pending, line)
+			}
+		}
+	}
+	line := c.pending[0]
+	c.pending = c.pending[1:]
+	return line, nil
+}
+
+func (c *wsConn) WriteLine(line string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(websocket.TextMessage, []byte(line+"\r\n"))
+}
+
+func (c *wsConn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+func (c *wsConn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,215 @@
+package twitchirc
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeServer Twitch チャットの代わりになるプロセス内の IRC サーバー（テスト・ローカルでの動作確認用）
+// ログイン（PASS/NICK）・JOIN・PING に Twitch と同じ形で応答し、受信した行を記録する
+type FakeServer struct {
+	// Token 受け付ける PASS（空ならどのトークンでもログインできる）
+	Token string
+
+	listener net.Listener
+	mu       sync.Mutex
+	cond     *sync.Cond
+	conns    map[net.Conn]struct{}
+	accepted int
+	received []string
+	cursor   int
+	closed   bool
+}
+
+// NewFakeServer 127.0.0.1 の空いているポートで待ち受ける
+func NewFakeServer() (*FakeServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &FakeServer{
+		listener: listener,
+		conns:    make(map[net.Conn]struct{}),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	go s.serve()
+	return s, nil
+}
+
+// Addr Config.Addr に渡すアドレス
+func (s *FakeServer) Addr() string {
+	return "irc://" + s.listener.Addr().String()
+}
+
+// Close 待ち受けと接続をすべて閉じる
+func (s *FakeServer) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	_ = s.listener.Close()
+	s.Disconnect()
+}
+
+// Disconnect 接続中のクライアントをすべて切断する（再接続の確認用）
+func (s *FakeServer) Disconnect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		_ = c.Close()
+	}
+}
+
+// Accepted これまでに受け付けた接続の数
+func (s *FakeServer) Accepted() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accepted
+}
+
+// Send 接続中のクライアントすべてに 1 行送る
+func (s *FakeServer) Send(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		_, _ = c.Write([]byte(line + "\r\n"))
+	}
+}
+
+// Privmsg login の発言を送る（id・user-id・display-name・badges のタグを付ける。user-id は login から決める）
+func (s *FakeServer) Privmsg(channel, login, id, text string, badges ...string) {
+	msg := &Message{
+		Tags: map[string]string{
+			"id":           id,
+			"user-id":      fakeUserID(login),
+			"display-name": login,
+			"badges":       strings.Join(badges, ","),
+		},
+		Prefix:  login + "!" + login + "@" + login + ".tmi.twitch.tv",
+		Command: "PRIVMSG",
+		Params:  []string{"#" + channel, text},
+	}
+	s.Send(msg.String())
+}
+
+// Expect 前回の Expect 以降に受信した行から、prefix で始まる最初の行を待って返す
+// 返信の PRIVMSG はタグ（@reply-parent-msg-id）から始まるので ExpectContains を使う
+func (s *FakeServer) Expect(prefix string, timeout time.Duration) (string, error) {
+	return s.expect(func(line string) bool { return strings.HasPrefix(line, prefix) }, prefix, timeout)
+}
+
+// ExpectContains 前回の Expect 以降に受信した行から、substr を含む最初の行を待って返す
+func (s *FakeServer) ExpectContains(substr string, timeout time.Duration) (string, error) {
+	return s.expect(func(line string) bool { return strings.Contains(line, substr) }, substr, timeout)
+}
+
+// Received これまでに受信した行
+func (s *FakeServer) Received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.received...)
+}
+
+func fakeUserID(login string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(login))
+	return fmt.Sprintf("%d", h.Sum32())
+}
+
+func (s *FakeServer) expect(match func(string) bool, desc string, timeout time.Duration) (string, error) {
+	timer := time.AfterFunc(timeout, func() {
+		s.mu.Lock()
+		s.cond.Broadcast()
+		s.mu.Unlock()
+	})
+	defer timer.Stop()
+	deadline := time.Now().Add(timeout)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		for i := s.cursor; i < len(s.received); i++ {
+			if match(s.received[i]) {
+				s.cursor = i + 1
+				return s.received[i], nil
+			}
+		}
+		if s.closed || !time.Now().Before(deadline) {
+			return "", fmt.Errorf("fake irc: %q not received (got %q)", desc, s.received[s.cursor:])
+		}
+		s.cond.Wait()
+	}
+}
+
+func (s *FakeServer) serve() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[c] = struct{}{}
+		s.accepted++
+		s.mu.Unlock()
+		go s.handle(c)
+	}
+}
+
+func (s *FakeServer) handle(c net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		_ = c.Close()
+	}()
+
+	var pass, nick string
+	write := func(line string) {
+		_, _ = c.Write([]byte(line + "\r\n"))
+	}
+	reader := bufio.NewReader(c)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		s.mu.Lock()
+		s.received = append(s.received, line)
+		s.cond.Broadcast()
+		s.mu.Unlock()
+
+		msg, err := ParseMessage(line)
+		if err != nil {
+			continue
+		}
+		switch msg.Command {
+		case "CAP":
+			write(":tmi.twitch.tv CAP * ACK :" + msg.Param(1))
+		case "PASS":
+			pass = msg.Param(0)
+		case "NICK":
+			nick = msg.Param(0)
+			if s.Token != "" && pass != "oauth:"+strings.TrimPrefix(s.Token, "oauth:") {
+				write(":tmi.twitch.tv NOTICE * :Login authentication failed")
+				return
+			}
+			write(":tmi.twitch.tv 001 " + nick + " :Welcome, GLHF!")
+			write(":tmi.twitch.tv 376 " + nick + " :>")
+		case "JOIN":
+			channels := strings.Split(msg.Param(0), ",")
+			sort.Strings(channels)
+			for _, ch := range channels {
+				write(":" + nick + "!" + nick + "@" + nick + ".tmi.twitch.tv JOIN " + ch)
+			}
+		case "PING":
+			write(":tmi.twitch.tv PONG tmi.twitch.tv :" + msg.Param(0))
+		}
+	}
+}
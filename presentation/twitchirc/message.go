@@ -0,0 +1,166 @@
+// Package twitchirc は Twitch チャット（IRC 方言）に接続し、チャットのコマンドを処理する
+//
+// PASS/NICK/JOIN によるログイン、IRCv3 タグ（badges・user-id など）の解析、PING/PONG、
+// 切断時のバックオフ付き再接続、送信のレート制限を行う。
+// 受け取った !in などのコマンドは Commands が usecase/command に渡し、結果をチャットに返信する
+package twitchirc
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+var errEmptyMessage = errors.New("empty irc message")
+
+// Message IRC のメッセージ 1 行
+type Message struct {
+	Tags    map[string]string
+	Prefix  string
+	Command string
+	Params  []string
+}
+
+// ParseMessage IRC の 1 行（末尾の CRLF は除いてもよい）を解析する
+// 形式: [@tags] [:prefix] command [params...] [:trailing]
+func ParseMessage(line string) (*Message, error) {
+	line = strings.TrimRight(line, "\r\n")
+	msg := &Message{}
+
+	if strings.HasPrefix(line, "@") {
+		tags, rest, _ := strings.Cut(line[1:], " ")
+		msg.Tags = parseTags(tags)
+		line = rest
+	}
+	line = strings.TrimLeft(line, " ")
+
+	if strings.HasPrefix(line, ":") {
+		prefix, rest, _ := strings.Cut(line[1:], " ")
+		msg.Prefix = prefix
+		line = strings.TrimLeft(rest, " ")
+	}
+
+	command, rest, _ := strings.Cut(line, " ")
+	if command == "" {
+		return nil, errEmptyMessage
+	}
+	msg.Command = strings.ToUpper(command)
+
+	for rest != "" {
+		rest = strings.TrimLeft(rest, " ")
+		if strings.HasPrefix(rest, ":") {
+			msg.Params = append(msg.Params, rest[1:])
+			break
+		}
+		var param string
+		param, rest, _ = strings.Cut(rest, " ")
+		if param != "" {
+			msg.Params = append(msg.Params, param)
+		}
+	}
+	return msg, nil
+}
+
+// String 送信用の 1 行にする（CRLF は含まない）
+// 最後のパラメーターは空白を含みうるので常に ":" を付ける
+func (m *Message) String() string {
+	var b strings.Builder
+	if len(m.Tags) > 0 {
+		keys := make([]string, 0, len(m.Tags))
+		for k := range m.Tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteByte('@')
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteByte(';')
+			}
+			b.WriteString(k)
+			if v := m.Tags[k]; v != "" {
+				b.WriteByte('=')
+				b.WriteString(escapeTagValue(v))
+			}
+		}
+		b.WriteByte(' ')
+	}
+	if m.Prefix != "" {
+		b.WriteByte(':')
+		b.WriteString(m.Prefix)
+		b.WriteByte(' ')
+	}
+	b.WriteString(m.Command)
+	for i, p := range m.Params {
+		b.WriteByte(' ')
+		if i == len(m.Params)-1 {
+			b.WriteByte(':')
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// Param i 番目のパラメーター（なければ空文字列）
+func (m *Message) Param(i int) string {
+	if i < len(m.Params) {
+		return m.Params[i]
+	}
+	return ""
+}
+
+// Nick prefix（nick!user@host）のニックネーム
+func (m *Message) Nick() string {
+	nick, _, _ := strings.Cut(m.Prefix, "!")
+	return nick
+}
+
+// parseTags "a=1;b=2" 形式のタグを解析する（値のエスケープは戻す）
+func parseTags(s string) map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(s, ";") {
+		if tag == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(tag, "=")
+		tags[k] = unescapeTagValue(v)
+	}
+	return tags
+}
+
+// tagValueEscaper IRCv3 のタグの値のエスケープ（";" → \:、" " → \s など）
+var tagValueEscaper = strings.NewReplacer(`\`, `\\`, ";", `\:`, " ", `\s`, "\r", `\r`, "\n", `\n`)
+
+func escapeTagValue(v string) string {
+	return tagValueEscaper.Replace(v)
+}
+
+// unescapeTagValue エスケープを戻す（未知のエスケープは "\" を除き、末尾の単独の "\" は捨てる）
+func unescapeTagValue(v string) string {
+	if !strings.Contains(v, `\`) {
+		return v
+	}
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		if v[i] != '\\' {
+			b.WriteByte(v[i])
+			continue
+		}
+		i++
+		if i == len(v) {
+			break
+		}
+		switch v[i] {
+		case ':':
+			b.WriteByte(';')
+		case 's':
+			b.WriteByte(' ')
+		case 'r':
+			b.WriteByte('\r')
+		case 'n':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(v[i])
+		}
+	}
+	return b.String()
+}
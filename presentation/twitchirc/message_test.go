@@ -0,0 +1,105 @@
+package twitchirc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want *Message
+	}{
+		{
+			name: "PING",
+			line: "PING :tmi.twitch.tv\r\n",
+			want: &Message{Command: "PING", Params: []string{"tmi.twitch.tv"}},
+		},
+		{
+			name: "数値のリプライ",
+			line: ":tmi.twitch.tv 001 bot :Welcome, GLHF!",
+			want: &Message{Prefix: "tmi.twitch.tv", Command: "001", Params: []string{"bot", "Welcome, GLHF!"}},
+		},
+		{
+			name: "タグ付きの PRIVMSG",
+			line: `@badge-info=subscriber/8;badges=broadcaster/1,subscriber/6;display-name=Yamada;id=abc-1;user-id=1234;msg-id=;system-msg=a\sb\:c\\d :yamada!yamada@yamada.tmi.twitch.tv PRIVMSG #ch :!in 資料 作成`,
+			want: &Message{
+				Tags: map[string]string{
+					"badge-info":   "subscriber/8",
+					"badges":       "broadcaster/1,subscriber/6",
+					"display-name": "Yamada",
+					"id":           "abc-1",
+					"user-id":      "1234",
+					"msg-id":       "",
+					"system-msg":   `a b;c\d`,
+				},
+				Prefix:  "yamada!yamada@yamada.tmi.twitch.tv",
+				Command: "PRIVMSG",
+				Params:  []string{"#ch", "!in 資料 作成"},
+			},
+		},
+		{
+			name: "trailing なし",
+			line: "JOIN #ch",
+			want: &Message{Command: "JOIN", Params: []string{"#ch"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMessage(tt.line)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseMessage("@a=b :prefix"); err == nil {
+		t.Errorf("expected an error for a message without a command")
+	}
+}
+
+func TestMessage_String(t *testing.T) {
+	msg := &Message{
+		Tags:    map[string]string{"reply-parent-msg-id": "abc-1", "client-nonce": "x y;z"},
+		Command: "PRIVMSG",
+		Params:  []string{"#ch", "@yamada 作業を開始しました！"},
+	}
+	want := `@client-nonce=x\sy\:z;reply-parent-msg-id=abc-1 PRIVMSG #ch :@yamada 作業を開始しました！`
+	if got := msg.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	parsed, err := ParseMessage(msg.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, msg) {
+		t.Errorf("round trip mismatch: %+v", parsed)
+	}
+}
+
+func TestNewChatMessage(t *testing.T) {
+	msg, err := ParseMessage(`@badges=moderator/1,subscriber/12;display-name=Yamada;id=abc-1;user-id=1234 :yamada!yamada@yamada.tmi.twitch.tv PRIVMSG #ch :` + "\x01ACTION !out\x01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	chat := newChatMessage(msg)
+	if chat == nil {
+		t.Fatal("expected a chat message")
+	}
+	if chat.ID != "abc-1" || chat.Channel != "ch" || chat.UserID != "1234" || chat.Login != "yamada" || chat.UserName() != "Yamada" || chat.Text != "!out" {
+		t.Errorf("unexpected chat message: %+v", chat)
+	}
+	if !chat.IsModerator() || chat.Badges["subscriber"] != "12" || chat.HasBadge("broadcaster") {
+		t.Errorf("unexpected badges: %+v", chat.Badges)
+	}
+
+	notice, _ := ParseMessage(":tmi.twitch.tv NOTICE #ch :hello")
+	if newChatMessage(notice) != nil {
+		t.Errorf("NOTICE must not be a chat message")
+	}
+}
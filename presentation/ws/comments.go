@@ -0,0 +1,62 @@
+package ws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+// commentBoard コメント欄に表示中のコメント（ユーザーごとに最新の 1 件）
+// 表示中に同じユーザーがコメントしたら前のコメントを上書きし、表示時間を 5 秒に戻す
+// 上書きの判定をサーバーで行うので、どのクライアントも同じ表示になる
+type commentBoard struct {
+	mu      sync.Mutex
+	lastID  int64
+	visible map[int64]visibleComment // user ID → 表示中のコメント
+}
+
+type visibleComment struct {
+	id        int64
+	expiresAt time.Time
+}
+
+func newCommentBoard() *commentBoard {
+	return &commentBoard{visible: make(map[int64]visibleComment)}
+}
+
+// post コメントに ID と表示期限を付け、表示中のコメントがあれば上書きする
+func (b *commentBoard) post(comment command.CommentBroadcast) CommentEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event := CommentEvent{
+		Type:      EventTypeComment,
+		CommentID: b.lastID,
+		UserID:    comment.UserID,
+		UserName:  comment.UserName,
+		Text:      comment.Text,
+		PostedAt:  comment.PostedAt,
+		ExpiresAt: comment.PostedAt.Add(domain.CommentDisplayDuration),
+	}
+	if prev, ok := b.visible[comment.UserID]; ok && comment.PostedAt.Before(prev.expiresAt) {
+		replaces := prev.id
+		event.Replaces = &replaces
+	}
+
+	// 表示が終わったコメントは覚えておく必要がない
+	for userID, c := range b.visible {
+		if !comment.PostedAt.Before(c.expiresAt) {
+			delete(b.visible, userID)
+		}
+	}
+	b.visible[comment.UserID] = visibleComment{id: event.CommentID, expiresAt: event.ExpiresAt}
+	return event
+}
+
+// BroadcastComment implements command.CommentBroadcaster
+func (h *Hub) BroadcastComment(comment command.CommentBroadcast) {
+	h.Broadcast(h.comments.post(comment))
+}
@@ -0,0 +1,90 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+func TestCommentBoard_ReplacesWithinDisplayDuration(t *testing.T) {
+	board := newCommentBoard()
+	t0 := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+	post := func(userID int64, at time.Duration) CommentEvent {
+		return board.post(command.CommentBroadcast{UserID: userID, UserName: "u", Text: "hi", PostedAt: t0.Add(at)})
+	}
+
+	first := post(1, 0)
+	if first.CommentID != 1 || first.Replaces != nil {
+		t.Fatalf("first comment should not replace anything: %+v", first)
+	}
+	if !first.ExpiresAt.Equal(t0.Add(domain.CommentDisplayDuration)) {
+		t.Errorf("expected expiry after %s, got %s", domain.CommentDisplayDuration, first.ExpiresAt)
+	}
+
+	// 別のユーザーのコメントは上書きしない
+	if other := post(2, time.Second); other.Replaces != nil {
+		t.Errorf("comment of another user must not replace: %+v", other)
+	}
+
+	// 5 秒以内なら上書きして、表示時間を延ばす
+	second := post(1, 4*time.Second)
+	if second.Replaces == nil || *second.Replaces != first.CommentID {
+		t.Fatalf("expected to replace comment %d, got %+v", first.CommentID, second.Replaces)
+	}
+	if !second.ExpiresAt.Equal(t0.Add(4*time.Second + domain.CommentDisplayDuration)) {
+		t.Errorf("expiry should be extended, got %s", second.ExpiresAt)
+	}
+
+	// 延長後の期限（9 秒）より前なら、さらに上書きする
+	third := post(1, 8*time.Second)
+	if third.Replaces == nil || *third.Replaces != second.CommentID {
+		t.Fatalf("expected to replace comment %d, got %+v", second.CommentID, third.Replaces)
+	}
+
+	// 表示が終わった後のコメントは新しいコメントとして出す
+	if fourth := post(1, 13*time.Second); fourth.Replaces != nil {
+		t.Errorf("expired comment must not be replaced: %+v", fourth)
+	}
+	if _, ok := board.visible[2]; ok {
+		t.Error("expired comments should be forgotten")
+	}
+}
+
+func TestHub_BroadcastComment(t *testing.T) {
+	hub := NewHub()
+	hub.Start(context.Background())
+
+	actions := NewClient(hub, nil)
+	room := NewClient(hub, nil)
+	for _, c := range []*Client{actions, room} {
+		hub.Register(c)
+	}
+	if _, err := hub.Subscribe(actions, []string{TopicActions}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hub.Subscribe(room, []string{TopicSessions}); err != nil {
+		t.Fatal(err)
+	}
+
+	hub.BroadcastComment(command.CommentBroadcast{UserID: 7, UserName: "yamada", Text: "がんばる", PostedAt: time.Now()})
+
+	select {
+	case msg := <-actions.send:
+		var event CommentEvent
+		if err := json.Unmarshal(msg, &event); err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		if event.Type != EventTypeComment || event.UserID != 7 || event.Text != "がんばる" {
+			t.Errorf("unexpected event: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("comment was not delivered to the actions topic")
+	}
+	if len(room.send) != 0 {
+		t.Error("comment must not be delivered to the sessions topic")
+	}
+}
@@ -1,66 +1,80 @@
 package ws
 
-import "time"
+//go:generate go run ../../cmd/wsgen -in ../../shared/ws/events.yaml -go events.gen.go -ts ../../shared/ws/events.gen.ts -jsonschema ../../shared/ws/events.schema.json
 
-// EventType represents the type of WebSocket event
-type EventType string
-
-const (
-	EventTypeSessionStart   EventType = "session_start"
-	EventTypeSessionEnd     EventType = "session_end"
-	EventTypeSessionExtend  EventType = "session_extend"
-	EventTypeWorkNameChange EventType = "work_name_change"
+import (
+	"github.com/yamada-ai/workspace-backend/usecase/command"
 )
 
 // BaseEvent contains common fields for all events
+// EventID is the outbox event ID; clients use it to drop duplicate deliveries
 type BaseEvent struct {
-	Type EventType `json:"type"`
+	Type    EventType `json:"type"`
+	EventID int64     `json:"event_id"`
 }
 
-// SessionStartEvent is sent when a user starts a work session
-type SessionStartEvent struct {
-	Type       EventType `json:"type"`
-	ID         int64     `json:"id"`
-	UserID     int64     `json:"user_id"`
-	UserName   string    `json:"user_name"`
-	WorkName   string    `json:"work_name"`
-	Tier       int       `json:"tier"`
-	Icon       *string   `json:"icon,omitempty"`
-	StartTime  time.Time `json:"start_time"`
-	PlannedEnd time.Time `json:"planned_end"`
+// Event is a union type of all possible WebSocket events
+// The event structs and their marker methods are generated from shared/ws/events.yaml (events.gen.go)
+type Event interface {
+	isEvent()
 }
 
-// SessionEndEvent is sent when a user ends their work session
-type SessionEndEvent struct {
-	Type      EventType `json:"type"`
-	ID        int64     `json:"id"`
-	UserID    int64     `json:"user_id"`
-	ActualEnd time.Time `json:"actual_end"`
+func newSessionStartEvent(event command.SessionStartBroadcast) SessionStartEvent {
+	return SessionStartEvent{
+		Type:         EventTypeSessionStart,
+		EventID:      event.EventID,
+		ID:           event.SessionID,
+		UserID:       event.UserID,
+		UserName:     event.UserName,
+		WorkName:     event.WorkName,
+		Tier:         int64(event.Tier),
+		IconID:       event.IconID,
+		IconAssetKey: event.IconAssetKey,
+		StartTime:    event.StartTime,
+		PlannedEnd:   event.PlannedEnd,
+	}
 }
 
-// SessionExtendEvent is sent when a user extends their session
-type SessionExtendEvent struct {
-	Type          EventType `json:"type"`
-	ID            int64     `json:"id"`
-	UserID        int64     `json:"user_id"`
-	NewPlannedEnd time.Time `json:"new_planned_end"`
+func newSessionEndEvent(event command.SessionEndBroadcast) SessionEndEvent {
+	return SessionEndEvent{
+		Type:      EventTypeSessionEnd,
+		EventID:   event.EventID,
+		ID:        event.SessionID,
+		UserID:    event.UserID,
+		ActualEnd: event.ActualEnd,
+	}
 }
 
-// WorkNameChangeEvent is sent when a user changes their work name
-type WorkNameChangeEvent struct {
-	Type     EventType `json:"type"`
-	ID       int64     `json:"id"`
-	UserID   int64     `json:"user_id"`
-	WorkName string    `json:"work_name"`
+func newWorkNameChangeEvent(event command.WorkNameChangeBroadcast) WorkNameChangeEvent {
+	return WorkNameChangeEvent{
+		Type:     EventTypeWorkNameChange,
+		EventID:  event.EventID,
+		ID:       event.SessionID,
+		UserID:   event.UserID,
+		WorkName: event.WorkName,
+	}
 }
 
-// Event is a union type of all possible WebSocket events
-type Event interface {
-	isEvent()
+func newSessionExtendEvent(event command.SessionExtendBroadcast) SessionExtendEvent {
+	return SessionExtendEvent{
+		Type:          EventTypeSessionExtend,
+		EventID:       event.EventID,
+		ID:            event.SessionID,
+		UserID:        event.UserID,
+		NewPlannedEnd: event.NewPlannedEnd,
+	}
 }
 
-// Implement marker methods
-func (SessionStartEvent) isEvent()   {}
-func (SessionEndEvent) isEvent()     {}
-func (SessionExtendEvent) isEvent()  {}
-func (WorkNameChangeEvent) isEvent() {}
+func newSettingsChangedEvent(event command.SettingsChangedBroadcast) SettingsChangedEvent {
+	return SettingsChangedEvent{
+		Type:                     EventTypeSettingsChanged,
+		EventID:                  event.EventID,
+		Changed:                  event.Changed,
+		SessionDefaultSeconds:    event.SessionDefaultSeconds,
+		MinExtensionMinutes:      int64(event.MinExtensionMinutes),
+		MaxExtensionMinutes:      int64(event.MaxExtensionMinutes),
+		CheerBonusPoints:         event.CheerBonusPoints,
+		ChannelPointsPerRaziiipo: event.ChannelPointsPerRaziiipo,
+		ChangedAt:                event.ChangedAt,
+	}
+}
@@ -0,0 +1,112 @@
+package ws
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+// eventSchema shared/ws/events.schema.json のうち、検証に使う部分
+type eventSchema struct {
+	Defs map[string]struct {
+		Properties map[string]struct {
+			Const string `json:"const"`
+		} `json:"properties"`
+		Required []string `json:"required"`
+	} `json:"$defs"`
+}
+
+// TestEvents_MatchSchema Hub が送るイベントのフィールドがスキーマと一致するか確認する
+// 変換関数がスキーマにないフィールドを出力したり、必須フィールドを落としたりすると失敗する
+func TestEvents_MatchSchema(t *testing.T) {
+	data, err := os.ReadFile("../../shared/ws/events.schema.json")
+	if err != nil {
+		t.Fatalf("failed to read schema: %v", err)
+	}
+	var schema eventSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	iconID := int64(7)
+	iconAssetKey := "tier2-07"
+	events := map[string]Event{
+		"SessionStartEvent": newSessionStartEvent(command.SessionStartBroadcast{
+			EventID: 1, SessionID: 2, UserID: 3, UserName: "alice", WorkName: "coding",
+			Tier: 2, IconID: &iconID, IconAssetKey: &iconAssetKey, StartTime: now, PlannedEnd: now.Add(time.Hour),
+		}),
+		"SessionEndEvent": newSessionEndEvent(command.SessionEndBroadcast{
+			EventID: 1, SessionID: 2, UserID: 3, ActualEnd: now,
+		}),
+		"SessionExtendEvent": newSessionExtendEvent(command.SessionExtendBroadcast{
+			EventID: 1, SessionID: 2, UserID: 3, NewPlannedEnd: now,
+		}),
+		"WorkNameChangeEvent": newWorkNameChangeEvent(command.WorkNameChangeBroadcast{
+			EventID: 1, SessionID: 2, UserID: 3, WorkName: "reading",
+		}),
+		"SettingsChangedEvent": newSettingsChangedEvent(command.SettingsChangedBroadcast{
+			EventID: 1, Changed: []string{"cheer.bonus_points"}, SessionDefaultSeconds: 3600,
+			MinExtensionMinutes: 1, MaxExtensionMinutes: 360, CheerBonusPoints: 5, ChannelPointsPerRaziiipo: 10, ChangedAt: now,
+		}),
+		"CommentEvent": newCommentBoard().post(command.CommentBroadcast{
+			UserID: 3, UserName: "alice", Text: "がんばる", PostedAt: now,
+		}),
+		"SubscribedEvent": SubscribedEvent{Type: EventTypeSubscribed, Topics: []string{TopicSessions}},
+		"ErrorEvent":      ErrorEvent{Type: EventTypeError, Message: "invalid message"},
+	}
+
+	for name, event := range events {
+		t.Run(name, func(t *testing.T) {
+			def, ok := schema.Defs[name]
+			if !ok {
+				t.Fatalf("%s is not declared in the schema", name)
+			}
+
+			encoded, err := json.Marshal(event)
+			if err != nil {
+				t.Fatalf("failed to encode: %v", err)
+			}
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(encoded, &fields); err != nil {
+				t.Fatalf("failed to decode: %v", err)
+			}
+
+			var undeclared []string
+			for key := range fields {
+				if _, ok := def.Properties[key]; !ok {
+					undeclared = append(undeclared, key)
+				}
+			}
+			sort.Strings(undeclared)
+			if len(undeclared) > 0 {
+				t.Errorf("fields not declared in events.yaml: %v", undeclared)
+			}
+			for _, key := range def.Required {
+				if _, ok := fields[key]; !ok {
+					t.Errorf("required field %q is missing", key)
+				}
+			}
+
+			var typ string
+			if err := json.Unmarshal(fields["type"], &typ); err != nil || typ != def.Properties["type"].Const {
+				t.Errorf("expected type %q, got %s", def.Properties["type"].Const, fields["type"])
+			}
+		})
+	}
+
+	// スキーマに追加されたイベントもこのテストで検証する
+	for name := range schema.Defs {
+		if !strings.HasSuffix(name, "Event") || name == "ServerEvent" {
+			continue
+		}
+		if _, ok := events[name]; !ok {
+			t.Errorf("%s is declared in the schema but not covered by this test", name)
+		}
+	}
+}
@@ -1,44 +1,57 @@
 package ws
 
 import (
-	"log"
 	"net/http"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/yamada-ai/workspace-backend/shared/logging"
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// Allow all origins in development
-		// In production, you should check the origin properly
-		return true
-	},
+// HandlerOptions WebSocket ハンドラーの設定
+type HandlerOptions struct {
+	// CheckOrigin 接続を受け付けるオリジンか（nil ならすべて受け付ける）
+	CheckOrigin func(r *http.Request) bool
 }
 
 // Handler handles WebSocket connections
 type Handler struct {
-	hub *Hub
+	hub      *Hub
+	upgrader websocket.Upgrader
 }
 
-// NewHandler creates a new WebSocket handler
+// NewHandler creates a new WebSocket handler that accepts every origin
 func NewHandler(hub *Hub) *Handler {
+	return NewHandlerWithOptions(hub, HandlerOptions{})
+}
+
+// NewHandlerWithOptions creates a new WebSocket handler
+func NewHandlerWithOptions(hub *Hub, opts HandlerOptions) *Handler {
+	checkOrigin := opts.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = func(r *http.Request) bool { return true }
+	}
 	return &Handler{
 		hub: hub,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     checkOrigin,
+		},
 	}
 }
 
 // ServeWS handles websocket requests from clients
 func (h *Handler) ServeWS(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+	conn, err := h.upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		log.Printf("Failed to upgrade connection: %v", err)
+		logging.FromContext(r.Context()).Warn("failed to upgrade websocket connection", logging.Err(err))
 		return
 	}
 
 	client := NewClient(h.hub, conn)
-	h.hub.register <- client
+	client.logger = logging.FromContext(r.Context())
+	h.hub.Register(client)
 
 	// Start goroutines for reading and writing
 	go client.WritePump()
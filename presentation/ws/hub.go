@@ -1,170 +1,439 @@
 package ws
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/websocket"
 
+	"github.com/yamada-ai/workspace-backend/shared/logging"
 	"github.com/yamada-ai/workspace-backend/usecase/command"
 )
 
+// SlowClientPolicy 送信バッファが満杯のクライアントへの対応
+type SlowClientPolicy string
+
+const (
+	// SlowClientEvict 送信バッファが満杯のクライアントを切断する（再接続して最新の状態を取り直してもらう）
+	SlowClientEvict SlowClientPolicy = "evict"
+	// SlowClientDrop 送信バッファが満杯のクライアントへのメッセージだけを破棄し、接続は維持する
+	SlowClientDrop SlowClientPolicy = "drop"
+)
+
+// ParseSlowClientPolicy 設定値を SlowClientPolicy に変換する
+func ParseSlowClientPolicy(s string) (SlowClientPolicy, error) {
+	switch p := SlowClientPolicy(s); p {
+	case SlowClientEvict, SlowClientDrop:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown slow client policy %q (want %q or %q)", s, SlowClientEvict, SlowClientDrop)
+	}
+}
+
+const (
+	// DefaultBroadcastQueueSize Run が処理する前のイベントを溜めておける数
+	DefaultBroadcastQueueSize = 256
+	// DefaultClientBufferSize クライアントごとの送信バッファのメッセージ数
+	DefaultClientBufferSize = 256
+)
+
+// HubOptions Hub の設定
+type HubOptions struct {
+	BroadcastQueueSize int
+	ClientBufferSize   int
+	SlowClientPolicy   SlowClientPolicy
+}
+
+// HubStats Hub の送信状況（バックプレッシャーの監視用）
+type HubStats struct {
+	Clients         int    // 接続中のクライアント数
+	QueueDepth      int    // Run がまだ処理していないイベント数
+	DroppedEvents   uint64 // Run が追いつかず破棄したイベント数
+	DroppedMessages uint64 // SlowClientDrop で破棄したクライアント宛てのメッセージ数
+	EvictedClients  uint64 // SlowClientEvict で切断したクライアント数
+}
+
 // Hub maintains the set of active clients and broadcasts messages to them
+// Broadcast はブロックしない。Run が詰まっている間のイベントは破棄して DroppedEvents に数える
 type Hub struct {
-	// Registered clients
+	// Registered clients (guarded by mu)
 	clients map[*Client]bool
+	mu      sync.Mutex
 
-	// Inbound messages from the clients
+	// Events waiting to be sent by Run
 	broadcast chan Event
 
-	// Register requests from the clients
-	register chan *Client
+	clientBufferSize int
+	slowClientPolicy SlowClientPolicy
 
-	// Unregister requests from clients
-	unregister chan *Client
+	// Overlay comments currently on screen
+	comments *commentBoard
 
-	mu sync.RWMutex
+	// Lifecycle: Start runs the loop, Stop closes broadcast and waits for done
+	started  atomic.Bool
+	running  atomic.Bool
+	done     chan struct{}
+	stopMu   sync.RWMutex // guards stopping against Broadcast sending on the closed channel
+	stopping bool
+	stopped  bool // guarded by mu; clients registered after this are closed immediately
+
+	// logger is the logger of the ctx passed to Start (guarded by stopMu)
+	logger *slog.Logger
+
+	droppedEvents   atomic.Uint64
+	droppedMessages atomic.Uint64
+	evictedClients  atomic.Uint64
 }
 
-// NewHub creates a new Hub
+// NewHub creates a new Hub with the default options
 func NewHub() *Hub {
+	return NewHubWithOptions(HubOptions{})
+}
+
+// NewHubWithOptions creates a new Hub (zero values fall back to the defaults)
+func NewHubWithOptions(opts HubOptions) *Hub {
+	if opts.BroadcastQueueSize <= 0 {
+		opts.BroadcastQueueSize = DefaultBroadcastQueueSize
+	}
+	if opts.ClientBufferSize <= 0 {
+		opts.ClientBufferSize = DefaultClientBufferSize
+	}
+	if opts.SlowClientPolicy == "" {
+		opts.SlowClientPolicy = SlowClientEvict
+	}
 	return &Hub{
-		broadcast:  make(chan Event, 256),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
-		clients:    make(map[*Client]bool),
+		clients:          make(map[*Client]bool),
+		broadcast:        make(chan Event, opts.BroadcastQueueSize),
+		clientBufferSize: opts.ClientBufferSize,
+		slowClientPolicy: opts.SlowClientPolicy,
+		comments:         newCommentBoard(),
+		done:             make(chan struct{}),
+		logger:           slog.Default(),
 	}
 }
 
-// Run starts the hub's main loop
-func (h *Hub) Run() {
-	for {
+// goingAwayMessage シャットダウン時にクライアントに送る close フレーム（1001）
+// オーバーレイはこれを受け取るとすぐに再接続し、新しいインスタンスにつながる
+var goingAwayMessage = websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+
+// Start starts the hub's main loop in the background (calling it again does nothing)
+// ctx のロガーを配信ループと Broadcast のログに使う
+func (h *Hub) Start(ctx context.Context) {
+	if !h.started.CompareAndSwap(false, true) {
+		return
+	}
+	logger := logging.FromContext(ctx)
+	h.stopMu.Lock()
+	h.logger = logger
+	h.stopMu.Unlock()
+	go h.run(logger)
+}
+
+// run キューのイベントをクライアントの送信バッファに移す（Stop でキューが閉じられ、空になると終わる）
+func (h *Hub) run(logger *slog.Logger) {
+	h.running.Store(true)
+	defer func() {
+		h.running.Store(false)
+		close(h.done)
+	}()
+
+	for event := range h.broadcast {
+		// Marshal event to JSON
+		message, err := json.Marshal(event)
+		if err != nil {
+			logger.Error("failed to marshal event", logging.Err(err), "event", fmt.Sprintf("%T", event))
+			continue
+		}
+		h.send(message, eventTopics(event))
+	}
+}
+
+// Stop はイベントの受け付けを止め、キューに残ったイベントを送り切ってから全クライアントを切断する
+// WebSocket クライアントには送信バッファを書き終えた後に close コード 1001（Going Away）を送り、その完了まで待つ
+// ctx が先に終わった場合は、残りの送信を待たずに ctx のエラーを返す
+func (h *Hub) Stop(ctx context.Context) error {
+	h.stopMu.Lock()
+	if h.stopping {
+		h.stopMu.Unlock()
+		return nil
+	}
+	h.stopping = true
+	close(h.broadcast)
+	h.stopMu.Unlock()
+
+	// 1. Let run move the queued events into the client buffers
+	if h.started.Load() {
 		select {
-		case client := <-h.register:
-			h.mu.Lock()
-			h.clients[client] = true
-			h.mu.Unlock()
-			log.Printf("Client connected. Total clients: %d", len(h.clients))
-
-		case client := <-h.unregister:
-			h.mu.Lock()
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				close(client.send)
-			}
-			h.mu.Unlock()
-			log.Printf("Client disconnected. Total clients: %d", len(h.clients))
-
-		case event := <-h.broadcast:
-			// Marshal event to JSON
-			message, err := json.Marshal(event)
-			if err != nil {
-				log.Printf("Error marshaling event: %v", err)
-				continue
-			}
+		case <-h.done:
+		case <-ctx.Done():
+			return fmt.Errorf("websocket hub: draining events: %w", ctx.Err())
+		}
+	}
+
+	// 2. Close every client; WritePump flushes the buffer and then sends the close frame
+	h.mu.Lock()
+	h.stopped = true
+	var writers []*Client
+	for client := range h.clients {
+		client.closeMessage = goingAwayMessage
+		h.removeLocked(client)
+		if client.conn != nil {
+			writers = append(writers, client)
+		}
+	}
+	h.mu.Unlock()
+
+	// 3. Wait until the close frames are written
+	for _, client := range writers {
+		select {
+		case <-client.done:
+		case <-ctx.Done():
+			return fmt.Errorf("websocket hub: closing clients: %w", ctx.Err())
+		}
+	}
+	logging.FromContext(ctx).Info("websocket hub stopped", "closed_clients", len(writers))
+	return nil
+}
 
-			h.mu.RLock()
-			for client := range h.clients {
-				select {
-				case client.send <- message:
-				default:
-					// Client's send buffer is full, disconnect them
-					close(client.send)
-					delete(h.clients, client)
-				}
+// send トピックを購読しているクライアントの送信バッファにメッセージを入れる
+func (h *Hub) send(message []byte, topics []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for client := range h.clients {
+		if !client.subs.matches(topics) {
+			continue
+		}
+		select {
+		case client.send <- message:
+		default:
+			// Client's send buffer is full
+			if h.slowClientPolicy == SlowClientDrop {
+				h.droppedMessages.Add(1)
+				continue
 			}
-			h.mu.RUnlock()
+			h.removeLocked(client)
+			h.evictedClients.Add(1)
+			client.logger.Warn("evicted slow websocket client", "total_clients", len(h.clients))
 		}
 	}
 }
 
-// Broadcast sends an event to all connected clients
+// Register adds a client to the hub
+// 停止後に登録したクライアントはすぐに 1001 で切断する
+func (h *Hub) Register(client *Client) {
+	h.mu.Lock()
+	if h.stopped {
+		client.closeMessage = goingAwayMessage
+		close(client.send)
+		h.mu.Unlock()
+		return
+	}
+	h.clients[client] = true
+	total := len(h.clients)
+	h.mu.Unlock()
+	client.logger.Info("websocket client connected", "total_clients", total)
+}
+
+// Unregister removes a client from the hub (no-op if it was already evicted)
+func (h *Hub) Unregister(client *Client) {
+	h.mu.Lock()
+	removed := h.removeLocked(client)
+	total := len(h.clients)
+	h.mu.Unlock()
+	if removed {
+		client.logger.Info("websocket client disconnected", "total_clients", total)
+	}
+}
+
+// removeLocked クライアントを削除して送信チャネルを閉じる（h.mu を保持して呼ぶ）
+// 削除済みのクライアントは何もしないため、チャネルを二重に閉じることはない
+func (h *Hub) removeLocked(client *Client) bool {
+	if _, ok := h.clients[client]; !ok {
+		return false
+	}
+	delete(h.clients, client)
+	close(client.send)
+	return true
+}
+
+// Subscribe adds topics to the client's subscriptions and returns the subscribed topics
+func (h *Hub) Subscribe(client *Client, topics []string) ([]string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := client.subs.add(topics); err != nil {
+		return nil, err
+	}
+	return client.subs.list(), nil
+}
+
+// Unsubscribe removes topics from the client's subscriptions and returns the remaining topics
+func (h *Hub) Unsubscribe(client *Client, topics []string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	client.subs.remove(topics)
+	return client.subs.list()
+}
+
+// matches クライアントがトピックのいずれかを購読しているかを返す
+func (h *Hub) matches(client *Client, topics []string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return client.subs.matches(topics)
+}
+
+// reply クライアント 1 件に応答を送る（切断済み・バッファが満杯なら破棄する）
+func (h *Hub) reply(client *Client, event Event) {
+	message, err := json.Marshal(event)
+	if err != nil {
+		client.logger.Error("failed to marshal reply", logging.Err(err))
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.clients[client] {
+		return
+	}
+	select {
+	case client.send <- message:
+	default:
+		h.droppedMessages.Add(1)
+	}
+}
+
+// Broadcast sends an event to all connected clients without blocking
+// Stop の後に渡されたイベントは捨てる
 func (h *Hub) Broadcast(event Event) {
-	h.broadcast <- event
+	h.stopMu.RLock()
+	defer h.stopMu.RUnlock()
+	if h.stopping {
+		return
+	}
+	select {
+	case h.broadcast <- event:
+	default:
+		if h.droppedEvents.Add(1) == 1 {
+			h.logger.Warn("websocket hub is not keeping up; dropping events (see Stats for the count)")
+		}
+	}
+}
+
+// Stats returns the current client count, queue depth and backpressure counters
+func (h *Hub) Stats() HubStats {
+	h.mu.Lock()
+	clients := len(h.clients)
+	h.mu.Unlock()
+
+	return HubStats{
+		Clients:         clients,
+		QueueDepth:      len(h.broadcast),
+		DroppedEvents:   h.droppedEvents.Load(),
+		DroppedMessages: h.droppedMessages.Load(),
+		EvictedClients:  h.evictedClients.Load(),
+	}
+}
+
+// Ready 配信ループが動いていて、イベントのキューに空きがあれば nil を返す（readiness チェック用）
+// Stop が始まった後は停止中として扱う
+func (h *Hub) Ready() error {
+	h.stopMu.RLock()
+	stopping := h.stopping
+	h.stopMu.RUnlock()
+	if stopping {
+		return errors.New("hub is shutting down")
+	}
+	if !h.running.Load() {
+		return errors.New("hub is not running")
+	}
+	if len(h.broadcast) == cap(h.broadcast) {
+		return fmt.Errorf("event queue is full (%d events)", cap(h.broadcast))
+	}
+	return nil
 }
 
 // BroadcastSessionStart implements command.EventBroadcaster
 func (h *Hub) BroadcastSessionStart(event command.SessionStartBroadcast) {
-	wsEvent := SessionStartEvent{
-		Type:       EventTypeSessionStart,
-		ID:         event.SessionID,
-		UserID:     event.UserID,
-		UserName:   event.UserName,
-		WorkName:   event.WorkName,
-		Tier:       event.Tier,
-		StartTime:  event.StartTime,
-		PlannedEnd: event.PlannedEnd,
-	}
-	h.Broadcast(wsEvent)
+	h.Broadcast(newSessionStartEvent(event))
 }
 
 // BroadcastSessionEnd implements command.EventBroadcaster
 func (h *Hub) BroadcastSessionEnd(event command.SessionEndBroadcast) {
-	wsEvent := SessionEndEvent{
-		Type:      EventTypeSessionEnd,
-		ID:        event.SessionID,
-		UserID:    event.UserID,
-		ActualEnd: event.ActualEnd,
-	}
-	h.Broadcast(wsEvent)
+	h.Broadcast(newSessionEndEvent(event))
 }
 
-// BroadcastWorkNameChange implements command.WorkNameChangeBroadcaster
+// BroadcastWorkNameChange implements command.EventBroadcaster
 func (h *Hub) BroadcastWorkNameChange(event command.WorkNameChangeBroadcast) {
-	wsEvent := WorkNameChangeEvent{
-		Type:     EventTypeWorkNameChange,
-		ID:       event.SessionID,
-		UserID:   event.UserID,
-		WorkName: event.WorkName,
-	}
-	h.Broadcast(wsEvent)
+	h.Broadcast(newWorkNameChangeEvent(event))
 }
 
 // BroadcastSessionExtend implements command.EventBroadcaster
 func (h *Hub) BroadcastSessionExtend(event command.SessionExtendBroadcast) {
-	wsEvent := SessionExtendEvent{
-		Type:          EventTypeSessionExtend,
-		ID:            event.SessionID,
-		UserID:        event.UserID,
-		NewPlannedEnd: event.NewPlannedEnd,
-	}
-	h.Broadcast(wsEvent)
+	h.Broadcast(newSessionExtendEvent(event))
 }
 
+// BroadcastSettingsChanged implements command.EventBroadcaster
+func (h *Hub) BroadcastSettingsChanged(event command.SettingsChangedBroadcast) {
+	h.Broadcast(newSettingsChangedEvent(event))
+}
+
+const (
+	// maxClientMessageSize クライアントから受け付けるメッセージの最大バイト数
+	maxClientMessageSize = 4096
+	// closeWriteWait close フレームの送信を待つ時間
+	closeWriteWait = time.Second
+)
+
 // Client represents a WebSocket client
 type Client struct {
 	hub  *Hub
 	conn *websocket.Conn
 	send chan []byte
+	subs subscriptions // guarded by hub.mu
+	// logger 接続したリクエストのロガー（request_id 付き）
+	logger *slog.Logger
+	// closeMessage send を閉じた後に WritePump が送る close フレーム（Hub が h.mu を保持して設定する）
+	closeMessage []byte
+	// done WritePump が終わると閉じる
+	done chan struct{}
 }
 
 // NewClient creates a new Client
 func NewClient(hub *Hub, conn *websocket.Conn) *Client {
 	return &Client{
-		hub:  hub,
-		conn: conn,
-		send: make(chan []byte, 256),
+		hub:    hub,
+		conn:   conn,
+		send:   make(chan []byte, hub.clientBufferSize),
+		logger: slog.Default(),
+		done:   make(chan struct{}),
 	}
 }
 
 // ReadPump pumps messages from the websocket connection to the hub
 func (c *Client) ReadPump() {
 	defer func() {
-		c.hub.unregister <- c
+		c.hub.Unregister(c)
 		c.conn.Close()
 	}()
 
+	c.conn.SetReadLimit(maxClientMessageSize)
+
 	for {
-		_, _, err := c.conn.ReadMessage()
+		_, message, err := c.conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				log.Printf("WebSocket error: %v", err)
+				c.logger.Warn("websocket read failed", logging.Err(err))
 			}
 			break
 		}
-		// Currently we don't process messages from clients
-		// In the future, we might handle client-to-server messages here
+		// subscribe / unsubscribe
+		c.hub.reply(c, c.hub.handleClientMessage(c, message))
 	}
 }
 
@@ -172,13 +441,14 @@ func (c *Client) ReadPump() {
 func (c *Client) WritePump() {
 	defer func() {
 		c.conn.Close()
+		close(c.done)
 	}()
 
 	for {
 		message, ok := <-c.send
 		if !ok {
-			// The hub closed the channel
-			_ = c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+			// The hub closed the channel (evicted, or shutting down with 1001)
+			_ = c.conn.WriteControl(websocket.CloseMessage, c.closeMessage, time.Now().Add(closeWriteWait))
 			return
 		}
 
@@ -0,0 +1,290 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+func TestHub_BroadcastDoesNotBlockWhenRunStalls(t *testing.T) {
+	// Start を呼ばない（配信ループが動いていない Hub）
+	hub := NewHubWithOptions(HubOptions{BroadcastQueueSize: 2})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := int64(1); i <= 5; i++ {
+			hub.BroadcastSessionEnd(command.SessionEndBroadcast{SessionID: i})
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Broadcast blocked while the hub was not running")
+	}
+
+	stats := hub.Stats()
+	if stats.DroppedEvents != 3 {
+		t.Errorf("expected 3 dropped events, got %d", stats.DroppedEvents)
+	}
+	if stats.QueueDepth != 2 {
+		t.Errorf("expected 2 queued events, got %d", stats.QueueDepth)
+	}
+}
+
+func TestHub_EvictsSlowClient(t *testing.T) {
+	hub := NewHubWithOptions(HubOptions{ClientBufferSize: 1})
+	slow := NewClient(hub, nil)
+	hub.Register(slow)
+
+	hub.send([]byte("first"), nil)
+	hub.send([]byte("second"), nil)
+
+	stats := hub.Stats()
+	if stats.Clients != 0 || stats.EvictedClients != 1 {
+		t.Fatalf("expected the slow client to be evicted, got %+v", stats)
+	}
+
+	// バッファ済みのメッセージを読み切るとチャネルが閉じている
+	if msg := <-slow.send; string(msg) != "first" {
+		t.Errorf("expected buffered message, got %q", msg)
+	}
+	if _, ok := <-slow.send; ok {
+		t.Error("expected the send channel to be closed")
+	}
+
+	// 切断済みのクライアントの Unregister は何もしない（二重 close しない）
+	hub.Unregister(slow)
+}
+
+func TestHub_DropPolicyKeepsSlowClient(t *testing.T) {
+	hub := NewHubWithOptions(HubOptions{ClientBufferSize: 1, SlowClientPolicy: SlowClientDrop})
+	slow := NewClient(hub, nil)
+	hub.Register(slow)
+
+	hub.send([]byte("first"), nil)
+	hub.send([]byte("second"), nil)
+
+	stats := hub.Stats()
+	if stats.Clients != 1 || stats.DroppedMessages != 1 || stats.EvictedClients != 0 {
+		t.Fatalf("expected the message to be dropped and the client kept, got %+v", stats)
+	}
+	if msg := <-slow.send; string(msg) != "first" {
+		t.Errorf("expected the first message, got %q", msg)
+	}
+}
+
+func TestHub_ConcurrentRegisterAndBroadcast(t *testing.T) {
+	hub := NewHubWithOptions(HubOptions{ClientBufferSize: 1})
+	hub.Start(context.Background())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client := NewClient(hub, nil)
+			hub.Register(client)
+			hub.Unregister(client)
+		}()
+		go func(id int64) {
+			defer wg.Done()
+			hub.BroadcastWorkNameChange(command.WorkNameChangeBroadcast{SessionID: id})
+		}(int64(i))
+	}
+	wg.Wait()
+}
+
+func TestParseSlowClientPolicy(t *testing.T) {
+	for _, s := range []string{"evict", "drop"} {
+		if p, err := ParseSlowClientPolicy(s); err != nil || string(p) != s {
+			t.Errorf("ParseSlowClientPolicy(%q) = %q, %v", s, p, err)
+		}
+	}
+	if _, err := ParseSlowClientPolicy("block"); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
+
+func TestHub_RoutesEventsToSubscribedTopics(t *testing.T) {
+	hub := NewHub()
+	all := NewClient(hub, nil)
+	room := NewClient(hub, nil)
+	ranking := NewClient(hub, nil)
+	timer := NewClient(hub, nil)
+	for _, c := range []*Client{all, room, ranking, timer} {
+		hub.Register(c)
+	}
+
+	subscribe := func(c *Client, topics ...string) {
+		t.Helper()
+		data, _ := json.Marshal(ClientMessage{Type: ClientMessageSubscribe, Topics: topics})
+		reply, ok := hub.handleClientMessage(c, data).(SubscribedEvent)
+		if !ok {
+			t.Fatalf("expected subscribed reply for %v", topics)
+		}
+		if len(reply.Topics) != len(topics) {
+			t.Fatalf("expected %v to be subscribed, got %v", topics, reply.Topics)
+		}
+	}
+	subscribe(room, TopicSessions)
+	subscribe(ranking, TopicRankings)
+	subscribe(timer, UserTopic(42))
+
+	start := SessionStartEvent{Type: EventTypeSessionStart, UserID: 7}
+	hub.send([]byte("start"), eventTopics(start))
+	end := SessionEndEvent{Type: EventTypeSessionEnd, UserID: 42}
+	hub.send([]byte("end"), eventTopics(end))
+
+	expect := map[*Client][]string{
+		all:     {"start", "end"},
+		room:    {"start", "end"},
+		ranking: {"end"},
+		timer:   {"end"},
+	}
+	for c, want := range expect {
+		var got []string
+		for len(c.send) > 0 {
+			got = append(got, string(<-c.send))
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestHub_HandleClientMessage(t *testing.T) {
+	hub := NewHub()
+	client := NewClient(hub, nil)
+	hub.Register(client)
+
+	tests := []struct {
+		name    string
+		message string
+		want    EventType
+	}{
+		{"invalid json", `{`, EventTypeError},
+		{"unknown type", `{"type":"ping"}`, EventTypeError},
+		{"invalid topic", `{"type":"subscribe","topics":["user:abc"]}`, EventTypeError},
+		{"subscribe", `{"type":"subscribe","topics":["sessions","user:1"]}`, EventTypeSubscribed},
+		{"unsubscribe", `{"type":"unsubscribe","topics":["sessions"]}`, EventTypeSubscribed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got EventType
+			switch e := hub.handleClientMessage(client, []byte(tt.message)).(type) {
+			case ErrorEvent:
+				got = e.Type
+			case SubscribedEvent:
+				got = e.Type
+			}
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	// 失敗した subscribe は購読状態を変えない
+	topics := hub.Unsubscribe(client, nil)
+	if strings.Join(topics, ",") != "user:1" {
+		t.Errorf("expected [user:1], got %v", topics)
+	}
+}
+
+func TestHub_Ready(t *testing.T) {
+	hub := NewHubWithOptions(HubOptions{BroadcastQueueSize: 1})
+	if err := hub.Ready(); err == nil {
+		t.Error("hub should not be ready before Run starts")
+	}
+
+	hub.Start(context.Background())
+	deadline := time.Now().Add(time.Second)
+	for hub.Ready() != nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("hub did not become ready: %v", hub.Ready())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestHub_StopDrainsQueuedEvents(t *testing.T) {
+	hub := NewHub()
+	client := NewClient(hub, nil)
+	hub.Register(client)
+
+	// 配信ループを動かす前にキューに積んでおく
+	hub.BroadcastSessionStart(command.SessionStartBroadcast{EventID: 1, SessionID: 1})
+	hub.BroadcastSessionEnd(command.SessionEndBroadcast{EventID: 2, SessionID: 1})
+	hub.Start(context.Background())
+
+	if err := hub.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	var received []string
+	for message := range client.send {
+		var base BaseEvent
+		if err := json.Unmarshal(message, &base); err != nil {
+			t.Fatalf("failed to decode event: %v", err)
+		}
+		received = append(received, string(base.Type))
+	}
+	if len(received) != 2 || received[0] != "session_start" || received[1] != "session_end" {
+		t.Fatalf("expected the queued events before the close, got %v", received)
+	}
+	if hub.Stats().Clients != 0 {
+		t.Errorf("expected all clients to be removed")
+	}
+	if err := hub.Ready(); err == nil {
+		t.Errorf("expected a stopped hub not to be ready")
+	}
+
+	// 停止後のイベントは捨て、新しいクライアントはすぐに切断する
+	hub.BroadcastSessionEnd(command.SessionEndBroadcast{EventID: 3, SessionID: 1})
+	late := NewClient(hub, nil)
+	hub.Register(late)
+	if _, ok := <-late.send; ok {
+		t.Errorf("expected a client registered after Stop to be closed")
+	}
+	if err := hub.Stop(context.Background()); err != nil {
+		t.Errorf("second Stop should be a no-op, got %v", err)
+	}
+}
+
+func TestHub_StopSendsGoingAway(t *testing.T) {
+	hub := NewHub()
+	hub.Start(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(NewHandler(hub).ServeWS))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	waitForClients(t, hub, 1)
+
+	hub.BroadcastSessionEnd(command.SessionEndBroadcast{EventID: 1, SessionID: 1})
+	if err := hub.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	if _, message, err := conn.ReadMessage(); err != nil || !strings.Contains(string(message), "session_end") {
+		t.Fatalf("expected the pending event before the close, got %q, %v", message, err)
+	}
+	_, _, err = conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+		t.Fatalf("expected close code 1001, got %v", err)
+	}
+}
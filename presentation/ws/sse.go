@@ -0,0 +1,242 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+const (
+	// MaxReplayEvents Last-Event-ID から再開するときに再送するイベント数の上限
+	MaxReplayEvents = 1000
+	// sseHeartbeatInterval 接続を維持するためのコメント行を送る間隔
+	sseHeartbeatInterval = 15 * time.Second
+	// sseRetry 切断時にブラウザ（EventSource）が再接続するまでの待ち時間（ミリ秒）
+	sseRetry = 3000
+)
+
+// EventReplayer 配信済みのイベントを afterID の次から sink に渡す（outbox.Replayer）
+type EventReplayer interface {
+	ReplayAfter(ctx context.Context, afterID int64, limit int32, sink command.EventBroadcaster) (int, error)
+}
+
+// SSEHandler Server-Sent Events で WebSocket と同じイベントを配信する
+// 接続ごとに Hub のクライアントとして登録し、WebSocket と同じ JSON を data に載せる
+type SSEHandler struct {
+	hub       *Hub
+	replayer  EventReplayer
+	heartbeat time.Duration
+
+	shutdownOnce sync.Once
+	shutdown     chan struct{}
+}
+
+// NewSSEHandler creates a new SSE handler
+// replayer が nil の場合、Last-Event-ID は無視する
+func NewSSEHandler(hub *Hub, replayer EventReplayer) *SSEHandler {
+	return &SSEHandler{
+		hub:       hub,
+		replayer:  replayer,
+		heartbeat: sseHeartbeatInterval,
+		shutdown:  make(chan struct{}),
+	}
+}
+
+// Shutdown 開いているストリームをすべて終わらせる（http.Server.RegisterOnShutdown に渡す）
+// SSE のストリームは終わらないリクエストなので、閉じないと Server.Shutdown がタイムアウトまで待ってしまう
+// クライアントは retry の後に Last-Event-ID を付けて再接続し、取りこぼしたイベントは再送される
+func (h *SSEHandler) Shutdown() {
+	h.shutdownOnce.Do(func() { close(h.shutdown) })
+}
+
+// ServeSSE handles GET /api/events/stream
+//
+// クエリ:
+//   - topics: 購読するトピック（カンマ区切り、省略時は全イベント）
+//   - last_event_id: Last-Event-ID ヘッダーの代わり（初回接続でヘッダーを付けられないクライアント向け）
+func (h *SSEHandler) ServeSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	var topics []string
+	if v := r.URL.Query().Get("topics"); v != "" {
+		for _, topic := range strings.Split(v, ",") {
+			topic = strings.TrimSpace(topic)
+			if err := ValidateTopic(topic); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			topics = append(topics, topic)
+		}
+	}
+
+	lastEventID, err := parseLastEventID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// サーバーの WriteTimeout でストリームが切られないようにする
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		logging.FromContext(r.Context()).Warn("sse: failed to clear write deadline", logging.Err(err))
+	}
+
+	// 再送中に配信されたイベントを取りこぼさないよう、先に Hub に登録する
+	client := NewClient(h.hub, nil)
+	client.logger = logging.FromContext(r.Context())
+	if topics != nil {
+		if _, err := h.hub.Subscribe(client, topics); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	h.hub.Register(client)
+	defer h.hub.Unregister(client)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", sseRetry); err != nil {
+		return
+	}
+
+	replayed, err := h.replay(r.Context(), w, client, lastEventID)
+	if err != nil {
+		return
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.shutdown:
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case message, ok := <-client.send:
+			if !ok {
+				// Hub が切断した（送信が追いつかなかった、または停止した）
+				return
+			}
+			var base BaseEvent
+			if err := json.Unmarshal(message, &base); err != nil {
+				client.logger.Error("sse: failed to decode event", logging.Err(err))
+				continue
+			}
+			if replayed[base.EventID] {
+				continue
+			}
+			if err := writeSSEEvent(w, base, message); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// replay lastEventID より後のイベントを送り、送ったイベント ID を返す
+// 再送に失敗した場合もライブ配信は続ける
+func (h *SSEHandler) replay(ctx context.Context, w io.Writer, client *Client, lastEventID int64) (map[int64]bool, error) {
+	if lastEventID <= 0 || h.replayer == nil {
+		return nil, nil
+	}
+
+	collector := &eventCollector{}
+	if _, err := h.replayer.ReplayAfter(ctx, lastEventID, MaxReplayEvents, collector); err != nil {
+		client.logger.Error("sse: failed to replay events", "last_event_id", lastEventID, logging.Err(err))
+		return nil, nil
+	}
+
+	replayed := make(map[int64]bool, len(collector.events))
+	for _, event := range collector.events {
+		if !h.hub.matches(client, eventTopics(event)) {
+			continue
+		}
+		message, err := json.Marshal(event)
+		if err != nil {
+			client.logger.Error("sse: failed to encode event", logging.Err(err))
+			continue
+		}
+		var base BaseEvent
+		if err := json.Unmarshal(message, &base); err != nil {
+			continue
+		}
+		if err := writeSSEEvent(w, base, message); err != nil {
+			return nil, err
+		}
+		replayed[base.EventID] = true
+	}
+	return replayed, nil
+}
+
+func parseLastEventID(r *http.Request) (int64, error) {
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = r.URL.Query().Get("last_event_id")
+	}
+	if v == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid Last-Event-ID: %q", v)
+	}
+	return id, nil
+}
+
+// writeSSEEvent イベントを 1 件書き込む（ID がないイベントは id 行を省略し、クライアントの Last-Event-ID を変えない）
+func writeSSEEvent(w io.Writer, base BaseEvent, data []byte) error {
+	var b strings.Builder
+	if base.EventID > 0 {
+		fmt.Fprintf(&b, "id: %d\n", base.EventID)
+	}
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", base.Type, data)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// eventCollector 再送されたイベントを WebSocket のイベントに変換して溜める
+type eventCollector struct {
+	events []Event
+}
+
+func (c *eventCollector) BroadcastSessionStart(event command.SessionStartBroadcast) {
+	c.events = append(c.events, newSessionStartEvent(event))
+}
+
+func (c *eventCollector) BroadcastSessionEnd(event command.SessionEndBroadcast) {
+	c.events = append(c.events, newSessionEndEvent(event))
+}
+
+func (c *eventCollector) BroadcastWorkNameChange(event command.WorkNameChangeBroadcast) {
+	c.events = append(c.events, newWorkNameChangeEvent(event))
+}
+
+func (c *eventCollector) BroadcastSessionExtend(event command.SessionExtendBroadcast) {
+	c.events = append(c.events, newSessionExtendEvent(event))
+}
+
+func (c *eventCollector) BroadcastSettingsChanged(event command.SettingsChangedBroadcast) {
+	c.events = append(c.events, newSettingsChangedEvent(event))
+}
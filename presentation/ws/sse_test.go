@@ -0,0 +1,176 @@
+package ws
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+// fakeReplayer 配信済みのイベントとして session_start を返す
+type fakeReplayer struct {
+	events  []command.SessionStartBroadcast
+	afterID int64
+}
+
+func (r *fakeReplayer) ReplayAfter(ctx context.Context, afterID int64, limit int32, sink command.EventBroadcaster) (int, error) {
+	r.afterID = afterID
+	n := 0
+	for _, e := range r.events {
+		if e.EventID > afterID {
+			sink.BroadcastSessionStart(e)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// readSSEEvents ストリームから n 件のイベント（id と event の行）を読む
+func readSSEEvents(t *testing.T, scanner *bufio.Scanner, n int) []string {
+	t.Helper()
+	var events []string
+	var current []string
+	for len(events) < n && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(current) > 0 {
+				events = append(events, strings.Join(current, " "))
+				current = nil
+			}
+		case strings.HasPrefix(line, "id: "), strings.HasPrefix(line, "event: "):
+			current = append(current, line)
+		}
+	}
+	if len(events) < n {
+		t.Fatalf("expected %d events, got %v (err: %v)", n, events, scanner.Err())
+	}
+	return events
+}
+
+func TestSSEHandler_ResumesFromLastEventID(t *testing.T) {
+	hub := NewHub()
+	hub.Start(context.Background())
+
+	replayer := &fakeReplayer{events: []command.SessionStartBroadcast{
+		{EventID: 4, SessionID: 1},
+		{EventID: 5, SessionID: 2},
+		{EventID: 6, SessionID: 3},
+	}}
+	server := httptest.NewServer(http.HandlerFunc(NewSSEHandler(hub, replayer).ServeSSE))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Last-Event-ID", "4")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", ct)
+	}
+	if replayer.afterID != 4 {
+		t.Errorf("expected replay after 4, got %d", replayer.afterID)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	replayed := readSSEEvents(t, scanner, 2)
+	if replayed[0] != "id: 5 event: session_start" || replayed[1] != "id: 6 event: session_start" {
+		t.Fatalf("unexpected replayed events: %v", replayed)
+	}
+
+	// 再送済みのイベントがライブで届いても重複して送らない
+	waitForClients(t, hub, 1)
+	hub.BroadcastSessionStart(command.SessionStartBroadcast{EventID: 6, SessionID: 3})
+	hub.BroadcastSessionEnd(command.SessionEndBroadcast{EventID: 7, SessionID: 1})
+
+	live := readSSEEvents(t, scanner, 1)
+	if live[0] != "id: 7 event: session_end" {
+		t.Errorf("unexpected live event: %v", live)
+	}
+}
+
+func TestSSEHandler_FiltersByTopic(t *testing.T) {
+	hub := NewHub()
+	hub.Start(context.Background())
+
+	server := httptest.NewServer(http.HandlerFunc(NewSSEHandler(hub, nil).ServeSSE))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "?topics=rankings")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	waitForClients(t, hub, 1)
+	hub.BroadcastSessionStart(command.SessionStartBroadcast{EventID: 1, SessionID: 1})
+	hub.BroadcastSessionEnd(command.SessionEndBroadcast{EventID: 2, SessionID: 1})
+
+	events := readSSEEvents(t, bufio.NewScanner(resp.Body), 1)
+	if events[0] != "id: 2 event: session_end" {
+		t.Errorf("expected only session_end for rankings, got %v", events)
+	}
+}
+
+func TestSSEHandler_RejectsInvalidParameters(t *testing.T) {
+	handler := NewSSEHandler(NewHub(), nil)
+
+	for _, target := range []string{"/?topics=unknown", "/?last_event_id=abc"} {
+		rec := httptest.NewRecorder()
+		handler.ServeSSE(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, rec.Code)
+		}
+	}
+}
+
+func waitForClients(t *testing.T, hub *Hub, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for hub.Stats().Clients < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d clients to be registered", n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSSEHandler_ShutdownEndsStreams(t *testing.T) {
+	hub := NewHub()
+	hub.Start(context.Background())
+	handler := NewSSEHandler(hub, nil)
+
+	server := httptest.NewServer(http.HandlerFunc(handler.ServeSSE))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	waitForClients(t, hub, 1)
+
+	handler.Shutdown()
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(io.Discard, resp.Body)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected the stream to end cleanly, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream was not closed by Shutdown")
+	}
+}
@@ -0,0 +1,153 @@
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// クライアントが購読できるトピック
+const (
+	// TopicSessions セッションの開始・終了・延長・作業名変更（アバタールーム向け）
+	TopicSessions = "sessions"
+	// TopicRankings 作業時間の集計が変わるイベント（ランキングパネル向け）
+	TopicRankings = "rankings"
+	// TopicActions 視聴者のアクション（応援・コメントなど）
+	TopicActions = "actions"
+	// TopicSettings 配信中に変更できる設定の変更（オーバーレイの表示の更新用）
+	TopicSettings = "settings"
+	// TopicUserPrefix 特定ユーザーのイベント（"user:<user_id>"、個人タイマー向け）
+	TopicUserPrefix = "user:"
+)
+
+// MaxTopicsPerClient 1 クライアントが購読できるトピック数の上限
+const MaxTopicsPerClient = 32
+
+var ErrInvalidTopic = errors.New("invalid topic")
+
+// ClientMessage クライアントから送られるメッセージ
+type ClientMessage struct {
+	Type   ClientMessageType `json:"type"`
+	Topics []string          `json:"topics"`
+}
+
+// UserTopic ユーザー個別のトピック名を返す
+func UserTopic(userID int64) string {
+	return TopicUserPrefix + strconv.FormatInt(userID, 10)
+}
+
+// ValidateTopic 購読できるトピックかを確認する
+func ValidateTopic(topic string) error {
+	switch topic {
+	case TopicSessions, TopicRankings, TopicActions, TopicSettings:
+		return nil
+	}
+	if id, ok := strings.CutPrefix(topic, TopicUserPrefix); ok {
+		if n, err := strconv.ParseInt(id, 10, 64); err == nil && n > 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidTopic, topic)
+}
+
+// eventTopics イベントの配信先トピック
+// セッションの終了は作業時間の合計が変わるため rankings にも配信する
+func eventTopics(event Event) []string {
+	switch e := event.(type) {
+	case SessionStartEvent:
+		return []string{TopicSessions, UserTopic(e.UserID)}
+	case SessionEndEvent:
+		return []string{TopicSessions, TopicRankings, UserTopic(e.UserID)}
+	case SessionExtendEvent:
+		return []string{TopicSessions, UserTopic(e.UserID)}
+	case WorkNameChangeEvent:
+		return []string{TopicSessions, UserTopic(e.UserID)}
+	case SettingsChangedEvent:
+		return []string{TopicSettings}
+	case CommentEvent:
+		return []string{TopicActions, UserTopic(e.UserID)}
+	default:
+		return nil
+	}
+}
+
+// subscriptions クライアントの購読状態（Hub の mu を保持して操作する）
+// 一度も subscribe していないクライアントは、互換性のためすべてのイベントを受け取る
+type subscriptions struct {
+	topics map[string]bool // nil なら未購読（全イベント）
+}
+
+func (s *subscriptions) matches(topics []string) bool {
+	if s.topics == nil {
+		return true
+	}
+	for _, topic := range topics {
+		if s.topics[topic] {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *subscriptions) add(topics []string) error {
+	for _, topic := range topics {
+		if err := ValidateTopic(topic); err != nil {
+			return err
+		}
+	}
+	merged := make(map[string]bool, len(s.topics)+len(topics))
+	for topic := range s.topics {
+		merged[topic] = true
+	}
+	for _, topic := range topics {
+		merged[topic] = true
+	}
+	if len(merged) > MaxTopicsPerClient {
+		return fmt.Errorf("too many topics (max %d)", MaxTopicsPerClient)
+	}
+	s.topics = merged
+	return nil
+}
+
+func (s *subscriptions) remove(topics []string) {
+	if s.topics == nil {
+		// 未購読（全イベント）から外す場合は、購読なしの状態にする
+		s.topics = make(map[string]bool)
+	}
+	for _, topic := range topics {
+		delete(s.topics, topic)
+	}
+}
+
+func (s *subscriptions) list() []string {
+	topics := make([]string, 0, len(s.topics))
+	for topic := range s.topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// handleClientMessage クライアントのメッセージを処理し、応答を返す
+func (h *Hub) handleClientMessage(client *Client, data []byte) Event {
+	var msg ClientMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return ErrorEvent{Type: EventTypeError, Message: "invalid message: " + err.Error()}
+	}
+
+	switch msg.Type {
+	case ClientMessageSubscribe:
+		topics, err := h.Subscribe(client, msg.Topics)
+		if err != nil {
+			return ErrorEvent{Type: EventTypeError, Message: err.Error()}
+		}
+		return SubscribedEvent{Type: EventTypeSubscribed, Topics: topics}
+	case ClientMessageUnsubscribe:
+		return SubscribedEvent{Type: EventTypeSubscribed, Topics: h.Unsubscribe(client, msg.Topics)}
+	default:
+		return ErrorEvent{Type: EventTypeError, Message: fmt.Sprintf("unknown message type %q", msg.Type)}
+	}
+}
@@ -0,0 +1,12 @@
+#!/bin/bash
+set -e
+
+echo "Generating WebSocket event types..."
+
+go run ./cmd/wsgen \
+  -in shared/ws/events.yaml \
+  -go presentation/ws/events.gen.go \
+  -ts shared/ws/events.gen.ts \
+  -jsonschema shared/ws/events.schema.json
+
+echo "✅ WebSocket event generation completed!"
@@ -0,0 +1,86 @@
+// Package logging slog のロガーを context.Context で受け渡す
+// HTTP のミドルウェアがリクエスト ID を付けたロガーを context に入れ、usecase がユーザー名・セッション ID を足していく。
+// どの層からも使うため、domain 以外のすべての層から import してよい
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// ログの属性のキー
+const (
+	KeyRequestID = "request_id"
+	KeyUserName  = "user_name"
+	KeyUserID    = "user_id"
+	KeySessionID = "session_id"
+	KeyTraceID   = "trace_id"
+	KeyError     = "error"
+)
+
+// Format ログの出力形式
+type Format string
+
+const (
+	// FormatText key=value 形式（開発用）
+	FormatText Format = "text"
+	// FormatJSON 1 行 1 つの JSON（ログ収集用）
+	FormatJSON Format = "json"
+)
+
+// ParseFormat 設定値を Format に変換する
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatText, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown log format %q (want %q or %q)", s, FormatText, FormatJSON)
+	}
+}
+
+// ParseLevel 設定値（debug / info / warn / error）を slog.Level に変換する
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q (want debug, info, warn or error)", s)
+	}
+	return level, nil
+}
+
+// New w に format の形式で level 以上のログを書くロガーを作る
+func New(w io.Writer, format Format, level slog.Level) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == FormatJSON {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+type loggerKey struct{}
+
+// WithLogger logger を入れた context を返す
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext context のロガーを返す（入っていなければ slog.Default()）
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With context のロガーに属性を足した context を返す
+// 以降そのロガーで書くログにはすべて args の属性が付く
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
+
+// Err エラーの属性
+func Err(err error) slog.Attr {
+	return slog.Any(KeyError, err)
+}
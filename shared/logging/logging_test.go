@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+func TestWith_AddsAttributesToContextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := WithLogger(context.Background(), New(&buf, FormatJSON, slog.LevelInfo))
+	ctx = With(ctx, KeyRequestID, "req-1")
+	ctx = With(ctx, KeyUserName, "yamada", KeySessionID, int64(42))
+
+	FromContext(ctx).Info("session started", Err(errors.New("boom")))
+	FromContext(ctx).Debug("not written below the level")
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("expected one JSON line, got %q: %v", buf.String(), err)
+	}
+	want := map[string]any{"msg": "session started", KeyRequestID: "req-1", KeyUserName: "yamada", KeySessionID: float64(42), KeyError: "boom"}
+	for k, v := range want {
+		if line[k] != v {
+			t.Errorf("%s = %v, want %v", k, line[k], v)
+		}
+	}
+}
+
+func TestFromContext_FallsBackToDefault(t *testing.T) {
+	if FromContext(context.Background()) != slog.Default() {
+		t.Error("expected slog.Default() for a context without a logger")
+	}
+}
+
+func TestParse(t *testing.T) {
+	if f, err := ParseFormat("JSON"); err != nil || f != FormatJSON {
+		t.Errorf("ParseFormat(JSON) = %q, %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if l, err := ParseLevel("warn"); err != nil || l != slog.LevelWarn {
+		t.Errorf("ParseLevel(warn) = %v, %v", l, err)
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
@@ -0,0 +1,57 @@
+// Package tracing OpenTelemetry のスパンを作る
+// エクスポーターの設定（infrastructure/telemetry）とは分けてあり、どの層からも otel のグローバルな TracerProvider を使う。
+// TracerProvider が設定されていなければスパンは何もしない
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName スパンを作るライブラリの名前（otel の instrumentation scope）
+const instrumentationName = "github.com/yamada-ai/workspace-backend"
+
+// スパンの属性のキー
+const (
+	KeyUserName  = attribute.Key("workspace.user_name")
+	KeySessionID = attribute.Key("workspace.session_id")
+	KeyUserID    = attribute.Key("workspace.user_id")
+	KeyEventID   = attribute.Key("workspace.event_id")
+	KeyEventType = attribute.Key("workspace.event_type")
+	KeySink      = attribute.Key("workspace.sink")
+	// KeyQueueWaitMs イベントがキューで待った時間（ミリ秒）
+	KeyQueueWaitMs = attribute.Key("workspace.queue_wait_ms")
+)
+
+// Tracer このアプリケーションの Tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start ctx のスパンを親にしてスパンを開始する
+// 呼び出し側は defer で End を呼ぶ
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End err をスパンに記録して終了する（err が nil なら記録しない）
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceID ctx のスパンのトレース ID（スパンがなければ空文字）
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
@@ -0,0 +1,38 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEnd_RecordsError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	ctx, ok := tracer.Start(context.Background(), "ok")
+	End(ok, nil)
+	_, failed := tracer.Start(ctx, "failed")
+	End(failed, errors.New("row lock timeout"))
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Status().Code == codes.Error {
+		t.Errorf("span without an error should not be an error span")
+	}
+	if spans[1].Status().Code != codes.Error || spans[1].Status().Description != "row lock timeout" {
+		t.Errorf("unexpected status %+v", spans[1].Status())
+	}
+	if got := TraceID(ctx); got != spans[0].SpanContext().TraceID().String() {
+		t.Errorf("TraceID = %q, want %q", got, spans[0].SpanContext().TraceID())
+	}
+	if got := TraceID(context.Background()); got != "" {
+		t.Errorf("TraceID without a span = %q, want empty", got)
+	}
+}
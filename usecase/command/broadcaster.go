@@ -1,37 +1,70 @@
 package command
 
-import "time"
+import (
+	"context"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
 
 // SessionStartBroadcast represents the data to broadcast when a session starts
 type SessionStartBroadcast struct {
-	SessionID  int64
-	UserID     int64
-	UserName   string
-	WorkName   string
-	Tier       int
-	StartTime  time.Time
-	PlannedEnd time.Time
+	EventID      int64     `json:"-"` // outbox event ID (set by the dispatcher)
+	SessionID    int64     `json:"session_id"`
+	UserID       int64     `json:"user_id"`
+	UserName     string    `json:"user_name"`
+	WorkName     string    `json:"work_name"`
+	Tier         int       `json:"tier"`
+	IconID       *int64    `json:"icon_id,omitempty"`
+	IconAssetKey *string   `json:"icon_asset_key,omitempty"`
+	StartTime    time.Time `json:"start_time"`
+	PlannedEnd   time.Time `json:"planned_end"`
 }
 
 // SessionEndBroadcast represents the data to broadcast when a session ends
 type SessionEndBroadcast struct {
-	SessionID int64
-	UserID    int64
-	ActualEnd time.Time
+	EventID   int64     `json:"-"` // outbox event ID (set by the dispatcher)
+	SessionID int64     `json:"session_id"`
+	UserID    int64     `json:"user_id"`
+	ActualEnd time.Time `json:"actual_end"`
 }
 
 // WorkNameChangeBroadcast represents the data to broadcast when work name changes
 type WorkNameChangeBroadcast struct {
-	SessionID int64
-	UserID    int64
-	WorkName  string
+	EventID   int64  `json:"-"` // outbox event ID (set by the dispatcher)
+	SessionID int64  `json:"session_id"`
+	UserID    int64  `json:"user_id"`
+	WorkName  string `json:"work_name"`
 }
 
 // SessionExtendBroadcast represents the data to broadcast when a session is extended
 type SessionExtendBroadcast struct {
-	SessionID     int64
-	UserID        int64
-	NewPlannedEnd time.Time
+	EventID       int64     `json:"-"` // outbox event ID (set by the dispatcher)
+	SessionID     int64     `json:"session_id"`
+	UserID        int64     `json:"user_id"`
+	NewPlannedEnd time.Time `json:"new_planned_end"`
+}
+
+// SettingsChangedBroadcast represents the data to broadcast when runtime settings are changed
+// The values are the settings in effect after the change, so overlays can replace what they show
+type SettingsChangedBroadcast struct {
+	EventID                  int64     `json:"-"` // outbox event ID (set by the dispatcher)
+	Changed                  []string  `json:"changed"`
+	SessionDefaultSeconds    int64     `json:"session_default_seconds"`
+	MinExtensionMinutes      int       `json:"min_extension_minutes"`
+	MaxExtensionMinutes      int       `json:"max_extension_minutes"`
+	CheerBonusPoints         int64     `json:"cheer_bonus_points"`
+	ChannelPointsPerRaziiipo int64     `json:"channel_points_per_raziiipo"`
+	ChangedAt                time.Time `json:"changed_at"`
+}
+
+// CommentBroadcast represents a viewer's comment shown in the overlay comment area
+// Text is already sanitized and truncated for display
+type CommentBroadcast struct {
+	UserID   int64
+	UserName string
+	Text     string
+	PostedAt time.Time
 }
 
 // EventBroadcaster is an interface for broadcasting events to clients
@@ -40,26 +73,70 @@ type EventBroadcaster interface {
 	BroadcastSessionEnd(event SessionEndBroadcast)
 	BroadcastWorkNameChange(event WorkNameChangeBroadcast)
 	BroadcastSessionExtend(event SessionExtendBroadcast)
+	BroadcastSettingsChanged(event SettingsChangedBroadcast)
+}
+
+// EventOutbox records events in the same transaction as the state change.
+// Recorded events are delivered to EventBroadcaster sinks by the outbox dispatcher after commit
+type EventOutbox interface {
+	RecordSessionStart(ctx context.Context, tx repository.Tx, event SessionStartBroadcast) error
+	RecordSessionEnd(ctx context.Context, tx repository.Tx, event SessionEndBroadcast) error
+	RecordWorkNameChange(ctx context.Context, tx repository.Tx, event WorkNameChangeBroadcast) error
+	RecordSessionExtend(ctx context.Context, tx repository.Tx, event SessionExtendBroadcast) error
+
+	// Notify wakes up the dispatcher after a transaction with recorded events is committed
+	Notify()
+}
+
+// CommentBroadcaster shows comments in the overlay
+// Comments are ephemeral, so they are sent directly instead of going through the outbox
+type CommentBroadcaster interface {
+	BroadcastComment(event CommentBroadcast)
 }
 
 // NoOpBroadcaster is a no-op implementation of EventBroadcaster
 // Useful for testing or when WebSocket is disabled
 type NoOpBroadcaster struct{}
 
-func (NoOpBroadcaster) BroadcastSessionStart(event SessionStartBroadcast)     {}
-func (NoOpBroadcaster) BroadcastSessionEnd(event SessionEndBroadcast)         {}
-func (NoOpBroadcaster) BroadcastWorkNameChange(event WorkNameChangeBroadcast) {}
-func (NoOpBroadcaster) BroadcastSessionExtend(event SessionExtendBroadcast)   {}
+func (NoOpBroadcaster) BroadcastSessionStart(event SessionStartBroadcast)       {}
+func (NoOpBroadcaster) BroadcastSessionEnd(event SessionEndBroadcast)           {}
+func (NoOpBroadcaster) BroadcastWorkNameChange(event WorkNameChangeBroadcast)   {}
+func (NoOpBroadcaster) BroadcastSessionExtend(event SessionExtendBroadcast)     {}
+func (NoOpBroadcaster) BroadcastSettingsChanged(event SettingsChangedBroadcast) {}
+
+// NoOpCommentBroadcaster is a no-op implementation of CommentBroadcaster
+// Useful for testing
+type NoOpCommentBroadcaster struct{}
+
+func (NoOpCommentBroadcaster) BroadcastComment(event CommentBroadcast) {}
+
+// NoOpEventOutbox is a no-op implementation of EventOutbox
+// Useful for testing
+type NoOpEventOutbox struct{}
+
+func (NoOpEventOutbox) RecordSessionStart(ctx context.Context, tx repository.Tx, event SessionStartBroadcast) error {
+	return nil
+}
+func (NoOpEventOutbox) RecordSessionEnd(ctx context.Context, tx repository.Tx, event SessionEndBroadcast) error {
+	return nil
+}
+func (NoOpEventOutbox) RecordWorkNameChange(ctx context.Context, tx repository.Tx, event WorkNameChangeBroadcast) error {
+	return nil
+}
+func (NoOpEventOutbox) RecordSessionExtend(ctx context.Context, tx repository.Tx, event SessionExtendBroadcast) error {
+	return nil
+}
+func (NoOpEventOutbox) Notify() {}
 
 // NoOpExpirationScheduler is a no-op implementation of ExpirationScheduler
 // Useful for testing
 type NoOpExpirationScheduler struct{}
 
-func (NoOpExpirationScheduler) ScheduleExpiration(sessionID int64, userID int64, plannedEnd time.Time) {
+func (NoOpExpirationScheduler) ScheduleExpiration(ctx context.Context, sessionID int64, userID int64, plannedEnd time.Time) {
 }
 
 // NoOpExpirationCanceller is a no-op implementation of ExpirationCanceller
 // Useful for testing
 type NoOpExpirationCanceller struct{}
 
-func (NoOpExpirationCanceller) CancelExpiration(sessionID int64) {}
+func (NoOpExpirationCanceller) CancelExpiration(ctx context.Context, sessionID int64) {}
@@ -4,14 +4,12 @@ import (
 	"context"
 	"time"
 
+	"github.com/yamada-ai/workspace-backend/domain"
 	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
 )
 
-// WorkNameChangeBroadcaster defines the interface for broadcasting work name changes
-type WorkNameChangeBroadcaster interface {
-	BroadcastWorkNameChange(event WorkNameChangeBroadcast)
-}
-
 // ChangeCommandInput represents the input for change command
 type ChangeCommandInput struct {
 	UserName    string
@@ -29,7 +27,9 @@ type ChangeCommandOutput struct {
 type ChangeCommandUseCase struct {
 	userRepository    repository.UserRepository
 	sessionRepository repository.SessionRepository
-	broadcaster       WorkNameChangeBroadcaster
+	outbox            EventOutbox
+	rateLimiter       RateLimiter
+	textModerator     TextModerator
 	now               func() time.Time
 }
 
@@ -37,46 +37,74 @@ type ChangeCommandUseCase struct {
 func NewChangeCommandUseCase(
 	userRepository repository.UserRepository,
 	sessionRepository repository.SessionRepository,
-	broadcaster WorkNameChangeBroadcaster,
+	outbox EventOutbox,
+	rateLimiter RateLimiter,
+	textModerator TextModerator,
 ) *ChangeCommandUseCase {
 	return &ChangeCommandUseCase{
 		userRepository:    userRepository,
 		sessionRepository: sessionRepository,
-		broadcaster:       broadcaster,
+		outbox:            outbox,
+		rateLimiter:       rateLimiter,
+		textModerator:     textModerator,
 		now:               func() time.Time { return time.Now().UTC() },
 	}
 }
 
 // Execute executes the change command
-func (uc *ChangeCommandUseCase) Execute(ctx context.Context, input ChangeCommandInput) (*ChangeCommandOutput, error) {
+func (uc *ChangeCommandUseCase) Execute(ctx context.Context, input ChangeCommandInput) (_ *ChangeCommandOutput, err error) {
+	ctx, span := tracing.Start(ctx, "ChangeCommandUseCase.Execute", tracing.KeyUserName.String(input.UserName))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, logging.KeyUserName, input.UserName)
+
 	// 1. Find user
 	user, err := uc.userRepository.FindByName(ctx, input.UserName)
 	if err != nil {
 		return nil, err
 	}
 
-	// 2. Find active session
-	session, err := uc.sessionRepository.FindActiveByUserID(ctx, user.ID)
+	// 2. Refuse blocked users
+	if user.IsBlocked(uc.now) {
+		return nil, domain.ErrUserBlocked
+	}
+
+	// 3. Check rate limit
+	if err := uc.rateLimiter.Allow(ctx, user.Name, user.Tier, CommandChange); err != nil {
+		return nil, err
+	}
+
+	// 4. Moderate work name (may be masked)
+	workName, err := uc.textModerator.ModerateWorkName(ctx, user.Name, input.NewWorkName)
 	if err != nil {
 		return nil, err
 	}
 
-	// 3. Change work name
-	if err := session.ChangeWorkName(input.NewWorkName, uc.now); err != nil {
+	// 5. Find active session
+	session, err := uc.sessionRepository.FindActiveByUserID(ctx, user.ID)
+	if err != nil {
 		return nil, err
 	}
+	ctx = logging.With(ctx, logging.KeySessionID, session.ID)
+	span.SetAttributes(tracing.KeySessionID.Int64(session.ID))
 
-	// 4. Update session in database
-	if err := uc.sessionRepository.Update(ctx, session); err != nil {
+	// 6. Change work name
+	if err := session.ChangeWorkName(workName, uc.now); err != nil {
 		return nil, err
 	}
 
-	// 5. Broadcast work name change event
-	uc.broadcaster.BroadcastWorkNameChange(WorkNameChangeBroadcast{
+	// 7. Update session and record the work name change event in one transaction
+	if err := uc.saveWithEvent(ctx, session, WorkNameChangeBroadcast{
 		SessionID: session.ID,
 		UserID:    user.ID,
 		WorkName:  session.WorkName,
-	})
+	}); err != nil {
+		return nil, err
+	}
+
+	// 8. Wake up the outbox dispatcher to broadcast the event
+	uc.outbox.Notify()
+	logging.FromContext(ctx).Info("work name changed", "work_name", session.WorkName)
 
 	return &ChangeCommandOutput{
 		SessionID: session.ID,
@@ -84,3 +112,24 @@ func (uc *ChangeCommandUseCase) Execute(ctx context.Context, input ChangeCommand
 		WorkName:  session.WorkName,
 	}, nil
 }
+
+// saveWithEvent updates the session and records the event atomically
+func (uc *ChangeCommandUseCase) saveWithEvent(ctx context.Context, session *domain.Session, event WorkNameChangeBroadcast) (err error) {
+	tx, err := uc.userRepository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = uc.sessionRepository.UpdateWithTx(ctx, tx, session); err != nil {
+		return err
+	}
+	if err = uc.outbox.RecordWorkNameChange(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
 )
 
 func TestChangeCommand_Success(t *testing.T) {
@@ -48,23 +49,27 @@ func TestChangeCommand_Success(t *testing.T) {
 			}
 			return nil, domain.ErrSessionNotFound
 		},
-		updateFn: func(ctx context.Context, session *domain.Session) error {
+		updateWithTxFn: func(ctx context.Context, tx repository.Tx, session *domain.Session) error {
 			sessionUpdated = true
 			return nil
 		},
 	}
 
-	broadcastCalled := false
-	broadcaster := &mockWorkNameChangeBroadcaster{
-		broadcastWorkNameChangeFn: func(event WorkNameChangeBroadcast) {
+	recorded := false
+	events := &mockEventOutbox{
+		recordWorkNameChangeFn: func(tx repository.Tx, event WorkNameChangeBroadcast) error {
+			if tx == nil {
+				t.Error("expected event to be recorded within the transaction")
+			}
 			if event.SessionID != 99 || event.UserID != 42 || event.WorkName != "資格勉強" {
-				t.Errorf("unexpected broadcast: %+v", event)
+				t.Errorf("unexpected event: %+v", event)
 			}
-			broadcastCalled = true
+			recorded = true
+			return nil
 		},
 	}
 
-	uc := NewChangeCommandUseCase(userRepo, sessionRepo, broadcaster)
+	uc := NewChangeCommandUseCase(userRepo, sessionRepo, events, NoOpRateLimiter{}, NoOpTextModerator{})
 
 	input := ChangeCommandInput{
 		UserName:    "yamada",
@@ -90,8 +95,11 @@ func TestChangeCommand_Success(t *testing.T) {
 		t.Error("expected session to be updated")
 	}
 
-	if !broadcastCalled {
-		t.Error("expected work name change to be broadcast")
+	if !recorded {
+		t.Error("expected work name change event to be recorded")
+	}
+	if !events.notified {
+		t.Error("expected outbox dispatcher to be notified after commit")
 	}
 }
 
@@ -127,16 +135,14 @@ func TestChangeCommand_EmptyWorkName(t *testing.T) {
 		findActiveByUserIDFn: func(ctx context.Context, userID int64) (*domain.Session, error) {
 			return activeSession, nil
 		},
-		updateFn: func(ctx context.Context, session *domain.Session) error {
+		updateWithTxFn: func(ctx context.Context, tx repository.Tx, session *domain.Session) error {
 			return nil
 		},
 	}
 
-	broadcaster := &mockWorkNameChangeBroadcaster{
-		broadcastWorkNameChangeFn: func(event WorkNameChangeBroadcast) {},
-	}
+	events := &mockEventOutbox{}
 
-	uc := NewChangeCommandUseCase(userRepo, sessionRepo, broadcaster)
+	uc := NewChangeCommandUseCase(userRepo, sessionRepo, events, NoOpRateLimiter{}, NoOpTextModerator{})
 
 	input := ChangeCommandInput{
 		UserName:    "yamada",
@@ -160,9 +166,9 @@ func TestChangeCommand_UserNotFound(t *testing.T) {
 		},
 	}
 	sessionRepo := &mockSessionRepository{}
-	broadcaster := &mockWorkNameChangeBroadcaster{}
+	events := &mockEventOutbox{}
 
-	uc := NewChangeCommandUseCase(userRepo, sessionRepo, broadcaster)
+	uc := NewChangeCommandUseCase(userRepo, sessionRepo, events, NoOpRateLimiter{}, NoOpTextModerator{})
 
 	input := ChangeCommandInput{
 		UserName:    "nonexistent",
@@ -207,9 +213,9 @@ func TestChangeCommand_NoActiveSession(t *testing.T) {
 		},
 	}
 
-	broadcaster := &mockWorkNameChangeBroadcaster{}
+	events := &mockEventOutbox{}
 
-	uc := NewChangeCommandUseCase(userRepo, sessionRepo, broadcaster)
+	uc := NewChangeCommandUseCase(userRepo, sessionRepo, events, NoOpRateLimiter{}, NoOpTextModerator{})
 
 	input := ChangeCommandInput{
 		UserName:    "yamada",
@@ -230,13 +236,182 @@ func TestChangeCommand_NoActiveSession(t *testing.T) {
 	}
 }
 
-// Mock WorkNameChangeBroadcaster
-type mockWorkNameChangeBroadcaster struct {
-	broadcastWorkNameChangeFn func(event WorkNameChangeBroadcast)
+func TestChangeCommand_RateLimited(t *testing.T) {
+	existingUser := &domain.User{
+		ID:        42,
+		Name:      "yamada",
+		Tier:      domain.Tier2,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	userRepo := &mockUserRepository{
+		findByNameFn: func(ctx context.Context, name string) (*domain.User, error) {
+			return existingUser, nil
+		},
+	}
+
+	sessionUpdated := false
+	sessionRepo := &mockSessionRepository{
+		updateWithTxFn: func(ctx context.Context, tx repository.Tx, session *domain.Session) error {
+			sessionUpdated = true
+			return nil
+		},
+	}
+
+	events := &mockEventOutbox{
+		recordWorkNameChangeFn: func(tx repository.Tx, event WorkNameChangeBroadcast) error {
+			t.Error("event should not be recorded when rate limited")
+			return nil
+		},
+	}
+
+	errLimited := errors.New("slow down")
+	rateLimiter := &mockRateLimiter{
+		allowFn: func(ctx context.Context, userName string, tier domain.Tier, command string) error {
+			if userName != "yamada" || tier != domain.Tier2 || command != CommandChange {
+				t.Errorf("unexpected rate limit key: %s %d %s", userName, tier, command)
+			}
+			return errLimited
+		},
+	}
+
+	uc := NewChangeCommandUseCase(userRepo, sessionRepo, events, rateLimiter, NoOpTextModerator{})
+
+	output, err := uc.Execute(context.Background(), ChangeCommandInput{
+		UserName:    "yamada",
+		NewWorkName: "作業",
+	})
+	if !errors.Is(err, errLimited) {
+		t.Errorf("expected rate limit error, got %v", err)
+	}
+	if output != nil {
+		t.Errorf("expected output to be nil when error occurs, got %+v", output)
+	}
+	if sessionUpdated {
+		t.Error("session should not be updated when rate limited")
+	}
+}
+
+func TestChangeCommand_BlockedUser(t *testing.T) {
+	blockedUser := &domain.User{
+		ID:        42,
+		Name:      "troll",
+		Tier:      domain.Tier1,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	_ = blockedUser.Block("spam", "moderator", 0, time.Now)
+
+	userRepo := &mockUserRepository{
+		findByNameFn: func(ctx context.Context, name string) (*domain.User, error) {
+			return blockedUser, nil
+		},
+	}
+	sessionRepo := &mockSessionRepository{
+		updateWithTxFn: func(ctx context.Context, tx repository.Tx, session *domain.Session) error {
+			t.Error("session should not be updated for a blocked user")
+			return nil
+		},
+	}
+
+	uc := NewChangeCommandUseCase(userRepo, sessionRepo, &mockEventOutbox{}, NoOpRateLimiter{}, NoOpTextModerator{})
+
+	_, err := uc.Execute(context.Background(), ChangeCommandInput{UserName: "troll", NewWorkName: "作業"})
+	if !errors.Is(err, domain.ErrUserBlocked) {
+		t.Errorf("expected ErrUserBlocked, got %v", err)
+	}
+}
+
+func TestChangeCommand_ModeratedWorkName(t *testing.T) {
+	existingUser := &domain.User{ID: 42, Name: "yamada", Tier: domain.Tier1}
+	activeSession := &domain.Session{
+		ID:         99,
+		UserID:     42,
+		WorkName:   "論文執筆",
+		StartTime:  time.Now().Add(-30 * time.Minute),
+		PlannedEnd: time.Now().Add(30 * time.Minute),
+	}
+
+	userRepo := &mockUserRepository{
+		findByNameFn: func(ctx context.Context, name string) (*domain.User, error) {
+			return existingUser, nil
+		},
+	}
+	sessionRepo := &mockSessionRepository{
+		findActiveByUserIDFn: func(ctx context.Context, userID int64) (*domain.Session, error) {
+			return activeSession, nil
+		},
+	}
+	moderator := &mockTextModerator{
+		moderateWorkNameFn: func(ctx context.Context, userName, workName string) (string, error) {
+			if workName == "spam" {
+				return "", domain.ErrBannedTermDetected
+			}
+			return "**の勉強", nil
+		},
+	}
+
+	uc := NewChangeCommandUseCase(userRepo, sessionRepo, &mockEventOutbox{}, NoOpRateLimiter{}, moderator)
+
+	if _, err := uc.Execute(context.Background(), ChangeCommandInput{UserName: "yamada", NewWorkName: "spam"}); !errors.Is(err, domain.ErrBannedTermDetected) {
+		t.Errorf("expected ErrBannedTermDetected, got %v", err)
+	}
+	if activeSession.WorkName != "論文執筆" {
+		t.Errorf("rejected work name should not be applied, got %q", activeSession.WorkName)
+	}
+
+	output, err := uc.Execute(context.Background(), ChangeCommandInput{UserName: "yamada", NewWorkName: "ばかの勉強"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.WorkName != "**の勉強" || activeSession.WorkName != "**の勉強" {
+		t.Errorf("expected masked work name, got %q", output.WorkName)
+	}
+}
+
+// Mock RateLimiter
+type mockRateLimiter struct {
+	allowFn func(ctx context.Context, userName string, tier domain.Tier, command string) error
 }
 
-func (m *mockWorkNameChangeBroadcaster) BroadcastWorkNameChange(event WorkNameChangeBroadcast) {
-	if m.broadcastWorkNameChangeFn != nil {
-		m.broadcastWorkNameChangeFn(event)
+func (m *mockRateLimiter) Allow(ctx context.Context, userName string, tier domain.Tier, command string) error {
+	if m.allowFn != nil {
+		return m.allowFn(ctx, userName, tier, command)
 	}
+	return nil
+}
+
+// Mock EventOutbox
+type mockEventOutbox struct {
+	NoOpEventOutbox
+	recordSessionStartFn   func(tx repository.Tx, event SessionStartBroadcast) error
+	recordWorkNameChangeFn func(tx repository.Tx, event WorkNameChangeBroadcast) error
+	recordSessionExtendFn  func(tx repository.Tx, event SessionExtendBroadcast) error
+	notified               bool
+}
+
+func (m *mockEventOutbox) RecordSessionStart(ctx context.Context, tx repository.Tx, event SessionStartBroadcast) error {
+	if m.recordSessionStartFn != nil {
+		return m.recordSessionStartFn(tx, event)
+	}
+	return nil
+}
+
+func (m *mockEventOutbox) RecordWorkNameChange(ctx context.Context, tx repository.Tx, event WorkNameChangeBroadcast) error {
+	if m.recordWorkNameChangeFn != nil {
+		return m.recordWorkNameChangeFn(tx, event)
+	}
+	return nil
+}
+
+func (m *mockEventOutbox) RecordSessionExtend(ctx context.Context, tx repository.Tx, event SessionExtendBroadcast) error {
+	if m.recordSessionExtendFn != nil {
+		return m.recordSessionExtendFn(tx, event)
+	}
+	return nil
+}
+
+func (m *mockEventOutbox) Notify() {
+	m.notified = true
 }
@@ -0,0 +1,195 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
+)
+
+// CheerCommandInput represents the input for cheer command
+// TargetName may start with "@" (/cheer @yamada)
+type CheerCommandInput struct {
+	UserName   string
+	TargetName string
+}
+
+// CheerCommandOutput represents the output of cheer command
+type CheerCommandOutput struct {
+	CheerID      int64
+	UserID       int64
+	UserName     string
+	TargetUserID int64
+	TargetName   string
+	BonusPoints  int64 // Points credited to the target (0 when the bonus is disabled)
+}
+
+// CheerBonusSource provides the points credited to the cheered user (0 disables the bonus)
+// It is read on every cheer so that a bonus changed at runtime applies to the next one
+type CheerBonusSource interface {
+	CheerBonusPoints() int64
+}
+
+// FixedCheerBonus is a CheerBonusSource that always credits the same points
+type FixedCheerBonus int64
+
+// CheerBonusPoints implements CheerBonusSource
+func (b FixedCheerBonus) CheerBonusPoints() int64 {
+	return int64(b)
+}
+
+// CheerCommandUseCase handles the /cheer command logic
+// Both users must be in the room, and a user can cheer the same target once a day in the stream time zone
+type CheerCommandUseCase struct {
+	userRepository    repository.UserRepository
+	sessionRepository repository.SessionRepository
+	cheerRepository   repository.CheerRepository
+	ledgerRepository  repository.PointLedgerRepository
+	comments          CommentBroadcaster
+	rateLimiter       RateLimiter
+	bonus             CheerBonusSource
+	location          *time.Location
+	now               func() time.Time
+}
+
+// NewCheerCommandUseCase creates a new cheer command use case
+// bonus gives the points credited to the cheered user for each cheer
+// location is the stream time zone; the day for the once-a-day limit starts at its midnight (nil means UTC)
+func NewCheerCommandUseCase(
+	userRepository repository.UserRepository,
+	sessionRepository repository.SessionRepository,
+	cheerRepository repository.CheerRepository,
+	ledgerRepository repository.PointLedgerRepository,
+	comments CommentBroadcaster,
+	rateLimiter RateLimiter,
+	bonus CheerBonusSource,
+	location *time.Location,
+) *CheerCommandUseCase {
+	return &CheerCommandUseCase{
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		cheerRepository:   cheerRepository,
+		ledgerRepository:  ledgerRepository,
+		comments:          comments,
+		rateLimiter:       rateLimiter,
+		bonus:             bonus,
+		location:          location,
+		now:               func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Execute executes the cheer command
+func (uc *CheerCommandUseCase) Execute(ctx context.Context, input CheerCommandInput) (_ *CheerCommandOutput, err error) {
+	ctx, span := tracing.Start(ctx, "CheerCommandUseCase.Execute", tracing.KeyUserName.String(input.UserName))
+	defer func() { tracing.End(span, err) }()
+
+	targetName := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(input.TargetName), "@"))
+	if targetName == "" {
+		return nil, domain.ErrEmptyUserName
+	}
+
+	tx, err := uc.userRepository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// 1. Find and lock the cheering user
+	user, err := uc.userRepository.FindByNameWithTx(ctx, tx, input.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Refuse blocked users
+	if user.IsBlocked(uc.now) {
+		err = domain.ErrUserBlocked
+		return nil, err
+	}
+
+	// 3. Check rate limit
+	if err = uc.rateLimiter.Allow(ctx, user.Name, user.Tier, CommandCheer); err != nil {
+		return nil, err
+	}
+
+	// 4. The cheering user must be in the room
+	if _, err = uc.sessionRepository.FindActiveByUserIDWithTx(ctx, tx, user.ID); err != nil {
+		return nil, err
+	}
+
+	// 5. The target must be in the room too (the target row is not locked; the cheer only references it)
+	target, err := uc.findTargetInRoom(ctx, tx, targetName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 6. Record the cheer (the daily per-pair limit is enforced by the repository)
+	cheer, err := domain.NewCheer(user.ID, target.ID, uc.now, uc.location)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.cheerRepository.CreateWithTx(ctx, tx, cheer); err != nil {
+		return nil, err
+	}
+
+	// 7. Credit the optional bonus to the target (the cheer ID is the ledger reference)
+	var bonus int64
+	if points := uc.bonus.CheerBonusPoints(); points > 0 {
+		var entry *domain.PointEntry
+		entry, err = domain.NewPointEntry(target.ID, points, domain.PointReasonCheerBonus, strconv.FormatInt(cheer.ID, 10), uc.now)
+		if err != nil {
+			return nil, err
+		}
+		if err = uc.ledgerRepository.AppendWithTx(ctx, tx, entry); err != nil {
+			return nil, err
+		}
+		bonus = entry.Delta
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	// 8. Show the cheer in the cheering user's comment bubble
+	uc.comments.BroadcastComment(CommentBroadcast{
+		UserID:   user.ID,
+		UserName: user.Name,
+		Text:     domain.CheerComment(target.Name),
+		PostedAt: cheer.CreatedAt,
+	})
+
+	return &CheerCommandOutput{
+		CheerID:      cheer.ID,
+		UserID:       user.ID,
+		UserName:     user.Name,
+		TargetUserID: target.ID,
+		TargetName:   target.Name,
+		BonusPoints:  bonus,
+	}, nil
+}
+
+// findTargetInRoom returns the target user if they have an active session
+func (uc *CheerCommandUseCase) findTargetInRoom(ctx context.Context, tx repository.Tx, targetName string) (*domain.User, error) {
+	target, err := uc.userRepository.FindByName(ctx, targetName)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrCheerTargetNotInRoom
+		}
+		return nil, err
+	}
+	if _, err := uc.sessionRepository.FindActiveByUserIDWithTx(ctx, tx, target.ID); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, domain.ErrCheerTargetNotInRoom
+		}
+		return nil, err
+	}
+	return target, nil
+}
@@ -0,0 +1,236 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
+
+// Mock CheerRepository (enforces the daily per-pair limit like the unique index)
+type mockCheerRepository struct {
+	cheers []*domain.Cheer
+}
+
+func (m *mockCheerRepository) CreateWithTx(ctx context.Context, tx repository.Tx, cheer *domain.Cheer) error {
+	for _, c := range m.cheers {
+		if c.FromUserID == cheer.FromUserID && c.ToUserID == cheer.ToUserID && c.CheeredOn.Equal(cheer.CheeredOn) {
+			return domain.ErrCheerAlreadySent
+		}
+	}
+	cheer.ID = int64(len(m.cheers) + 1)
+	m.cheers = append(m.cheers, cheer)
+	return nil
+}
+
+// recordingCommentBroadcaster records the comments shown in the overlay
+type recordingCommentBroadcaster struct {
+	comments []CommentBroadcast
+}
+
+func (b *recordingCommentBroadcaster) BroadcastComment(event CommentBroadcast) {
+	b.comments = append(b.comments, event)
+}
+
+type cheerFixture struct {
+	uc        *CheerCommandUseCase
+	users     map[string]*domain.User
+	inRoom    map[int64]bool
+	cheers    *mockCheerRepository
+	ledger    *mockPointLedgerRepository
+	comments  *recordingCommentBroadcaster
+	committed int
+}
+
+func newCheerFixture(bonusPoints int64, users ...*domain.User) *cheerFixture {
+	f := &cheerFixture{
+		users:    make(map[string]*domain.User),
+		inRoom:   make(map[int64]bool),
+		cheers:   &mockCheerRepository{},
+		ledger:   &mockPointLedgerRepository{},
+		comments: &recordingCommentBroadcaster{},
+	}
+	for _, user := range users {
+		f.users[user.Name] = user
+		f.inRoom[user.ID] = true
+	}
+	findUser := func(name string) (*domain.User, error) {
+		if user, ok := f.users[name]; ok {
+			return user, nil
+		}
+		return nil, domain.ErrUserNotFound
+	}
+	userRepo := &mockUserRepository{
+		beginTxFn: func(ctx context.Context) (repository.Tx, error) {
+			return &mockTx{commitFn: func(ctx context.Context) error {
+				f.committed++
+				return nil
+			}}, nil
+		},
+		findByNameFn: func(ctx context.Context, name string) (*domain.User, error) {
+			return findUser(name)
+		},
+		findByNameWithTxFn: func(ctx context.Context, tx repository.Tx, name string) (*domain.User, error) {
+			return findUser(name)
+		},
+	}
+	sessionRepo := &mockSessionRepository{
+		findActiveByUserIDWithTxFn: func(ctx context.Context, tx repository.Tx, userID int64) (*domain.Session, error) {
+			if !f.inRoom[userID] {
+				return nil, domain.ErrSessionNotFound
+			}
+			return &domain.Session{ID: userID * 10, UserID: userID}, nil
+		},
+	}
+	f.uc = NewCheerCommandUseCase(userRepo, sessionRepo, f.cheers, f.ledger, f.comments, NoOpRateLimiter{}, FixedCheerBonus(bonusPoints), time.FixedZone("JST", 9*60*60))
+	// JST 10/9 23:30
+	f.uc.now = func() time.Time { return time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC) }
+	return f
+}
+
+func TestCheerCommand_Success(t *testing.T) {
+	yamada := &domain.User{ID: 1, Name: "yamada", Tier: domain.Tier1}
+	raziii := &domain.User{ID: 2, Name: "raziii03", Tier: domain.Tier2}
+
+	t.Run("応援を記録してコメントを出す", func(t *testing.T) {
+		f := newCheerFixture(0, yamada, raziii)
+
+		out, err := f.uc.Execute(context.Background(), CheerCommandInput{UserName: "yamada", TargetName: "@raziii03"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.CheerID != 1 || out.UserID != 1 || out.TargetUserID != 2 || out.TargetName != "raziii03" || out.BonusPoints != 0 {
+			t.Errorf("unexpected output: %+v", out)
+		}
+		if f.committed != 1 || len(f.cheers.cheers) != 1 {
+			t.Errorf("expected 1 committed cheer, got commits=%d cheers=%d", f.committed, len(f.cheers.cheers))
+		}
+		if len(f.ledger.entries) != 0 {
+			t.Errorf("bonus is disabled, got %+v", f.ledger.entries)
+		}
+		if len(f.comments.comments) != 1 {
+			t.Fatalf("expected 1 comment, got %d", len(f.comments.comments))
+		}
+		comment := f.comments.comments[0]
+		if comment.UserID != 1 || comment.Text != "razii…さんを応援中！" {
+			t.Errorf("unexpected comment: %+v", comment)
+		}
+	})
+
+	t.Run("ボーナスを応援された人に付与する", func(t *testing.T) {
+		f := newCheerFixture(5, yamada, raziii)
+
+		out, err := f.uc.Execute(context.Background(), CheerCommandInput{UserName: "yamada", TargetName: "raziii03"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.BonusPoints != 5 {
+			t.Errorf("expected bonus 5, got %d", out.BonusPoints)
+		}
+		if len(f.ledger.entries) != 1 {
+			t.Fatalf("expected 1 ledger entry, got %d", len(f.ledger.entries))
+		}
+		entry := f.ledger.entries[0]
+		if entry.UserID != 2 || entry.Delta != 5 || entry.Reason != domain.PointReasonCheerBonus || entry.Reference != "1" {
+			t.Errorf("unexpected ledger entry: %+v", entry)
+		}
+	})
+}
+
+func TestCheerCommand_Rejected(t *testing.T) {
+	yamada := &domain.User{ID: 1, Name: "yamada", Tier: domain.Tier1}
+	raziii := &domain.User{ID: 2, Name: "raziii03", Tier: domain.Tier2}
+	until := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	troll := &domain.User{ID: 3, Name: "troll", Tier: domain.Tier1, BlockedUntil: &until}
+
+	tests := []struct {
+		name    string
+		setup   func(f *cheerFixture)
+		input   CheerCommandInput
+		wantErr error
+	}{
+		{"対象が未指定", nil, CheerCommandInput{UserName: "yamada", TargetName: " @ "}, domain.ErrEmptyUserName},
+		{"自分自身", nil, CheerCommandInput{UserName: "yamada", TargetName: "@yamada"}, domain.ErrCannotCheerSelf},
+		{"対象が未登録", nil, CheerCommandInput{UserName: "yamada", TargetName: "@nobody"}, domain.ErrCheerTargetNotInRoom},
+		{"対象が未入室", func(f *cheerFixture) { f.inRoom[2] = false }, CheerCommandInput{UserName: "yamada", TargetName: "@raziii03"}, domain.ErrCheerTargetNotInRoom},
+		{"自分が未入室", func(f *cheerFixture) { f.inRoom[1] = false }, CheerCommandInput{UserName: "yamada", TargetName: "@raziii03"}, domain.ErrSessionNotFound},
+		{"ブロック中", nil, CheerCommandInput{UserName: "troll", TargetName: "@raziii03"}, domain.ErrUserBlocked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCheerFixture(5, yamada, raziii, troll)
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+
+			_, err := f.uc.Execute(context.Background(), tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if f.committed != 0 || len(f.cheers.cheers) != 0 || len(f.ledger.entries) != 0 || len(f.comments.comments) != 0 {
+				t.Errorf("nothing should be recorded: commits=%d cheers=%d entries=%d comments=%d",
+					f.committed, len(f.cheers.cheers), len(f.ledger.entries), len(f.comments.comments))
+			}
+		})
+	}
+}
+
+func TestCheerCommand_DailyLimitPerPair(t *testing.T) {
+	yamada := &domain.User{ID: 1, Name: "yamada", Tier: domain.Tier1}
+	raziii := &domain.User{ID: 2, Name: "raziii03", Tier: domain.Tier2}
+	f := newCheerFixture(5, yamada, raziii)
+	ctx := context.Background()
+
+	if _, err := f.uc.Execute(ctx, CheerCommandInput{UserName: "yamada", TargetName: "@raziii03"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.uc.Execute(ctx, CheerCommandInput{UserName: "yamada", TargetName: "@raziii03"}); !errors.Is(err, domain.ErrCheerAlreadySent) {
+		t.Fatalf("expected ErrCheerAlreadySent, got %v", err)
+	}
+	if len(f.ledger.entries) != 1 || len(f.comments.comments) != 1 {
+		t.Errorf("second cheer must not credit or comment: entries=%d comments=%d", len(f.ledger.entries), len(f.comments.comments))
+	}
+
+	// 逆向きは別の組として数える
+	if _, err := f.uc.Execute(ctx, CheerCommandInput{UserName: "raziii03", TargetName: "@yamada"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// 配信のタイムゾーン（JST）で翌日になればまた応援できる
+	f.uc.now = func() time.Time { return time.Date(2025, 10, 9, 15, 0, 1, 0, time.UTC) }
+	if _, err := f.uc.Execute(ctx, CheerCommandInput{UserName: "yamada", TargetName: "@raziii03"}); err != nil {
+		t.Errorf("unexpected error on the next day: %v", err)
+	}
+
+	// UTC の 0 時（JST 9 時）では日が変わらない
+	f.uc.now = func() time.Time { return time.Date(2025, 10, 10, 0, 0, 1, 0, time.UTC) }
+	if _, err := f.uc.Execute(ctx, CheerCommandInput{UserName: "yamada", TargetName: "@raziii03"}); !errors.Is(err, domain.ErrCheerAlreadySent) {
+		t.Errorf("expected ErrCheerAlreadySent at UTC midnight, got %v", err)
+	}
+}
+
+func TestCheerCommand_RateLimited(t *testing.T) {
+	yamada := &domain.User{ID: 1, Name: "yamada", Tier: domain.Tier1}
+	raziii := &domain.User{ID: 2, Name: "raziii03", Tier: domain.Tier2}
+	f := newCheerFixture(0, yamada, raziii)
+
+	errLimited := errors.New("slow down")
+	f.uc.rateLimiter = &mockRateLimiter{
+		allowFn: func(ctx context.Context, userName string, tier domain.Tier, command string) error {
+			if userName != "yamada" || command != CommandCheer {
+				t.Errorf("unexpected rate limit key: %s %s", userName, command)
+			}
+			return errLimited
+		},
+	}
+
+	if _, err := f.uc.Execute(context.Background(), CheerCommandInput{UserName: "yamada", TargetName: "@raziii03"}); !errors.Is(err, errLimited) {
+		t.Fatalf("expected rate limit error, got %v", err)
+	}
+	if len(f.cheers.cheers) != 0 {
+		t.Errorf("cheer should not be recorded when rate limited")
+	}
+}
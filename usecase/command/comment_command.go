@@ -0,0 +1,104 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
+)
+
+// CommentCommandInput represents the input for comment command
+type CommentCommandInput struct {
+	UserName string
+	Text     string
+}
+
+// CommentCommandOutput represents the output of comment command
+type CommentCommandOutput struct {
+	UserID int64
+	Text   string // The text shown in the overlay (sanitized, moderated and truncated)
+}
+
+// CommentCommandUseCase shows a viewer's chat message in their comment bubble
+// Only users in the room have a bubble, so comments from other viewers are rejected
+type CommentCommandUseCase struct {
+	userRepository    repository.UserRepository
+	sessionRepository repository.SessionRepository
+	comments          CommentBroadcaster
+	rateLimiter       RateLimiter
+	textModerator     TextModerator
+	now               func() time.Time
+}
+
+// NewCommentCommandUseCase creates a new comment command use case
+func NewCommentCommandUseCase(
+	userRepository repository.UserRepository,
+	sessionRepository repository.SessionRepository,
+	comments CommentBroadcaster,
+	rateLimiter RateLimiter,
+	textModerator TextModerator,
+) *CommentCommandUseCase {
+	return &CommentCommandUseCase{
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		comments:          comments,
+		rateLimiter:       rateLimiter,
+		textModerator:     textModerator,
+		now:               func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Execute executes the comment command
+func (uc *CommentCommandUseCase) Execute(ctx context.Context, input CommentCommandInput) (_ *CommentCommandOutput, err error) {
+	ctx, span := tracing.Start(ctx, "CommentCommandUseCase.Execute", tracing.KeyUserName.String(input.UserName))
+	defer func() { tracing.End(span, err) }()
+
+	// 1. Sanitize the text before touching the database
+	text := domain.SanitizeComment(input.Text)
+	if text == "" {
+		return nil, domain.ErrEmptyComment
+	}
+
+	// 2. Find user
+	user, err := uc.userRepository.FindByName(ctx, input.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Refuse blocked users
+	if user.IsBlocked(uc.now) {
+		return nil, domain.ErrUserBlocked
+	}
+
+	// 4. Only users in the room have a comment bubble
+	if _, err := uc.sessionRepository.FindActiveByUserID(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	// 5. Check rate limit
+	if err := uc.rateLimiter.Allow(ctx, user.Name, user.Tier, CommandComment); err != nil {
+		return nil, err
+	}
+
+	// 6. Moderate the full text (may be masked), then truncate it for display
+	text, err = uc.textModerator.ModerateComment(ctx, user.Name, text)
+	if err != nil {
+		return nil, err
+	}
+	text, err = domain.NewCommentText(text)
+	if err != nil {
+		return nil, err
+	}
+
+	// 7. Show the comment
+	uc.comments.BroadcastComment(CommentBroadcast{
+		UserID:   user.ID,
+		UserName: user.Name,
+		Text:     text,
+		PostedAt: uc.now(),
+	})
+
+	return &CommentCommandOutput{UserID: user.ID, Text: text}, nil
+}
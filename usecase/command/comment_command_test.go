@@ -0,0 +1,117 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+func newCommentCommand(user *domain.User, inRoom bool, moderator TextModerator) (*CommentCommandUseCase, *recordingCommentBroadcaster) {
+	userRepo := &mockUserRepository{
+		findByNameFn: func(ctx context.Context, name string) (*domain.User, error) {
+			if name == user.Name {
+				return user, nil
+			}
+			return nil, domain.ErrUserNotFound
+		},
+	}
+	sessionRepo := &mockSessionRepository{
+		findActiveByUserIDFn: func(ctx context.Context, userID int64) (*domain.Session, error) {
+			if !inRoom {
+				return nil, domain.ErrSessionNotFound
+			}
+			return &domain.Session{ID: 100, UserID: userID}, nil
+		},
+	}
+	comments := &recordingCommentBroadcaster{}
+	uc := NewCommentCommandUseCase(userRepo, sessionRepo, comments, NoOpRateLimiter{}, moderator)
+	uc.now = func() time.Time { return time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC) }
+	return uc, comments
+}
+
+func TestCommentCommand_Success(t *testing.T) {
+	user := &domain.User{ID: 42, Name: "yamada", Tier: domain.Tier1}
+	uc, comments := newCommentCommand(user, true, NoOpTextModerator{})
+
+	out, err := uc.Execute(context.Background(), CommentCommandInput{UserName: "yamada", Text: "  今日は\n論文を書きます！  "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Text != "今日は 論文を書きま…" {
+		t.Errorf("unexpected text: %q", out.Text)
+	}
+	if len(comments.comments) != 1 {
+		t.Fatalf("expected 1 comment, got %d", len(comments.comments))
+	}
+	got := comments.comments[0]
+	if got.UserID != 42 || got.UserName != "yamada" || got.Text != out.Text || got.PostedAt.IsZero() {
+		t.Errorf("unexpected comment: %+v", got)
+	}
+}
+
+func TestCommentCommand_ModeratesBeforeTruncating(t *testing.T) {
+	user := &domain.User{ID: 42, Name: "yamada", Tier: domain.Tier1}
+	var moderated string
+	moderator := &mockTextModerator{
+		moderateCommentFn: func(ctx context.Context, userName, comment string) (string, error) {
+			moderated = comment
+			return strings.ReplaceAll(comment, "ばか", "**"), nil
+		},
+	}
+	uc, comments := newCommentCommand(user, true, moderator)
+
+	out, err := uc.Execute(context.Background(), CommentCommandInput{UserName: "yamada", Text: "あいうえおかきくけこ ばか"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 省略される部分に禁止ワードがあっても検査する
+	if moderated != "あいうえおかきくけこ ばか" {
+		t.Errorf("moderator should see the full text, got %q", moderated)
+	}
+	if out.Text != "あいうえおかきくけこ…" || len(comments.comments) != 1 {
+		t.Errorf("unexpected output: %+v", out)
+	}
+}
+
+func TestCommentCommand_Rejected(t *testing.T) {
+	user := &domain.User{ID: 42, Name: "yamada", Tier: domain.Tier1}
+	until := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	blocked := &domain.User{ID: 43, Name: "troll", Tier: domain.Tier1, BlockedUntil: &until}
+	rejecting := &mockTextModerator{
+		moderateCommentFn: func(ctx context.Context, userName, comment string) (string, error) {
+			return "", domain.ErrBannedTermDetected
+		},
+	}
+
+	tests := []struct {
+		name      string
+		user      *domain.User
+		inRoom    bool
+		moderator TextModerator
+		input     CommentCommandInput
+		wantErr   error
+	}{
+		{"空のコメント", user, true, NoOpTextModerator{}, CommentCommandInput{UserName: "yamada", Text: " \u200b "}, domain.ErrEmptyComment},
+		{"未登録", user, true, NoOpTextModerator{}, CommentCommandInput{UserName: "nobody", Text: "hi"}, domain.ErrUserNotFound},
+		{"未入室", user, false, NoOpTextModerator{}, CommentCommandInput{UserName: "yamada", Text: "hi"}, domain.ErrSessionNotFound},
+		{"ブロック中", blocked, true, NoOpTextModerator{}, CommentCommandInput{UserName: "troll", Text: "hi"}, domain.ErrUserBlocked},
+		{"禁止ワード", user, true, rejecting, CommentCommandInput{UserName: "yamada", Text: "spam"}, domain.ErrBannedTermDetected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, comments := newCommentCommand(tt.user, tt.inRoom, tt.moderator)
+
+			_, err := uc.Execute(context.Background(), tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if len(comments.comments) != 0 {
+				t.Errorf("rejected comment must not be shown: %+v", comments.comments)
+			}
+		})
+	}
+}
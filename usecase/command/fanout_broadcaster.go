@@ -0,0 +1,209 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
+)
+
+// DefaultSinkQueueSize is the number of events buffered for each sink of a FanOutBroadcaster
+const DefaultSinkQueueSize = 256
+
+// Ensure FanOutBroadcaster implements EventBroadcaster
+var _ EventBroadcaster = (*FanOutBroadcaster)(nil)
+
+// SinkStats is a snapshot of the delivery counters of one sink
+type SinkStats struct {
+	Name      string
+	Queued    int    // events waiting in the queue
+	Delivered uint64 // events handed to the sink (including ones that panicked)
+	Dropped   uint64 // events discarded because the queue was full
+	Panics    uint64 // events on which the sink panicked
+}
+
+// FanOutBroadcaster forwards every event to all registered sinks.
+// Each sink has its own bounded queue and goroutine, so a slow or failing sink
+// never blocks the caller or the other sinks.
+// When a sink's queue is full the event is dropped for that sink and counted in its stats,
+// so it is only for in-memory sinks that can miss events (the WebSocket hub, whose clients replay on reconnect).
+// Sinks that must not lose events are called synchronously by the outbox dispatcher instead (outbox.Sink)
+type FanOutBroadcaster struct {
+	queueSize int
+
+	mu     sync.RWMutex
+	sinks  []*fanOutSink
+	closed bool
+	wg     sync.WaitGroup
+}
+
+type fanOutSink struct {
+	name      string
+	sink      EventBroadcaster
+	logger    *slog.Logger
+	queue     chan fanOutEvent
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+	panics    atomic.Uint64
+}
+
+// NewFanOutBroadcaster creates a fan-out broadcaster with the given per-sink queue size
+func NewFanOutBroadcaster(queueSize int) *FanOutBroadcaster {
+	if queueSize <= 0 {
+		queueSize = DefaultSinkQueueSize
+	}
+	return &FanOutBroadcaster{queueSize: queueSize}
+}
+
+// Add registers a sink and starts delivering events to it.
+// Events broadcast before the sink is added are not delivered to it.
+// Dropped events and sink failures are logged with the logger of ctx
+func (f *FanOutBroadcaster) Add(ctx context.Context, name string, sink EventBroadcaster) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return
+	}
+
+	s := &fanOutSink{
+		name:   name,
+		sink:   sink,
+		logger: logging.FromContext(ctx).With("sink", name),
+		queue:  make(chan fanOutEvent, f.queueSize),
+	}
+	f.sinks = append(f.sinks, s)
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		s.run()
+	}()
+}
+
+// Close stops accepting events and waits until every sink has drained its queue
+func (f *FanOutBroadcaster) Close() {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return
+	}
+	f.closed = true
+	for _, s := range f.sinks {
+		close(s.queue)
+	}
+	f.mu.Unlock()
+
+	f.wg.Wait()
+}
+
+// Stats returns the delivery counters of every sink in registration order
+func (f *FanOutBroadcaster) Stats() []SinkStats {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	stats := make([]SinkStats, 0, len(f.sinks))
+	for _, s := range f.sinks {
+		stats = append(stats, SinkStats{
+			Name:      s.name,
+			Queued:    len(s.queue),
+			Delivered: s.delivered.Load(),
+			Dropped:   s.dropped.Load(),
+			Panics:    s.panics.Load(),
+		})
+	}
+	return stats
+}
+
+// fanOutEvent is an event waiting in a sink's queue.
+// The IDs and the enqueue time are recorded on the delivery span
+type fanOutEvent struct {
+	method    string
+	eventID   int64
+	sessionID int64
+	queuedAt  time.Time
+	send      func(EventBroadcaster)
+}
+
+// BroadcastSessionStart implements EventBroadcaster
+func (f *FanOutBroadcaster) BroadcastSessionStart(event SessionStartBroadcast) {
+	f.publish(fanOutEvent{method: "BroadcastSessionStart", eventID: event.EventID, sessionID: event.SessionID,
+		send: func(b EventBroadcaster) { b.BroadcastSessionStart(event) }})
+}
+
+// BroadcastSessionEnd implements EventBroadcaster
+func (f *FanOutBroadcaster) BroadcastSessionEnd(event SessionEndBroadcast) {
+	f.publish(fanOutEvent{method: "BroadcastSessionEnd", eventID: event.EventID, sessionID: event.SessionID,
+		send: func(b EventBroadcaster) { b.BroadcastSessionEnd(event) }})
+}
+
+// BroadcastWorkNameChange implements EventBroadcaster
+func (f *FanOutBroadcaster) BroadcastWorkNameChange(event WorkNameChangeBroadcast) {
+	f.publish(fanOutEvent{method: "BroadcastWorkNameChange", eventID: event.EventID, sessionID: event.SessionID,
+		send: func(b EventBroadcaster) { b.BroadcastWorkNameChange(event) }})
+}
+
+// BroadcastSessionExtend implements EventBroadcaster
+func (f *FanOutBroadcaster) BroadcastSessionExtend(event SessionExtendBroadcast) {
+	f.publish(fanOutEvent{method: "BroadcastSessionExtend", eventID: event.EventID, sessionID: event.SessionID,
+		send: func(b EventBroadcaster) { b.BroadcastSessionExtend(event) }})
+}
+
+// BroadcastSettingsChanged implements EventBroadcaster
+func (f *FanOutBroadcaster) BroadcastSettingsChanged(event SettingsChangedBroadcast) {
+	f.publish(fanOutEvent{method: "BroadcastSettingsChanged", eventID: event.EventID,
+		send: func(b EventBroadcaster) { b.BroadcastSettingsChanged(event) }})
+}
+
+// publish enqueues the event for every sink without blocking
+func (f *FanOutBroadcaster) publish(event fanOutEvent) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.closed {
+		return
+	}
+
+	event.queuedAt = time.Now()
+	for _, s := range f.sinks {
+		select {
+		case s.queue <- event:
+		default:
+			if s.dropped.Add(1) == 1 {
+				s.logger.Warn("event queue of sink is full; dropping events (see Stats for the count)")
+			}
+		}
+	}
+}
+
+func (s *fanOutSink) run() {
+	for event := range s.queue {
+		if err := s.deliver(event); err != nil {
+			s.panics.Add(1)
+			s.logger.Error("sink failed to handle event", logging.Err(err))
+		}
+		s.delivered.Add(1)
+	}
+}
+
+// deliver recovers a panicking sink so that it keeps receiving later events.
+// Each delivery is its own trace; the queue wait shows whether the sink is falling behind
+func (s *fanOutSink) deliver(event fanOutEvent) (err error) {
+	_, span := tracing.Start(context.Background(), "EventSink."+event.method,
+		tracing.KeySink.String(s.name),
+		tracing.KeyEventID.Int64(event.eventID),
+		tracing.KeySessionID.Int64(event.sessionID),
+		tracing.KeyQueueWaitMs.Int64(time.Since(event.queuedAt).Milliseconds()),
+	)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("sink panicked: %v", r)
+		}
+		tracing.End(span, err)
+	}()
+	event.send(s.sink)
+	return nil
+}
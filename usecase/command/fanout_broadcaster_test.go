@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingBroadcaster records the session IDs of received session_start events
+type recordingBroadcaster struct {
+	NoOpBroadcaster
+	mu       sync.Mutex
+	received []int64
+	block    chan struct{} // blocks every delivery until closed (nil = no blocking)
+	panicOn  int64
+}
+
+func (b *recordingBroadcaster) BroadcastSessionStart(event SessionStartBroadcast) {
+	if b.block != nil {
+		<-b.block
+	}
+	if event.SessionID == b.panicOn {
+		panic("broken sink")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.received = append(b.received, event.SessionID)
+}
+
+func (b *recordingBroadcaster) sessionIDs() []int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]int64(nil), b.received...)
+}
+
+func TestFanOutBroadcaster_DeliversToAllSinks(t *testing.T) {
+	fanOut := NewFanOutBroadcaster(10)
+	first := &recordingBroadcaster{}
+	second := &recordingBroadcaster{}
+	fanOut.Add(context.Background(), "first", first)
+	fanOut.Add(context.Background(), "second", second)
+
+	fanOut.BroadcastSessionStart(SessionStartBroadcast{SessionID: 1})
+	fanOut.BroadcastSessionStart(SessionStartBroadcast{SessionID: 2})
+	fanOut.Close()
+
+	for name, sink := range map[string]*recordingBroadcaster{"first": first, "second": second} {
+		ids := sink.sessionIDs()
+		if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+			t.Errorf("expected %s sink to receive [1 2] in order, got %v", name, ids)
+		}
+	}
+}
+
+func TestFanOutBroadcaster_SlowSinkDoesNotBlock(t *testing.T) {
+	fanOut := NewFanOutBroadcaster(1)
+	slow := &recordingBroadcaster{block: make(chan struct{})}
+	fast := &recordingBroadcaster{}
+	fanOut.Add(context.Background(), "slow", slow)
+	fanOut.Add(context.Background(), "fast", fast)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := int64(1); i <= 5; i++ {
+			fanOut.BroadcastSessionStart(SessionStartBroadcast{SessionID: i})
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("broadcast blocked on a slow sink")
+	}
+
+	close(slow.block)
+	fanOut.Close()
+
+	stats := fanOut.Stats()
+	if stats[0].Name != "slow" || stats[0].Dropped == 0 {
+		t.Errorf("expected the slow sink to drop events, got %+v", stats[0])
+	}
+	if stats[0].Delivered+stats[0].Dropped != 5 {
+		t.Errorf("expected every event to be delivered or dropped, got %+v", stats[0])
+	}
+	if got := len(fast.sessionIDs()) + int(stats[1].Dropped); got != 5 {
+		t.Errorf("expected fast sink to account for all events, got %+v", stats[1])
+	}
+}
+
+func TestFanOutBroadcaster_RecoversFromPanic(t *testing.T) {
+	fanOut := NewFanOutBroadcaster(10)
+	sink := &recordingBroadcaster{panicOn: 1}
+	fanOut.Add(context.Background(), "panicky", sink)
+
+	fanOut.BroadcastSessionStart(SessionStartBroadcast{SessionID: 1})
+	fanOut.BroadcastSessionStart(SessionStartBroadcast{SessionID: 2})
+	fanOut.Close()
+
+	if ids := sink.sessionIDs(); len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("expected the sink to keep receiving events after a panic, got %v", ids)
+	}
+	if stats := fanOut.Stats(); stats[0].Panics != 1 {
+		t.Errorf("expected 1 panic, got %+v", stats[0])
+	}
+}
+
+func TestFanOutBroadcaster_IgnoresEventsAfterClose(t *testing.T) {
+	fanOut := NewFanOutBroadcaster(10)
+	sink := &recordingBroadcaster{}
+	fanOut.Add(context.Background(), "sink", sink)
+	fanOut.Close()
+
+	fanOut.BroadcastSessionStart(SessionStartBroadcast{SessionID: 1})
+	fanOut.Close()
+
+	if ids := sink.sessionIDs(); len(ids) != 0 {
+		t.Errorf("expected no events after close, got %v", ids)
+	}
+}
@@ -0,0 +1,129 @@
+package command
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
+)
+
+// TxSessionCompleter completes a session and runs withTx in the same transaction before it commits
+type TxSessionCompleter interface {
+	CompleteSessionWith(ctx context.Context, session *domain.Session, userID int64, withTx func(ctx context.Context, tx repository.Tx) error) error
+}
+
+// ForceOutCommandInput represents the input for an admin force-out
+type ForceOutCommandInput struct {
+	SessionID int64
+	Actor     string
+	Reason    string
+}
+
+// ForceOutCommandOutput represents the output of an admin force-out
+type ForceOutCommandOutput struct {
+	SessionID int64
+	UserID    int64
+	ActualEnd time.Time
+}
+
+// ForceOutCommandUseCase ends another user's session on behalf of an admin
+// The session is completed through CompleteSessionService, so the overlay receives the usual session_end event.
+// The audit log is written in the same transaction as the completion
+type ForceOutCommandUseCase struct {
+	sessionRepository   repository.SessionRepository
+	auditLogRepository  repository.AuditLogRepository
+	completeService     TxSessionCompleter
+	expirationCanceller ExpirationCanceller
+	now                 func() time.Time
+}
+
+// NewForceOutCommandUseCase creates a new force-out use case
+func NewForceOutCommandUseCase(
+	sessionRepository repository.SessionRepository,
+	auditLogRepository repository.AuditLogRepository,
+	completeService TxSessionCompleter,
+	expirationCanceller ExpirationCanceller,
+) *ForceOutCommandUseCase {
+	return &ForceOutCommandUseCase{
+		sessionRepository:   sessionRepository,
+		auditLogRepository:  auditLogRepository,
+		completeService:     completeService,
+		expirationCanceller: expirationCanceller,
+		now:                 func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Execute executes the force-out
+func (uc *ForceOutCommandUseCase) Execute(ctx context.Context, input ForceOutCommandInput) (_ *ForceOutCommandOutput, err error) {
+	ctx, span := tracing.Start(ctx, "ForceOutCommandUseCase.Execute", tracing.KeySessionID.Int64(input.SessionID))
+	defer func() { tracing.End(span, err) }()
+
+	// 1. Find session
+	session, err := uc.sessionRepository.FindByID(ctx, input.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsActive() {
+		return nil, domain.ErrSessionAlreadyCompleted
+	}
+	before := newSessionState(session)
+
+	// 2. Complete the session using shared service (Complete + Update + Broadcast),
+	//    recording the audit log in the same transaction
+	err = uc.completeService.CompleteSessionWith(ctx, session, session.UserID, func(ctx context.Context, tx repository.Tx) error {
+		auditLog, err := domain.NewAuditLog(
+			input.Actor,
+			domain.AuditActionSessionForceOut,
+			domain.AuditTargetSession,
+			strconv.FormatInt(session.ID, 10),
+			before,
+			newSessionState(session),
+			input.Reason,
+			uc.now,
+		)
+		if err != nil {
+			return err
+		}
+		return uc.auditLogRepository.SaveWithTx(ctx, tx, auditLog)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Cancel the automatic expiration timer
+	uc.expirationCanceller.CancelExpiration(ctx, session.ID)
+
+	return &ForceOutCommandOutput{
+		SessionID: session.ID,
+		UserID:    session.UserID,
+		ActualEnd: *session.ActualEnd,
+	}, nil
+}
+
+// sessionState is the session snapshot recorded in audit logs
+type sessionState struct {
+	SessionID  int64      `json:"session_id"`
+	UserID     int64      `json:"user_id"`
+	WorkName   string     `json:"work_name"`
+	StartTime  time.Time  `json:"start_time"`
+	PlannedEnd time.Time  `json:"planned_end"`
+	ActualEnd  *time.Time `json:"actual_end"`
+}
+
+func newSessionState(session *domain.Session) *sessionState {
+	state := &sessionState{
+		SessionID:  session.ID,
+		UserID:     session.UserID,
+		WorkName:   session.WorkName,
+		StartTime:  session.StartTime,
+		PlannedEnd: session.PlannedEnd,
+	}
+	if session.ActualEnd != nil {
+		actualEnd := *session.ActualEnd
+		state.ActualEnd = &actualEnd
+	}
+	return state
+}
@@ -0,0 +1,199 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
+
+func newActiveSession(id, userID int64) *domain.Session {
+	now := time.Now()
+	return &domain.Session{
+		ID:         id,
+		UserID:     userID,
+		WorkName:   "作業",
+		StartTime:  now.Add(-30 * time.Minute),
+		PlannedEnd: now.Add(30 * time.Minute),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+func TestForceOutCommand_Success(t *testing.T) {
+	session := newActiveSession(99, 42)
+	sessionRepo := &mockSessionRepository{
+		findByIDFn: func(ctx context.Context, id int64) (*domain.Session, error) {
+			if id == 99 {
+				return session, nil
+			}
+			return nil, domain.ErrSessionNotFound
+		},
+	}
+
+	completedFor := int64(0)
+	completeService := &mockCompleteSessionService{
+		completeSessionFn: func(ctx context.Context, s *domain.Session, userID int64) error {
+			completedFor = userID
+			return s.Complete(time.Now)
+		},
+	}
+	canceller := &mockExpirationCanceller{}
+	auditRepo := &mockAuditLogRepository{}
+
+	uc := NewForceOutCommandUseCase(sessionRepo, auditRepo, completeService, canceller)
+
+	output, err := uc.Execute(context.Background(), ForceOutCommandInput{SessionID: 99, Actor: "moderator", Reason: "荒らし"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.SessionID != 99 || output.UserID != 42 || output.ActualEnd.IsZero() {
+		t.Errorf("unexpected output: %+v", output)
+	}
+	if completedFor != 42 {
+		t.Errorf("expected session to be completed for user 42, got %d", completedFor)
+	}
+	if len(canceller.cancelled) != 1 || canceller.cancelled[0] != 99 {
+		t.Errorf("expected expiration of session 99 to be cancelled, got %v", canceller.cancelled)
+	}
+
+	if len(auditRepo.logs) != 1 {
+		t.Fatalf("expected 1 audit log, got %d", len(auditRepo.logs))
+	}
+	entry := auditRepo.logs[0]
+	if entry.Action != domain.AuditActionSessionForceOut || entry.TargetID != "99" || entry.Actor != "moderator" || entry.Reason != "荒らし" {
+		t.Errorf("unexpected audit log: %+v", entry)
+	}
+}
+
+func TestForceOutCommand_AuditLogFailureAbortsCompletion(t *testing.T) {
+	session := newActiveSession(99, 42)
+	sessionRepo := &mockSessionRepository{
+		findByIDFn: func(ctx context.Context, id int64) (*domain.Session, error) {
+			return session, nil
+		},
+	}
+	auditRepo := &mockAuditLogRepository{
+		saveWithTxFn: func(tx repository.Tx, log *domain.AuditLog) error {
+			if tx == nil {
+				t.Error("audit log must be written in the completion transaction")
+			}
+			return errors.New("db down")
+		},
+	}
+	canceller := &mockExpirationCanceller{}
+
+	uc := NewForceOutCommandUseCase(sessionRepo, auditRepo, &mockCompleteSessionService{}, canceller)
+
+	if _, err := uc.Execute(context.Background(), ForceOutCommandInput{SessionID: 99, Actor: "moderator"}); err == nil {
+		t.Fatal("expected the audit log error to be returned")
+	}
+	if len(canceller.cancelled) != 0 {
+		t.Errorf("expiration must stay scheduled when the completion is rolled back, got %v", canceller.cancelled)
+	}
+}
+
+func TestForceOutCommand_AlreadyCompleted(t *testing.T) {
+	session := newActiveSession(99, 42)
+	_ = session.Complete(time.Now)
+
+	sessionRepo := &mockSessionRepository{
+		findByIDFn: func(ctx context.Context, id int64) (*domain.Session, error) {
+			return session, nil
+		},
+	}
+	completeService := &mockCompleteSessionService{
+		completeSessionFn: func(ctx context.Context, s *domain.Session, userID int64) error {
+			t.Error("completed session should not be completed again")
+			return nil
+		},
+	}
+
+	uc := NewForceOutCommandUseCase(sessionRepo, &mockAuditLogRepository{}, completeService, &mockExpirationCanceller{})
+
+	_, err := uc.Execute(context.Background(), ForceOutCommandInput{SessionID: 99, Actor: "moderator"})
+	if !errors.Is(err, domain.ErrSessionAlreadyCompleted) {
+		t.Errorf("expected ErrSessionAlreadyCompleted, got %v", err)
+	}
+}
+
+func TestKickAllCommand(t *testing.T) {
+	sessions := map[int64]*domain.Session{
+		1: newActiveSession(1, 10),
+		2: newActiveSession(2, 20),
+		3: newActiveSession(3, 30),
+	}
+	// セッション2は一覧取得後に自動終了した想定
+	_ = sessions[2].Complete(time.Now)
+
+	sessionRepo := &mockSessionRepository{
+		findAllActiveFn: func(ctx context.Context) ([]domain.SessionInfo, error) {
+			return []domain.SessionInfo{{SessionID: 1}, {SessionID: 2}, {SessionID: 3}}, nil
+		},
+		findByIDFn: func(ctx context.Context, id int64) (*domain.Session, error) {
+			return sessions[id], nil
+		},
+	}
+	completeService := &mockCompleteSessionService{
+		completeSessionFn: func(ctx context.Context, s *domain.Session, userID int64) error {
+			if s.ID == 3 {
+				return errors.New("db down")
+			}
+			return s.Complete(time.Now)
+		},
+	}
+	auditRepo := &mockAuditLogRepository{}
+
+	forceOut := NewForceOutCommandUseCase(sessionRepo, auditRepo, completeService, &mockExpirationCanceller{})
+	uc := NewKickAllCommandUseCase(sessionRepo, forceOut)
+
+	output, err := uc.Execute(context.Background(), KickAllCommandInput{Actor: "moderator", Reason: "メンテナンス"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(output.SessionIDs) != 1 || output.SessionIDs[0] != 1 {
+		t.Errorf("expected only session 1 to be ended, got %v", output.SessionIDs)
+	}
+	if output.Failed != 1 {
+		t.Errorf("expected 1 failure, got %d", output.Failed)
+	}
+	if len(auditRepo.logs) != 1 {
+		t.Errorf("expected 1 audit log, got %d", len(auditRepo.logs))
+	}
+}
+
+// CompleteSessionWith runs withTx after completing the session, like the transaction in CompleteSessionService
+func (m *mockCompleteSessionService) CompleteSessionWith(ctx context.Context, session *domain.Session, userID int64, withTx func(ctx context.Context, tx repository.Tx) error) error {
+	if err := m.CompleteSession(ctx, session, userID); err != nil {
+		return err
+	}
+	return withTx(ctx, &mockTx{})
+}
+
+// Mock AuditLogRepository
+type mockAuditLogRepository struct {
+	logs         []*domain.AuditLog
+	saveWithTxFn func(tx repository.Tx, log *domain.AuditLog) error
+}
+
+func (m *mockAuditLogRepository) Save(ctx context.Context, log *domain.AuditLog) error {
+	log.ID = int64(len(m.logs) + 1)
+	m.logs = append(m.logs, log)
+	return nil
+}
+
+func (m *mockAuditLogRepository) SaveWithTx(ctx context.Context, tx repository.Tx, log *domain.AuditLog) error {
+	if m.saveWithTxFn != nil {
+		if err := m.saveWithTxFn(tx, log); err != nil {
+			return err
+		}
+	}
+	return m.Save(ctx, log)
+}
+
+func (m *mockAuditLogRepository) List(ctx context.Context, filter repository.AuditLogFilter) ([]*domain.AuditLog, error) {
+	return m.logs, nil
+}
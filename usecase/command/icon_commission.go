@@ -0,0 +1,333 @@
+package command
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
+
+const (
+	// DefaultIconCommissionListLimit is the default number of commissions returned by List
+	DefaultIconCommissionListLimit = 50
+	// MaxIconCommissionListLimit is the maximum number of commissions returned by List
+	MaxIconCommissionListLimit = 500
+)
+
+// RequestIconCommissionInput represents the input for /icon_creation
+type RequestIconCommissionInput struct {
+	UserName string
+	Points   int64
+}
+
+// RequestIconCommissionOutput represents the output of /icon_creation
+type RequestIconCommissionOutput struct {
+	Commission *domain.IconCommission
+	Balance    int64 // Points left after the debit
+}
+
+// StartIconCommissionInput represents the input for starting work on a commission
+type StartIconCommissionInput struct {
+	CommissionID int64
+	Actor        string
+	Reason       string
+}
+
+// DeliverIconCommissionInput represents the input for delivering a commission
+// AssetKey is the sprite that was uploaded for the icon; it becomes the user's exclusive icon
+type DeliverIconCommissionInput struct {
+	CommissionID int64
+	AssetKey     string
+	Actor        string
+	Reason       string
+}
+
+// RefundIconCommissionInput represents the input for cancelling a commission
+type RefundIconCommissionInput struct {
+	CommissionID int64
+	Actor        string
+	Reason       string
+}
+
+// IconCommissionUseCase handles custom icon commissions (/icon_creation and the admin workflow)
+// The user row is locked before the commission row so that the balance check, the debit
+// and the status change are serialized with other point operations of the same user
+type IconCommissionUseCase struct {
+	userRepository       repository.UserRepository
+	commissionRepository repository.IconCommissionRepository
+	ledgerRepository     repository.PointLedgerRepository
+	iconRepository       repository.IconRepository
+	auditLogRepository   repository.AuditLogRepository
+	rateLimiter          RateLimiter
+	now                  func() time.Time
+}
+
+// NewIconCommissionUseCase creates a new icon commission use case
+func NewIconCommissionUseCase(
+	userRepository repository.UserRepository,
+	commissionRepository repository.IconCommissionRepository,
+	ledgerRepository repository.PointLedgerRepository,
+	iconRepository repository.IconRepository,
+	auditLogRepository repository.AuditLogRepository,
+	rateLimiter RateLimiter,
+) *IconCommissionUseCase {
+	return &IconCommissionUseCase{
+		userRepository:       userRepository,
+		commissionRepository: commissionRepository,
+		ledgerRepository:     ledgerRepository,
+		iconRepository:       iconRepository,
+		auditLogRepository:   auditLogRepository,
+		rateLimiter:          rateLimiter,
+		now:                  func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Request debits the commission cost and creates a pending commission
+func (uc *IconCommissionUseCase) Request(ctx context.Context, input RequestIconCommissionInput) (*RequestIconCommissionOutput, error) {
+	tx, err := uc.userRepository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// 1. Find and lock user
+	user, err := uc.userRepository.FindByNameWithTx(ctx, tx, input.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Refuse blocked users
+	if user.IsBlocked(uc.now) {
+		err = domain.ErrUserBlocked
+		return nil, err
+	}
+
+	// 3. Check rate limit
+	if err = uc.rateLimiter.Allow(ctx, user.Name, user.Tier, CommandIconCreation); err != nil {
+		return nil, err
+	}
+
+	// 4. Validate the request and the balance
+	commission, err := domain.NewIconCommission(user.ID, input.Points, uc.now)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := uc.ledgerRepository.BalanceWithTx(ctx, tx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err = domain.CheckPointDebit(balance, commission.Cost); err != nil {
+		return nil, err
+	}
+
+	// 5. Save the commission and debit its cost (the commission ID is the ledger reference)
+	if err = uc.commissionRepository.CreateWithTx(ctx, tx, commission); err != nil {
+		return nil, err
+	}
+	if err = uc.appendEntry(ctx, tx, commission, -commission.Cost, domain.PointReasonIconCommission); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &RequestIconCommissionOutput{Commission: commission, Balance: balance - commission.Cost}, nil
+}
+
+// List returns commissions, oldest first (an empty status returns every status)
+func (uc *IconCommissionUseCase) List(ctx context.Context, status domain.IconCommissionStatus, limit int32) ([]*domain.IconCommission, error) {
+	if status != "" && !status.Valid() {
+		return nil, domain.ErrInvalidIconCommissionStatus
+	}
+	if limit <= 0 {
+		limit = DefaultIconCommissionListLimit
+	}
+	if limit > MaxIconCommissionListLimit {
+		limit = MaxIconCommissionListLimit
+	}
+	return uc.commissionRepository.List(ctx, status, limit)
+}
+
+// Start marks a pending commission as in progress
+func (uc *IconCommissionUseCase) Start(ctx context.Context, input StartIconCommissionInput) (*domain.IconCommission, error) {
+	tx, _, commission, err := uc.lockCommission(ctx, input.CommissionID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	before := newIconCommissionState(commission)
+	if err = commission.Start(uc.now); err != nil {
+		return nil, err
+	}
+	if err = uc.commissionRepository.UpdateWithTx(ctx, tx, commission); err != nil {
+		return nil, err
+	}
+	if err = uc.audit(ctx, tx, input.Actor, domain.AuditActionIconCommissionStart, commission, before, input.Reason); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return commission, nil
+}
+
+// Deliver registers the finished asset as the user's exclusive icon and closes the commission
+// The icon uses the user's current tier so that it is picked at the next /in
+func (uc *IconCommissionUseCase) Deliver(ctx context.Context, input DeliverIconCommissionInput) (*domain.IconCommission, error) {
+	tx, user, commission, err := uc.lockCommission(ctx, input.CommissionID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// 1. Check the transition before creating the icon
+	if !commission.IsOpen() {
+		err = domain.ErrIconCommissionTransition
+		return nil, err
+	}
+
+	// 2. Create the exclusive icon
+	icon, err := domain.NewExclusiveIcon(user.ID, user.Tier, input.AssetKey, uc.now)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.iconRepository.CreateWithTx(ctx, tx, icon); err != nil {
+		return nil, err
+	}
+
+	// 3. Close the commission and write the audit log
+	before := newIconCommissionState(commission)
+	if err = commission.Deliver(icon.ID, uc.now); err != nil {
+		return nil, err
+	}
+	if err = uc.commissionRepository.UpdateWithTx(ctx, tx, commission); err != nil {
+		return nil, err
+	}
+	if err = uc.audit(ctx, tx, input.Actor, domain.AuditActionIconCommissionDeliver, commission, before, input.Reason); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return commission, nil
+}
+
+// Refund cancels an open commission and credits its cost back through the ledger
+func (uc *IconCommissionUseCase) Refund(ctx context.Context, input RefundIconCommissionInput) (*domain.IconCommission, error) {
+	tx, _, commission, err := uc.lockCommission(ctx, input.CommissionID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	before := newIconCommissionState(commission)
+	if err = commission.Refund(input.Reason, uc.now); err != nil {
+		return nil, err
+	}
+	if err = uc.commissionRepository.UpdateWithTx(ctx, tx, commission); err != nil {
+		return nil, err
+	}
+	if err = uc.appendEntry(ctx, tx, commission, commission.Cost, domain.PointReasonIconCommissionRefund); err != nil {
+		return nil, err
+	}
+	if err = uc.audit(ctx, tx, input.Actor, domain.AuditActionIconCommissionRefund, commission, before, input.Reason); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return commission, nil
+}
+
+// lockCommission starts a transaction, locks the commission owner's user row and then the commission row
+func (uc *IconCommissionUseCase) lockCommission(ctx context.Context, commissionID int64) (repository.Tx, *domain.User, *domain.IconCommission, error) {
+	current, err := uc.commissionRepository.FindByID(ctx, commissionID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tx, err := uc.userRepository.BeginTx(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	user, err := uc.userRepository.FindByIDWithTx(ctx, tx, current.UserID)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, nil, nil, err
+	}
+	commission, err := uc.commissionRepository.FindByIDWithTx(ctx, tx, commissionID)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, nil, nil, err
+	}
+	return tx, user, commission, nil
+}
+
+func (uc *IconCommissionUseCase) appendEntry(ctx context.Context, tx repository.Tx, commission *domain.IconCommission, delta int64, reason domain.PointReason) error {
+	entry, err := domain.NewPointEntry(commission.UserID, delta, reason, strconv.FormatInt(commission.ID, 10), uc.now)
+	if err != nil {
+		return err
+	}
+	return uc.ledgerRepository.AppendWithTx(ctx, tx, entry)
+}
+
+func (uc *IconCommissionUseCase) audit(ctx context.Context, tx repository.Tx, actor, action string, commission *domain.IconCommission, before *iconCommissionState, reason string) error {
+	auditLog, err := domain.NewAuditLog(
+		actor,
+		action,
+		domain.AuditTargetIconCommission,
+		strconv.FormatInt(commission.ID, 10),
+		before,
+		newIconCommissionState(commission),
+		reason,
+		uc.now,
+	)
+	if err != nil {
+		return err
+	}
+	return uc.auditLogRepository.SaveWithTx(ctx, tx, auditLog)
+}
+
+type iconCommissionState struct {
+	CommissionID int64                       `json:"commission_id"`
+	UserID       int64                       `json:"user_id"`
+	Status       domain.IconCommissionStatus `json:"status"`
+	IconID       *int64                      `json:"icon_id"`
+	Note         string                      `json:"note"`
+}
+
+func newIconCommissionState(commission *domain.IconCommission) *iconCommissionState {
+	state := &iconCommissionState{
+		CommissionID: commission.ID,
+		UserID:       commission.UserID,
+		Status:       commission.Status,
+		Note:         commission.Note,
+	}
+	if commission.IconID != nil {
+		iconID := *commission.IconID
+		state.IconID = &iconID
+	}
+	return state
+}
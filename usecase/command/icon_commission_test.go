@@ -0,0 +1,285 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
+
+// Mock IconCommissionRepository
+type mockIconCommissionRepository struct {
+	commissions map[int64]*domain.IconCommission
+	createErr   error
+}
+
+func (m *mockIconCommissionRepository) List(ctx context.Context, status domain.IconCommissionStatus, limit int32) ([]*domain.IconCommission, error) {
+	var result []*domain.IconCommission
+	for _, c := range m.commissions {
+		if status == "" || c.Status == status {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockIconCommissionRepository) FindByID(ctx context.Context, id int64) (*domain.IconCommission, error) {
+	c, ok := m.commissions[id]
+	if !ok {
+		return nil, domain.ErrIconCommissionNotFound
+	}
+	copied := *c
+	return &copied, nil
+}
+
+func (m *mockIconCommissionRepository) FindByIDWithTx(ctx context.Context, tx repository.Tx, id int64) (*domain.IconCommission, error) {
+	return m.FindByID(ctx, id)
+}
+
+func (m *mockIconCommissionRepository) CreateWithTx(ctx context.Context, tx repository.Tx, commission *domain.IconCommission) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	if m.commissions == nil {
+		m.commissions = make(map[int64]*domain.IconCommission)
+	}
+	commission.ID = int64(len(m.commissions) + 1)
+	copied := *commission
+	m.commissions[commission.ID] = &copied
+	return nil
+}
+
+func (m *mockIconCommissionRepository) UpdateWithTx(ctx context.Context, tx repository.Tx, commission *domain.IconCommission) error {
+	copied := *commission
+	m.commissions[commission.ID] = &copied
+	return nil
+}
+
+// Mock PointLedgerRepository
+type mockPointLedgerRepository struct {
+	entries []*domain.PointEntry
+}
+
+func (m *mockPointLedgerRepository) Balance(ctx context.Context, userID int64) (int64, error) {
+	var balance int64
+	for _, e := range m.entries {
+		if e.UserID == userID {
+			balance += e.Delta
+		}
+	}
+	return balance, nil
+}
+
+func (m *mockPointLedgerRepository) BalanceWithTx(ctx context.Context, tx repository.Tx, userID int64) (int64, error) {
+	return m.Balance(ctx, userID)
+}
+
+func (m *mockPointLedgerRepository) AppendWithTx(ctx context.Context, tx repository.Tx, entry *domain.PointEntry) error {
+	for _, e := range m.entries {
+		if e.Reason == entry.Reason && e.Reference != "" && e.Reference == entry.Reference {
+			return domain.ErrDuplicatePointEntry
+		}
+	}
+	entry.ID = int64(len(m.entries) + 1)
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+// Mock IconRepository
+type mockIconRepository struct {
+	created []*domain.Icon
+}
+
+func (m *mockIconRepository) ListAssignable(ctx context.Context, userID int64, tier domain.Tier) ([]*domain.Icon, error) {
+	return m.created, nil
+}
+
+func (m *mockIconRepository) CreateWithTx(ctx context.Context, tx repository.Tx, icon *domain.Icon) error {
+	icon.ID = int64(100 + len(m.created))
+	m.created = append(m.created, icon)
+	return nil
+}
+
+type iconCommissionFixture struct {
+	uc          *IconCommissionUseCase
+	commissions *mockIconCommissionRepository
+	ledger      *mockPointLedgerRepository
+	icons       *mockIconRepository
+	audit       *mockAuditLogRepository
+	committed   int
+}
+
+func newIconCommissionFixture(user *domain.User, balance int64) *iconCommissionFixture {
+	f := &iconCommissionFixture{
+		commissions: &mockIconCommissionRepository{},
+		ledger:      &mockPointLedgerRepository{},
+		icons:       &mockIconRepository{},
+		audit:       &mockAuditLogRepository{},
+	}
+	if balance != 0 {
+		f.ledger.entries = append(f.ledger.entries, &domain.PointEntry{UserID: user.ID, Delta: balance, Reason: "seed"})
+	}
+	userRepo := &mockUserRepository{
+		beginTxFn: func(ctx context.Context) (repository.Tx, error) {
+			return &mockTx{commitFn: func(ctx context.Context) error {
+				f.committed++
+				return nil
+			}}, nil
+		},
+		findByNameWithTxFn: func(ctx context.Context, tx repository.Tx, name string) (*domain.User, error) {
+			return user, nil
+		},
+		findByIDWithTxFn: func(ctx context.Context, tx repository.Tx, id int64) (*domain.User, error) {
+			return user, nil
+		},
+	}
+	f.uc = NewIconCommissionUseCase(userRepo, f.commissions, f.ledger, f.icons, f.audit, NoOpRateLimiter{})
+	return f
+}
+
+func TestIconCommission_Request(t *testing.T) {
+	user := &domain.User{ID: 42, Name: "yamada", Tier: domain.Tier2}
+
+	t.Run("ポイントを消費して依頼を作成する", func(t *testing.T) {
+		f := newIconCommissionFixture(user, 1_200_000)
+
+		out, err := f.uc.Request(context.Background(), RequestIconCommissionInput{UserName: "yamada", Points: domain.IconCommissionCost})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.Commission.ID == 0 || out.Commission.Status != domain.IconCommissionPending {
+			t.Errorf("unexpected commission: %+v", out.Commission)
+		}
+		if out.Balance != 200_000 {
+			t.Errorf("expected balance 200000, got %d", out.Balance)
+		}
+		last := f.ledger.entries[len(f.ledger.entries)-1]
+		if last.Delta != -domain.IconCommissionCost || last.Reason != domain.PointReasonIconCommission || last.Reference != "1" {
+			t.Errorf("unexpected ledger entry: %+v", last)
+		}
+		if f.committed != 1 {
+			t.Errorf("expected 1 commit, got %d", f.committed)
+		}
+	})
+
+	t.Run("残高不足", func(t *testing.T) {
+		f := newIconCommissionFixture(user, 999_999)
+
+		_, err := f.uc.Request(context.Background(), RequestIconCommissionInput{UserName: "yamada", Points: domain.IconCommissionCost})
+		if !errors.Is(err, domain.ErrInsufficientPoints) {
+			t.Fatalf("expected ErrInsufficientPoints, got %v", err)
+		}
+		if len(f.commissions.commissions) != 0 || len(f.ledger.entries) != 1 || f.committed != 0 {
+			t.Errorf("nothing should be recorded: %+v %+v", f.commissions.commissions, f.ledger.entries)
+		}
+	})
+
+	t.Run("金額が違う", func(t *testing.T) {
+		f := newIconCommissionFixture(user, 2_000_000)
+
+		_, err := f.uc.Request(context.Background(), RequestIconCommissionInput{UserName: "yamada", Points: 500})
+		if !errors.Is(err, domain.ErrInvalidIconCommissionCost) {
+			t.Fatalf("expected ErrInvalidIconCommissionCost, got %v", err)
+		}
+	})
+
+	t.Run("未完了の依頼がある", func(t *testing.T) {
+		f := newIconCommissionFixture(user, 2_000_000)
+		f.commissions.createErr = domain.ErrIconCommissionAlreadyOpen
+
+		_, err := f.uc.Request(context.Background(), RequestIconCommissionInput{UserName: "yamada", Points: domain.IconCommissionCost})
+		if !errors.Is(err, domain.ErrIconCommissionAlreadyOpen) {
+			t.Fatalf("expected ErrIconCommissionAlreadyOpen, got %v", err)
+		}
+		if len(f.ledger.entries) != 1 {
+			t.Errorf("points must not be debited, got %+v", f.ledger.entries)
+		}
+	})
+}
+
+func TestIconCommission_Deliver(t *testing.T) {
+	user := &domain.User{ID: 42, Name: "yamada", Tier: domain.Tier3}
+	f := newIconCommissionFixture(user, domain.IconCommissionCost)
+	ctx := context.Background()
+
+	out, err := f.uc.Request(ctx, RequestIconCommissionInput{UserName: "yamada", Points: domain.IconCommissionCost})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.uc.Start(ctx, StartIconCommissionInput{CommissionID: out.Commission.ID, Actor: "admin"}); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+
+	delivered, err := f.uc.Deliver(ctx, DeliverIconCommissionInput{CommissionID: out.Commission.ID, AssetKey: " yamada-01 ", Actor: "admin"})
+	if err != nil {
+		t.Fatalf("failed to deliver: %v", err)
+	}
+	if delivered.Status != domain.IconCommissionDelivered || delivered.IconID == nil {
+		t.Fatalf("unexpected commission: %+v", delivered)
+	}
+	if len(f.icons.created) != 1 {
+		t.Fatalf("expected 1 icon, got %d", len(f.icons.created))
+	}
+	icon := f.icons.created[0]
+	if icon.ID != *delivered.IconID || !icon.IsExclusive() || *icon.OwnerUserID != 42 || icon.Tier != domain.Tier3 || icon.AssetKey != "yamada-01" {
+		t.Errorf("unexpected icon: %+v", icon)
+	}
+	if len(f.audit.logs) != 2 || f.audit.logs[1].Action != domain.AuditActionIconCommissionDeliver || f.audit.logs[1].TargetID != "1" {
+		t.Errorf("unexpected audit logs: %+v", f.audit.logs)
+	}
+
+	// 納品済みの依頼は返金できない
+	if _, err := f.uc.Refund(ctx, RefundIconCommissionInput{CommissionID: out.Commission.ID, Actor: "admin"}); !errors.Is(err, domain.ErrIconCommissionTransition) {
+		t.Errorf("expected ErrIconCommissionTransition, got %v", err)
+	}
+	if _, err := f.uc.Deliver(ctx, DeliverIconCommissionInput{CommissionID: out.Commission.ID, AssetKey: "yamada-02", Actor: "admin"}); !errors.Is(err, domain.ErrIconCommissionTransition) {
+		t.Errorf("expected ErrIconCommissionTransition, got %v", err)
+	}
+	if len(f.icons.created) != 1 {
+		t.Errorf("no icon should be created for a closed commission")
+	}
+}
+
+func TestIconCommission_Refund(t *testing.T) {
+	user := &domain.User{ID: 42, Name: "yamada", Tier: domain.Tier1}
+	f := newIconCommissionFixture(user, domain.IconCommissionCost)
+	ctx := context.Background()
+
+	out, err := f.uc.Request(ctx, RequestIconCommissionInput{UserName: "yamada", Points: domain.IconCommissionCost})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	refunded, err := f.uc.Refund(ctx, RefundIconCommissionInput{CommissionID: out.Commission.ID, Actor: "admin", Reason: "素材が用意できない"})
+	if err != nil {
+		t.Fatalf("failed to refund: %v", err)
+	}
+	if refunded.Status != domain.IconCommissionRefunded || refunded.Note != "素材が用意できない" {
+		t.Errorf("unexpected commission: %+v", refunded)
+	}
+
+	balance, _ := f.ledger.Balance(ctx, 42)
+	if balance != domain.IconCommissionCost {
+		t.Errorf("expected points to be credited back, balance %d", balance)
+	}
+	last := f.ledger.entries[len(f.ledger.entries)-1]
+	if last.Reason != domain.PointReasonIconCommissionRefund || last.Reference != "1" {
+		t.Errorf("unexpected refund entry: %+v", last)
+	}
+
+	if _, err := f.uc.Refund(ctx, RefundIconCommissionInput{CommissionID: out.Commission.ID, Actor: "admin"}); !errors.Is(err, domain.ErrIconCommissionTransition) {
+		t.Errorf("expected ErrIconCommissionTransition on second refund, got %v", err)
+	}
+	if _, err := f.uc.Refund(ctx, RefundIconCommissionInput{CommissionID: 99, Actor: "admin"}); !errors.Is(err, domain.ErrIconCommissionNotFound) {
+		t.Errorf("expected ErrIconCommissionNotFound, got %v", err)
+	}
+}
+
+func TestIconCommission_ListRejectsUnknownStatus(t *testing.T) {
+	f := newIconCommissionFixture(&domain.User{ID: 1}, 0)
+	if _, err := f.uc.List(context.Background(), "done", 10); !errors.Is(err, domain.ErrInvalidIconCommissionStatus) {
+		t.Errorf("expected ErrInvalidIconCommissionStatus, got %v", err)
+	}
+}
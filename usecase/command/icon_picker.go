@@ -0,0 +1,21 @@
+package command
+
+import (
+	"context"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// IconPicker defines the interface for choosing the avatar icon of a new session
+// Returns nil when the user has no assignable icon
+type IconPicker interface {
+	PickIcon(ctx context.Context, userID int64, tier domain.Tier) (*domain.Icon, error)
+}
+
+// NoOpIconPicker is a no-op implementation of IconPicker
+// Useful for testing or when icons are not configured
+type NoOpIconPicker struct{}
+
+func (NoOpIconPicker) PickIcon(ctx context.Context, userID int64, tier domain.Tier) (*domain.Icon, error) {
+	return nil, nil
+}
@@ -6,10 +6,12 @@ import (
 
 	"github.com/yamada-ai/workspace-backend/domain"
 	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
 )
 
 const (
-	// DefaultSessionDuration is the default work session duration (60 minutes)
+	// DefaultSessionDuration is the default work session duration (60 minutes, see SessionSettings)
 	DefaultSessionDuration = 60 * time.Minute
 )
 
@@ -31,15 +33,19 @@ type JoinCommandOutput struct {
 
 // ExpirationScheduler defines the interface for scheduling session expiration
 type ExpirationScheduler interface {
-	ScheduleExpiration(sessionID int64, userID int64, plannedEnd time.Time)
+	ScheduleExpiration(ctx context.Context, sessionID int64, userID int64, plannedEnd time.Time)
 }
 
 // JoinCommandUseCase handles the /in command logic
 type JoinCommandUseCase struct {
 	userRepository      repository.UserRepository
 	sessionRepository   repository.SessionRepository
-	broadcaster         EventBroadcaster
+	outbox              EventOutbox
 	expirationScheduler ExpirationScheduler
+	rateLimiter         RateLimiter
+	textModerator       TextModerator
+	iconPicker          IconPicker
+	settings            SessionSettingsSource
 	now                 func() time.Time
 }
 
@@ -47,20 +53,43 @@ type JoinCommandUseCase struct {
 func NewJoinCommandUseCase(
 	userRepository repository.UserRepository,
 	sessionRepository repository.SessionRepository,
-	broadcaster EventBroadcaster,
+	outbox EventOutbox,
 	expirationScheduler ExpirationScheduler,
+	rateLimiter RateLimiter,
+	textModerator TextModerator,
+	iconPicker IconPicker,
+	settings SessionSettingsSource,
 ) *JoinCommandUseCase {
 	return &JoinCommandUseCase{
 		userRepository:      userRepository,
 		sessionRepository:   sessionRepository,
-		broadcaster:         broadcaster,
+		outbox:              outbox,
 		expirationScheduler: expirationScheduler,
+		rateLimiter:         rateLimiter,
+		textModerator:       textModerator,
+		iconPicker:          iconPicker,
+		settings:            settings,
 		now:                 func() time.Time { return time.Now().UTC() },
 	}
 }
 
 // Execute executes the join command
-func (uc *JoinCommandUseCase) Execute(ctx context.Context, input JoinCommandInput) (*JoinCommandOutput, error) {
+func (uc *JoinCommandUseCase) Execute(ctx context.Context, input JoinCommandInput) (_ *JoinCommandOutput, err error) {
+	ctx, span := tracing.Start(ctx, "JoinCommandUseCase.Execute", tracing.KeyUserName.String(input.UserName))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, logging.KeyUserName, input.UserName)
+
+	// Moderate user name and work name before taking the user row lock
+	// (auto-block updates the user in its own transaction)
+	if err := uc.textModerator.ModerateUserName(ctx, input.UserName); err != nil {
+		return nil, err
+	}
+	workName, err := uc.textModerator.ModerateWorkName(ctx, input.UserName, input.WorkName)
+	if err != nil {
+		return nil, err
+	}
+
 	// Start transaction
 	tx, err := uc.userRepository.BeginTx(ctx)
 	if err != nil {
@@ -98,7 +127,18 @@ func (uc *JoinCommandUseCase) Execute(ctx context.Context, input JoinCommandInpu
 		return nil, err
 	}
 
-	// 2. Check if user already has an active session
+	// 2. Refuse blocked users
+	if user.IsBlocked(uc.now) {
+		err = domain.ErrUserBlocked
+		return nil, err
+	}
+
+	// 3. Check rate limit (tier is known only after the user is resolved)
+	if err = uc.rateLimiter.Allow(ctx, user.Name, user.Tier, CommandJoin); err != nil {
+		return nil, err
+	}
+
+	// 4. Check if user already has an active session
 	_, err = uc.sessionRepository.FindActiveByUserIDWithTx(ctx, tx, user.ID)
 	if err == nil {
 		// User already has an active session, return error
@@ -108,34 +148,53 @@ func (uc *JoinCommandUseCase) Execute(ctx context.Context, input JoinCommandInpu
 		return nil, err
 	}
 
-	// 3. Create new session
-	session, err := domain.NewSession(user.ID, input.WorkName, DefaultSessionDuration, uc.now)
+	// 5. Create new session
+	session, err := domain.NewSession(user.ID, workName, uc.settings.SessionSettings().DefaultDuration, uc.now)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := uc.sessionRepository.CreateWithTx(ctx, tx, session); err != nil {
+	// 6. Assign an avatar icon (exclusive icon if the user has one, otherwise a random icon of the tier)
+	icon, err := uc.iconPicker.PickIcon(ctx, user.ID, user.Tier)
+	if err != nil {
 		return nil, err
 	}
+	var iconAssetKey *string
+	if icon != nil {
+		session.IconID = &icon.ID
+		iconAssetKey = &icon.AssetKey
+	}
 
-	// Commit transaction
-	if err := tx.Commit(ctx); err != nil {
+	if err = uc.sessionRepository.CreateWithTx(ctx, tx, session); err != nil {
 		return nil, err
 	}
 
-	// Broadcast session start event to all connected WebSocket clients
-	uc.broadcaster.BroadcastSessionStart(SessionStartBroadcast{
-		SessionID:  session.ID,
-		UserID:     user.ID,
-		UserName:   user.Name,
-		WorkName:   session.WorkName,
-		Tier:       int(user.Tier),
-		StartTime:  session.StartTime,
-		PlannedEnd: session.PlannedEnd,
-	})
+	// 7. Record session start event in the same transaction (delivered after commit)
+	if err = uc.outbox.RecordSessionStart(ctx, tx, SessionStartBroadcast{
+		SessionID:    session.ID,
+		UserID:       user.ID,
+		UserName:     user.Name,
+		WorkName:     session.WorkName,
+		Tier:         int(user.Tier),
+		IconID:       session.IconID,
+		IconAssetKey: iconAssetKey,
+		StartTime:    session.StartTime,
+		PlannedEnd:   session.PlannedEnd,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	uc.outbox.Notify()
+	ctx = logging.With(ctx, logging.KeySessionID, session.ID)
+	span.SetAttributes(tracing.KeySessionID.Int64(session.ID))
+	logging.FromContext(ctx).Info("session started", "work_name", session.WorkName, "new_user", isNewUser)
 
 	// Schedule automatic expiration
-	uc.expirationScheduler.ScheduleExpiration(session.ID, user.ID, session.PlannedEnd)
+	uc.expirationScheduler.ScheduleExpiration(ctx, session.ID, user.ID, session.PlannedEnd)
 
 	return &JoinCommandOutput{
 		SessionID:  session.ID,
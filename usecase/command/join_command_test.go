@@ -37,7 +37,9 @@ type mockUserRepository struct {
 	saveFn             func(ctx context.Context, user *domain.User) error
 	beginTxFn          func(ctx context.Context) (repository.Tx, error)
 	findByNameWithTxFn func(ctx context.Context, tx repository.Tx, name string) (*domain.User, error)
+	findByIDWithTxFn   func(ctx context.Context, tx repository.Tx, id int64) (*domain.User, error)
 	saveWithTxFn       func(ctx context.Context, tx repository.Tx, user *domain.User) error
+	updateWithTxFn     func(ctx context.Context, tx repository.Tx, user *domain.User) error
 }
 
 func (m *mockUserRepository) FindByName(ctx context.Context, name string) (*domain.User, error) {
@@ -79,6 +81,13 @@ func (m *mockUserRepository) FindByNameWithTx(ctx context.Context, tx repository
 	return nil, domain.ErrUserNotFound
 }
 
+func (m *mockUserRepository) FindByIDWithTx(ctx context.Context, tx repository.Tx, id int64) (*domain.User, error) {
+	if m.findByIDWithTxFn != nil {
+		return m.findByIDWithTxFn(ctx, tx, id)
+	}
+	return nil, domain.ErrUserNotFound
+}
+
 func (m *mockUserRepository) SaveWithTx(ctx context.Context, tx repository.Tx, user *domain.User) error {
 	if m.saveWithTxFn != nil {
 		return m.saveWithTxFn(ctx, tx, user)
@@ -90,6 +99,13 @@ func (m *mockUserRepository) SaveWithTx(ctx context.Context, tx repository.Tx, u
 	return nil
 }
 
+func (m *mockUserRepository) UpdateWithTx(ctx context.Context, tx repository.Tx, user *domain.User) error {
+	if m.updateWithTxFn != nil {
+		return m.updateWithTxFn(ctx, tx, user)
+	}
+	return nil
+}
+
 // Mock SessionRepository
 type mockSessionRepository struct {
 	saveFn                     func(ctx context.Context, session *domain.Session) error
@@ -97,10 +113,15 @@ type mockSessionRepository struct {
 	findByIDFn                 func(ctx context.Context, id int64) (*domain.Session, error)
 	findActiveByUserIDFn       func(ctx context.Context, userID int64) (*domain.Session, error)
 	updateFn                   func(ctx context.Context, session *domain.Session) error
+	updateWithTxFn             func(ctx context.Context, tx repository.Tx, session *domain.Session) error
 	listByUserIDFn             func(ctx context.Context, userID int64, limit, offset int32) ([]*domain.Session, error)
 	findActiveByUserIDWithTxFn func(ctx context.Context, tx repository.Tx, userID int64) (*domain.Session, error)
 	createWithTxFn             func(ctx context.Context, tx repository.Tx, session *domain.Session) error
 	findAllActiveFn            func(ctx context.Context) ([]domain.SessionInfo, error)
+	findByIDWithTxFn           func(ctx context.Context, tx repository.Tx, id int64) (*domain.Session, error)
+	findOverlappingWithTxFn    func(ctx context.Context, tx repository.Tx, userID, excludeID int64, start time.Time, end *time.Time) ([]*domain.Session, error)
+	correctWithTxFn            func(ctx context.Context, tx repository.Tx, session *domain.Session) error
+	deleteWithTxFn             func(ctx context.Context, tx repository.Tx, id int64) error
 }
 
 func (m *mockSessionRepository) Save(ctx context.Context, session *domain.Session) error {
@@ -146,6 +167,13 @@ func (m *mockSessionRepository) Update(ctx context.Context, session *domain.Sess
 	return nil
 }
 
+func (m *mockSessionRepository) UpdateWithTx(ctx context.Context, tx repository.Tx, session *domain.Session) error {
+	if m.updateWithTxFn != nil {
+		return m.updateWithTxFn(ctx, tx, session)
+	}
+	return nil
+}
+
 func (m *mockSessionRepository) ListByUserID(ctx context.Context, userID int64, limit, offset int32) ([]*domain.Session, error) {
 	if m.listByUserIDFn != nil {
 		return m.listByUserIDFn(ctx, userID, limit, offset)
@@ -171,6 +199,34 @@ func (m *mockSessionRepository) CreateWithTx(ctx context.Context, tx repository.
 	return nil
 }
 
+func (m *mockSessionRepository) FindByIDWithTx(ctx context.Context, tx repository.Tx, id int64) (*domain.Session, error) {
+	if m.findByIDWithTxFn != nil {
+		return m.findByIDWithTxFn(ctx, tx, id)
+	}
+	return nil, domain.ErrSessionNotFound
+}
+
+func (m *mockSessionRepository) FindOverlappingWithTx(ctx context.Context, tx repository.Tx, userID, excludeID int64, start time.Time, end *time.Time) ([]*domain.Session, error) {
+	if m.findOverlappingWithTxFn != nil {
+		return m.findOverlappingWithTxFn(ctx, tx, userID, excludeID, start, end)
+	}
+	return nil, nil
+}
+
+func (m *mockSessionRepository) CorrectWithTx(ctx context.Context, tx repository.Tx, session *domain.Session) error {
+	if m.correctWithTxFn != nil {
+		return m.correctWithTxFn(ctx, tx, session)
+	}
+	return nil
+}
+
+func (m *mockSessionRepository) DeleteWithTx(ctx context.Context, tx repository.Tx, id int64) error {
+	if m.deleteWithTxFn != nil {
+		return m.deleteWithTxFn(ctx, tx, id)
+	}
+	return nil
+}
+
 func (m *mockSessionRepository) FindAllActive(ctx context.Context) ([]domain.SessionInfo, error) {
 	if m.findAllActiveFn != nil {
 		return m.findAllActiveFn(ctx)
@@ -178,6 +234,10 @@ func (m *mockSessionRepository) FindAllActive(ctx context.Context) ([]domain.Ses
 	return []domain.SessionInfo{}, nil
 }
 
+func (m *mockSessionRepository) CountActive(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
 func (m *mockSessionRepository) FindByUserIDAndDateRange(ctx context.Context, userID int64, startTime, endTime time.Time) ([]*domain.Session, error) {
 	return nil, nil
 }
@@ -187,7 +247,7 @@ func TestJoinCommand_NewUser(t *testing.T) {
 	userRepository := &mockUserRepository{}
 	sessionRepository := &mockSessionRepository{}
 
-	uc := NewJoinCommandUseCase(userRepository, sessionRepository, NoOpBroadcaster{}, NoOpExpirationScheduler{})
+	uc := NewJoinCommandUseCase(userRepository, sessionRepository, NoOpEventOutbox{}, NoOpExpirationScheduler{}, NoOpRateLimiter{}, NoOpTextModerator{}, NoOpIconPicker{}, DefaultSessionSettings())
 
 	input := JoinCommandInput{
 		UserName: "yamada",
@@ -232,7 +292,7 @@ func TestJoinCommand_ExistingUser(t *testing.T) {
 	}
 	sessionRepo := &mockSessionRepository{}
 
-	uc := NewJoinCommandUseCase(userRepo, sessionRepo, NoOpBroadcaster{}, NoOpExpirationScheduler{})
+	uc := NewJoinCommandUseCase(userRepo, sessionRepo, NoOpEventOutbox{}, NoOpExpirationScheduler{}, NoOpRateLimiter{}, NoOpTextModerator{}, NoOpIconPicker{}, DefaultSessionSettings())
 
 	input := JoinCommandInput{
 		UserName: "yamada",
@@ -286,7 +346,7 @@ func TestJoinCommand_AlreadyActiveSession(t *testing.T) {
 		},
 	}
 
-	uc := NewJoinCommandUseCase(userRepo, sessionRepo, NoOpBroadcaster{}, NoOpExpirationScheduler{})
+	uc := NewJoinCommandUseCase(userRepo, sessionRepo, NoOpEventOutbox{}, NoOpExpirationScheduler{}, NoOpRateLimiter{}, NoOpTextModerator{}, NoOpIconPicker{}, DefaultSessionSettings())
 
 	input := JoinCommandInput{
 		UserName: "yamada",
@@ -306,3 +366,195 @@ func TestJoinCommand_AlreadyActiveSession(t *testing.T) {
 		t.Errorf("expected output to be nil when error occurs, got %+v", output)
 	}
 }
+
+func TestJoinCommand_BannedTermRejected(t *testing.T) {
+	userRepo := &mockUserRepository{
+		beginTxFn: func(ctx context.Context) (repository.Tx, error) {
+			t.Error("transaction should not be started when the work name is rejected")
+			return &mockTx{}, nil
+		},
+	}
+	moderator := &mockTextModerator{
+		moderateWorkNameFn: func(ctx context.Context, userName, workName string) (string, error) {
+			return "", domain.ErrBannedTermDetected
+		},
+	}
+
+	uc := NewJoinCommandUseCase(userRepo, &mockSessionRepository{}, NoOpEventOutbox{}, NoOpExpirationScheduler{}, NoOpRateLimiter{}, moderator, NoOpIconPicker{}, DefaultSessionSettings())
+
+	output, err := uc.Execute(context.Background(), JoinCommandInput{UserName: "yamada", WorkName: "spam"})
+	if !errors.Is(err, domain.ErrBannedTermDetected) {
+		t.Errorf("expected ErrBannedTermDetected, got %v", err)
+	}
+	if output != nil {
+		t.Errorf("expected output to be nil when error occurs, got %+v", output)
+	}
+}
+
+func TestJoinCommand_MaskedWorkName(t *testing.T) {
+	var created *domain.Session
+	sessionRepo := &mockSessionRepository{
+		createWithTxFn: func(ctx context.Context, tx repository.Tx, session *domain.Session) error {
+			created = session
+			session.ID = 100
+			return nil
+		},
+	}
+	moderator := &mockTextModerator{
+		moderateWorkNameFn: func(ctx context.Context, userName, workName string) (string, error) {
+			return "**の勉強", nil
+		},
+	}
+
+	uc := NewJoinCommandUseCase(&mockUserRepository{}, sessionRepo, NoOpEventOutbox{}, NoOpExpirationScheduler{}, NoOpRateLimiter{}, moderator, NoOpIconPicker{}, DefaultSessionSettings())
+
+	output, err := uc.Execute(context.Background(), JoinCommandInput{UserName: "yamada", WorkName: "ばかの勉強"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.WorkName != "**の勉強" || created == nil || created.WorkName != "**の勉強" {
+		t.Errorf("expected masked work name to be stored, got output=%q", output.WorkName)
+	}
+}
+
+func TestJoinCommand_BlockedUser(t *testing.T) {
+	blockedUser := &domain.User{
+		ID:        42,
+		Name:      "troll",
+		Tier:      domain.Tier1,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	_ = blockedUser.Block("spam", "moderator", time.Hour, time.Now)
+
+	rolledBack := false
+	userRepo := &mockUserRepository{
+		beginTxFn: func(ctx context.Context) (repository.Tx, error) {
+			return &mockTx{rollbackFn: func(ctx context.Context) error {
+				rolledBack = true
+				return nil
+			}}, nil
+		},
+		findByNameWithTxFn: func(ctx context.Context, tx repository.Tx, name string) (*domain.User, error) {
+			return blockedUser, nil
+		},
+	}
+	sessionRepo := &mockSessionRepository{
+		createWithTxFn: func(ctx context.Context, tx repository.Tx, session *domain.Session) error {
+			t.Error("session should not be created for a blocked user")
+			return nil
+		},
+	}
+
+	uc := NewJoinCommandUseCase(userRepo, sessionRepo, NoOpEventOutbox{}, NoOpExpirationScheduler{}, NoOpRateLimiter{}, NoOpTextModerator{}, NoOpIconPicker{}, DefaultSessionSettings())
+
+	_, err := uc.Execute(context.Background(), JoinCommandInput{UserName: "troll", WorkName: "作業"})
+	if !errors.Is(err, domain.ErrUserBlocked) {
+		t.Errorf("expected ErrUserBlocked, got %v", err)
+	}
+	if !rolledBack {
+		t.Error("expected transaction to be rolled back")
+	}
+}
+
+// Mock IconPicker
+type mockIconPicker struct {
+	pickIconFn func(ctx context.Context, userID int64, tier domain.Tier) (*domain.Icon, error)
+}
+
+func (m *mockIconPicker) PickIcon(ctx context.Context, userID int64, tier domain.Tier) (*domain.Icon, error) {
+	return m.pickIconFn(ctx, userID, tier)
+}
+
+func TestJoinCommand_AssignsIcon(t *testing.T) {
+	existingUser := &domain.User{ID: 42, Name: "yamada", Tier: domain.Tier2}
+	userRepo := &mockUserRepository{
+		findByNameWithTxFn: func(ctx context.Context, tx repository.Tx, name string) (*domain.User, error) {
+			return existingUser, nil
+		},
+	}
+	var created *domain.Session
+	sessionRepo := &mockSessionRepository{
+		createWithTxFn: func(ctx context.Context, tx repository.Tx, session *domain.Session) error {
+			session.ID = 1
+			created = session
+			return nil
+		},
+	}
+	var broadcast SessionStartBroadcast
+	outbox := &mockEventOutbox{
+		recordSessionStartFn: func(tx repository.Tx, event SessionStartBroadcast) error {
+			broadcast = event
+			return nil
+		},
+	}
+	picker := &mockIconPicker{
+		pickIconFn: func(ctx context.Context, userID int64, tier domain.Tier) (*domain.Icon, error) {
+			if userID != 42 || tier != domain.Tier2 {
+				t.Errorf("unexpected pick for user %d tier %v", userID, tier)
+			}
+			return &domain.Icon{ID: 7, Tier: domain.Tier2, AssetKey: "tier2-03"}, nil
+		},
+	}
+
+	uc := NewJoinCommandUseCase(userRepo, sessionRepo, outbox, NoOpExpirationScheduler{}, NoOpRateLimiter{}, NoOpTextModerator{}, picker, DefaultSessionSettings())
+	if _, err := uc.Execute(context.Background(), JoinCommandInput{UserName: "yamada", WorkName: "作業"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if created == nil || created.IconID == nil || *created.IconID != 7 {
+		t.Fatalf("expected session to be created with icon 7, got %+v", created)
+	}
+	if broadcast.IconID == nil || *broadcast.IconID != 7 {
+		t.Errorf("expected session_start icon_id 7, got %v", broadcast.IconID)
+	}
+	if broadcast.IconAssetKey == nil || *broadcast.IconAssetKey != "tier2-03" {
+		t.Errorf("expected session_start icon asset key tier2-03, got %v", broadcast.IconAssetKey)
+	}
+}
+
+func TestJoinCommand_NoIconAvailable(t *testing.T) {
+	var created *domain.Session
+	sessionRepo := &mockSessionRepository{
+		createWithTxFn: func(ctx context.Context, tx repository.Tx, session *domain.Session) error {
+			created = session
+			return nil
+		},
+	}
+
+	uc := NewJoinCommandUseCase(&mockUserRepository{}, sessionRepo, NoOpEventOutbox{}, NoOpExpirationScheduler{}, NoOpRateLimiter{}, NoOpTextModerator{}, NoOpIconPicker{}, DefaultSessionSettings())
+	if _, err := uc.Execute(context.Background(), JoinCommandInput{UserName: "yamada", WorkName: "作業"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created == nil || created.IconID != nil {
+		t.Errorf("expected session without icon, got %+v", created)
+	}
+}
+
+// Mock TextModerator
+type mockTextModerator struct {
+	moderateUserNameFn func(ctx context.Context, userName string) error
+	moderateWorkNameFn func(ctx context.Context, userName, workName string) (string, error)
+	moderateCommentFn  func(ctx context.Context, userName, comment string) (string, error)
+}
+
+func (m *mockTextModerator) ModerateUserName(ctx context.Context, userName string) error {
+	if m.moderateUserNameFn != nil {
+		return m.moderateUserNameFn(ctx, userName)
+	}
+	return nil
+}
+
+func (m *mockTextModerator) ModerateWorkName(ctx context.Context, userName, workName string) (string, error) {
+	if m.moderateWorkNameFn != nil {
+		return m.moderateWorkNameFn(ctx, userName, workName)
+	}
+	return workName, nil
+}
+
+func (m *mockTextModerator) ModerateComment(ctx context.Context, userName, comment string) (string, error) {
+	if m.moderateCommentFn != nil {
+		return m.moderateCommentFn(ctx, userName, comment)
+	}
+	return comment, nil
+}
@@ -0,0 +1,74 @@
+package command
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
+)
+
+// KickAllCommandInput represents the input for ending all active sessions
+type KickAllCommandInput struct {
+	Actor  string
+	Reason string
+}
+
+// KickAllCommandOutput represents the output of ending all active sessions
+type KickAllCommandOutput struct {
+	SessionIDs []int64 // sessions ended by this request
+	Failed     int     // sessions that could not be ended
+}
+
+// KickAllCommandUseCase ends every active session on behalf of an admin
+// Each session goes through the force-out flow, so each one is broadcast and audited individually
+type KickAllCommandUseCase struct {
+	sessionRepository repository.SessionRepository
+	forceOut          *ForceOutCommandUseCase
+}
+
+// NewKickAllCommandUseCase creates a new kick-all use case
+func NewKickAllCommandUseCase(
+	sessionRepository repository.SessionRepository,
+	forceOut *ForceOutCommandUseCase,
+) *KickAllCommandUseCase {
+	return &KickAllCommandUseCase{
+		sessionRepository: sessionRepository,
+		forceOut:          forceOut,
+	}
+}
+
+// Execute executes the kick-all
+func (uc *KickAllCommandUseCase) Execute(ctx context.Context, input KickAllCommandInput) (_ *KickAllCommandOutput, err error) {
+	ctx, span := tracing.Start(ctx, "KickAllCommandUseCase.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	// 1. Find all active sessions
+	sessions, err := uc.sessionRepository.FindAllActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Force-out each session (sessions that ended in the meantime are skipped)
+	output := &KickAllCommandOutput{SessionIDs: make([]int64, 0, len(sessions))}
+	for _, s := range sessions {
+		_, err := uc.forceOut.Execute(ctx, ForceOutCommandInput{
+			SessionID: s.SessionID,
+			Actor:     input.Actor,
+			Reason:    input.Reason,
+		})
+		switch {
+		case err == nil:
+			output.SessionIDs = append(output.SessionIDs, s.SessionID)
+		case errors.Is(err, domain.ErrSessionAlreadyCompleted), errors.Is(err, domain.ErrSessionNotFound):
+			continue
+		default:
+			logging.FromContext(ctx).Error("failed to force-out session", logging.KeySessionID, s.SessionID, logging.Err(err))
+			output.Failed++
+		}
+	}
+
+	return output, nil
+}
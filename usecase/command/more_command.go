@@ -6,18 +6,20 @@ import (
 
 	"github.com/yamada-ai/workspace-backend/domain"
 	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
 )
 
 const (
-	// MinExtensionMinutes 最小延長時間（分）
+	// MinExtensionMinutes 最小延長時間の既定値（分、SessionSettings で変更できる）
 	MinExtensionMinutes = 1
-	// MaxExtensionMinutes 最大延長時間（分）
+	// MaxExtensionMinutes 最大延長時間の既定値（分）
 	MaxExtensionMinutes = 360
 )
 
 // ExpirationRescheduler defines the interface for rescheduling session expiration
 type ExpirationRescheduler interface {
-	RescheduleExpiration(sessionID int64, userID int64, newPlannedEnd time.Time)
+	RescheduleExpiration(ctx context.Context, sessionID int64, userID int64, newPlannedEnd time.Time)
 }
 
 // MoreCommandInput represents the input for more command
@@ -38,8 +40,10 @@ type MoreCommandOutput struct {
 type MoreCommandUseCase struct {
 	userRepository      repository.UserRepository
 	sessionRepository   repository.SessionRepository
-	broadcaster         EventBroadcaster
+	outbox              EventOutbox
 	expirationScheduler ExpirationRescheduler
+	rateLimiter         RateLimiter
+	settings            SessionSettingsSource
 	now                 func() time.Time
 }
 
@@ -47,23 +51,33 @@ type MoreCommandUseCase struct {
 func NewMoreCommandUseCase(
 	userRepository repository.UserRepository,
 	sessionRepository repository.SessionRepository,
-	broadcaster EventBroadcaster,
+	outbox EventOutbox,
 	expirationScheduler ExpirationRescheduler,
+	rateLimiter RateLimiter,
+	settings SessionSettingsSource,
 ) *MoreCommandUseCase {
 	return &MoreCommandUseCase{
 		userRepository:      userRepository,
 		sessionRepository:   sessionRepository,
-		broadcaster:         broadcaster,
+		outbox:              outbox,
 		expirationScheduler: expirationScheduler,
+		rateLimiter:         rateLimiter,
+		settings:            settings,
 		now:                 func() time.Time { return time.Now().UTC() },
 	}
 }
 
 // Execute executes the more command
-func (uc *MoreCommandUseCase) Execute(ctx context.Context, input MoreCommandInput) (*MoreCommandOutput, error) {
+func (uc *MoreCommandUseCase) Execute(ctx context.Context, input MoreCommandInput) (_ *MoreCommandOutput, err error) {
+	ctx, span := tracing.Start(ctx, "MoreCommandUseCase.Execute", tracing.KeyUserName.String(input.UserName))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, logging.KeyUserName, input.UserName)
+
 	// 1. Validate minutes
-	if input.Minutes < MinExtensionMinutes || input.Minutes > MaxExtensionMinutes {
-		return nil, domain.ErrInvalidExtension
+	settings := uc.settings.SessionSettings()
+	if input.Minutes < settings.MinExtensionMinutes || input.Minutes > settings.MaxExtensionMinutes {
+		return nil, &ExtensionRangeError{Minutes: input.Minutes, Min: settings.MinExtensionMinutes, Max: settings.MaxExtensionMinutes}
 	}
 
 	// 2. Find user
@@ -72,32 +86,45 @@ func (uc *MoreCommandUseCase) Execute(ctx context.Context, input MoreCommandInpu
 		return nil, err
 	}
 
-	// 3. Find active session
+	// 3. Refuse blocked users
+	if user.IsBlocked(uc.now) {
+		return nil, domain.ErrUserBlocked
+	}
+
+	// 4. Check rate limit
+	if err := uc.rateLimiter.Allow(ctx, user.Name, user.Tier, CommandMore); err != nil {
+		return nil, err
+	}
+
+	// 5. Find active session
 	session, err := uc.sessionRepository.FindActiveByUserID(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
+	ctx = logging.With(ctx, logging.KeySessionID, session.ID)
+	span.SetAttributes(tracing.KeySessionID.Int64(session.ID))
 
-	// 4. Extend the session
+	// 6. Extend the session
 	duration := time.Duration(input.Minutes) * time.Minute
 	if err := session.Extend(duration, uc.now); err != nil {
 		return nil, err
 	}
 
-	// 5. Update session in database
-	if err := uc.sessionRepository.Update(ctx, session); err != nil {
-		return nil, err
-	}
-
-	// 6. Broadcast session extension event to all connected WebSocket clients
-	uc.broadcaster.BroadcastSessionExtend(SessionExtendBroadcast{
+	// 7. Update session and record the extension event in one transaction
+	if err := uc.saveWithEvent(ctx, session, SessionExtendBroadcast{
 		SessionID:     session.ID,
 		UserID:        user.ID,
 		NewPlannedEnd: session.PlannedEnd,
-	})
+	}); err != nil {
+		return nil, err
+	}
+
+	// 8. Wake up the outbox dispatcher to broadcast the event
+	uc.outbox.Notify()
 
-	// 7. Reschedule expiration timer
-	uc.expirationScheduler.RescheduleExpiration(session.ID, user.ID, session.PlannedEnd)
+	// 9. Reschedule expiration timer
+	uc.expirationScheduler.RescheduleExpiration(ctx, session.ID, user.ID, session.PlannedEnd)
+	logging.FromContext(ctx).Info("session extended", "minutes", input.Minutes, "planned_end", session.PlannedEnd)
 
 	return &MoreCommandOutput{
 		SessionID:  session.ID,
@@ -106,3 +133,24 @@ func (uc *MoreCommandUseCase) Execute(ctx context.Context, input MoreCommandInpu
 		PlannedEnd: session.PlannedEnd,
 	}, nil
 }
+
+// saveWithEvent updates the session and records the event atomically
+func (uc *MoreCommandUseCase) saveWithEvent(ctx context.Context, session *domain.Session, event SessionExtendBroadcast) (err error) {
+	tx, err := uc.userRepository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = uc.sessionRepository.UpdateWithTx(ctx, tx, session); err != nil {
+		return err
+	}
+	if err = uc.outbox.RecordSessionExtend(ctx, tx, event); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
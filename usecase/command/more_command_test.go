@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
 )
 
 func TestMoreCommand_Success(t *testing.T) {
@@ -49,7 +50,7 @@ func TestMoreCommand_Success(t *testing.T) {
 			}
 			return nil, domain.ErrSessionNotFound
 		},
-		updateFn: func(ctx context.Context, session *domain.Session) error {
+		updateWithTxFn: func(ctx context.Context, tx repository.Tx, session *domain.Session) error {
 			sessionUpdated = true
 			return nil
 		},
@@ -65,7 +66,7 @@ func TestMoreCommand_Success(t *testing.T) {
 		},
 	}
 
-	uc := NewMoreCommandUseCase(userRepo, sessionRepo, NoOpBroadcaster{}, expirationScheduler)
+	uc := NewMoreCommandUseCase(userRepo, sessionRepo, NoOpEventOutbox{}, expirationScheduler, NoOpRateLimiter{}, DefaultSessionSettings())
 
 	input := MoreCommandInput{
 		UserName: "yamada",
@@ -102,12 +103,28 @@ func TestMoreCommand_Success(t *testing.T) {
 	}
 }
 
+func TestMoreCommand_ConfiguredRange(t *testing.T) {
+	settings := DefaultSessionSettings()
+	settings.MaxExtensionMinutes = 30
+	uc := NewMoreCommandUseCase(&mockUserRepository{}, &mockSessionRepository{}, NoOpEventOutbox{}, &mockExpirationRescheduler{}, NoOpRateLimiter{}, settings)
+
+	_, err := uc.Execute(context.Background(), MoreCommandInput{UserName: "yamada", Minutes: 45})
+
+	var rangeErr *ExtensionRangeError
+	if !errors.As(err, &rangeErr) || !errors.Is(err, domain.ErrInvalidExtension) {
+		t.Fatalf("expected ExtensionRangeError, got %v", err)
+	}
+	if rangeErr.Min != 1 || rangeErr.Max != 30 {
+		t.Errorf("expected the configured range 1-30, got %d-%d", rangeErr.Min, rangeErr.Max)
+	}
+}
+
 func TestMoreCommand_InvalidMinutes_TooSmall(t *testing.T) {
 	userRepo := &mockUserRepository{}
 	sessionRepo := &mockSessionRepository{}
 	expirationScheduler := &mockExpirationRescheduler{}
 
-	uc := NewMoreCommandUseCase(userRepo, sessionRepo, NoOpBroadcaster{}, expirationScheduler)
+	uc := NewMoreCommandUseCase(userRepo, sessionRepo, NoOpEventOutbox{}, expirationScheduler, NoOpRateLimiter{}, DefaultSessionSettings())
 
 	input := MoreCommandInput{
 		UserName: "yamada",
@@ -133,7 +150,7 @@ func TestMoreCommand_InvalidMinutes_TooLarge(t *testing.T) {
 	sessionRepo := &mockSessionRepository{}
 	expirationScheduler := &mockExpirationRescheduler{}
 
-	uc := NewMoreCommandUseCase(userRepo, sessionRepo, NoOpBroadcaster{}, expirationScheduler)
+	uc := NewMoreCommandUseCase(userRepo, sessionRepo, NoOpEventOutbox{}, expirationScheduler, NoOpRateLimiter{}, DefaultSessionSettings())
 
 	input := MoreCommandInput{
 		UserName: "yamada",
@@ -163,7 +180,7 @@ func TestMoreCommand_UserNotFound(t *testing.T) {
 	sessionRepo := &mockSessionRepository{}
 	expirationScheduler := &mockExpirationRescheduler{}
 
-	uc := NewMoreCommandUseCase(userRepo, sessionRepo, NoOpBroadcaster{}, expirationScheduler)
+	uc := NewMoreCommandUseCase(userRepo, sessionRepo, NoOpEventOutbox{}, expirationScheduler, NoOpRateLimiter{}, DefaultSessionSettings())
 
 	input := MoreCommandInput{
 		UserName: "nonexistent",
@@ -210,7 +227,7 @@ func TestMoreCommand_NoActiveSession(t *testing.T) {
 
 	expirationScheduler := &mockExpirationRescheduler{}
 
-	uc := NewMoreCommandUseCase(userRepo, sessionRepo, NoOpBroadcaster{}, expirationScheduler)
+	uc := NewMoreCommandUseCase(userRepo, sessionRepo, NoOpEventOutbox{}, expirationScheduler, NoOpRateLimiter{}, DefaultSessionSettings())
 
 	input := MoreCommandInput{
 		UserName: "yamada",
@@ -231,12 +248,96 @@ func TestMoreCommand_NoActiveSession(t *testing.T) {
 	}
 }
 
+func TestMoreCommand_BlockedUser(t *testing.T) {
+	blockedUser := &domain.User{
+		ID:        42,
+		Name:      "troll",
+		Tier:      domain.Tier1,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	_ = blockedUser.Block("spam", "moderator", time.Hour, time.Now)
+
+	userRepo := &mockUserRepository{
+		findByNameFn: func(ctx context.Context, name string) (*domain.User, error) {
+			return blockedUser, nil
+		},
+	}
+	sessionRepo := &mockSessionRepository{
+		updateWithTxFn: func(ctx context.Context, tx repository.Tx, session *domain.Session) error {
+			t.Error("session should not be extended for a blocked user")
+			return nil
+		},
+	}
+
+	uc := NewMoreCommandUseCase(userRepo, sessionRepo, NoOpEventOutbox{}, &mockExpirationRescheduler{}, NoOpRateLimiter{}, DefaultSessionSettings())
+
+	_, err := uc.Execute(context.Background(), MoreCommandInput{UserName: "troll", Minutes: 30})
+	if !errors.Is(err, domain.ErrUserBlocked) {
+		t.Errorf("expected ErrUserBlocked, got %v", err)
+	}
+}
+
+func TestMoreCommand_RecordEventFailure(t *testing.T) {
+	now := time.Now()
+	userRepo := &mockUserRepository{
+		findByNameFn: func(ctx context.Context, name string) (*domain.User, error) {
+			return &domain.User{ID: 42, Name: "yamada", Tier: domain.Tier1}, nil
+		},
+	}
+
+	committed, rolledBack := false, false
+	userRepo.beginTxFn = func(ctx context.Context) (repository.Tx, error) {
+		return &mockTx{
+			commitFn: func(ctx context.Context) error {
+				committed = true
+				return nil
+			},
+			rollbackFn: func(ctx context.Context) error {
+				rolledBack = true
+				return nil
+			},
+		}, nil
+	}
+
+	sessionRepo := &mockSessionRepository{
+		findActiveByUserIDFn: func(ctx context.Context, userID int64) (*domain.Session, error) {
+			return &domain.Session{ID: 99, UserID: 42, StartTime: now, PlannedEnd: now.Add(time.Hour)}, nil
+		},
+	}
+
+	errOutbox := errors.New("outbox unavailable")
+	events := &mockEventOutbox{
+		recordSessionExtendFn: func(tx repository.Tx, event SessionExtendBroadcast) error {
+			return errOutbox
+		},
+	}
+	rescheduler := &mockExpirationRescheduler{
+		rescheduleExpirationFn: func(sessionID int64, userID int64, newPlannedEnd time.Time) {
+			t.Error("expiration should not be rescheduled when the transaction fails")
+		},
+	}
+
+	uc := NewMoreCommandUseCase(userRepo, sessionRepo, events, rescheduler, NoOpRateLimiter{}, DefaultSessionSettings())
+
+	_, err := uc.Execute(context.Background(), MoreCommandInput{UserName: "yamada", Minutes: 30})
+	if !errors.Is(err, errOutbox) {
+		t.Fatalf("expected outbox error, got %v", err)
+	}
+	if committed || !rolledBack {
+		t.Errorf("expected transaction to be rolled back (committed=%v, rolledBack=%v)", committed, rolledBack)
+	}
+	if events.notified {
+		t.Error("dispatcher should not be notified when nothing was committed")
+	}
+}
+
 // Mock ExpirationRescheduler
 type mockExpirationRescheduler struct {
 	rescheduleExpirationFn func(sessionID int64, userID int64, newPlannedEnd time.Time)
 }
 
-func (m *mockExpirationRescheduler) RescheduleExpiration(sessionID int64, userID int64, newPlannedEnd time.Time) {
+func (m *mockExpirationRescheduler) RescheduleExpiration(ctx context.Context, sessionID int64, userID int64, newPlannedEnd time.Time) {
 	if m.rescheduleExpirationFn != nil {
 		m.rescheduleExpirationFn(sessionID, userID, newPlannedEnd)
 	}
@@ -0,0 +1,19 @@
+package command
+
+// Where a command came from (the source label of command metrics)
+const (
+	SourceHTTP = "http"
+	SourceChat = "chat"
+)
+
+// CommandObserver records the outcome of every command execution
+// err is nil when the command succeeded
+type CommandObserver interface {
+	ObserveCommand(source, command string, err error)
+}
+
+// NoOpCommandObserver is a no-op implementation of CommandObserver
+type NoOpCommandObserver struct{}
+
+// ObserveCommand does nothing
+func (NoOpCommandObserver) ObserveCommand(source, command string, err error) {}
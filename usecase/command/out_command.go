@@ -6,6 +6,8 @@ import (
 
 	"github.com/yamada-ai/workspace-backend/domain"
 	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
 )
 
 // CompleteSessionService defines the interface for completing sessions
@@ -15,7 +17,7 @@ type CompleteSessionService interface {
 
 // ExpirationCanceller defines the interface for cancelling session expiration
 type ExpirationCanceller interface {
-	CancelExpiration(sessionID int64)
+	CancelExpiration(ctx context.Context, sessionID int64)
 }
 
 // OutCommandInput represents the input for out command
@@ -36,6 +38,8 @@ type OutCommandUseCase struct {
 	sessionRepository   repository.SessionRepository
 	completeService     CompleteSessionService
 	expirationCanceller ExpirationCanceller
+	rateLimiter         RateLimiter
+	now                 func() time.Time
 }
 
 // NewOutCommandUseCase creates a new out command use case
@@ -44,36 +48,57 @@ func NewOutCommandUseCase(
 	sessionRepository repository.SessionRepository,
 	completeService CompleteSessionService,
 	expirationCanceller ExpirationCanceller,
+	rateLimiter RateLimiter,
 ) *OutCommandUseCase {
 	return &OutCommandUseCase{
 		userRepository:      userRepository,
 		sessionRepository:   sessionRepository,
 		completeService:     completeService,
 		expirationCanceller: expirationCanceller,
+		rateLimiter:         rateLimiter,
+		now:                 func() time.Time { return time.Now().UTC() },
 	}
 }
 
 // Execute executes the out command
-func (uc *OutCommandUseCase) Execute(ctx context.Context, input OutCommandInput) (*OutCommandOutput, error) {
+func (uc *OutCommandUseCase) Execute(ctx context.Context, input OutCommandInput) (_ *OutCommandOutput, err error) {
+	ctx, span := tracing.Start(ctx, "OutCommandUseCase.Execute", tracing.KeyUserName.String(input.UserName))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, logging.KeyUserName, input.UserName)
+
 	// 1. Find user
 	user, err := uc.userRepository.FindByName(ctx, input.UserName)
 	if err != nil {
 		return nil, err
 	}
 
-	// 2. Find active session
+	// 2. Refuse blocked users
+	if user.IsBlocked(uc.now) {
+		return nil, domain.ErrUserBlocked
+	}
+
+	// 3. Check rate limit
+	if err := uc.rateLimiter.Allow(ctx, user.Name, user.Tier, CommandOut); err != nil {
+		return nil, err
+	}
+
+	// 4. Find active session
 	session, err := uc.sessionRepository.FindActiveByUserID(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
+	ctx = logging.With(ctx, logging.KeySessionID, session.ID)
+	span.SetAttributes(tracing.KeySessionID.Int64(session.ID))
 
-	// 3. Complete the session using shared service (Complete + Update + Broadcast)
+	// 5. Complete the session using shared service (Complete + Update + Broadcast)
 	if err := uc.completeService.CompleteSession(ctx, session, user.ID); err != nil {
 		return nil, err
 	}
 
-	// 4. Cancel the automatic expiration timer
-	uc.expirationCanceller.CancelExpiration(session.ID)
+	// 6. Cancel the automatic expiration timer
+	uc.expirationCanceller.CancelExpiration(ctx, session.ID)
+	logging.FromContext(ctx).Info("session completed")
 
 	return &OutCommandOutput{
 		SessionID: session.ID,
@@ -0,0 +1,64 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+func TestOutCommand_BlockedUser(t *testing.T) {
+	blockedUser := &domain.User{
+		ID:        42,
+		Name:      "troll",
+		Tier:      domain.Tier1,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	_ = blockedUser.Block("spam", "moderator", 0, time.Now)
+
+	userRepo := &mockUserRepository{
+		findByNameFn: func(ctx context.Context, name string) (*domain.User, error) {
+			return blockedUser, nil
+		},
+	}
+	completeService := &mockCompleteSessionService{
+		completeSessionFn: func(ctx context.Context, session *domain.Session, userID int64) error {
+			t.Error("session should not be completed for a blocked user")
+			return nil
+		},
+	}
+
+	uc := NewOutCommandUseCase(userRepo, &mockSessionRepository{}, completeService, &mockExpirationCanceller{}, NoOpRateLimiter{})
+
+	output, err := uc.Execute(context.Background(), OutCommandInput{UserName: "troll"})
+	if !errors.Is(err, domain.ErrUserBlocked) {
+		t.Errorf("expected ErrUserBlocked, got %v", err)
+	}
+	if output != nil {
+		t.Errorf("expected output to be nil when error occurs, got %+v", output)
+	}
+}
+
+// Mock CompleteSessionService
+type mockCompleteSessionService struct {
+	completeSessionFn func(ctx context.Context, session *domain.Session, userID int64) error
+}
+
+func (m *mockCompleteSessionService) CompleteSession(ctx context.Context, session *domain.Session, userID int64) error {
+	if m.completeSessionFn != nil {
+		return m.completeSessionFn(ctx, session, userID)
+	}
+	return session.Complete(time.Now)
+}
+
+// Mock ExpirationCanceller
+type mockExpirationCanceller struct {
+	cancelled []int64
+}
+
+func (m *mockExpirationCanceller) CancelExpiration(ctx context.Context, sessionID int64) {
+	m.cancelled = append(m.cancelled, sessionID)
+}
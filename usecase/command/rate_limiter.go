@@ -0,0 +1,32 @@
+package command
+
+import (
+	"context"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// Command names used as rate limit keys
+const (
+	CommandJoin         = "in"
+	CommandOut          = "out"
+	CommandMore         = "more"
+	CommandChange       = "change"
+	CommandIconCreation = "icon_creation"
+	CommandCheer        = "cheer"
+	CommandComment      = "comment"
+)
+
+// RateLimiter defines the interface for limiting how often a user can run a command
+// 制限を超えた場合は ratelimit.ErrRateLimited に一致するエラーを返す
+type RateLimiter interface {
+	Allow(ctx context.Context, userName string, tier domain.Tier, command string) error
+}
+
+// NoOpRateLimiter is a no-op implementation of RateLimiter
+// Useful for testing or when rate limiting is disabled
+type NoOpRateLimiter struct{}
+
+func (NoOpRateLimiter) Allow(ctx context.Context, userName string, tier domain.Tier, command string) error {
+	return nil
+}
@@ -0,0 +1,287 @@
+package command
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
+
+// CreateManualSessionInput represents the input for adding a missing session record
+type CreateManualSessionInput struct {
+	UserName  string
+	WorkName  string
+	StartTime time.Time
+	ActualEnd time.Time
+	Actor     string
+	Reason    string
+}
+
+// CorrectSessionInput represents the input for correcting a session record
+// Nil fields are left unchanged
+type CorrectSessionInput struct {
+	SessionID int64
+	StartTime *time.Time
+	ActualEnd *time.Time
+	WorkName  *string
+	Actor     string
+	Reason    string
+}
+
+// DeleteSessionInput represents the input for deleting a bogus session record
+type DeleteSessionInput struct {
+	SessionID int64
+	Actor     string
+	Reason    string
+}
+
+// SessionCorrectionUseCase lets admins fix session records (server downtime, forgotten /out, bogus sessions)
+// Each change locks the user row (like /in does), checks the invariants in the domain layer,
+// and writes an audit log entry with the before/after state in the same transaction.
+// Corrections to an active session also record the event that updates the overlay
+type SessionCorrectionUseCase struct {
+	userRepository     repository.UserRepository
+	sessionRepository  repository.SessionRepository
+	auditLogRepository repository.AuditLogRepository
+	outbox             EventOutbox
+	now                func() time.Time
+}
+
+// NewSessionCorrectionUseCase creates a new session correction use case
+func NewSessionCorrectionUseCase(
+	userRepository repository.UserRepository,
+	sessionRepository repository.SessionRepository,
+	auditLogRepository repository.AuditLogRepository,
+	outbox EventOutbox,
+) *SessionCorrectionUseCase {
+	return &SessionCorrectionUseCase{
+		userRepository:     userRepository,
+		sessionRepository:  sessionRepository,
+		auditLogRepository: auditLogRepository,
+		outbox:             outbox,
+		now:                func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Create adds a completed session for an existing user
+func (uc *SessionCorrectionUseCase) Create(ctx context.Context, input CreateManualSessionInput) (*domain.Session, error) {
+	tx, err := uc.userRepository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// 1. Find and lock user
+	user, err := uc.userRepository.FindByNameWithTx(ctx, tx, input.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Build the session and check it against the user's other sessions
+	session, err := domain.NewManualSession(user.ID, input.WorkName, input.StartTime, input.ActualEnd, uc.now)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.checkConflicts(ctx, tx, session); err != nil {
+		return nil, err
+	}
+
+	// 3. Save session and audit log
+	if err = uc.sessionRepository.CreateWithTx(ctx, tx, session); err != nil {
+		return nil, err
+	}
+	if err = uc.audit(ctx, tx, input.Actor, domain.AuditActionSessionCreate, session.ID, nil, newSessionState(session), input.Reason); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Correct changes the start time, end time or work name of a session
+// The work name is validated like /in and /change. For an active session, the corrected start time
+// is sent as session_start (overlays replace the session with the same ID) and a corrected work name
+// as work_name_change, in the same transaction
+func (uc *SessionCorrectionUseCase) Correct(ctx context.Context, input CorrectSessionInput) (*domain.Session, error) {
+	tx, user, session, err := uc.lockSession(ctx, input.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// 1. Apply the correction (validates the period)
+	before := newSessionState(session)
+	err = session.Correct(domain.SessionCorrection{
+		StartTime: input.StartTime,
+		ActualEnd: input.ActualEnd,
+		WorkName:  input.WorkName,
+	}, uc.now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Check against the user's other sessions
+	if err = uc.checkConflicts(ctx, tx, session); err != nil {
+		return nil, err
+	}
+
+	// 3. Save session and audit log
+	if err = uc.sessionRepository.CorrectWithTx(ctx, tx, session); err != nil {
+		return nil, err
+	}
+	if err = uc.audit(ctx, tx, input.Actor, domain.AuditActionSessionCorrect, session.ID, before, newSessionState(session), input.Reason); err != nil {
+		return nil, err
+	}
+
+	// 4. Record the event that updates the overlay (completed sessions are no longer shown)
+	recorded := false
+	if session.IsActive() {
+		if recorded, err = uc.recordActiveCorrection(ctx, tx, user, before, session); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	if recorded {
+		uc.outbox.Notify()
+	}
+	return session, nil
+}
+
+// recordActiveCorrection records the event for a corrected active session and reports whether one was recorded
+// session_start carries every field the overlay shows, so it is used when the start time changed
+func (uc *SessionCorrectionUseCase) recordActiveCorrection(ctx context.Context, tx repository.Tx, user *domain.User, before *sessionState, session *domain.Session) (bool, error) {
+	switch {
+	case !session.StartTime.Equal(before.StartTime):
+		event := SessionStartBroadcast{
+			SessionID:  session.ID,
+			UserID:     user.ID,
+			UserName:   user.Name,
+			WorkName:   session.WorkName,
+			Tier:       int(user.Tier),
+			IconID:     session.IconID,
+			StartTime:  session.StartTime,
+			PlannedEnd: session.PlannedEnd,
+		}
+		iconAssetKey, err := uc.iconAssetKey(ctx, session.ID)
+		if err != nil {
+			return false, err
+		}
+		event.IconAssetKey = iconAssetKey
+		return true, uc.outbox.RecordSessionStart(ctx, tx, event)
+	case session.WorkName != before.WorkName:
+		return true, uc.outbox.RecordWorkNameChange(ctx, tx, WorkNameChangeBroadcast{
+			SessionID: session.ID,
+			UserID:    user.ID,
+			WorkName:  session.WorkName,
+		})
+	default:
+		return false, nil
+	}
+}
+
+// iconAssetKey looks up the asset key of the icon shown for an active session
+func (uc *SessionCorrectionUseCase) iconAssetKey(ctx context.Context, sessionID int64) (*string, error) {
+	active, err := uc.sessionRepository.FindAllActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range active {
+		if info.SessionID == sessionID {
+			return info.IconAssetKey, nil
+		}
+	}
+	return nil, nil
+}
+
+// Delete removes a completed session
+// Active sessions must be ended with force-out first so that the overlay and expiration timer stay consistent
+func (uc *SessionCorrectionUseCase) Delete(ctx context.Context, input DeleteSessionInput) error {
+	tx, _, session, err := uc.lockSession(ctx, input.SessionID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if session.IsActive() {
+		err = domain.ErrSessionActive
+		return err
+	}
+
+	if err = uc.sessionRepository.DeleteWithTx(ctx, tx, session.ID); err != nil {
+		return err
+	}
+	if err = uc.audit(ctx, tx, input.Actor, domain.AuditActionSessionDelete, session.ID, newSessionState(session), nil, input.Reason); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// lockSession starts a transaction, locks the session owner's user row and then the session row
+// The user row is locked first, in the same order as /in
+func (uc *SessionCorrectionUseCase) lockSession(ctx context.Context, sessionID int64) (repository.Tx, *domain.User, *domain.Session, error) {
+	current, err := uc.sessionRepository.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tx, err := uc.userRepository.BeginTx(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	user, err := uc.userRepository.FindByIDWithTx(ctx, tx, current.UserID)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, nil, nil, err
+	}
+	session, err := uc.sessionRepository.FindByIDWithTx(ctx, tx, sessionID)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, nil, nil, err
+	}
+	return tx, user, session, nil
+}
+
+func (uc *SessionCorrectionUseCase) checkConflicts(ctx context.Context, tx repository.Tx, session *domain.Session) error {
+	others, err := uc.sessionRepository.FindOverlappingWithTx(ctx, tx, session.UserID, session.ID, session.StartTime, session.ActualEnd)
+	if err != nil {
+		return err
+	}
+	return domain.CheckSessionConflicts(session, others)
+}
+
+func (uc *SessionCorrectionUseCase) audit(ctx context.Context, tx repository.Tx, actor, action string, sessionID int64, before, after *sessionState, reason string) error {
+	auditLog, err := domain.NewAuditLog(
+		actor,
+		action,
+		domain.AuditTargetSession,
+		strconv.FormatInt(sessionID, 10),
+		before,
+		after,
+		reason,
+		uc.now,
+	)
+	if err != nil {
+		return err
+	}
+	return uc.auditLogRepository.SaveWithTx(ctx, tx, auditLog)
+}
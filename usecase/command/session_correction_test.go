@@ -0,0 +1,321 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
+
+func newCompletedSession(id, userID int64, from, to time.Duration) *domain.Session {
+	now := time.Now().UTC()
+	end := now.Add(to)
+	return &domain.Session{
+		ID:         id,
+		UserID:     userID,
+		WorkName:   "作業",
+		StartTime:  now.Add(from),
+		PlannedEnd: end,
+		ActualEnd:  &end,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+func TestSessionCorrection_Correct(t *testing.T) {
+	session := newCompletedSession(99, 42, -3*time.Hour, -2*time.Hour)
+
+	committed := false
+	userRepo := &mockUserRepository{
+		beginTxFn: func(ctx context.Context) (repository.Tx, error) {
+			return &mockTx{commitFn: func(ctx context.Context) error {
+				committed = true
+				return nil
+			}}, nil
+		},
+		findByIDWithTxFn: func(ctx context.Context, tx repository.Tx, id int64) (*domain.User, error) {
+			return &domain.User{ID: id, Name: "yamada"}, nil
+		},
+	}
+
+	var saved *domain.Session
+	sessionRepo := &mockSessionRepository{
+		findByIDFn: func(ctx context.Context, id int64) (*domain.Session, error) {
+			return session, nil
+		},
+		findByIDWithTxFn: func(ctx context.Context, tx repository.Tx, id int64) (*domain.Session, error) {
+			return session, nil
+		},
+		findOverlappingWithTxFn: func(ctx context.Context, tx repository.Tx, userID, excludeID int64, start time.Time, end *time.Time) ([]*domain.Session, error) {
+			if userID != 42 || excludeID != 99 || end == nil {
+				t.Errorf("unexpected overlap query: user=%d exclude=%d end=%v", userID, excludeID, end)
+			}
+			return nil, nil
+		},
+		correctWithTxFn: func(ctx context.Context, tx repository.Tx, s *domain.Session) error {
+			saved = s
+			return nil
+		},
+	}
+	auditRepo := &mockAuditLogRepository{}
+
+	uc := NewSessionCorrectionUseCase(userRepo, sessionRepo, auditRepo, &mockEventOutbox{})
+
+	newEnd := session.StartTime.Add(90 * time.Minute)
+	result, err := uc.Correct(context.Background(), CorrectSessionInput{
+		SessionID: 99,
+		ActualEnd: &newEnd,
+		Actor:     "moderator",
+		Reason:    "/out し忘れ",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved == nil || !result.ActualEnd.Equal(newEnd) {
+		t.Errorf("expected corrected session to be saved, got %+v", result)
+	}
+	if !committed {
+		t.Error("expected transaction to be committed")
+	}
+
+	if len(auditRepo.logs) != 1 {
+		t.Fatalf("expected 1 audit log, got %d", len(auditRepo.logs))
+	}
+	entry := auditRepo.logs[0]
+	if entry.Action != domain.AuditActionSessionCorrect || entry.TargetID != "99" || entry.Actor != "moderator" {
+		t.Errorf("unexpected audit log: %+v", entry)
+	}
+	if entry.Before == nil || entry.After == nil || string(entry.Before) == string(entry.After) {
+		t.Errorf("expected before/after state, got before=%s after=%s", entry.Before, entry.After)
+	}
+}
+
+func TestSessionCorrection_CorrectActive(t *testing.T) {
+	newActive := func() *domain.Session {
+		now := time.Now().UTC()
+		iconID := int64(5)
+		return &domain.Session{
+			ID:         99,
+			UserID:     42,
+			WorkName:   "作業",
+			IconID:     &iconID,
+			StartTime:  now.Add(-30 * time.Minute),
+			PlannedEnd: now.Add(30 * time.Minute),
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+	}
+	setup := func(session *domain.Session, events *mockEventOutbox) *SessionCorrectionUseCase {
+		userRepo := &mockUserRepository{
+			beginTxFn: func(ctx context.Context) (repository.Tx, error) {
+				return &mockTx{}, nil
+			},
+			findByIDWithTxFn: func(ctx context.Context, tx repository.Tx, id int64) (*domain.User, error) {
+				return &domain.User{ID: id, Name: "yamada", Tier: domain.Tier2}, nil
+			},
+		}
+		assetKey := "tier2-05"
+		sessionRepo := &mockSessionRepository{
+			findByIDFn: func(ctx context.Context, id int64) (*domain.Session, error) {
+				return session, nil
+			},
+			findByIDWithTxFn: func(ctx context.Context, tx repository.Tx, id int64) (*domain.Session, error) {
+				return session, nil
+			},
+			findOverlappingWithTxFn: func(ctx context.Context, tx repository.Tx, userID, excludeID int64, start time.Time, end *time.Time) ([]*domain.Session, error) {
+				return nil, nil
+			},
+			correctWithTxFn: func(ctx context.Context, tx repository.Tx, s *domain.Session) error {
+				return nil
+			},
+			findAllActiveFn: func(ctx context.Context) ([]domain.SessionInfo, error) {
+				return []domain.SessionInfo{{SessionID: 99, IconAssetKey: &assetKey}}, nil
+			},
+		}
+		return NewSessionCorrectionUseCase(userRepo, sessionRepo, &mockAuditLogRepository{}, events)
+	}
+
+	t.Run("開始時刻の修正は session_start で送り直す", func(t *testing.T) {
+		session := newActive()
+		var sent *SessionStartBroadcast
+		events := &mockEventOutbox{
+			recordSessionStartFn: func(tx repository.Tx, event SessionStartBroadcast) error {
+				sent = &event
+				return nil
+			},
+		}
+		uc := setup(session, events)
+
+		newStart := session.StartTime.Add(-time.Hour)
+		name := "資格勉強"
+		if _, err := uc.Correct(context.Background(), CorrectSessionInput{SessionID: 99, StartTime: &newStart, WorkName: &name, Actor: "moderator"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent == nil {
+			t.Fatal("expected session_start to be recorded")
+		}
+		if !sent.StartTime.Equal(newStart) || sent.WorkName != "資格勉強" || sent.UserName != "yamada" || sent.Tier != 2 ||
+			sent.IconAssetKey == nil || *sent.IconAssetKey != "tier2-05" || !sent.PlannedEnd.Equal(session.PlannedEnd) {
+			t.Errorf("unexpected event: %+v", sent)
+		}
+		if !events.notified {
+			t.Error("expected the dispatcher to be notified")
+		}
+	})
+
+	t.Run("作業名の修正は work_name_change で送る", func(t *testing.T) {
+		var sent *WorkNameChangeBroadcast
+		events := &mockEventOutbox{
+			recordWorkNameChangeFn: func(tx repository.Tx, event WorkNameChangeBroadcast) error {
+				sent = &event
+				return nil
+			},
+		}
+		uc := setup(newActive(), events)
+
+		name := " 資格勉強 "
+		if _, err := uc.Correct(context.Background(), CorrectSessionInput{SessionID: 99, WorkName: &name, Actor: "moderator"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent == nil || sent.SessionID != 99 || sent.UserID != 42 || sent.WorkName != "資格勉強" {
+			t.Errorf("unexpected event: %+v", sent)
+		}
+	})
+
+	t.Run("長すぎる作業名は拒否する", func(t *testing.T) {
+		events := &mockEventOutbox{}
+		uc := setup(newActive(), events)
+
+		name := strings.Repeat("あ", domain.WorkNameMaxLength+1)
+		if _, err := uc.Correct(context.Background(), CorrectSessionInput{SessionID: 99, WorkName: &name, Actor: "moderator"}); !errors.Is(err, domain.ErrWorkNameTooLong) {
+			t.Fatalf("expected ErrWorkNameTooLong, got %v", err)
+		}
+		if events.notified {
+			t.Error("no event should be sent")
+		}
+	})
+}
+
+func TestSessionCorrection_CorrectOverlap(t *testing.T) {
+	session := newCompletedSession(99, 42, -3*time.Hour, -2*time.Hour)
+	other := newCompletedSession(100, 42, -2*time.Hour, -time.Hour)
+
+	rolledBack := false
+	userRepo := &mockUserRepository{
+		beginTxFn: func(ctx context.Context) (repository.Tx, error) {
+			return &mockTx{rollbackFn: func(ctx context.Context) error {
+				rolledBack = true
+				return nil
+			}}, nil
+		},
+		findByIDWithTxFn: func(ctx context.Context, tx repository.Tx, id int64) (*domain.User, error) {
+			return &domain.User{ID: id}, nil
+		},
+	}
+	sessionRepo := &mockSessionRepository{
+		findByIDFn: func(ctx context.Context, id int64) (*domain.Session, error) {
+			return session, nil
+		},
+		findByIDWithTxFn: func(ctx context.Context, tx repository.Tx, id int64) (*domain.Session, error) {
+			return session, nil
+		},
+		findOverlappingWithTxFn: func(ctx context.Context, tx repository.Tx, userID, excludeID int64, start time.Time, end *time.Time) ([]*domain.Session, error) {
+			return []*domain.Session{other}, nil
+		},
+		correctWithTxFn: func(ctx context.Context, tx repository.Tx, s *domain.Session) error {
+			t.Error("overlapping correction should not be saved")
+			return nil
+		},
+	}
+	auditRepo := &mockAuditLogRepository{}
+
+	uc := NewSessionCorrectionUseCase(userRepo, sessionRepo, auditRepo, &mockEventOutbox{})
+
+	newEnd := other.StartTime.Add(30 * time.Minute)
+	_, err := uc.Correct(context.Background(), CorrectSessionInput{SessionID: 99, ActualEnd: &newEnd, Actor: "moderator"})
+	if !errors.Is(err, domain.ErrSessionOverlap) {
+		t.Errorf("expected ErrSessionOverlap, got %v", err)
+	}
+	if !rolledBack {
+		t.Error("expected transaction to be rolled back")
+	}
+	if len(auditRepo.logs) != 0 {
+		t.Errorf("no audit log should be written, got %d", len(auditRepo.logs))
+	}
+}
+
+func TestSessionCorrection_Create(t *testing.T) {
+	userRepo := &mockUserRepository{
+		findByNameWithTxFn: func(ctx context.Context, tx repository.Tx, name string) (*domain.User, error) {
+			if name == "yamada" {
+				return &domain.User{ID: 42, Name: name}, nil
+			}
+			return nil, domain.ErrUserNotFound
+		},
+	}
+	sessionRepo := &mockSessionRepository{}
+	auditRepo := &mockAuditLogRepository{}
+
+	uc := NewSessionCorrectionUseCase(userRepo, sessionRepo, auditRepo, &mockEventOutbox{})
+
+	now := time.Now().UTC()
+	session, err := uc.Create(context.Background(), CreateManualSessionInput{
+		UserName:  "yamada",
+		WorkName:  "論文執筆",
+		StartTime: now.Add(-2 * time.Hour),
+		ActualEnd: now.Add(-time.Hour),
+		Actor:     "moderator",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.ID == 0 || session.UserID != 42 || session.IsActive() {
+		t.Errorf("unexpected session: %+v", session)
+	}
+	if len(auditRepo.logs) != 1 || auditRepo.logs[0].Action != domain.AuditActionSessionCreate || auditRepo.logs[0].Before != nil {
+		t.Errorf("unexpected audit logs: %+v", auditRepo.logs)
+	}
+
+	_, err = uc.Create(context.Background(), CreateManualSessionInput{
+		UserName:  "nobody",
+		StartTime: now.Add(-2 * time.Hour),
+		ActualEnd: now.Add(-time.Hour),
+		Actor:     "moderator",
+	})
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestSessionCorrection_DeleteActive(t *testing.T) {
+	session := newActiveSession(99, 42)
+
+	userRepo := &mockUserRepository{
+		findByIDWithTxFn: func(ctx context.Context, tx repository.Tx, id int64) (*domain.User, error) {
+			return &domain.User{ID: id}, nil
+		},
+	}
+	sessionRepo := &mockSessionRepository{
+		findByIDFn: func(ctx context.Context, id int64) (*domain.Session, error) {
+			return session, nil
+		},
+		findByIDWithTxFn: func(ctx context.Context, tx repository.Tx, id int64) (*domain.Session, error) {
+			return session, nil
+		},
+		deleteWithTxFn: func(ctx context.Context, tx repository.Tx, id int64) error {
+			t.Error("active session should not be deleted")
+			return nil
+		},
+	}
+
+	uc := NewSessionCorrectionUseCase(userRepo, sessionRepo, &mockAuditLogRepository{}, &mockEventOutbox{})
+
+	err := uc.Delete(context.Background(), DeleteSessionInput{SessionID: 99, Actor: "moderator"})
+	if !errors.Is(err, domain.ErrSessionActive) {
+		t.Errorf("expected ErrSessionActive, got %v", err)
+	}
+}
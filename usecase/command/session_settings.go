@@ -0,0 +1,54 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// SessionSettings configures how long /in sessions last and how far /more can extend them
+type SessionSettings struct {
+	// DefaultDuration is the planned length of a session started by /in
+	DefaultDuration time.Duration
+	// MinExtensionMinutes and MaxExtensionMinutes bound the minutes accepted by /more
+	MinExtensionMinutes int
+	MaxExtensionMinutes int
+}
+
+// DefaultSessionSettings returns the settings used when nothing is configured
+func DefaultSessionSettings() SessionSettings {
+	return SessionSettings{
+		DefaultDuration:     DefaultSessionDuration,
+		MinExtensionMinutes: MinExtensionMinutes,
+		MaxExtensionMinutes: MaxExtensionMinutes,
+	}
+}
+
+// SessionSettingsSource provides the session settings currently in effect
+// /in and /more read it on every call, so settings changed while the server is running apply to the next command
+type SessionSettingsSource interface {
+	SessionSettings() SessionSettings
+}
+
+// SessionSettings implements SessionSettingsSource with fixed settings
+func (s SessionSettings) SessionSettings() SessionSettings {
+	return s
+}
+
+// ExtensionRangeError is returned when /more is given minutes outside the configured range
+// It matches domain.ErrInvalidExtension with errors.Is
+type ExtensionRangeError struct {
+	Minutes int
+	Min     int
+	Max     int
+}
+
+func (e *ExtensionRangeError) Error() string {
+	return fmt.Sprintf("invalid extension: %d minutes is outside %d-%d", e.Minutes, e.Min, e.Max)
+}
+
+// Is makes errors.Is match domain.ErrInvalidExtension
+func (e *ExtensionRangeError) Is(target error) bool {
+	return target == domain.ErrInvalidExtension
+}
@@ -0,0 +1,29 @@
+package command
+
+import "context"
+
+// TextModerator defines the interface for checking user-supplied text against banned terms
+type TextModerator interface {
+	// ModerateUserName returns an error if the user name must not be accepted
+	ModerateUserName(ctx context.Context, userName string) error
+	// ModerateWorkName returns the work name to store (possibly masked) or an error if it must be rejected
+	ModerateWorkName(ctx context.Context, userName, workName string) (string, error)
+	// ModerateComment returns the comment to show (possibly masked) or an error if it must be rejected
+	ModerateComment(ctx context.Context, userName, comment string) (string, error)
+}
+
+// NoOpTextModerator is a no-op implementation of TextModerator
+// Useful for testing or when moderation is disabled
+type NoOpTextModerator struct{}
+
+func (NoOpTextModerator) ModerateUserName(ctx context.Context, userName string) error {
+	return nil
+}
+
+func (NoOpTextModerator) ModerateWorkName(ctx context.Context, userName, workName string) (string, error) {
+	return workName, nil
+}
+
+func (NoOpTextModerator) ModerateComment(ctx context.Context, userName, comment string) (string, error) {
+	return comment, nil
+}
@@ -0,0 +1,175 @@
+package command
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
+
+// ChangeTierInput represents the input for changing a user's tier by hand
+type ChangeTierInput struct {
+	UserName string
+	Tier     domain.Tier
+	Actor    string
+	Reason   string
+}
+
+// AdjustPointsInput represents the input for granting (Delta > 0) or revoking (Delta < 0) Raziiipo by hand
+// A non-empty Reference makes the adjustment idempotent: a second adjustment with the same reference is refused
+type AdjustPointsInput struct {
+	UserName  string
+	Delta     int64
+	Reference string
+	Actor     string
+	Reason    string
+}
+
+// AdjustPointsOutput represents the output of a point adjustment
+type AdjustPointsOutput struct {
+	Entry   *domain.PointEntry
+	Balance int64 // Balance after the adjustment
+}
+
+// UserAdjustmentUseCase lets admins fix a user's tier and Raziiipo balance (missed subscriptions, support requests)
+// The user row is locked like the other point operations, and the audit log is written in the same transaction
+type UserAdjustmentUseCase struct {
+	userRepository     repository.UserRepository
+	ledgerRepository   repository.PointLedgerRepository
+	auditLogRepository repository.AuditLogRepository
+	now                func() time.Time
+}
+
+// NewUserAdjustmentUseCase creates a new user adjustment use case
+func NewUserAdjustmentUseCase(
+	userRepository repository.UserRepository,
+	ledgerRepository repository.PointLedgerRepository,
+	auditLogRepository repository.AuditLogRepository,
+) *UserAdjustmentUseCase {
+	return &UserAdjustmentUseCase{
+		userRepository:     userRepository,
+		ledgerRepository:   ledgerRepository,
+		auditLogRepository: auditLogRepository,
+		now:                func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// ChangeTier sets the user's tier
+// The next /in picks an icon of the new tier; a running session keeps its icon
+func (uc *UserAdjustmentUseCase) ChangeTier(ctx context.Context, input ChangeTierInput) (_ *domain.User, err error) {
+	tx, err := uc.userRepository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// 1. Find and lock user
+	user, err := uc.userRepository.FindByNameWithTx(ctx, tx, input.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Change the tier (validates it)
+	before := tierState{Tier: user.Tier.Int()}
+	if err = user.ChangeTier(input.Tier, uc.now); err != nil {
+		return nil, err
+	}
+
+	// 3. Save user and audit log
+	if err = uc.userRepository.UpdateWithTx(ctx, tx, user); err != nil {
+		return nil, err
+	}
+	if err = uc.audit(ctx, tx, input.Actor, domain.AuditActionUserTierChange, user.ID, before, tierState{Tier: user.Tier.Int()}, input.Reason); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// AdjustPoints grants or revokes Raziiipo through the ledger
+// Revoking more than the balance is refused with domain.ErrInsufficientPoints
+func (uc *UserAdjustmentUseCase) AdjustPoints(ctx context.Context, input AdjustPointsInput) (_ *AdjustPointsOutput, err error) {
+	tx, err := uc.userRepository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	// 1. Find and lock user
+	user, err := uc.userRepository.FindByNameWithTx(ctx, tx, input.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Validate the amount and, for a revocation, the balance
+	entry, err := domain.NewPointEntry(user.ID, input.Delta, domain.PointReasonAdminAdjustment, input.Reference, uc.now)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := uc.ledgerRepository.BalanceWithTx(ctx, tx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Delta < 0 {
+		if err = domain.CheckPointDebit(balance, -entry.Delta); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. Append the entry and audit log
+	if err = uc.ledgerRepository.AppendWithTx(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+	before := pointsState{Balance: balance}
+	after := pointsState{Balance: balance + entry.Delta, Delta: entry.Delta, Reference: entry.Reference}
+	if err = uc.audit(ctx, tx, input.Actor, domain.AuditActionUserPointsAdjust, user.ID, before, after, input.Reason); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &AdjustPointsOutput{Entry: entry, Balance: after.Balance}, nil
+}
+
+func (uc *UserAdjustmentUseCase) audit(ctx context.Context, tx repository.Tx, actor, action string, userID int64, before, after any, reason string) error {
+	auditLog, err := domain.NewAuditLog(
+		actor,
+		action,
+		domain.AuditTargetUser,
+		strconv.FormatInt(userID, 10),
+		before,
+		after,
+		reason,
+		uc.now,
+	)
+	if err != nil {
+		return err
+	}
+	return uc.auditLogRepository.SaveWithTx(ctx, tx, auditLog)
+}
+
+// tierState is the tier recorded in audit logs
+type tierState struct {
+	Tier int `json:"tier"`
+}
+
+// pointsState is the balance recorded in audit logs (the entry is recorded on the after side)
+type pointsState struct {
+	Balance   int64  `json:"balance"`
+	Delta     int64  `json:"delta,omitempty"`
+	Reference string `json:"reference,omitempty"`
+}
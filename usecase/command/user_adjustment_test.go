@@ -0,0 +1,144 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
+
+type userAdjustmentFixture struct {
+	uc         *UserAdjustmentUseCase
+	ledger     *mockPointLedgerRepository
+	audit      *mockAuditLogRepository
+	updated    *domain.User
+	committed  int
+	rolledBack int
+}
+
+func newUserAdjustmentFixture(user *domain.User, balance int64) *userAdjustmentFixture {
+	f := &userAdjustmentFixture{
+		ledger: &mockPointLedgerRepository{},
+		audit:  &mockAuditLogRepository{},
+	}
+	if balance != 0 {
+		f.ledger.entries = append(f.ledger.entries, &domain.PointEntry{UserID: user.ID, Delta: balance, Reason: "seed"})
+	}
+	userRepo := &mockUserRepository{
+		beginTxFn: func(ctx context.Context) (repository.Tx, error) {
+			return &mockTx{
+				commitFn: func(ctx context.Context) error {
+					f.committed++
+					return nil
+				},
+				rollbackFn: func(ctx context.Context) error {
+					f.rolledBack++
+					return nil
+				},
+			}, nil
+		},
+		findByNameWithTxFn: func(ctx context.Context, tx repository.Tx, name string) (*domain.User, error) {
+			if name != user.Name {
+				return nil, domain.ErrUserNotFound
+			}
+			return user, nil
+		},
+		updateWithTxFn: func(ctx context.Context, tx repository.Tx, u *domain.User) error {
+			f.updated = u
+			return nil
+		},
+	}
+	f.uc = NewUserAdjustmentUseCase(userRepo, f.ledger, f.audit)
+	return f
+}
+
+func TestUserAdjustmentUseCase_ChangeTier(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ティアを変更して監査ログを記録する", func(t *testing.T) {
+		user := &domain.User{ID: 7, Name: "yamada", Tier: domain.Tier1}
+		f := newUserAdjustmentFixture(user, 0)
+
+		got, err := f.uc.ChangeTier(ctx, ChangeTierInput{UserName: "yamada", Tier: domain.Tier3, Actor: "admin", Reason: "サブスクの反映漏れ"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Tier != domain.Tier3 || f.updated != user || f.committed != 1 {
+			t.Errorf("expected the tier to be saved, got %v (committed %d)", got.Tier, f.committed)
+		}
+		if len(f.audit.logs) != 1 {
+			t.Fatalf("expected 1 audit log, got %d", len(f.audit.logs))
+		}
+		log := f.audit.logs[0]
+		if log.Action != domain.AuditActionUserTierChange || log.TargetID != "7" ||
+			string(log.Before) != `{"tier":1}` || string(log.After) != `{"tier":3}` || log.Reason != "サブスクの反映漏れ" {
+			t.Errorf("unexpected audit log: %+v", log)
+		}
+	})
+
+	t.Run("不正なティアは保存しない", func(t *testing.T) {
+		user := &domain.User{ID: 7, Name: "yamada", Tier: domain.Tier1}
+		f := newUserAdjustmentFixture(user, 0)
+
+		_, err := f.uc.ChangeTier(ctx, ChangeTierInput{UserName: "yamada", Tier: domain.TierUnknown, Actor: "admin"})
+		if !errors.Is(err, domain.ErrInvalidTier) {
+			t.Fatalf("expected ErrInvalidTier, got %v", err)
+		}
+		if f.updated != nil || f.rolledBack != 1 || len(f.audit.logs) != 0 {
+			t.Errorf("expected nothing to be saved")
+		}
+	})
+}
+
+func TestUserAdjustmentUseCase_AdjustPoints(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("付与すると台帳に追記し、残高を返す", func(t *testing.T) {
+		user := &domain.User{ID: 7, Name: "yamada", Tier: domain.Tier1}
+		f := newUserAdjustmentFixture(user, 100)
+
+		out, err := f.uc.AdjustPoints(ctx, AdjustPointsInput{UserName: "yamada", Delta: 50, Reference: "support-12", Actor: "admin"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.Balance != 150 || out.Entry.Reason != domain.PointReasonAdminAdjustment || out.Entry.Reference != "support-12" {
+			t.Errorf("unexpected output: %+v", out)
+		}
+		if log := f.audit.logs[0]; log.Action != domain.AuditActionUserPointsAdjust ||
+			string(log.Before) != `{"balance":100}` || string(log.After) != `{"balance":150,"delta":50,"reference":"support-12"}` {
+			t.Errorf("unexpected audit log: before=%s after=%s", log.Before, log.After)
+		}
+
+		// 同じ参照での再実行は二重に付与しない
+		if _, err := f.uc.AdjustPoints(ctx, AdjustPointsInput{UserName: "yamada", Delta: 50, Reference: "support-12", Actor: "admin"}); !errors.Is(err, domain.ErrDuplicatePointEntry) {
+			t.Errorf("expected ErrDuplicatePointEntry, got %v", err)
+		}
+	})
+
+	t.Run("残高を超える取り消しは拒否する", func(t *testing.T) {
+		user := &domain.User{ID: 7, Name: "yamada", Tier: domain.Tier1}
+		f := newUserAdjustmentFixture(user, 30)
+
+		if _, err := f.uc.AdjustPoints(ctx, AdjustPointsInput{UserName: "yamada", Delta: -31, Actor: "admin"}); !errors.Is(err, domain.ErrInsufficientPoints) {
+			t.Fatalf("expected ErrInsufficientPoints, got %v", err)
+		}
+		out, err := f.uc.AdjustPoints(ctx, AdjustPointsInput{UserName: "yamada", Delta: -30, Actor: "admin"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.Balance != 0 || f.rolledBack != 1 || f.committed != 1 {
+			t.Errorf("unexpected result: balance=%d rolledBack=%d committed=%d", out.Balance, f.rolledBack, f.committed)
+		}
+	})
+
+	t.Run("0 は指定できない", func(t *testing.T) {
+		user := &domain.User{ID: 7, Name: "yamada", Tier: domain.Tier1}
+		f := newUserAdjustmentFixture(user, 0)
+
+		if _, err := f.uc.AdjustPoints(ctx, AdjustPointsInput{UserName: "yamada", Delta: 0, Actor: "admin"}); !errors.Is(err, domain.ErrInvalidPointAmount) {
+			t.Errorf("expected ErrInvalidPointAmount, got %v", err)
+		}
+	})
+}
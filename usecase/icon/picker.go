@@ -0,0 +1,37 @@
+package icon
+
+import (
+	"context"
+	"math/rand/v2"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+// Ensure Picker implements command.IconPicker
+var _ command.IconPicker = (*Picker)(nil)
+
+// Picker /in のときにアイコンカタログからセッションのアイコンを選ぶ
+type Picker struct {
+	iconRepo repository.IconRepository
+	intn     func(n int) int
+}
+
+// NewPicker creates a new icon picker
+// intn は [0, n) の乱数を返す関数（nil の場合は math/rand/v2 の IntN）。テストでは固定値を返す関数を渡す
+func NewPicker(iconRepo repository.IconRepository, intn func(n int) int) *Picker {
+	if intn == nil {
+		intn = rand.IntN
+	}
+	return &Picker{iconRepo: iconRepo, intn: intn}
+}
+
+// PickIcon 専用アイコンがあればその中から、なければティアの共通アイコンからランダムに選ぶ
+func (p *Picker) PickIcon(ctx context.Context, userID int64, tier domain.Tier) (*domain.Icon, error) {
+	icons, err := p.iconRepo.ListAssignable(ctx, userID, tier)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ChooseIcon(icons, userID, tier, p.intn), nil
+}
@@ -0,0 +1,58 @@
+package icon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
+
+type fakeIconRepository struct {
+	icons []*domain.Icon
+	err   error
+}
+
+func (r *fakeIconRepository) ListAssignable(ctx context.Context, userID int64, tier domain.Tier) ([]*domain.Icon, error) {
+	return r.icons, r.err
+}
+
+func (r *fakeIconRepository) CreateWithTx(ctx context.Context, tx repository.Tx, icon *domain.Icon) error {
+	return errors.New("not implemented")
+}
+
+func TestPicker_PickIcon(t *testing.T) {
+	owner := int64(5)
+	repo := &fakeIconRepository{icons: []*domain.Icon{
+		{ID: 1, Tier: domain.Tier1, AssetKey: "tier1-01"},
+		{ID: 2, Tier: domain.Tier1, AssetKey: "tier1-02"},
+		{ID: 3, Tier: domain.Tier1, OwnerUserID: &owner, AssetKey: "user5-01"},
+	}}
+	picker := NewPicker(repo, func(n int) int { return n - 1 })
+
+	icon, err := picker.PickIcon(context.Background(), 1, domain.Tier1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if icon == nil || icon.AssetKey != "tier1-02" {
+		t.Errorf("expected shared icon tier1-02, got %+v", icon)
+	}
+
+	icon, err = picker.PickIcon(context.Background(), owner, domain.Tier1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if icon == nil || icon.AssetKey != "user5-01" {
+		t.Errorf("expected exclusive icon user5-01, got %+v", icon)
+	}
+}
+
+func TestPicker_PickIcon_RepositoryError(t *testing.T) {
+	want := errors.New("db down")
+	picker := NewPicker(&fakeIconRepository{err: want}, nil)
+
+	if _, err := picker.PickIcon(context.Background(), 1, domain.Tier1); !errors.Is(err, want) {
+		t.Fatalf("expected %v, got %v", want, err)
+	}
+}
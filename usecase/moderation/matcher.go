@@ -0,0 +1,117 @@
+package moderation
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// matcher 照合用に前処理した禁止ワード
+type matcher struct {
+	term    *domain.BannedTerm
+	pattern string         // exact / substring 用の正規化済みワード
+	re      *regexp.Regexp // regex 用（正規化後のテキストに適用する）
+}
+
+func newMatcher(term *domain.BannedTerm) (*matcher, error) {
+	m := &matcher{term: term}
+	if term.MatchType == domain.MatchRegex {
+		expr, err := normalizeRegex(term.Term)
+		if err != nil {
+			return nil, domain.ErrInvalidBannedTermRegex
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, domain.ErrInvalidBannedTermRegex
+		}
+		m.re = re
+		return m, nil
+	}
+	m.pattern = strings.TrimSpace(Normalize(term.Term))
+	return m, nil
+}
+
+// maxNormalizedClassRange 文字クラスの範囲を 1 文字ずつ正規化する上限（否定クラスなどの広い範囲はそのまま使う）
+const maxNormalizedClassRange = 0x400
+
+// normalizeRegex 正規表現のリテラルと文字クラスを Normalize と同じ規則で変換する（大文字小文字は区別しない）
+// 正規表現は正規化後のテキストに適用するため、「バカ.*」や全角文字を含むパターンも変換しないと一致しない
+func normalizeRegex(expr string) (string, error) {
+	re, err := syntax.Parse(expr, syntax.Perl|syntax.FoldCase)
+	if err != nil {
+		return "", err
+	}
+	normalizeRegexp(re)
+	return re.String(), nil
+}
+
+func normalizeRegexp(re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		re.Rune = []rune(Normalize(string(re.Rune)))
+		if len(re.Rune) == 0 {
+			re.Op = syntax.OpEmptyMatch
+		}
+	case syntax.OpCharClass:
+		// 元の範囲は残し、正規化すると別の文字になるものを追加する（[ァ-ン] は [ぁ-ん] にも一致する）
+		ranges := re.Rune
+		for i := 0; i+1 < len(ranges); i += 2 {
+			lo, hi := ranges[i], ranges[i+1]
+			if hi-lo > maxNormalizedClassRange {
+				continue
+			}
+			for r := lo; r <= hi; r++ {
+				if n := []rune(Normalize(string(r))); len(n) == 1 && n[0] != r {
+					re.Rune = append(re.Rune, n[0], n[0])
+				}
+			}
+		}
+	}
+	for _, sub := range re.Sub {
+		normalizeRegexp(sub)
+	}
+}
+
+// find 正規化後テキスト上で一致した区間を返す
+func (m *matcher) find(text string) [][2]int {
+	switch m.term.MatchType {
+	case domain.MatchExact:
+		trimmed := strings.TrimFunc(text, unicode.IsSpace)
+		if m.pattern == "" || trimmed != m.pattern {
+			return nil
+		}
+		start := strings.Index(text, trimmed)
+		return [][2]int{{start, start + len(trimmed)}}
+
+	case domain.MatchSubstring:
+		if m.pattern == "" {
+			return nil
+		}
+		var spans [][2]int
+		for offset := 0; offset < len(text); {
+			i := strings.Index(text[offset:], m.pattern)
+			if i < 0 {
+				break
+			}
+			start := offset + i
+			spans = append(spans, [2]int{start, start + len(m.pattern)})
+			offset = start + len(m.pattern)
+		}
+		return spans
+
+	case domain.MatchRegex:
+		var spans [][2]int
+		for _, loc := range m.re.FindAllStringIndex(text, -1) {
+			if loc[0] == loc[1] {
+				// 空文字列への一致は無視する
+				continue
+			}
+			spans = append(spans, [2]int{loc[0], loc[1]})
+		}
+		return spans
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package moderation
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// segment 元テキストの区間と正規化後テキストの区間の対応
+type segment struct {
+	origStart, origEnd int // 元テキストのバイト位置
+	normStart, normEnd int // 正規化後テキストのバイト位置
+}
+
+// normalizedText 正規化後のテキストと、元テキストへの位置の対応表
+type normalizedText struct {
+	original   string
+	normalized string
+	segments   []segment
+}
+
+// Normalize 照合用にテキストを正規化する
+// NFKC（全角英数→半角、半角カナ→全角など）、小文字化、カタカナ→ひらがなの順に変換する
+func Normalize(s string) string {
+	return normalize(s).normalized
+}
+
+// normalize NFKC の区切り単位で正規化し、元テキストとの位置の対応を保持する
+// 区切り単位ごとの正規化結果を連結したものは全体を正規化した結果と一致する
+func normalize(s string) normalizedText {
+	var b strings.Builder
+	segments := make([]segment, 0, len(s))
+
+	for pos := 0; pos < len(s); {
+		n := norm.NFKC.NextBoundaryInString(s[pos:], true)
+		if n <= 0 {
+			_, n = utf8.DecodeRuneInString(s[pos:])
+		}
+		chunk := foldKana(strings.ToLower(norm.NFKC.String(s[pos : pos+n])))
+
+		start := b.Len()
+		b.WriteString(chunk)
+		segments = append(segments, segment{
+			origStart: pos,
+			origEnd:   pos + n,
+			normStart: start,
+			normEnd:   b.Len(),
+		})
+		pos += n
+	}
+
+	return normalizedText{
+		original:   s,
+		normalized: b.String(),
+		segments:   segments,
+	}
+}
+
+// foldKana カタカナをひらがなに寄せる（「バカ」と「ばか」を同一視する）
+func foldKana(s string) string {
+	return strings.Map(func(r rune) rune {
+		// ァ(U+30A1)〜ヶ(U+30F6) → ぁ(U+3041)〜ゖ(U+3096)
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 0x60
+		}
+		return r
+	}, s)
+}
+
+// mask 正規化後テキスト上の区間 spans に対応する元テキストの文字を伏せ字にする
+func (t normalizedText) mask(spans [][2]int, maskRune rune) string {
+	if len(spans) == 0 {
+		return t.original
+	}
+
+	var b strings.Builder
+	for _, seg := range t.segments {
+		if overlapsAny(seg, spans) {
+			b.WriteString(strings.Repeat(string(maskRune), utf8.RuneCountInString(t.original[seg.origStart:seg.origEnd])))
+			continue
+		}
+		b.WriteString(t.original[seg.origStart:seg.origEnd])
+	}
+	return b.String()
+}
+
+func overlapsAny(seg segment, spans [][2]int) bool {
+	for _, span := range spans {
+		if seg.normStart < span[1] && span[0] < seg.normEnd {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,313 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+)
+
+// MaskRune 伏せ字に使う文字
+const MaskRune = '*'
+
+// 検査対象のフィールド名（監査ログの理由に記録する）
+const (
+	FieldUserName = "user_name"
+	FieldWorkName = "work_name"
+	FieldComment  = "comment"
+)
+
+// Result テキスト検査の結果
+type Result struct {
+	Text      string               // 伏せ字を適用したテキスト（一致がなければ元のまま）
+	Matched   []*domain.BannedTerm // 一致した禁止ワード
+	Rejected  bool                 // reject の禁止ワードに一致した
+	AutoBlock bool                 // 自動ブロック対象の禁止ワードに一致した
+}
+
+// TermInput 禁止ワードの作成・更新内容
+type TermInput struct {
+	Term      string
+	MatchType domain.MatchType
+	Action    domain.ModerationAction
+	AutoBlock bool
+}
+
+// AutoBlockDurationSource 禁止ワードによる自動ブロックの期間（0なら無期限）
+// ブロックのたびに読むため、配信中に変更した期間は次のブロックから適用される
+type AutoBlockDurationSource interface {
+	AutoBlockDuration() time.Duration
+}
+
+// Service 禁止ワードの管理と、作業名・ユーザー名の検査を行う
+// 禁止ワードはメモリにキャッシュし、管理操作のたびに読み直す
+type Service struct {
+	bannedTermRepository repository.BannedTermRepository
+	userRepository       repository.UserRepository
+	auditLogRepository   repository.AuditLogRepository
+	autoBlockDuration    AutoBlockDurationSource
+	now                  func() time.Time
+
+	mu       sync.RWMutex
+	matchers []*matcher
+	loaded   bool
+}
+
+// NewService creates a new moderation service
+// autoBlockDuration が nil の場合、自動ブロックは無期限になる
+func NewService(
+	bannedTermRepository repository.BannedTermRepository,
+	userRepository repository.UserRepository,
+	auditLogRepository repository.AuditLogRepository,
+	autoBlockDuration AutoBlockDurationSource,
+) *Service {
+	return &Service{
+		bannedTermRepository: bannedTermRepository,
+		userRepository:       userRepository,
+		auditLogRepository:   auditLogRepository,
+		autoBlockDuration:    autoBlockDuration,
+		now:                  func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Check テキストを禁止ワードと照合する
+func (s *Service) Check(ctx context.Context, text string) (Result, error) {
+	result := Result{Text: text}
+	if strings.TrimSpace(text) == "" {
+		return result, nil
+	}
+
+	matchers, err := s.loadMatchers(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	normalized := normalize(text)
+	var spans [][2]int
+	for _, m := range matchers {
+		found := m.find(normalized.normalized)
+		if len(found) == 0 {
+			continue
+		}
+		result.Matched = append(result.Matched, m.term)
+		spans = append(spans, found...)
+		if m.term.Action == domain.ModerationReject {
+			result.Rejected = true
+		}
+		if m.term.AutoBlock {
+			result.AutoBlock = true
+		}
+	}
+
+	if len(result.Matched) > 0 {
+		result.Text = normalized.mask(spans, MaskRune)
+	}
+	return result, nil
+}
+
+// ModerateUserName ユーザー名を検査する
+// ユーザー名は伏せ字にできないため、禁止ワードに一致した時点で拒否する
+func (s *Service) ModerateUserName(ctx context.Context, userName string) error {
+	result, ok := s.check(ctx, userName)
+	if !ok || len(result.Matched) == 0 {
+		return nil
+	}
+	if result.AutoBlock {
+		return s.autoBlock(ctx, userName, FieldUserName, userName, result)
+	}
+	return domain.ErrBannedTermDetected
+}
+
+// ModerateWorkName 作業名を検査し、受け付ける作業名を返す
+// reject の禁止ワードに一致した場合は拒否し、mask のみの場合は伏せ字にして返す
+func (s *Service) ModerateWorkName(ctx context.Context, userName, workName string) (string, error) {
+	return s.moderateText(ctx, userName, FieldWorkName, workName)
+}
+
+// ModerateComment コメント欄に出すコメントを検査する（作業名と同じく mask は伏せ字、reject は拒否）
+func (s *Service) ModerateComment(ctx context.Context, userName, comment string) (string, error) {
+	return s.moderateText(ctx, userName, FieldComment, comment)
+}
+
+func (s *Service) moderateText(ctx context.Context, userName, field, text string) (string, error) {
+	result, ok := s.check(ctx, text)
+	if !ok || len(result.Matched) == 0 {
+		return text, nil
+	}
+	if result.AutoBlock {
+		return "", s.autoBlock(ctx, userName, field, text, result)
+	}
+	if result.Rejected {
+		return "", domain.ErrBannedTermDetected
+	}
+	return result.Text, nil
+}
+
+// check 禁止ワードの読み込みに失敗した場合はコマンドを止めないよう検査を省略する（fail-open）
+func (s *Service) check(ctx context.Context, text string) (Result, bool) {
+	result, err := s.Check(ctx, text)
+	if err != nil {
+		logging.FromContext(ctx).Error("moderation check skipped: failed to load banned terms", logging.Err(err))
+		return result, false
+	}
+	return result, true
+}
+
+// autoBlock 禁止ワードを使用したユーザーをブロックし、監査ログを記録する
+// 未登録のユーザーも作成してブロックする（ブロック済みのユーザー名で /in できないようにする）
+func (s *Service) autoBlock(ctx context.Context, userName, field, text string, result Result) error {
+	reason := blockReason(field, text, result.Matched)
+	_, err := s.changeBlock(ctx, userName, true, domain.SystemActorModeration, domain.AuditActionUserBlock, reason, func(user *domain.User) error {
+		if user.IsBlocked(s.now) {
+			return domain.ErrUserBlocked
+		}
+		return user.Block(reason, domain.SystemActorModeration, s.blockDuration(), s.now)
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Warn("user was automatically blocked", logging.KeyUserName, userName, "reason", reason)
+	return domain.ErrUserBlocked
+}
+
+func (s *Service) blockDuration() time.Duration {
+	if s.autoBlockDuration == nil {
+		return 0
+	}
+	return s.autoBlockDuration.AutoBlockDuration()
+}
+
+// blockReason 自動ブロックの理由（一致した禁止ワードのID・フィールド・テキスト）
+func blockReason(field, text string, matched []*domain.BannedTerm) string {
+	ids := make([]string, 0, len(matched))
+	for _, term := range matched {
+		ids = append(ids, "#"+strconv.FormatInt(term.ID, 10))
+	}
+	return fmt.Sprintf("banned term %s detected in %s: %q", strings.Join(ids, ","), field, text)
+}
+
+// loadMatchers キャッシュ済みの禁止ワードを返す（未読み込みならリポジトリから読み込む）
+func (s *Service) loadMatchers(ctx context.Context) ([]*matcher, error) {
+	s.mu.RLock()
+	if s.loaded {
+		matchers := s.matchers
+		s.mu.RUnlock()
+		return matchers, nil
+	}
+	s.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loaded {
+		return s.matchers, nil
+	}
+
+	terms, err := s.bannedTermRepository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matchers := make([]*matcher, 0, len(terms))
+	for _, term := range terms {
+		m, err := newMatcher(term)
+		if err != nil {
+			logging.FromContext(ctx).Warn("skipping banned term", "banned_term_id", term.ID, logging.Err(err))
+			continue
+		}
+		matchers = append(matchers, m)
+	}
+
+	s.matchers = matchers
+	s.loaded = true
+	return matchers, nil
+}
+
+// Invalidate キャッシュを破棄し、次回の検査で禁止ワードを読み直す
+func (s *Service) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.matchers = nil
+	s.loaded = false
+}
+
+// ListTerms 登録済みの禁止ワードを返す
+func (s *Service) ListTerms(ctx context.Context) ([]*domain.BannedTerm, error) {
+	return s.bannedTermRepository.List(ctx)
+}
+
+// CreateTerm 禁止ワードを登録する
+func (s *Service) CreateTerm(ctx context.Context, input TermInput, actor string) (*domain.BannedTerm, error) {
+	term, err := domain.NewBannedTerm(input.Term, input.MatchType, input.Action, input.AutoBlock, actor, s.now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.bannedTermRepository.Save(ctx, term); err != nil {
+		return nil, err
+	}
+	s.Invalidate()
+
+	if err := s.audit(ctx, actor, domain.AuditActionBannedTermCreate, term.ID, nil, term); err != nil {
+		return nil, err
+	}
+	return term, nil
+}
+
+// UpdateTerm 禁止ワードを変更する
+func (s *Service) UpdateTerm(ctx context.Context, id int64, input TermInput, actor string) (*domain.BannedTerm, error) {
+	term, err := s.bannedTermRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *term
+
+	if err := term.Update(input.Term, input.MatchType, input.Action, input.AutoBlock, s.now); err != nil {
+		return nil, err
+	}
+	if err := s.bannedTermRepository.Save(ctx, term); err != nil {
+		return nil, err
+	}
+	s.Invalidate()
+
+	if err := s.audit(ctx, actor, domain.AuditActionBannedTermUpdate, term.ID, &before, term); err != nil {
+		return nil, err
+	}
+	return term, nil
+}
+
+// DeleteTerm 禁止ワードを削除する
+func (s *Service) DeleteTerm(ctx context.Context, id int64, actor string) error {
+	term, err := s.bannedTermRepository.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.bannedTermRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.Invalidate()
+
+	return s.audit(ctx, actor, domain.AuditActionBannedTermDelete, id, term, nil)
+}
+
+func (s *Service) audit(ctx context.Context, actor, action string, termID int64, before, after *domain.BannedTerm) error {
+	auditLog, err := domain.NewAuditLog(
+		actor,
+		action,
+		domain.AuditTargetBannedTerm,
+		strconv.FormatInt(termID, 10),
+		newTermState(before),
+		newTermState(after),
+		"",
+		s.now,
+	)
+	if err != nil {
+		return err
+	}
+	return s.auditLogRepository.Save(ctx, auditLog)
+}
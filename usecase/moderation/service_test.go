@@ -0,0 +1,406 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
+
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeTx トランザクションの確定・破棄を記録する
+type fakeTx struct {
+	committed  bool
+	rolledBack bool
+}
+
+func (t *fakeTx) Commit(ctx context.Context) error {
+	t.committed = true
+	return nil
+}
+
+func (t *fakeTx) Rollback(ctx context.Context) error {
+	t.rolledBack = true
+	return nil
+}
+
+// fakeBannedTermRepository メモリ上の禁止ワードリポジトリ
+type fakeBannedTermRepository struct {
+	terms     []*domain.BannedTerm
+	listCalls int
+	listErr   error
+}
+
+func (r *fakeBannedTermRepository) List(ctx context.Context) ([]*domain.BannedTerm, error) {
+	r.listCalls++
+	if r.listErr != nil {
+		return nil, r.listErr
+	}
+	return r.terms, nil
+}
+
+func (r *fakeBannedTermRepository) FindByID(ctx context.Context, id int64) (*domain.BannedTerm, error) {
+	for _, term := range r.terms {
+		if term.ID == id {
+			return term, nil
+		}
+	}
+	return nil, domain.ErrBannedTermNotFound
+}
+
+func (r *fakeBannedTermRepository) Save(ctx context.Context, term *domain.BannedTerm) error {
+	if term.ID == 0 {
+		term.ID = int64(len(r.terms) + 1)
+		r.terms = append(r.terms, term)
+	}
+	return nil
+}
+
+func (r *fakeBannedTermRepository) Delete(ctx context.Context, id int64) error {
+	for i, term := range r.terms {
+		if term.ID == id {
+			r.terms = append(r.terms[:i], r.terms[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrBannedTermNotFound
+}
+
+// fakeUserRepository 自動ブロックで使う操作のみを実装したユーザーリポジトリ
+type fakeUserRepository struct {
+	repository.UserRepository
+	users map[string]*domain.User
+	tx    *fakeTx
+}
+
+func (r *fakeUserRepository) BeginTx(ctx context.Context) (repository.Tx, error) {
+	r.tx = &fakeTx{}
+	return r.tx, nil
+}
+
+func (r *fakeUserRepository) FindByNameWithTx(ctx context.Context, tx repository.Tx, name string) (*domain.User, error) {
+	if user, ok := r.users[name]; ok {
+		return user, nil
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+func (r *fakeUserRepository) SaveWithTx(ctx context.Context, tx repository.Tx, user *domain.User) error {
+	user.ID = int64(len(r.users) + 1)
+	r.users[user.Name] = user
+	return nil
+}
+
+func (r *fakeUserRepository) UpdateWithTx(ctx context.Context, tx repository.Tx, user *domain.User) error {
+	r.users[user.Name] = user
+	return nil
+}
+
+// fakeAuditLogRepository 記録された監査ログを保持する
+type fakeAuditLogRepository struct {
+	logs []*domain.AuditLog
+}
+
+func (r *fakeAuditLogRepository) Save(ctx context.Context, log *domain.AuditLog) error {
+	log.ID = int64(len(r.logs) + 1)
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *fakeAuditLogRepository) SaveWithTx(ctx context.Context, tx repository.Tx, log *domain.AuditLog) error {
+	return r.Save(ctx, log)
+}
+
+func (r *fakeAuditLogRepository) List(ctx context.Context, filter repository.AuditLogFilter) ([]*domain.AuditLog, error) {
+	return r.logs, nil
+}
+
+func newTestService(terms ...*domain.BannedTerm) (*Service, *fakeBannedTermRepository, *fakeUserRepository, *fakeAuditLogRepository) {
+	for i, term := range terms {
+		term.ID = int64(i + 1)
+	}
+	termRepo := &fakeBannedTermRepository{terms: terms}
+	userRepo := &fakeUserRepository{users: map[string]*domain.User{}}
+	auditRepo := &fakeAuditLogRepository{}
+
+	s := NewService(termRepo, userRepo, auditRepo, nil)
+	s.now = func() time.Time { return testNow }
+	return s, termRepo, userRepo, auditRepo
+}
+
+func bannedTerm(term string, matchType domain.MatchType, action domain.ModerationAction, autoBlock bool) *domain.BannedTerm {
+	return &domain.BannedTerm{Term: term, MatchType: matchType, Action: action, AutoBlock: autoBlock}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"ﾊﾞｶ":  "ばか",
+		"バカ":   "ばか",
+		"ＢＡＫＡ": "baka",
+		"Baka": "baka",
+		"ばか":   "ばか",
+	}
+	for input, want := range tests {
+		if got := Normalize(input); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestService_Check(t *testing.T) {
+	tests := []struct {
+		name        string
+		term        *domain.BannedTerm
+		text        string
+		wantMatched bool
+		wantText    string
+	}{
+		{name: "部分一致（半角カナ）", term: bannedTerm("ばか", domain.MatchSubstring, domain.ModerationMask, false), text: "ﾊﾞｶな作業", wantMatched: true, wantText: "***な作業"},
+		{name: "部分一致（全角英字）", term: bannedTerm("baka", domain.MatchSubstring, domain.ModerationMask, false), text: "ＢＡＫＡ test", wantMatched: true, wantText: "**** test"},
+		{name: "完全一致は部分には一致しない", term: bannedTerm("spam", domain.MatchExact, domain.ModerationReject, false), text: "spam mail", wantMatched: false, wantText: "spam mail"},
+		{name: "完全一致", term: bannedTerm("spam", domain.MatchExact, domain.ModerationReject, false), text: "ＳＰＡＭ", wantMatched: true, wantText: "****"},
+		{name: "正規表現", term: bannedTerm(`b+a+k+a+`, domain.MatchRegex, domain.ModerationMask, false), text: "bbbaaka!", wantMatched: true, wantText: "*******!"},
+		{name: "正規表現（カタカナ）", term: bannedTerm(`バカ.*`, domain.MatchRegex, domain.ModerationMask, false), text: "作業 ﾊﾞｶだな", wantMatched: true, wantText: "作業 *****"},
+		{name: "正規表現（全角の文字クラス）", term: bannedTerm(`[Ａ-Ｚ]+[０-９]`, domain.MatchRegex, domain.ModerationMask, false), text: "spam1 ok", wantMatched: true, wantText: "***** ok"},
+		{name: "正規表現（カタカナの文字クラス）", term: bannedTerm(`[ア-オ]ほ`, domain.MatchRegex, domain.ModerationMask, false), text: "アホ", wantMatched: true, wantText: "**"},
+		{name: "一致なし", term: bannedTerm("ばか", domain.MatchSubstring, domain.ModerationMask, false), text: "論文執筆", wantMatched: false, wantText: "論文執筆"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, _, _ := newTestService(tt.term)
+
+			result, err := s.Check(context.Background(), tt.text)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (len(result.Matched) > 0) != tt.wantMatched {
+				t.Errorf("matched = %v, want %v", result.Matched, tt.wantMatched)
+			}
+			if result.Text != tt.wantText {
+				t.Errorf("text = %q, want %q", result.Text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestService_ModerateWorkName(t *testing.T) {
+	t.Run("mask は伏せ字にして受け付ける", func(t *testing.T) {
+		s, _, _, _ := newTestService(bannedTerm("ばか", domain.MatchSubstring, domain.ModerationMask, false))
+
+		workName, err := s.ModerateWorkName(context.Background(), "yamada", "バカ真面目に勉強")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if workName != "**真面目に勉強" {
+			t.Errorf("unexpected work name: %q", workName)
+		}
+	})
+
+	t.Run("reject は拒否する", func(t *testing.T) {
+		s, _, _, _ := newTestService(
+			bannedTerm("ばか", domain.MatchSubstring, domain.ModerationMask, false),
+			bannedTerm("spam", domain.MatchSubstring, domain.ModerationReject, false),
+		)
+
+		_, err := s.ModerateWorkName(context.Background(), "yamada", "ばか spam")
+		if !errors.Is(err, domain.ErrBannedTermDetected) {
+			t.Errorf("expected ErrBannedTermDetected, got %v", err)
+		}
+	})
+
+	t.Run("自動ブロックはユーザーをブロックして監査ログを残す", func(t *testing.T) {
+		s, _, userRepo, auditRepo := newTestService(bannedTerm("spam", domain.MatchSubstring, domain.ModerationReject, true))
+
+		_, err := s.ModerateWorkName(context.Background(), "troll", "spam spam")
+		if !errors.Is(err, domain.ErrUserBlocked) {
+			t.Fatalf("expected ErrUserBlocked, got %v", err)
+		}
+
+		user := userRepo.users["troll"]
+		if user == nil || !user.IsPermanentlyBlocked() {
+			t.Fatalf("user should be blocked permanently, got %+v", user)
+		}
+		if user.BlockedBy != domain.SystemActorModeration {
+			t.Errorf("unexpected BlockedBy: %q", user.BlockedBy)
+		}
+		if !userRepo.tx.committed {
+			t.Error("auto block should be committed")
+		}
+
+		if len(auditRepo.logs) != 1 {
+			t.Fatalf("expected 1 audit log, got %d", len(auditRepo.logs))
+		}
+		entry := auditRepo.logs[0]
+		if entry.Action != domain.AuditActionUserBlock || entry.Actor != domain.SystemActorModeration || entry.TargetID != "1" {
+			t.Errorf("unexpected audit log: %+v", entry)
+		}
+	})
+
+	t.Run("ブロック済みのユーザーは再ブロックしない", func(t *testing.T) {
+		s, _, userRepo, auditRepo := newTestService(bannedTerm("spam", domain.MatchSubstring, domain.ModerationReject, true))
+		blocked, _ := domain.NewUser("troll", domain.Tier1, func() time.Time { return testNow })
+		_ = blocked.Block("earlier", "admin", 0, func() time.Time { return testNow })
+		userRepo.users["troll"] = blocked
+
+		_, err := s.ModerateWorkName(context.Background(), "troll", "spam")
+		if !errors.Is(err, domain.ErrUserBlocked) {
+			t.Fatalf("expected ErrUserBlocked, got %v", err)
+		}
+		if blocked.BlockReason != "earlier" || len(auditRepo.logs) != 0 {
+			t.Errorf("existing block should be kept: %+v, logs=%d", blocked, len(auditRepo.logs))
+		}
+		if !userRepo.tx.rolledBack {
+			t.Error("transaction should be rolled back")
+		}
+	})
+
+	t.Run("禁止ワードを読み込めない場合は検査を省略する", func(t *testing.T) {
+		s, termRepo, _, _ := newTestService()
+		termRepo.listErr = errors.New("db down")
+
+		workName, err := s.ModerateWorkName(context.Background(), "yamada", "spam")
+		if err != nil || workName != "spam" {
+			t.Errorf("expected fail-open, got %q, %v", workName, err)
+		}
+	})
+}
+
+func TestService_ModerateComment(t *testing.T) {
+	s, _, _, auditRepo := newTestService(
+		bannedTerm("ばか", domain.MatchSubstring, domain.ModerationMask, false),
+		bannedTerm("spam", domain.MatchSubstring, domain.ModerationReject, true),
+	)
+
+	comment, err := s.ModerateComment(context.Background(), "yamada", "ばかみたいに眠い")
+	if err != nil || comment != "**みたいに眠い" {
+		t.Errorf("expected masked comment, got %q, %v", comment, err)
+	}
+
+	if _, err := s.ModerateComment(context.Background(), "troll", "spam"); !errors.Is(err, domain.ErrUserBlocked) {
+		t.Fatalf("expected ErrUserBlocked, got %v", err)
+	}
+	if len(auditRepo.logs) != 1 || !strings.Contains(auditRepo.logs[0].Reason, FieldComment) {
+		t.Errorf("audit log should name the comment field: %+v", auditRepo.logs)
+	}
+}
+
+func TestService_ModerateUserName(t *testing.T) {
+	s, _, _, _ := newTestService(bannedTerm("ばか", domain.MatchSubstring, domain.ModerationMask, false))
+
+	if err := s.ModerateUserName(context.Background(), "ﾊﾞｶ太郎"); !errors.Is(err, domain.ErrBannedTermDetected) {
+		t.Errorf("expected ErrBannedTermDetected for mask term, got %v", err)
+	}
+	if err := s.ModerateUserName(context.Background(), "yamada"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestService_TermManagement(t *testing.T) {
+	s, termRepo, _, auditRepo := newTestService()
+	ctx := context.Background()
+
+	// 空の状態で一度読み込み、キャッシュさせる
+	if result, _ := s.Check(ctx, "spam"); len(result.Matched) != 0 {
+		t.Fatal("no term should match before creation")
+	}
+
+	term, err := s.CreateTerm(ctx, TermInput{Term: "spam", MatchType: domain.MatchSubstring, Action: domain.ModerationReject}, "moderator")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result, _ := s.Check(ctx, "spam"); !result.Rejected {
+		t.Error("cache should be invalidated after CreateTerm")
+	}
+
+	if _, err := s.UpdateTerm(ctx, term.ID, TermInput{Term: "spam", MatchType: domain.MatchSubstring, Action: domain.ModerationMask}, "moderator"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result, _ := s.Check(ctx, "spam"); result.Rejected || result.Text != "****" {
+		t.Errorf("updated term should mask, got %+v", result)
+	}
+
+	if err := s.DeleteTerm(ctx, term.ID, "moderator"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result, _ := s.Check(ctx, "spam"); len(result.Matched) != 0 {
+		t.Error("deleted term should not match")
+	}
+	if termRepo.listCalls != 4 {
+		t.Errorf("expected banned terms to be reloaded after each change, got %d loads", termRepo.listCalls)
+	}
+
+	wantActions := []string{domain.AuditActionBannedTermCreate, domain.AuditActionBannedTermUpdate, domain.AuditActionBannedTermDelete}
+	if len(auditRepo.logs) != len(wantActions) {
+		t.Fatalf("expected %d audit logs, got %d", len(wantActions), len(auditRepo.logs))
+	}
+	for i, action := range wantActions {
+		if auditRepo.logs[i].Action != action || auditRepo.logs[i].Actor != "moderator" {
+			t.Errorf("audit log %d: %+v", i, auditRepo.logs[i])
+		}
+	}
+	if auditRepo.logs[0].Before != nil || auditRepo.logs[2].After != nil {
+		t.Error("create should have no before state and delete no after state")
+	}
+}
+
+func TestService_BlockUser(t *testing.T) {
+	s, _, userRepo, auditRepo := newTestService()
+	ctx := context.Background()
+
+	user, err := s.BlockUser(ctx, "troll", "荒らし", time.Hour, "moderator")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !user.IsBlocked(func() time.Time { return testNow }) || user.IsPermanentlyBlocked() {
+		t.Errorf("expected a timed block, got %+v", user)
+	}
+	if userRepo.users["troll"] != user {
+		t.Error("unknown user should be registered")
+	}
+
+	// ブロック中の再ブロックは期限と理由を上書きする
+	user, err = s.BlockUser(ctx, "troll", "再犯", 0, "moderator")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !user.IsPermanentlyBlocked() || user.BlockReason != "再犯" {
+		t.Errorf("expected block to be replaced, got %+v", user)
+	}
+
+	user, err = s.UnblockUser(ctx, "troll", "解除", "moderator")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.IsBlocked(nil) {
+		t.Error("user should be unblocked")
+	}
+
+	if _, err := s.UnblockUser(ctx, "troll", "", "moderator"); !errors.Is(err, domain.ErrUserNotBlocked) {
+		t.Errorf("expected ErrUserNotBlocked, got %v", err)
+	}
+	if !userRepo.tx.rolledBack {
+		t.Error("failed unblock should roll back")
+	}
+	if _, err := s.UnblockUser(ctx, "nobody", "", "moderator"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+
+	wantActions := []string{domain.AuditActionUserBlock, domain.AuditActionUserBlock, domain.AuditActionUserUnblock}
+	if len(auditRepo.logs) != len(wantActions) {
+		t.Fatalf("expected %d audit logs, got %d", len(wantActions), len(auditRepo.logs))
+	}
+	for i, action := range wantActions {
+		if auditRepo.logs[i].Action != action {
+			t.Errorf("audit log %d: expected %s, got %s", i, action, auditRepo.logs[i].Action)
+		}
+	}
+	if auditRepo.logs[2].Reason != "解除" {
+		t.Errorf("unexpected unblock reason: %q", auditRepo.logs[2].Reason)
+	}
+}
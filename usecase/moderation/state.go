@@ -0,0 +1,53 @@
+package moderation
+
+import (
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// BlockState 監査ログに記録するユーザーのブロック状態
+type BlockState struct {
+	UserID       int64      `json:"user_id"`
+	UserName     string     `json:"user_name"`
+	BlockedUntil *time.Time `json:"blocked_until"`
+	BlockReason  string     `json:"block_reason,omitempty"`
+	BlockedBy    string     `json:"blocked_by,omitempty"`
+}
+
+// NewBlockState ユーザーの現在のブロック状態を取得する
+func NewBlockState(user *domain.User) *BlockState {
+	state := &BlockState{
+		UserID:      user.ID,
+		UserName:    user.Name,
+		BlockReason: user.BlockReason,
+		BlockedBy:   user.BlockedBy,
+	}
+	if user.BlockedUntil != nil {
+		until := *user.BlockedUntil
+		state.BlockedUntil = &until
+	}
+	return state
+}
+
+// termState 監査ログに記録する禁止ワードの状態
+type termState struct {
+	ID        int64  `json:"id"`
+	Term      string `json:"term"`
+	MatchType string `json:"match_type"`
+	Action    string `json:"action"`
+	AutoBlock bool   `json:"auto_block"`
+}
+
+func newTermState(term *domain.BannedTerm) *termState {
+	if term == nil {
+		return nil
+	}
+	return &termState{
+		ID:        term.ID,
+		Term:      term.Term,
+		MatchType: string(term.MatchType),
+		Action:    string(term.Action),
+		AutoBlock: term.AutoBlock,
+	}
+}
@@ -0,0 +1,86 @@
+package moderation
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// BlockUser 管理者がユーザーをブロックする（duration が0なら無期限）
+// 未登録のユーザー名も作成してブロックする。ブロック中のユーザーは期限と理由を上書きする
+func (s *Service) BlockUser(ctx context.Context, userName, reason string, duration time.Duration, actor string) (*domain.User, error) {
+	return s.changeBlock(ctx, userName, true, actor, domain.AuditActionUserBlock, reason, func(user *domain.User) error {
+		return user.Block(reason, actor, duration, s.now)
+	})
+}
+
+// UnblockUser 管理者がユーザーのブロックを解除する
+func (s *Service) UnblockUser(ctx context.Context, userName, reason, actor string) (*domain.User, error) {
+	return s.changeBlock(ctx, userName, false, actor, domain.AuditActionUserUnblock, reason, func(user *domain.User) error {
+		return user.Unblock(s.now)
+	})
+}
+
+// changeBlock ユーザー行をロックしてブロック状態を変更し、同じトランザクションで監査ログを記録する
+func (s *Service) changeBlock(
+	ctx context.Context,
+	userName string,
+	createIfMissing bool,
+	actor, action, reason string,
+	apply func(user *domain.User) error,
+) (*domain.User, error) {
+	tx, err := s.userRepository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	user, err := s.userRepository.FindByNameWithTx(ctx, tx, userName)
+	if err == domain.ErrUserNotFound && createIfMissing {
+		user, err = domain.NewUser(userName, domain.Tier1, s.now)
+		if err != nil {
+			return nil, err
+		}
+		if err = s.userRepository.SaveWithTx(ctx, tx, user); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	before := NewBlockState(user)
+	if err = apply(user); err != nil {
+		return nil, err
+	}
+	if err = s.userRepository.UpdateWithTx(ctx, tx, user); err != nil {
+		return nil, err
+	}
+
+	auditLog, err := domain.NewAuditLog(
+		actor,
+		action,
+		domain.AuditTargetUser,
+		strconv.FormatInt(user.ID, 10),
+		before,
+		NewBlockState(user),
+		reason,
+		s.now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.auditLogRepository.SaveWithTx(ctx, tx, auditLog); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,309 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+const (
+	// DefaultPollInterval Notify がなくても未配信イベントを確認する間隔
+	DefaultPollInterval = time.Second
+	// DefaultBatchSize 1 回の取得で配信するイベント数
+	DefaultBatchSize = 100
+	// DefaultMaxAttempts 配信を諦めてデッドレターにするまでの失敗回数
+	DefaultMaxAttempts = 10
+	// DefaultRetention 配信済みイベントを保持する期間
+	DefaultRetention = 24 * time.Hour
+	// DefaultDeadRetention デッドレターのイベントを保持する期間（調査できるよう配信済みより長く残す）
+	DefaultDeadRetention = 7 * 24 * time.Hour
+	// purgeInterval 配信済みイベントとデッドレターを削除する間隔
+	purgeInterval = time.Hour
+)
+
+// errUndecodable 復元できないイベント（再試行しても配信できないため、すぐにデッドレターにする）
+var errUndecodable = errors.New("outbox event cannot be decoded")
+
+// dispatchResult イベント 1 件を配信した結果
+type dispatchResult int
+
+const (
+	// dispatchDelivered 配信した
+	dispatchDelivered dispatchResult = iota
+	// dispatchRetrying 配信に失敗し、再試行を待つ（後続のイベントも待たせる）
+	dispatchRetrying
+	// dispatchDead 配信を諦めてデッドレターにした（後続のイベントは配信を続ける）
+	dispatchDead
+)
+
+// Sink イベントを取りこぼしてはいけない送信先（Webhook の配信キューなど）
+// Dispatcher が同期的に呼び、Deliver がエラーを返したイベントは配信済みにせず失敗として記録して再試行する
+// 同じイベントが再試行で何度も渡されるため、Deliver は冪等にする
+type Sink interface {
+	Deliver(ctx context.Context, event *domain.OutboxEvent) error
+}
+
+// Dispatcher アウトボックスの未配信イベントを ID 順に sink へ配信する
+// 配信に失敗したイベントは再試行間隔を空けて再試行し、それまで後続のイベントも配信しない
+// （session_end が session_start より先に届かないようにする）
+// 配信済みの記録に失敗した場合は再配信されるため、配信は at-least-once になる
+// （クライアントは EventID で重複を除去する）
+// EventBroadcaster の sink は受け取ったイベントをメモリ上で配る（WebSocket など、取りこぼしは再接続時の再送で補う）
+type Dispatcher struct {
+	repo          repository.OutboxRepository
+	sinks         []command.EventBroadcaster
+	durableSinks  []Sink
+	wake          chan struct{}
+	pollInterval  time.Duration
+	batchSize     int32
+	maxAttempts   int32
+	retention     time.Duration
+	deadRetention time.Duration
+	now           func() time.Time
+}
+
+// NewDispatcher creates a new outbox dispatcher delivering to the given sinks
+func NewDispatcher(repo repository.OutboxRepository, sinks ...command.EventBroadcaster) *Dispatcher {
+	return &Dispatcher{
+		repo:          repo,
+		sinks:         sinks,
+		wake:          make(chan struct{}, 1),
+		pollInterval:  DefaultPollInterval,
+		batchSize:     DefaultBatchSize,
+		maxAttempts:   DefaultMaxAttempts,
+		retention:     DefaultRetention,
+		deadRetention: DefaultDeadRetention,
+		now:           func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// AddSink 取りこぼしてはいけない送信先を追加する（Run の前に呼ぶ）
+// イベントは EventBroadcaster の sink より先に、追加した順に渡す
+func (d *Dispatcher) AddSink(sink Sink) {
+	d.durableSinks = append(d.durableSinks, sink)
+}
+
+// Notify 新しいイベントがコミットされたことを知らせる（ブロックしない）
+func (d *Dispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+		// 既に通知済み
+	}
+}
+
+// Run ctx がキャンセルされるまで未配信イベントを配信し続ける
+// 起動時に前回のプロセスで配信できなかったイベントも配信する
+func (d *Dispatcher) Run(ctx context.Context) {
+	poll := time.NewTicker(d.pollInterval)
+	defer poll.Stop()
+	purge := time.NewTicker(purgeInterval)
+	defer purge.Stop()
+
+	d.dispatch(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+			d.dispatch(ctx)
+		case <-poll.C:
+			d.dispatch(ctx)
+		case <-purge.C:
+			d.purge(ctx)
+		}
+	}
+}
+
+// purge 保持期間を過ぎた配信済みイベントとデッドレターを削除する
+func (d *Dispatcher) purge(ctx context.Context) {
+	now := d.now()
+	deleted, err := d.repo.DeleteDispatchedBefore(ctx, now.Add(-d.retention))
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			logging.FromContext(ctx).Error("failed to purge dispatched outbox events", logging.Err(err))
+		}
+		return
+	}
+	if deleted > 0 {
+		logging.FromContext(ctx).Info("purged dispatched outbox events", "deleted", deleted)
+	}
+
+	deleted, err = d.repo.DeleteDeadBefore(ctx, now.Add(-d.deadRetention))
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			logging.FromContext(ctx).Error("failed to purge dead outbox events", logging.Err(err))
+		}
+		return
+	}
+	if deleted > 0 {
+		logging.FromContext(ctx).Info("purged dead outbox events", "deleted", deleted)
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	if _, err := d.DispatchPending(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		logging.FromContext(ctx).Error("failed to dispatch outbox events", logging.Err(err))
+	}
+}
+
+// DispatchPending 未配信のイベントを ID 順に配信し、配信したイベント数を返す
+// 配信に失敗したイベントは失敗回数と次の再試行時刻を記録し、そこで配信を止める
+// （後続のイベントは、失敗したイベントが配信されるかデッドレターになるまで待つ）
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	dispatched := 0
+	for {
+		events, err := d.repo.ListPending(ctx, d.batchSize)
+		if err != nil {
+			return dispatched, err
+		}
+
+		for _, event := range events {
+			if !event.IsDue(d.now()) {
+				return dispatched, nil
+			}
+			result, err := d.dispatchEvent(ctx, event)
+			if err != nil {
+				return dispatched, err
+			}
+			switch result {
+			case dispatchDelivered:
+				dispatched++
+			case dispatchRetrying:
+				return dispatched, nil
+			}
+		}
+
+		if len(events) < int(d.batchSize) {
+			return dispatched, nil
+		}
+	}
+}
+
+// dispatchEvent イベント 1 件を配信して結果を記録する
+// 配信の失敗は記録して結果で返し、記録自体の失敗だけをエラーとして返す
+// 失敗回数が maxAttempts に達したイベントと復元できないイベントはデッドレターにする
+// ポーリングのたびにトレースが増えないよう、スパンは配信するイベントがあるときだけ作る
+func (d *Dispatcher) dispatchEvent(ctx context.Context, event *domain.OutboxEvent) (_ dispatchResult, err error) {
+	ctx, span := tracing.Start(ctx, "Dispatcher.dispatchEvent",
+		tracing.KeyEventID.Int64(event.ID), tracing.KeyEventType.String(event.EventType))
+	defer func() { tracing.End(span, err) }()
+
+	deliverErr := d.deliver(ctx, event)
+	if deliverErr == nil {
+		if err := d.repo.MarkDispatched(ctx, event.ID, d.now()); err != nil {
+			return dispatchRetrying, err
+		}
+		return dispatchDelivered, nil
+	}
+
+	span.RecordError(deliverErr)
+	attempt := event.Attempts + 1
+	logger := logging.FromContext(ctx).With("event_id", event.ID, "event_type", event.EventType, "attempt", attempt)
+	if attempt >= d.maxAttempts || errors.Is(deliverErr, errUndecodable) {
+		logger.Error("giving up outbox event, moved to dead letter", logging.Err(deliverErr))
+		if err := d.repo.MarkDead(ctx, event.ID, deliverErr.Error(), d.now()); err != nil {
+			return dispatchRetrying, err
+		}
+		return dispatchDead, nil
+	}
+
+	nextAttemptAt := d.now().Add(domain.OutboxBackoff(attempt))
+	logger.Error("failed to deliver outbox event", "next_attempt_at", nextAttemptAt, logging.Err(deliverErr))
+	if err := d.repo.MarkFailed(ctx, event.ID, deliverErr.Error(), nextAttemptAt); err != nil {
+		return dispatchRetrying, err
+	}
+	return dispatchRetrying, nil
+}
+
+// deliver イベントを復元してすべての sink に渡す
+// Sink が失敗した場合は EventBroadcaster の sink には渡さない（再試行でまとめて渡す）
+func (d *Dispatcher) deliver(ctx context.Context, event *domain.OutboxEvent) error {
+	send, err := decode(event)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUndecodable, err)
+	}
+	for _, sink := range d.durableSinks {
+		if err := deliverToSink(ctx, sink, event); err != nil {
+			return err
+		}
+	}
+	for _, sink := range d.sinks {
+		if err := deliverTo(sink, send); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliverTo sink の panic を配信エラーとして扱う
+func deliverTo(sink command.EventBroadcaster, send func(command.EventBroadcaster)) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("sink panicked: %v", r)
+		}
+	}()
+	send(sink)
+	return nil
+}
+
+// deliverToSink Sink の panic を配信エラーとして扱う
+func deliverToSink(ctx context.Context, sink Sink, event *domain.OutboxEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("sink panicked: %v", r)
+		}
+	}()
+	return sink.Deliver(ctx, event)
+}
+
+// decode アウトボックスのペイロードを broadcast イベントに戻し、EventID を設定する
+func decode(event *domain.OutboxEvent) (func(command.EventBroadcaster), error) {
+	switch event.EventType {
+	case EventTypeSessionStart:
+		var e command.SessionStartBroadcast
+		if err := json.Unmarshal(event.Payload, &e); err != nil {
+			return nil, err
+		}
+		e.EventID = event.ID
+		return func(b command.EventBroadcaster) { b.BroadcastSessionStart(e) }, nil
+	case EventTypeSessionEnd:
+		var e command.SessionEndBroadcast
+		if err := json.Unmarshal(event.Payload, &e); err != nil {
+			return nil, err
+		}
+		e.EventID = event.ID
+		return func(b command.EventBroadcaster) { b.BroadcastSessionEnd(e) }, nil
+	case EventTypeSessionExtend:
+		var e command.SessionExtendBroadcast
+		if err := json.Unmarshal(event.Payload, &e); err != nil {
+			return nil, err
+		}
+		e.EventID = event.ID
+		return func(b command.EventBroadcaster) { b.BroadcastSessionExtend(e) }, nil
+	case EventTypeWorkNameChange:
+		var e command.WorkNameChangeBroadcast
+		if err := json.Unmarshal(event.Payload, &e); err != nil {
+			return nil, err
+		}
+		e.EventID = event.ID
+		return func(b command.EventBroadcaster) { b.BroadcastWorkNameChange(e) }, nil
+	case EventTypeSettingsChanged:
+		var e command.SettingsChangedBroadcast
+		if err := json.Unmarshal(event.Payload, &e); err != nil {
+			return nil, err
+		}
+		e.EventID = event.ID
+		return func(b command.EventBroadcaster) { b.BroadcastSettingsChanged(e) }, nil
+	default:
+		return nil, fmt.Errorf("unknown outbox event type: %q", event.EventType)
+	}
+}
@@ -0,0 +1,475 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+// fakeOutboxRepository インメモリのアウトボックス
+type fakeOutboxRepository struct {
+	events  map[int64]*domain.OutboxEvent
+	nextID  int64
+	nextSeq int64
+}
+
+func newFakeOutboxRepository() *fakeOutboxRepository {
+	return &fakeOutboxRepository{events: make(map[int64]*domain.OutboxEvent)}
+}
+
+func (r *fakeOutboxRepository) SaveWithTx(ctx context.Context, tx repository.Tx, event *domain.OutboxEvent) error {
+	r.nextID++
+	event.ID = r.nextID
+	copied := *event
+	r.events[event.ID] = &copied
+	return nil
+}
+
+func (r *fakeOutboxRepository) ListPending(ctx context.Context, limit int32) ([]*domain.OutboxEvent, error) {
+	var pending []*domain.OutboxEvent
+	for _, e := range r.events {
+		if !e.IsDispatched() && !e.IsDead() {
+			copied := *e
+			pending = append(pending, &copied)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+	if int32(len(pending)) > limit {
+		pending = pending[:limit]
+	}
+	return pending, nil
+}
+
+func (r *fakeOutboxRepository) ListDispatchedAfter(ctx context.Context, afterID int64, limit int32) ([]*domain.OutboxEvent, error) {
+	var afterSeq int64
+	if e, ok := r.events[afterID]; ok && e.IsDispatched() {
+		afterSeq = e.DispatchSeq
+	} else {
+		for _, e := range r.events {
+			if e.IsDispatched() && e.ID <= afterID && e.DispatchSeq > afterSeq {
+				afterSeq = e.DispatchSeq
+			}
+		}
+	}
+
+	var dispatched []*domain.OutboxEvent
+	for _, e := range r.events {
+		if e.IsDispatched() && e.DispatchSeq > afterSeq {
+			copied := *e
+			dispatched = append(dispatched, &copied)
+		}
+	}
+	sort.Slice(dispatched, func(i, j int) bool { return dispatched[i].DispatchSeq < dispatched[j].DispatchSeq })
+	if int32(len(dispatched)) > limit {
+		dispatched = dispatched[:limit]
+	}
+	return dispatched, nil
+}
+
+func (r *fakeOutboxRepository) MarkDispatched(ctx context.Context, id int64, dispatchedAt time.Time) error {
+	r.nextSeq++
+	r.events[id].DispatchedAt = &dispatchedAt
+	r.events[id].DispatchSeq = r.nextSeq
+	return nil
+}
+
+func (r *fakeOutboxRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	r.events[id].Attempts++
+	r.events[id].LastError = lastError
+	r.events[id].NextAttemptAt = &nextAttemptAt
+	return nil
+}
+
+func (r *fakeOutboxRepository) MarkDead(ctx context.Context, id int64, lastError string, deadAt time.Time) error {
+	r.events[id].Attempts++
+	r.events[id].LastError = lastError
+	r.events[id].DeadAt = &deadAt
+	return nil
+}
+
+func (r *fakeOutboxRepository) ListDead(ctx context.Context, limit int32) ([]*domain.OutboxEvent, error) {
+	var dead []*domain.OutboxEvent
+	for _, e := range r.events {
+		if e.IsDead() {
+			copied := *e
+			dead = append(dead, &copied)
+		}
+	}
+	sort.Slice(dead, func(i, j int) bool { return dead[i].ID > dead[j].ID })
+	if int32(len(dead)) > limit {
+		dead = dead[:limit]
+	}
+	return dead, nil
+}
+
+func (r *fakeOutboxRepository) DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for id, e := range r.events {
+		if e.IsDispatched() && e.DispatchedAt.Before(before) {
+			delete(r.events, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (r *fakeOutboxRepository) DeleteDeadBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for id, e := range r.events {
+		if e.IsDead() && e.DeadAt.Before(before) {
+			delete(r.events, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// fakeClock テストで進められる時計
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// recordingSink 受け取ったイベントを記録する sink
+type recordingSink struct {
+	command.NoOpBroadcaster
+	starts []command.SessionStartBroadcast
+	ends   []command.SessionEndBroadcast
+	panics bool
+}
+
+func (s *recordingSink) BroadcastSessionStart(event command.SessionStartBroadcast) {
+	if s.panics {
+		panic("sink is broken")
+	}
+	s.starts = append(s.starts, event)
+}
+
+func (s *recordingSink) BroadcastSessionEnd(event command.SessionEndBroadcast) {
+	s.ends = append(s.ends, event)
+}
+
+func TestDispatcher_DispatchPending(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeOutboxRepository()
+	recorder := NewRecorder(repo, nil)
+
+	start := time.Date(2025, 11, 24, 10, 0, 0, 0, time.UTC)
+	if err := recorder.RecordSessionStart(ctx, nil, command.SessionStartBroadcast{SessionID: 99, UserID: 42, UserName: "yamada", StartTime: start}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := recorder.RecordSessionEnd(ctx, nil, command.SessionEndBroadcast{SessionID: 99, UserID: 42, ActualEnd: start.Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sink := &recordingSink{}
+	dispatcher := NewDispatcher(repo, sink)
+
+	n, err := dispatcher.DispatchPending(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 dispatched events, got %d", n)
+	}
+	if len(sink.starts) != 1 || len(sink.ends) != 1 {
+		t.Fatalf("expected one start and one end, got %+v", sink)
+	}
+	if got := sink.starts[0]; got.EventID != 1 || got.SessionID != 99 || got.UserName != "yamada" || !got.StartTime.Equal(start) {
+		t.Errorf("unexpected session start: %+v", got)
+	}
+	if got := sink.ends[0]; got.EventID != 2 || !got.ActualEnd.Equal(start.Add(time.Hour)) {
+		t.Errorf("unexpected session end: %+v", got)
+	}
+
+	// 配信済みのイベントは再配信されない
+	if n, _ := dispatcher.DispatchPending(ctx); n != 0 {
+		t.Errorf("expected no events to be redelivered, got %d", n)
+	}
+}
+
+func TestDispatcher_RetriesFailedEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeOutboxRepository()
+	if err := NewRecorder(repo, nil).RecordSessionStart(ctx, nil, command.SessionStartBroadcast{SessionID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clock := &fakeClock{now: time.Date(2025, 11, 24, 10, 0, 0, 0, time.UTC)}
+	sink := &recordingSink{panics: true}
+	dispatcher := NewDispatcher(repo, sink)
+	dispatcher.now = clock.Now
+
+	if n, err := dispatcher.DispatchPending(ctx); err != nil || n != 0 {
+		t.Fatalf("expected failed delivery, got n=%d err=%v", n, err)
+	}
+	e := repo.events[1]
+	if e.Attempts != 1 || e.LastError == "" || e.IsDispatched() || e.IsDead() {
+		t.Errorf("expected failure to be recorded, got %+v", e)
+	}
+	if e.NextAttemptAt == nil || !e.NextAttemptAt.Equal(clock.now.Add(domain.OutboxBackoff(1))) {
+		t.Errorf("expected next attempt after backoff, got %v", e.NextAttemptAt)
+	}
+
+	// 再試行間隔が過ぎるまでは再試行しない
+	sink.panics = false
+	if n, err := dispatcher.DispatchPending(ctx); err != nil || n != 0 || len(sink.starts) != 0 {
+		t.Fatalf("expected no retry before backoff, got n=%d err=%v starts=%+v", n, err, sink.starts)
+	}
+
+	// sink が復旧していれば、再試行間隔が過ぎた後の配信で届く
+	clock.Advance(domain.OutboxBackoff(1))
+	if n, err := dispatcher.DispatchPending(ctx); err != nil || n != 1 {
+		t.Fatalf("expected redelivery, got n=%d err=%v", n, err)
+	}
+	if len(sink.starts) != 1 || sink.starts[0].EventID != 1 {
+		t.Errorf("unexpected deliveries: %+v", sink.starts)
+	}
+}
+
+// failingSink Deliver が err を返す Sink
+type failingSink struct {
+	err       error
+	delivered []int64
+}
+
+func (s *failingSink) Deliver(ctx context.Context, event *domain.OutboxEvent) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.delivered = append(s.delivered, event.ID)
+	return nil
+}
+
+func TestDispatcher_RetriesWhenSinkFails(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeOutboxRepository()
+	if err := NewRecorder(repo, nil).RecordSessionStart(ctx, nil, command.SessionStartBroadcast{SessionID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clock := &fakeClock{now: time.Date(2025, 11, 24, 10, 0, 0, 0, time.UTC)}
+	durable := &failingSink{err: errors.New("database is down")}
+	broadcaster := &recordingSink{}
+	dispatcher := NewDispatcher(repo, broadcaster)
+	dispatcher.AddSink(durable)
+	dispatcher.now = clock.Now
+
+	if n, err := dispatcher.DispatchPending(ctx); err != nil || n != 0 {
+		t.Fatalf("expected failed delivery, got n=%d err=%v", n, err)
+	}
+	if e := repo.events[1]; e.Attempts != 1 || e.LastError != "database is down" || e.IsDispatched() {
+		t.Errorf("expected the failure to be recorded, got %+v", e)
+	}
+	if len(broadcaster.starts) != 0 {
+		t.Errorf("broadcasters must not receive an event the sink failed on, got %+v", broadcaster.starts)
+	}
+
+	// Sink が復旧すれば再試行で両方に届く
+	durable.err = nil
+	clock.Advance(domain.OutboxBackoff(1))
+	if n, err := dispatcher.DispatchPending(ctx); err != nil || n != 1 {
+		t.Fatalf("expected redelivery, got n=%d err=%v", n, err)
+	}
+	if len(durable.delivered) != 1 || durable.delivered[0] != 1 || len(broadcaster.starts) != 1 {
+		t.Errorf("unexpected deliveries: sink=%v broadcaster=%+v", durable.delivered, broadcaster.starts)
+	}
+}
+
+func TestDispatcher_StopsAtFirstFailure(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeOutboxRepository()
+	recorder := NewRecorder(repo, nil)
+	if err := recorder.RecordSessionStart(ctx, nil, command.SessionStartBroadcast{SessionID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := recorder.RecordSessionEnd(ctx, nil, command.SessionEndBroadcast{SessionID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clock := &fakeClock{now: time.Date(2025, 11, 24, 10, 0, 0, 0, time.UTC)}
+	sink := &recordingSink{panics: true}
+	dispatcher := NewDispatcher(repo, sink)
+	dispatcher.now = clock.Now
+
+	// session_start の配信に失敗したら、session_end は先に配信しない
+	if n, err := dispatcher.DispatchPending(ctx); err != nil || n != 0 {
+		t.Fatalf("expected failed delivery, got n=%d err=%v", n, err)
+	}
+	if len(sink.ends) != 0 || repo.events[2].IsDispatched() || repo.events[2].Attempts != 0 {
+		t.Fatalf("expected session_end to wait for session_start, got ends=%+v event=%+v", sink.ends, repo.events[2])
+	}
+
+	sink.panics = false
+	clock.Advance(domain.OutboxBackoff(1))
+	if n, err := dispatcher.DispatchPending(ctx); err != nil || n != 2 {
+		t.Fatalf("expected both events to be delivered, got n=%d err=%v", n, err)
+	}
+	if len(sink.starts) != 1 || len(sink.ends) != 1 {
+		t.Errorf("unexpected deliveries: %+v", sink)
+	}
+}
+
+func TestDispatcher_DeadLettersAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeOutboxRepository()
+	recorder := NewRecorder(repo, nil)
+	if err := recorder.RecordSessionStart(ctx, nil, command.SessionStartBroadcast{SessionID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := recorder.RecordSessionEnd(ctx, nil, command.SessionEndBroadcast{SessionID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clock := &fakeClock{now: time.Date(2025, 11, 24, 10, 0, 0, 0, time.UTC)}
+	sink := &recordingSink{panics: true}
+	dispatcher := NewDispatcher(repo, sink)
+	dispatcher.maxAttempts = 3
+	dispatcher.now = clock.Now
+
+	for i := 0; i < 5; i++ {
+		if _, err := dispatcher.DispatchPending(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		clock.Advance(time.Minute)
+	}
+
+	// 上限まで失敗したイベントはデッドレターになり、後続のイベントの配信が再開する
+	if e := repo.events[1]; e.Attempts != 3 || !e.IsDead() || e.IsDispatched() {
+		t.Errorf("expected event to be dead-lettered after 3 attempts, got %+v", e)
+	}
+	if len(sink.ends) != 1 || !repo.events[2].IsDispatched() {
+		t.Errorf("expected the next event to be delivered, got ends=%+v", sink.ends)
+	}
+	if dead, _ := repo.ListDead(ctx, 10); len(dead) != 1 || dead[0].ID != 1 {
+		t.Errorf("expected event 1 in dead letters, got %+v", dead)
+	}
+}
+
+func TestDispatcher_DeadLettersUndecodableEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeOutboxRepository()
+	repo.events[1] = &domain.OutboxEvent{ID: 1, EventType: "unknown", Payload: []byte(`{}`)}
+	repo.events[2] = &domain.OutboxEvent{ID: 2, EventType: EventTypeSessionStart, Payload: []byte(`{"session_id":1}`)}
+
+	sink := &recordingSink{}
+	dispatcher := NewDispatcher(repo, sink)
+
+	// 再試行しても復元できないイベントはすぐにデッドレターにする
+	if n, err := dispatcher.DispatchPending(ctx); err != nil || n != 1 {
+		t.Fatalf("expected the next event to be delivered, got n=%d err=%v", n, err)
+	}
+	if e := repo.events[1]; e.Attempts != 1 || !e.IsDead() {
+		t.Errorf("expected undecodable event to be dead-lettered, got %+v", e)
+	}
+	if len(sink.starts) != 1 || sink.starts[0].EventID != 2 {
+		t.Errorf("unexpected deliveries: %+v", sink.starts)
+	}
+}
+
+func TestDispatcher_PurgesDeadEvents(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2025, 11, 24, 10, 0, 0, 0, time.UTC)}
+	repo := newFakeOutboxRepository()
+	old := clock.now.Add(-DefaultDeadRetention - time.Second)
+	recent := clock.now.Add(-time.Hour)
+	repo.events[1] = &domain.OutboxEvent{ID: 1, EventType: "unknown", DeadAt: &old}
+	repo.events[2] = &domain.OutboxEvent{ID: 2, EventType: "unknown", DeadAt: &recent}
+
+	dispatcher := NewDispatcher(repo)
+	dispatcher.now = clock.Now
+	dispatcher.purge(ctx)
+
+	if _, ok := repo.events[1]; ok {
+		t.Error("expected dead event past retention to be purged")
+	}
+	if _, ok := repo.events[2]; !ok {
+		t.Error("expected recent dead event to be kept")
+	}
+}
+
+func TestDispatcher_Notify(t *testing.T) {
+	dispatcher := NewDispatcher(newFakeOutboxRepository())
+
+	// 複数回の通知はまとめられ、ブロックしない
+	dispatcher.Notify()
+	dispatcher.Notify()
+	if len(dispatcher.wake) != 1 {
+		t.Errorf("expected one pending wake-up, got %d", len(dispatcher.wake))
+	}
+}
+
+func TestReplayer_ReplayAfter(t *testing.T) {
+	repo := newFakeOutboxRepository()
+	recorder := NewRecorder(repo, nil)
+	for i := int64(1); i <= 3; i++ {
+		if err := recorder.RecordSessionStart(context.Background(), nil, command.SessionStartBroadcast{SessionID: i}); err != nil {
+			t.Fatalf("failed to record event: %v", err)
+		}
+	}
+	// 未配信のイベントは再送の対象外
+	sink := &recordingSink{}
+	dispatcher := NewDispatcher(repo, sink)
+	if _, err := dispatcher.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("failed to dispatch: %v", err)
+	}
+	if err := recorder.RecordSessionEnd(context.Background(), nil, command.SessionEndBroadcast{SessionID: 1}); err != nil {
+		t.Fatalf("failed to record event: %v", err)
+	}
+
+	replayed := &recordingSink{}
+	n, err := NewReplayer(repo).ReplayAfter(context.Background(), 1, 100, replayed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 || len(replayed.starts) != 2 || len(replayed.ends) != 0 {
+		t.Fatalf("expected 2 replayed session_start events, got n=%d starts=%+v ends=%+v", n, replayed.starts, replayed.ends)
+	}
+	if replayed.starts[0].EventID != 2 || replayed.starts[1].EventID != 3 {
+		t.Errorf("expected events 2 and 3 in order, got %+v", replayed.starts)
+	}
+}
+
+func TestReplayer_ReplayAfter_OutOfOrderCommits(t *testing.T) {
+	// ID 1 のトランザクションが ID 2 より後にコミットされ、2 → 1 の順に配信された
+	repo := newFakeOutboxRepository()
+	recorder := NewRecorder(repo, nil)
+	for i := int64(1); i <= 3; i++ {
+		if err := recorder.RecordSessionStart(context.Background(), nil, command.SessionStartBroadcast{SessionID: i}); err != nil {
+			t.Fatalf("failed to record event: %v", err)
+		}
+	}
+	now := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+	for _, id := range []int64{2, 1, 3} {
+		if err := repo.MarkDispatched(context.Background(), id, now); err != nil {
+			t.Fatalf("failed to mark dispatched: %v", err)
+		}
+	}
+
+	// ID 2 を受け取った直後に切断したクライアントは、後から配信された 1 と 3 を受け取る
+	replayed := &recordingSink{}
+	if _, err := NewReplayer(repo).ReplayAfter(context.Background(), 2, 100, replayed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(replayed.starts) != 2 || replayed.starts[0].EventID != 1 || replayed.starts[1].EventID != 3 {
+		t.Fatalf("expected events 1 and 3 in delivery order, got %+v", replayed.starts)
+	}
+
+	// 最後に受け取ったのが ID 3 なら再送するものはない
+	replayed = &recordingSink{}
+	if _, err := NewReplayer(repo).ReplayAfter(context.Background(), 3, 100, replayed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(replayed.starts) != 0 {
+		t.Errorf("expected nothing to replay, got %+v", replayed.starts)
+	}
+}
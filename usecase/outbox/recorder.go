@@ -0,0 +1,79 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+// アウトボックスに記録するイベントの種別（WebSocket イベントの type と同じ値）
+const (
+	EventTypeSessionStart    = "session_start"
+	EventTypeSessionEnd      = "session_end"
+	EventTypeSessionExtend   = "session_extend"
+	EventTypeWorkNameChange  = "work_name_change"
+	EventTypeSettingsChanged = "settings_changed"
+)
+
+// Ensure Recorder implements command.EventOutbox
+var _ command.EventOutbox = (*Recorder)(nil)
+
+// Recorder 状態変更と同じトランザクションでイベントをアウトボックスに記録する
+type Recorder struct {
+	repo       repository.OutboxRepository
+	dispatcher *Dispatcher
+	now        func() time.Time
+}
+
+// NewRecorder creates a new outbox recorder
+// dispatcher が nil の場合、Notify は何もしない（次のポーリングで配信される）
+func NewRecorder(repo repository.OutboxRepository, dispatcher *Dispatcher) *Recorder {
+	return &Recorder{
+		repo:       repo,
+		dispatcher: dispatcher,
+		now:        func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// RecordSessionStart implements command.EventOutbox
+func (r *Recorder) RecordSessionStart(ctx context.Context, tx repository.Tx, event command.SessionStartBroadcast) error {
+	return r.record(ctx, tx, EventTypeSessionStart, event)
+}
+
+// RecordSessionEnd implements command.EventOutbox
+func (r *Recorder) RecordSessionEnd(ctx context.Context, tx repository.Tx, event command.SessionEndBroadcast) error {
+	return r.record(ctx, tx, EventTypeSessionEnd, event)
+}
+
+// RecordWorkNameChange implements command.EventOutbox
+func (r *Recorder) RecordWorkNameChange(ctx context.Context, tx repository.Tx, event command.WorkNameChangeBroadcast) error {
+	return r.record(ctx, tx, EventTypeWorkNameChange, event)
+}
+
+// RecordSessionExtend implements command.EventOutbox
+func (r *Recorder) RecordSessionExtend(ctx context.Context, tx repository.Tx, event command.SessionExtendBroadcast) error {
+	return r.record(ctx, tx, EventTypeSessionExtend, event)
+}
+
+// RecordSettingsChanged 配信中に変更できる設定の変更を記録する
+func (r *Recorder) RecordSettingsChanged(ctx context.Context, tx repository.Tx, event command.SettingsChangedBroadcast) error {
+	return r.record(ctx, tx, EventTypeSettingsChanged, event)
+}
+
+// Notify implements command.EventOutbox
+func (r *Recorder) Notify() {
+	if r.dispatcher != nil {
+		r.dispatcher.Notify()
+	}
+}
+
+func (r *Recorder) record(ctx context.Context, tx repository.Tx, eventType string, payload any) error {
+	event, err := domain.NewOutboxEvent(eventType, payload, r.now)
+	if err != nil {
+		return err
+	}
+	return r.repo.SaveWithTx(ctx, tx, event)
+}
@@ -0,0 +1,42 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+// Replayer 配信済みのイベントをアウトボックスから読み直し、sink に渡す
+// 切断したクライアントが Last-Event-ID から再開するために使う
+type Replayer struct {
+	repo repository.OutboxRepository
+}
+
+// NewReplayer creates a new outbox replayer
+func NewReplayer(repo repository.OutboxRepository) *Replayer {
+	return &Replayer{repo: repo}
+}
+
+// ReplayAfter afterID のイベントより後に配信されたイベントを配信した順に最大 limit 件 sink に渡し、渡した件数を返す
+// ID は配信順と一致しない（小さい ID のトランザクションが後からコミットされる）ため、ID の大小では比べない
+// 保持期間（DefaultRetention）を過ぎて削除されたイベントは再送できない
+func (r *Replayer) ReplayAfter(ctx context.Context, afterID int64, limit int32, sink command.EventBroadcaster) (int, error) {
+	events, err := r.repo.ListDispatchedAfter(ctx, afterID, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for _, event := range events {
+		send, err := decode(event)
+		if err != nil {
+			logging.FromContext(ctx).Warn("skipping outbox event on replay", "event_id", event.ID, logging.Err(err))
+			continue
+		}
+		send(sink)
+		replayed++
+	}
+	return replayed, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/yamada-ai/workspace-backend/domain"
 	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
 )
 
 // GetActiveSessionsOutput represents the output of GetActiveSessions query
@@ -27,7 +28,10 @@ func NewGetActiveSessionsUseCase(
 }
 
 // Execute retrieves all active sessions with user information
-func (uc *GetActiveSessionsUseCase) Execute(ctx context.Context) (*GetActiveSessionsOutput, error) {
+func (uc *GetActiveSessionsUseCase) Execute(ctx context.Context) (_ *GetActiveSessionsOutput, err error) {
+	ctx, span := tracing.Start(ctx, "GetActiveSessionsUseCase.Execute")
+	defer func() { tracing.End(span, err) }()
+
 	sessions, err := uc.sessionRepository.FindAllActive(ctx)
 	if err != nil {
 		return nil, err
@@ -6,6 +6,7 @@ import (
 
 	"github.com/yamada-ai/workspace-backend/domain"
 	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
 )
 
 // GetUserInfoInput represents the input for GetUserInfo query
@@ -41,7 +42,10 @@ func NewGetUserInfoUseCase(
 }
 
 // Execute retrieves user session information
-func (uc *GetUserInfoUseCase) Execute(ctx context.Context, input GetUserInfoInput) (*GetUserInfoOutput, error) {
+func (uc *GetUserInfoUseCase) Execute(ctx context.Context, input GetUserInfoInput) (_ *GetUserInfoOutput, err error) {
+	ctx, span := tracing.Start(ctx, "GetUserInfoUseCase.Execute", tracing.KeyUserName.String(input.UserName))
+	defer func() { tracing.End(span, err) }()
+
 	// 1. Find user
 	user, err := uc.userRepository.FindByName(ctx, input.UserName)
 	if err != nil {
@@ -224,10 +224,18 @@ func (m *mockUserRepository) FindByNameWithTx(ctx context.Context, tx repository
 	return nil, domain.ErrUserNotFound
 }
 
+func (m *mockUserRepository) FindByIDWithTx(ctx context.Context, tx repository.Tx, id int64) (*domain.User, error) {
+	return nil, domain.ErrUserNotFound
+}
+
 func (m *mockUserRepository) SaveWithTx(ctx context.Context, tx repository.Tx, user *domain.User) error {
 	return nil
 }
 
+func (m *mockUserRepository) UpdateWithTx(ctx context.Context, tx repository.Tx, user *domain.User) error {
+	return nil
+}
+
 type mockSessionRepository struct {
 	findActiveByUserIDFn       func(ctx context.Context, userID int64) (*domain.Session, error)
 	findByUserIDAndDateRangeFn func(ctx context.Context, userID int64, startTime, endTime time.Time) ([]*domain.Session, error)
@@ -275,10 +283,34 @@ func (m *mockSessionRepository) FindActiveByUserIDWithTx(ctx context.Context, tx
 	return nil, domain.ErrSessionNotFound
 }
 
+func (m *mockSessionRepository) UpdateWithTx(ctx context.Context, tx repository.Tx, session *domain.Session) error {
+	return nil
+}
+
 func (m *mockSessionRepository) CreateWithTx(ctx context.Context, tx repository.Tx, session *domain.Session) error {
 	return nil
 }
 
+func (m *mockSessionRepository) FindByIDWithTx(ctx context.Context, tx repository.Tx, id int64) (*domain.Session, error) {
+	return nil, domain.ErrSessionNotFound
+}
+
+func (m *mockSessionRepository) FindOverlappingWithTx(ctx context.Context, tx repository.Tx, userID, excludeID int64, start time.Time, end *time.Time) ([]*domain.Session, error) {
+	return nil, nil
+}
+
+func (m *mockSessionRepository) CorrectWithTx(ctx context.Context, tx repository.Tx, session *domain.Session) error {
+	return nil
+}
+
+func (m *mockSessionRepository) DeleteWithTx(ctx context.Context, tx repository.Tx, id int64) error {
+	return nil
+}
+
 func (m *mockSessionRepository) FindAllActive(ctx context.Context) ([]domain.SessionInfo, error) {
 	return nil, nil
 }
+
+func (m *mockSessionRepository) CountActive(ctx context.Context) (int64, error) {
+	return 0, nil
+}
@@ -0,0 +1,73 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
+)
+
+const (
+	// DefaultAuditLogLimit 件数指定がない場合の取得件数
+	DefaultAuditLogLimit = 100
+	// MaxAuditLogLimit 一度に取得できる最大件数
+	MaxAuditLogLimit = 1000
+)
+
+// ErrInvalidAuditLogLimit 取得件数が範囲外
+var ErrInvalidAuditLogLimit = errors.New("invalid audit log limit")
+
+// ListAuditLogsInput represents the input for ListAuditLogs query
+type ListAuditLogsInput struct {
+	TargetType string
+	TargetID   string
+	Limit      int // 0 なら DefaultAuditLogLimit
+}
+
+// ListAuditLogsOutput represents the output of ListAuditLogs query
+type ListAuditLogsOutput struct {
+	Logs []*domain.AuditLog
+}
+
+// ListAuditLogsUseCase handles retrieving audit log entries
+type ListAuditLogsUseCase struct {
+	auditLogRepository repository.AuditLogRepository
+}
+
+// NewListAuditLogsUseCase creates a new use case instance
+func NewListAuditLogsUseCase(
+	auditLogRepository repository.AuditLogRepository,
+) *ListAuditLogsUseCase {
+	return &ListAuditLogsUseCase{
+		auditLogRepository: auditLogRepository,
+	}
+}
+
+// Execute retrieves audit log entries, newest first
+func (uc *ListAuditLogsUseCase) Execute(ctx context.Context, input ListAuditLogsInput) (_ *ListAuditLogsOutput, err error) {
+	ctx, span := tracing.Start(ctx, "ListAuditLogsUseCase.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = DefaultAuditLogLimit
+	}
+	if limit < 0 || limit > MaxAuditLogLimit {
+		return nil, ErrInvalidAuditLogLimit
+	}
+
+	logs, err := uc.auditLogRepository.List(ctx, repository.AuditLogFilter{
+		TargetType: input.TargetType,
+		TargetID:   input.TargetID,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListAuditLogsOutput{
+		Logs: logs,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
+)
+
+const (
+	// DefaultDeadOutboxEventLimit 件数指定がない場合の取得件数
+	DefaultDeadOutboxEventLimit = 50
+	// MaxDeadOutboxEventLimit 一度に取得できる最大件数
+	MaxDeadOutboxEventLimit = 500
+)
+
+// ErrInvalidDeadOutboxEventLimit 取得件数が範囲外
+var ErrInvalidDeadOutboxEventLimit = errors.New("invalid dead outbox event limit")
+
+// ListDeadOutboxEventsInput represents the input for ListDeadOutboxEvents query
+type ListDeadOutboxEventsInput struct {
+	Limit int // 0 なら DefaultDeadOutboxEventLimit
+}
+
+// ListDeadOutboxEventsOutput represents the output of ListDeadOutboxEvents query
+type ListDeadOutboxEventsOutput struct {
+	Events []*domain.OutboxEvent
+}
+
+// ListDeadOutboxEventsUseCase handles retrieving outbox events the dispatcher gave up delivering
+type ListDeadOutboxEventsUseCase struct {
+	outboxRepository repository.OutboxRepository
+}
+
+// NewListDeadOutboxEventsUseCase creates a new use case instance
+func NewListDeadOutboxEventsUseCase(
+	outboxRepository repository.OutboxRepository,
+) *ListDeadOutboxEventsUseCase {
+	return &ListDeadOutboxEventsUseCase{
+		outboxRepository: outboxRepository,
+	}
+}
+
+// Execute retrieves dead-lettered outbox events, most recently dead-lettered first
+func (uc *ListDeadOutboxEventsUseCase) Execute(ctx context.Context, input ListDeadOutboxEventsInput) (_ *ListDeadOutboxEventsOutput, err error) {
+	ctx, span := tracing.Start(ctx, "ListDeadOutboxEventsUseCase.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = DefaultDeadOutboxEventLimit
+	}
+	if limit < 0 || limit > MaxDeadOutboxEventLimit {
+		return nil, ErrInvalidDeadOutboxEventLimit
+	}
+
+	events, err := uc.outboxRepository.ListDead(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListDeadOutboxEventsOutput{
+		Events: events,
+	}, nil
+}
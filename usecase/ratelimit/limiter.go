@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+)
+
+// ErrRateLimited コマンドの実行頻度が制限を超えた
+var ErrRateLimited = errors.New("rate limited")
+
+const (
+	// ScopeUser ユーザー単位の制限に達した
+	ScopeUser = "user"
+	// ScopeGlobal 全体の制限に達した
+	ScopeGlobal = "global"
+)
+
+// LimitError レート制限による拒否を表すエラー
+// errors.Is(err, ErrRateLimited) で判定できる
+type LimitError struct {
+	Scope      string
+	Command    string
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("rate limited (%s, %s): retry after %s", e.Scope, e.Command, e.RetryAfter)
+}
+
+// Is errors.Is で ErrRateLimited と一致させる
+func (e *LimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// Limiter ユーザー×コマンド単位と、コマンド単位の全体のトークンバケットで実行頻度を制限する
+type Limiter struct {
+	policy Policy
+	store  Store
+	now    func() time.Time
+}
+
+// NewLimiter creates a new rate limiter
+func NewLimiter(policy Policy, store Store) *Limiter {
+	return &Limiter{
+		policy: policy,
+		store:  store,
+		now:    time.Now,
+	}
+}
+
+// Allow コマンドの実行を許可する場合は nil、制限を超えた場合は *LimitError を返す
+// ユーザーと全体の両方のバケットにトークンがある場合だけ両方から取得する
+// （全体の制限で拒否されたコマンドがユーザーのトークンを使わないようにする）
+// ストアの障害時はコマンドを止めないよう許可する（fail-open）
+func (l *Limiter) Allow(ctx context.Context, userName string, tier domain.Tier, command string) error {
+	scopes := []string{ScopeUser, ScopeGlobal}
+	requests := []Request{
+		{Key: "user:" + userName + ":" + command, Limit: l.policy.UserLimit(tier)},
+		{Key: "global:" + command, Limit: l.policy.Global},
+	}
+
+	decision, denied, err := l.store.TakeAll(ctx, requests, l.now())
+	if err != nil {
+		logging.FromContext(ctx).Error("rate limit store error", "command", command, logging.Err(err))
+		return nil
+	}
+	if !decision.Allowed {
+		return &LimitError{Scope: scopes[denied], Command: command, RetryAfter: decision.RetryAfter}
+	}
+	return nil
+}
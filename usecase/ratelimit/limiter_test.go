@@ -0,0 +1,199 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+func newTestLimiter(policy Policy, now *time.Time) *Limiter {
+	l := NewLimiter(policy, NewMemoryStore())
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestLimiter_UserBucket(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newTestLimiter(Policy{
+		PerUser: map[domain.Tier]domain.RateLimit{domain.Tier1: {Count: 3, Period: time.Minute}},
+	}, &now)
+	ctx := context.Background()
+
+	t.Run("バースト分までは許可される", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if err := l.Allow(ctx, "yamada", domain.Tier1, "change"); err != nil {
+				t.Fatalf("call %d: unexpected error: %v", i, err)
+			}
+		}
+	})
+
+	t.Run("超過すると Retry-After 付きで拒否される", func(t *testing.T) {
+		err := l.Allow(ctx, "yamada", domain.Tier1, "change")
+		if !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("expected ErrRateLimited, got %v", err)
+		}
+		var limitErr *LimitError
+		if !errors.As(err, &limitErr) {
+			t.Fatalf("expected *LimitError, got %T", err)
+		}
+		if limitErr.Scope != ScopeUser {
+			t.Errorf("expected scope %q, got %q", ScopeUser, limitErr.Scope)
+		}
+		// 3回/分 = 20秒ごとに1トークン補充
+		if limitErr.RetryAfter != 20*time.Second {
+			t.Errorf("expected RetryAfter 20s, got %s", limitErr.RetryAfter)
+		}
+	})
+
+	t.Run("別のコマンドと別のユーザーは独立している", func(t *testing.T) {
+		if err := l.Allow(ctx, "yamada", domain.Tier1, "more"); err != nil {
+			t.Errorf("other command should be allowed: %v", err)
+		}
+		if err := l.Allow(ctx, "tanaka", domain.Tier1, "change"); err != nil {
+			t.Errorf("other user should be allowed: %v", err)
+		}
+	})
+
+	t.Run("時間経過でトークンが補充される", func(t *testing.T) {
+		now = now.Add(20 * time.Second)
+		if err := l.Allow(ctx, "yamada", domain.Tier1, "change"); err != nil {
+			t.Errorf("expected token to be refilled: %v", err)
+		}
+		if err := l.Allow(ctx, "yamada", domain.Tier1, "change"); !errors.Is(err, ErrRateLimited) {
+			t.Errorf("expected ErrRateLimited, got %v", err)
+		}
+	})
+}
+
+func TestLimiter_TierLimits(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newTestLimiter(Policy{
+		PerUser: map[domain.Tier]domain.RateLimit{
+			domain.Tier1: {Count: 1, Period: time.Minute},
+			domain.Tier3: {Count: 5, Period: time.Minute},
+		},
+	}, &now)
+	ctx := context.Background()
+
+	allowed := func(user string, tier domain.Tier, command string) int {
+		n := 0
+		for i := 0; i < 10; i++ {
+			if l.Allow(ctx, user, tier, command) == nil {
+				n++
+			}
+		}
+		return n
+	}
+
+	if n := allowed("a", domain.Tier1, "change"); n != 1 {
+		t.Errorf("tier1: expected 1 allowed, got %d", n)
+	}
+	if n := allowed("b", domain.Tier3, "change"); n != 5 {
+		t.Errorf("tier3: expected 5 allowed, got %d", n)
+	}
+	// コマンドごとに別のバケットで数える
+	if n := allowed("b", domain.Tier3, "in"); n != 5 {
+		t.Errorf("tier3 /in: expected 5 allowed, got %d", n)
+	}
+	// 未設定のティアは Tier1 の制限になる
+	if n := allowed("d", domain.Tier2, "change"); n != 1 {
+		t.Errorf("tier2 fallback: expected 1 allowed, got %d", n)
+	}
+}
+
+func TestLimiter_Global(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newTestLimiter(Policy{
+		PerUser: map[domain.Tier]domain.RateLimit{domain.Tier1: {Count: 10, Period: time.Minute}},
+		Global:  domain.RateLimit{Count: 2, Period: time.Second},
+	}, &now)
+	ctx := context.Background()
+
+	if err := l.Allow(ctx, "a", domain.Tier1, "change"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := l.Allow(ctx, "b", domain.Tier1, "change"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := l.Allow(ctx, "c", domain.Tier1, "change")
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Scope != ScopeGlobal {
+		t.Fatalf("expected global limit error, got %v", err)
+	}
+
+	if err := l.Allow(ctx, "c", domain.Tier1, "out"); err != nil {
+		t.Errorf("global limit is per command: %v", err)
+	}
+}
+
+func TestLimiter_GlobalRejectionKeepsUserToken(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newTestLimiter(Policy{
+		PerUser: map[domain.Tier]domain.RateLimit{domain.Tier1: {Count: 1, Period: time.Minute}},
+		Global:  domain.RateLimit{Count: 1, Period: time.Second},
+	}, &now)
+	ctx := context.Background()
+
+	if err := l.Allow(ctx, "a", domain.Tier1, "change"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := l.Allow(ctx, "b", domain.Tier1, "change")
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Scope != ScopeGlobal {
+		t.Fatalf("expected global limit error, got %v", err)
+	}
+
+	// 全体のトークンが補充されれば、b はユーザーのトークンを使わずに残している
+	now = now.Add(time.Second)
+	if err := l.Allow(ctx, "b", domain.Tier1, "change"); err != nil {
+		t.Errorf("user token must not be spent by a globally rejected command: %v", err)
+	}
+}
+
+func TestLimiter_Unlimited(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newTestLimiter(Policy{}, &now)
+
+	for i := 0; i < 100; i++ {
+		if err := l.Allow(context.Background(), "yamada", domain.Tier1, "change"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) TakeAll(ctx context.Context, requests []Request, now time.Time) (Decision, int, error) {
+	return Decision{}, -1, errors.New("store unavailable")
+}
+
+func TestLimiter_StoreErrorFailsOpen(t *testing.T) {
+	l := NewLimiter(DefaultPolicy(), failingStore{})
+	if err := l.Allow(context.Background(), "yamada", domain.Tier1, "change"); err != nil {
+		t.Errorf("expected store error to be ignored, got %v", err)
+	}
+}
+
+func TestMemoryStore_SweepsIdleBuckets(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limit := domain.RateLimit{Count: 1, Period: time.Second}
+
+	if _, err := store.Take(ctx, "a", limit, start); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Take(ctx, "b", limit, start.Add(2*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.buckets["a"]; ok {
+		t.Error("idle bucket should be swept")
+	}
+	if _, ok := store.buckets["b"]; !ok {
+		t.Error("active bucket should remain")
+	}
+}
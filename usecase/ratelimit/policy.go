@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// Policy ティアごとのレート制限設定（制限はコマンドごとに別のバケットで数える）
+type Policy struct {
+	// PerUser ユーザー×コマンド単位の制限（ティア別）
+	PerUser map[domain.Tier]domain.RateLimit
+	// Global 全ユーザー合計でのコマンド単位の制限
+	Global domain.RateLimit
+}
+
+// DefaultPolicy 既定のレート制限設定
+func DefaultPolicy() Policy {
+	return Policy{
+		PerUser: map[domain.Tier]domain.RateLimit{
+			domain.Tier1: {Count: 6, Period: time.Minute},
+			domain.Tier2: {Count: 10, Period: time.Minute},
+			domain.Tier3: {Count: 15, Period: time.Minute},
+		},
+		Global: domain.RateLimit{Count: 600, Period: time.Minute},
+	}
+}
+
+// UserLimit ユーザーのティアに適用する制限を返す
+func (p Policy) UserLimit(tier domain.Tier) domain.RateLimit {
+	if limit, ok := p.PerUser[tier]; ok {
+		return limit
+	}
+	// 未知のティアは最も厳しい Tier1 の制限を適用する
+	return p.PerUser[domain.Tier1]
+}
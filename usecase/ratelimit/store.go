@@ -0,0 +1,128 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// Decision トークン取得の結果
+type Decision struct {
+	Allowed    bool
+	RetryAfter time.Duration // 拒否された場合、次のトークンが補充されるまでの時間
+}
+
+// Request TakeAll で取得するバケットとその制限
+type Request struct {
+	Key   string
+	Limit domain.RateLimit
+}
+
+// Store トークンバケットの保存先
+// 現在はプロセス内の MemoryStore のみだが、複数インスタンスで共有する場合は
+// Redis 等で同じインターフェースを実装する
+type Store interface {
+	// TakeAll すべてのバケットにトークンがある場合だけ、それぞれから1つずつ取得する
+	// 足りないバケットがあればどれも減らさず、最初に足りなかった requests の位置を返す（許可した場合は -1）
+	TakeAll(ctx context.Context, requests []Request, now time.Time) (Decision, int, error)
+}
+
+// sweepInterval 放置されたバケットを掃除する間隔
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	period time.Duration
+}
+
+// MemoryStore プロセス内でトークンバケットを保持する Store
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates a new in-process token bucket store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Take key のバケットからトークンを1つ取得する
+func (s *MemoryStore) Take(ctx context.Context, key string, limit domain.RateLimit, now time.Time) (Decision, error) {
+	decision, _, err := s.TakeAll(ctx, []Request{{Key: key, Limit: limit}}, now)
+	return decision, err
+}
+
+// TakeAll すべてのバケットにトークンがある場合だけ、それぞれから1つずつ取得する
+func (s *MemoryStore) TakeAll(ctx context.Context, requests []Request, now time.Time) (Decision, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	// 先にすべてのバケットを確認し、1つでも足りなければどのトークンも使わない
+	buckets := make([]*bucket, len(requests))
+	for i, r := range requests {
+		if r.Limit.Unlimited() {
+			continue
+		}
+		b := s.refill(r.Key, r.Limit, now)
+		if b.tokens < 1 {
+			wait := (1 - b.tokens) / ratePerSecond(r.Limit)
+			return Decision{
+				Allowed:    false,
+				RetryAfter: time.Duration(math.Ceil(wait * float64(time.Second))),
+			}, i, nil
+		}
+		buckets[i] = b
+	}
+
+	for _, b := range buckets {
+		if b != nil {
+			b.tokens--
+		}
+	}
+	return Decision{Allowed: true}, -1, nil
+}
+
+// refill key のバケットに経過時間に応じてトークンを補充して返す（なければ満タンで作る）
+func (s *MemoryStore) refill(key string, limit domain.RateLimit, now time.Time) *bucket {
+	capacity := float64(limit.Count)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, last: now, period: limit.Period}
+		s.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*ratePerSecond(limit))
+		b.last = now
+	}
+	b.period = limit.Period
+	return b
+}
+
+// sweep 満タンに戻っているはずのバケットを削除してメモリを解放する
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if now.Sub(b.last) >= b.period {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// ratePerSecond 1秒あたりのトークン補充量
+func ratePerSecond(limit domain.RateLimit) float64 {
+	return float64(limit.Count) / limit.Period.Seconds()
+}
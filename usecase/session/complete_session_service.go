@@ -6,51 +6,91 @@ import (
 
 	"github.com/yamada-ai/workspace-backend/domain"
 	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
 	"github.com/yamada-ai/workspace-backend/usecase/command"
 )
 
 // CompleteSessionService handles the common logic for completing a session
 type CompleteSessionService struct {
+	txBeginner        repository.TxBeginner
 	sessionRepository repository.SessionRepository
-	broadcaster       command.EventBroadcaster
+	outbox            command.EventOutbox
 	now               func() time.Time
 }
 
 // NewCompleteSessionService creates a new complete session service
 func NewCompleteSessionService(
+	txBeginner repository.TxBeginner,
 	sessionRepository repository.SessionRepository,
-	broadcaster command.EventBroadcaster,
+	outbox command.EventOutbox,
 ) *CompleteSessionService {
 	return &CompleteSessionService{
+		txBeginner:        txBeginner,
 		sessionRepository: sessionRepository,
-		broadcaster:       broadcaster,
+		outbox:            outbox,
 		now:               func() time.Time { return time.Now().UTC() },
 	}
 }
 
-// CompleteSession completes a session, updates it in the database, and broadcasts the event
+// CompleteSession completes a session, updates it in the database, and records the session end event
 // This method is used by both manual /out command and automatic expiration
 func (s *CompleteSessionService) CompleteSession(
 	ctx context.Context,
 	session *domain.Session,
 	userID int64,
 ) error {
+	return s.CompleteSessionWith(ctx, session, userID, nil)
+}
+
+// CompleteSessionWith completes a session like CompleteSession and runs withTx in the same transaction
+// before it commits, so that records about the completion (such as an audit log) are written atomically.
+// If withTx returns an error, the transaction is rolled back and the session stays active in the database
+func (s *CompleteSessionService) CompleteSessionWith(
+	ctx context.Context,
+	session *domain.Session,
+	userID int64,
+	withTx func(ctx context.Context, tx repository.Tx) error,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "CompleteSessionService.CompleteSession", tracing.KeySessionID.Int64(session.ID))
+	defer func() { tracing.End(span, err) }()
+
 	// 1. Complete the session (sets actual_end)
-	if err := session.Complete(s.now); err != nil {
+	if err = session.Complete(s.now); err != nil {
 		return err
 	}
 
-	// 2. Update session in database
-	if err := s.sessionRepository.Update(ctx, session); err != nil {
+	// 2. Update session and record the session end event in one transaction
+	tx, err := s.txBeginner.BeginTx(ctx)
+	if err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
 
-	// 3. Broadcast session end event to all connected WebSocket clients
-	s.broadcaster.BroadcastSessionEnd(command.SessionEndBroadcast{
+	if err = s.sessionRepository.UpdateWithTx(ctx, tx, session); err != nil {
+		return err
+	}
+	if err = s.outbox.RecordSessionEnd(ctx, tx, command.SessionEndBroadcast{
 		SessionID: session.ID,
 		UserID:    userID,
 		ActualEnd: *session.ActualEnd,
-	})
+	}); err != nil {
+		return err
+	}
+	if withTx != nil {
+		if err = withTx(ctx, tx); err != nil {
+			return err
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	// 3. Wake up the outbox dispatcher to broadcast the event to connected clients
+	s.outbox.Notify()
 
 	return nil
 }
@@ -2,11 +2,15 @@ package session
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/shared/tracing"
 )
 
 // SessionExpirationManager manages automatic session expiration using timers
@@ -14,7 +18,13 @@ type SessionExpirationManager struct {
 	timers          sync.Map // map[int64]*time.Timer
 	sessionRepo     repository.SessionRepository
 	completeService *CompleteSessionService
+	initialized     atomic.Bool // Start has restored the timers
 	now             func() time.Time
+
+	// mu guards stopped against timers firing while Stop waits for inflight
+	mu       sync.Mutex
+	stopped  bool
+	inflight sync.WaitGroup // expirations being completed
 }
 
 // NewSessionExpirationManager creates a new session expiration manager
@@ -30,80 +40,175 @@ func NewSessionExpirationManager(
 }
 
 // ScheduleExpiration schedules a session to be automatically completed at its planned end time
-func (m *SessionExpirationManager) ScheduleExpiration(sessionID int64, userID int64, plannedEnd time.Time) {
+// ctx is only used for logging; the timer outlives the request
+func (m *SessionExpirationManager) ScheduleExpiration(ctx context.Context, sessionID int64, userID int64, plannedEnd time.Time) {
+	logger := logging.FromContext(ctx).With(logging.KeySessionID, sessionID)
 	duration := time.Until(plannedEnd)
 
 	// If already expired, don't schedule (should be handled elsewhere)
 	if duration <= 0 {
-		log.Printf("Session %d already expired, skipping timer", sessionID)
+		logger.Warn("session already expired, skipping timer")
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopped {
+		// The session stays active and Start on the next instance restores its timer
+		logger.Debug("expiration manager is stopped, skipping timer")
 		return
 	}
 
 	timer := time.AfterFunc(duration, func() {
+		if !m.beginExpiration() {
+			return
+		}
+		defer m.inflight.Done()
 		m.handleExpiration(sessionID, userID)
 	})
 
 	m.timers.Store(sessionID, timer)
-	log.Printf("Scheduled expiration for session %d in %v", sessionID, duration)
+	logger.Debug("scheduled session expiration", "in", duration.String())
 }
 
 // CancelExpiration cancels a scheduled expiration (e.g., when user manually ends session)
-func (m *SessionExpirationManager) CancelExpiration(sessionID int64) {
+func (m *SessionExpirationManager) CancelExpiration(ctx context.Context, sessionID int64) {
 	if timerInterface, ok := m.timers.LoadAndDelete(sessionID); ok {
 		if timer, ok := timerInterface.(*time.Timer); ok {
 			timer.Stop()
-			log.Printf("Cancelled expiration timer for session %d", sessionID)
+			logging.FromContext(ctx).Debug("cancelled session expiration", logging.KeySessionID, sessionID)
 		}
 	}
 }
 
 // RescheduleExpiration reschedules a session expiration to a new planned end time
 // This is used when a user extends their session with /more command
-func (m *SessionExpirationManager) RescheduleExpiration(sessionID int64, userID int64, newPlannedEnd time.Time) {
+func (m *SessionExpirationManager) RescheduleExpiration(ctx context.Context, sessionID int64, userID int64, newPlannedEnd time.Time) {
 	// Cancel existing timer
-	m.CancelExpiration(sessionID)
+	m.CancelExpiration(ctx, sessionID)
 
 	// Schedule new timer
-	m.ScheduleExpiration(sessionID, userID, newPlannedEnd)
+	m.ScheduleExpiration(ctx, sessionID, userID, newPlannedEnd)
+
+	logging.FromContext(ctx).Info("rescheduled session expiration",
+		logging.KeySessionID, sessionID, "planned_end", newPlannedEnd)
+}
 
-	log.Printf("⏰ Rescheduled expiration for session %d to %v", sessionID, newPlannedEnd)
+// PendingTimers returns the number of scheduled expiration timers
+func (m *SessionExpirationManager) PendingTimers() int {
+	count := 0
+	m.timers.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// Ready returns nil once the timers of the existing sessions have been restored
+// Until then, sessions that should have expired may still look active
+func (m *SessionExpirationManager) Ready() error {
+	m.mu.Lock()
+	stopped := m.stopped
+	m.mu.Unlock()
+	if stopped {
+		return errors.New("expiration manager is stopped")
+	}
+	if !m.initialized.Load() {
+		return errors.New("expiration timers have not been restored from the database yet")
+	}
+	return nil
 }
 
 // handleExpiration is called when a session reaches its planned end time
+// It runs on the timer goroutine, so it logs with the default logger instead of the request's
 func (m *SessionExpirationManager) handleExpiration(sessionID int64, userID int64) {
-	ctx := context.Background()
+	// タイマーのコールバックには呼び出し元のスパンがないので、期限切れ処理ごとに新しいトレースを始める
+	ctx, span := tracing.Start(context.Background(), "SessionExpirationManager.handleExpiration",
+		tracing.KeySessionID.Int64(sessionID), tracing.KeyUserID.Int64(userID))
+	var err error
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.With(ctx, logging.KeySessionID, sessionID, logging.KeyUserID, userID)
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		ctx = logging.With(ctx, logging.KeyTraceID, traceID)
+	}
+	logger := logging.FromContext(ctx)
 
-	log.Printf("Session %d reached planned end, completing automatically", sessionID)
+	logger.Info("session reached planned end, completing automatically")
 
 	// 1. Fetch the session
 	session, err := m.sessionRepo.FindByID(ctx, sessionID)
 	if err != nil {
-		log.Printf("Failed to fetch session %d for expiration: %v", sessionID, err)
+		logger.Error("failed to fetch session for expiration", logging.Err(err))
 		return
 	}
 
 	// 2. Check if already completed (race condition with manual /out)
 	if session.ActualEnd != nil {
-		log.Printf("Session %d already completed, skipping auto-expiration", sessionID)
+		logger.Info("session already completed, skipping auto-expiration")
 		return
 	}
 
 	// 3. Complete the session using the shared service
-	if err := m.completeService.CompleteSession(ctx, session, userID); err != nil {
-		log.Printf("Failed to auto-complete session %d: %v", sessionID, err)
+	if err = m.completeService.CompleteSession(ctx, session, userID); err != nil {
+		logger.Error("failed to auto-complete session", logging.Err(err))
 		return
 	}
 
 	// 4. Remove timer from map
 	m.timers.Delete(sessionID)
 
-	log.Printf("Session %d auto-completed successfully", sessionID)
+	logger.Info("session auto-completed")
+}
+
+// beginExpiration registers a firing timer with inflight, unless Stop has already been called
+func (m *SessionExpirationManager) beginExpiration() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopped {
+		return false
+	}
+	m.inflight.Add(1)
+	return true
+}
+
+// Stop cancels all pending timers and waits for expirations that are already running
+// After Stop, timers are no longer scheduled; the sessions stay active in the database
+// and the next instance restores them in Start. Returns ctx's error if it ends first.
+func (m *SessionExpirationManager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	m.stopped = true
+	m.mu.Unlock()
+
+	cancelled := 0
+	m.timers.Range(func(key, value any) bool {
+		if timer, ok := value.(*time.Timer); ok && timer.Stop() {
+			cancelled++
+		}
+		m.timers.Delete(key)
+		return true
+	})
+
+	done := make(chan struct{})
+	go func() {
+		m.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for running session expirations: %w", ctx.Err())
+	}
+
+	logging.FromContext(ctx).Info("stopped session expiration timers", "cancelled", cancelled)
+	return nil
 }
 
-// InitializeFromDatabase loads all active sessions and schedules their expiration timers
+// Start loads all active sessions and schedules their expiration timers
 // This is called on server startup to restore timers for existing sessions
-func (m *SessionExpirationManager) InitializeFromDatabase(ctx context.Context) error {
-	log.Println("Initializing session expiration timers from database...")
+func (m *SessionExpirationManager) Start(ctx context.Context) error {
+	logger := logging.FromContext(ctx)
+	logger.Info("initializing session expiration timers from database")
 
 	// Fetch all active sessions
 	sessions, err := m.sessionRepo.FindAllActive(ctx)
@@ -118,29 +223,32 @@ func (m *SessionExpirationManager) InitializeFromDatabase(ctx context.Context) e
 	for _, sessionInfo := range sessions {
 		// If session is already expired, complete it immediately
 		if sessionInfo.PlannedEnd.Before(now) || sessionInfo.PlannedEnd.Equal(now) {
-			log.Printf("Session %d expired during downtime, completing now", sessionInfo.SessionID)
+			sessionCtx := logging.With(ctx, logging.KeySessionID, sessionInfo.SessionID, logging.KeyUserName, sessionInfo.UserName)
+			sessionLogger := logging.FromContext(sessionCtx)
+			sessionLogger.Info("session expired during downtime, completing now")
 
 			// Fetch full session object
-			session, err := m.sessionRepo.FindByID(ctx, sessionInfo.SessionID)
+			session, err := m.sessionRepo.FindByID(sessionCtx, sessionInfo.SessionID)
 			if err != nil {
-				log.Printf("Failed to fetch expired session %d: %v", sessionInfo.SessionID, err)
+				sessionLogger.Error("failed to fetch expired session", logging.Err(err))
 				continue
 			}
 
 			// Complete immediately
-			if err := m.completeService.CompleteSession(ctx, session, sessionInfo.UserID); err != nil {
-				log.Printf("Failed to complete expired session %d: %v", sessionInfo.SessionID, err)
+			if err := m.completeService.CompleteSession(sessionCtx, session, sessionInfo.UserID); err != nil {
+				sessionLogger.Error("failed to complete expired session", logging.Err(err))
 				continue
 			}
 
 			expiredCount++
 		} else {
 			// Schedule future expiration
-			m.ScheduleExpiration(sessionInfo.SessionID, sessionInfo.UserID, sessionInfo.PlannedEnd)
+			m.ScheduleExpiration(ctx, sessionInfo.SessionID, sessionInfo.UserID, sessionInfo.PlannedEnd)
 			scheduledCount++
 		}
 	}
 
-	log.Printf("Initialized %d timers, completed %d expired sessions", scheduledCount, expiredCount)
+	m.initialized.Store(true)
+	logger.Info("initialized session expiration timers", "scheduled", scheduledCount, "completed", expiredCount)
 	return nil
 }
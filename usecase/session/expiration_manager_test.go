@@ -0,0 +1,56 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSessionExpirationManager_Stop(t *testing.T) {
+	m := NewSessionExpirationManager(nil, nil)
+	ctx := context.Background()
+	m.ScheduleExpiration(ctx, 1, 10, time.Now().Add(time.Hour))
+	m.ScheduleExpiration(ctx, 2, 20, time.Now().Add(time.Hour))
+	m.initialized.Store(true)
+	if m.PendingTimers() != 2 {
+		t.Fatalf("expected 2 timers, got %d", m.PendingTimers())
+	}
+
+	if err := m.Stop(ctx); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	if m.PendingTimers() != 0 {
+		t.Errorf("expected all timers to be cancelled, got %d", m.PendingTimers())
+	}
+	if err := m.Ready(); err == nil {
+		t.Errorf("expected a stopped manager not to be ready")
+	}
+
+	// 停止後は新しいタイマーを作らない（セッションは次のインスタンスの Start で復元する）
+	m.ScheduleExpiration(ctx, 3, 30, time.Now().Add(time.Hour))
+	m.RescheduleExpiration(ctx, 1, 10, time.Now().Add(2*time.Hour))
+	if m.PendingTimers() != 0 {
+		t.Errorf("expected no timers after Stop, got %d", m.PendingTimers())
+	}
+}
+
+func TestSessionExpirationManager_StopWaitsForRunningExpiration(t *testing.T) {
+	m := NewSessionExpirationManager(nil, nil)
+	if !m.beginExpiration() {
+		t.Fatal("expected the expiration to start before Stop")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := m.Stop(ctx); err == nil {
+		t.Fatal("expected Stop to time out while an expiration is running")
+	}
+	if m.beginExpiration() {
+		t.Error("expected timers that fire after Stop to be skipped")
+	}
+
+	m.inflight.Done()
+	if err := m.Stop(context.Background()); err != nil {
+		t.Errorf("expected Stop to return once the expiration finished, got %v", err)
+	}
+}
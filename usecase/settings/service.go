@@ -0,0 +1,272 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+// ErrNotApplied 変更はコミットしたが、Watcher が読み直した設定を適用しなかった
+var ErrNotApplied = errors.New("runtime settings were saved but not applied")
+
+// ChangeRecorder 設定の変更を、保存と同じトランザクションでアウトボックスに記録する
+type ChangeRecorder interface {
+	RecordSettingsChanged(ctx context.Context, tx repository.Tx, event command.SettingsChangedBroadcast) error
+	Notify()
+}
+
+// Entry 設定 1 件の現在の値
+type Entry struct {
+	Key     string
+	Value   string // 現在の値
+	Default string // 起動時の設定の値
+	// UpdatedBy, UpdatedAt 管理 API で変更した場合のみ設定される（nil なら起動時の値のまま）
+	UpdatedBy string
+	UpdatedAt *time.Time
+}
+
+// Service 管理 API から設定を変更し、変更を Watcher とオーバーレイに知らせる
+type Service struct {
+	txBeginner         repository.TxBeginner
+	settingRepository  repository.RuntimeSettingRepository
+	auditLogRepository repository.AuditLogRepository
+	recorder           ChangeRecorder
+	watcher            *Watcher
+	now                func() time.Time
+}
+
+// NewService creates a new runtime settings service
+func NewService(
+	txBeginner repository.TxBeginner,
+	settingRepository repository.RuntimeSettingRepository,
+	auditLogRepository repository.AuditLogRepository,
+	recorder ChangeRecorder,
+	watcher *Watcher,
+) *Service {
+	return &Service{
+		txBeginner:         txBeginner,
+		settingRepository:  settingRepository,
+		auditLogRepository: auditLogRepository,
+		recorder:           recorder,
+		watcher:            watcher,
+		now:                func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// List すべての設定の現在の値を返す（DB の値を読み直してから返す）
+func (s *Service) List(ctx context.Context) ([]Entry, error) {
+	overrides, err := s.watcher.Reload(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.entries(overrides), nil
+}
+
+// Update 設定をまとめて変更する（values はキーと値の組）
+// 設定をロックして DB の値を読み直し、変更後の設定全体が範囲内であることを確認してから
+// 保存・監査ログ・settings_changed イベントを 1 つのトランザクションで記録する
+// （同時に変更されても、後から変更する側は先にコミットされた値に重ねて確認する）
+func (s *Service) Update(ctx context.Context, values map[string]string, actor string) (_ []Entry, err error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w: no settings given", domain.ErrInvalidRuntimeSetting)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	updates := make([]*domain.RuntimeSetting, 0, len(keys))
+	var errs []error
+	for _, key := range keys {
+		setting, err := domain.NewRuntimeSetting(key, values[key], actor, s.now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		updates = append(updates, setting)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.txBeginner.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	before, err := s.lockStored(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	after := before
+	for _, setting := range updates {
+		if setErr := after.Set(setting.Key, setting.Value); setErr != nil {
+			errs = append(errs, setErr)
+		}
+	}
+	if err = errors.Join(append(errs, after.Validate())...); err != nil {
+		return nil, err
+	}
+
+	for _, setting := range updates {
+		if err = s.settingRepository.SaveWithTx(ctx, tx, setting); err != nil {
+			return nil, err
+		}
+		if err = s.audit(ctx, tx, actor, domain.AuditActionRuntimeSettingUpdate, setting.Key, before, after); err != nil {
+			return nil, err
+		}
+	}
+	if err = s.recordChange(ctx, tx, before, after); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return s.applied(ctx)
+}
+
+// Reset 管理 API で変更した値を削除し、起動時の設定に戻す
+// 変更されていない設定は domain.ErrRuntimeSettingNotFound を返す
+func (s *Service) Reset(ctx context.Context, key, actor string) (_ []Entry, err error) {
+	tx, err := s.txBeginner.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	before, err := s.lockStored(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	after := before
+	if err = after.Set(key, s.watcher.Base().Get(key)); err != nil {
+		return nil, err
+	}
+	if err = after.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err = s.settingRepository.DeleteWithTx(ctx, tx, key); err != nil {
+		return nil, err
+	}
+	if err = s.audit(ctx, tx, actor, domain.AuditActionRuntimeSettingReset, key, before, after); err != nil {
+		return nil, err
+	}
+	if err = s.recordChange(ctx, tx, before, after); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return s.applied(ctx)
+}
+
+// lockStored 設定をロックし、DB に保存された値を起動時の設定に重ねて返す
+// メモリ上の値は別のプロセスの変更をまだ読み直していないことがあるため、変更の確認には使わない
+// 保存された値のうち不正なものは重ねない（変更後の設定全体は呼び出し側で Validate する）
+func (s *Service) lockStored(ctx context.Context, tx repository.Tx) (domain.RuntimeSettings, error) {
+	overrides, err := s.settingRepository.ListForUpdateWithTx(ctx, tx)
+	if err != nil {
+		return domain.RuntimeSettings{}, err
+	}
+	stored := s.watcher.Base()
+	if err := stored.Apply(overrides); err != nil {
+		logging.FromContext(ctx).Warn("ignoring invalid stored runtime settings", logging.Err(err))
+	}
+	return stored, nil
+}
+
+// recordChange 値が変わった設定があれば settings_changed イベントを記録する
+func (s *Service) recordChange(ctx context.Context, tx repository.Tx, before, after domain.RuntimeSettings) error {
+	changed := changedKeys(before, after)
+	if len(changed) == 0 {
+		return nil
+	}
+	return s.recorder.RecordSettingsChanged(ctx, tx, command.SettingsChangedBroadcast{
+		Changed:                  changed,
+		SessionDefaultSeconds:    int64(after.SessionDefaultDuration / time.Second),
+		MinExtensionMinutes:      after.MinExtensionMinutes,
+		MaxExtensionMinutes:      after.MaxExtensionMinutes,
+		CheerBonusPoints:         after.CheerBonusPoints,
+		ChannelPointsPerRaziiipo: after.ChannelPointsPerRaziiipo,
+		ChangedAt:                s.now(),
+	})
+}
+
+// applied コミット後に Watcher へ反映し、イベントの配信を起こして現在の値を返す
+// 変更はコミット済みのため、読み直しに失敗した場合や Watcher が適用しなかった場合は ErrNotApplied を返す
+// （読み込みの失敗なら Watcher の次の読み直しで反映される）
+func (s *Service) applied(ctx context.Context) ([]Entry, error) {
+	s.recorder.Notify()
+
+	overrides, err := s.watcher.Reload(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("runtime settings were saved but not applied", logging.Err(err))
+		s.watcher.Notify()
+		return nil, fmt.Errorf("%w: %v", ErrNotApplied, err)
+	}
+	return s.entries(overrides), nil
+}
+
+func (s *Service) entries(overrides []*domain.RuntimeSetting) []Entry {
+	stored := make(map[string]*domain.RuntimeSetting, len(overrides))
+	for _, o := range overrides {
+		stored[o.Key] = o
+	}
+
+	current, base := s.watcher.Current(), s.watcher.Base()
+	keys := domain.RuntimeSettingKeys()
+	entries := make([]Entry, 0, len(keys))
+	for _, key := range keys {
+		entry := Entry{Key: key, Value: current.Get(key), Default: base.Get(key)}
+		if o, ok := stored[key]; ok {
+			updatedAt := o.UpdatedAt
+			entry.UpdatedBy = o.UpdatedBy
+			entry.UpdatedAt = &updatedAt
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (s *Service) audit(ctx context.Context, tx repository.Tx, actor, action, key string, before, after domain.RuntimeSettings) error {
+	auditLog, err := domain.NewAuditLog(
+		actor,
+		action,
+		domain.AuditTargetRuntimeSetting,
+		key,
+		settingState{Value: before.Get(key)},
+		settingState{Value: after.Get(key)},
+		"",
+		s.now,
+	)
+	if err != nil {
+		return err
+	}
+	return s.auditLogRepository.SaveWithTx(ctx, tx, auditLog)
+}
+
+// settingState 監査ログに記録する設定の値
+type settingState struct {
+	Value string `json:"value"`
+}
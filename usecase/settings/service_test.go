@@ -0,0 +1,357 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+)
+
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeTx トランザクションの確定・破棄を記録する
+type fakeTx struct {
+	committed  bool
+	rolledBack bool
+	onCommit   func()
+}
+
+func (t *fakeTx) Commit(ctx context.Context) error {
+	t.committed = true
+	if t.onCommit != nil {
+		t.onCommit()
+	}
+	return nil
+}
+
+func (t *fakeTx) Rollback(ctx context.Context) error {
+	t.rolledBack = true
+	return nil
+}
+
+// fakeTxBeginner 最後に開始したトランザクションを保持する
+type fakeTxBeginner struct {
+	tx       *fakeTx
+	onCommit func()
+}
+
+func (b *fakeTxBeginner) BeginTx(ctx context.Context) (repository.Tx, error) {
+	b.tx = &fakeTx{onCommit: b.onCommit}
+	return b.tx, nil
+}
+
+// fakeRuntimeSettingRepository メモリ上の設定リポジトリ
+type fakeRuntimeSettingRepository struct {
+	settings map[string]*domain.RuntimeSetting
+	listErr  error
+	locked   int
+}
+
+func (r *fakeRuntimeSettingRepository) List(ctx context.Context) ([]*domain.RuntimeSetting, error) {
+	if r.listErr != nil {
+		return nil, r.listErr
+	}
+	settings := make([]*domain.RuntimeSetting, 0, len(r.settings))
+	for _, s := range r.settings {
+		settings = append(settings, s)
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Key < settings[j].Key })
+	return settings, nil
+}
+
+func (r *fakeRuntimeSettingRepository) ListForUpdateWithTx(ctx context.Context, tx repository.Tx) ([]*domain.RuntimeSetting, error) {
+	r.locked++
+	return r.List(ctx)
+}
+
+func (r *fakeRuntimeSettingRepository) SaveWithTx(ctx context.Context, tx repository.Tx, setting *domain.RuntimeSetting) error {
+	r.settings[setting.Key] = setting
+	return nil
+}
+
+func (r *fakeRuntimeSettingRepository) DeleteWithTx(ctx context.Context, tx repository.Tx, key string) error {
+	if _, ok := r.settings[key]; !ok {
+		return domain.ErrRuntimeSettingNotFound
+	}
+	delete(r.settings, key)
+	return nil
+}
+
+// fakeAuditLogRepository 記録された監査ログを保持する
+type fakeAuditLogRepository struct {
+	logs []*domain.AuditLog
+}
+
+func (r *fakeAuditLogRepository) Save(ctx context.Context, log *domain.AuditLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *fakeAuditLogRepository) SaveWithTx(ctx context.Context, tx repository.Tx, log *domain.AuditLog) error {
+	return r.Save(ctx, log)
+}
+
+func (r *fakeAuditLogRepository) List(ctx context.Context, filter repository.AuditLogFilter) ([]*domain.AuditLog, error) {
+	return r.logs, nil
+}
+
+// fakeChangeRecorder 記録された settings_changed イベントを保持する
+type fakeChangeRecorder struct {
+	events   []command.SettingsChangedBroadcast
+	notified int
+}
+
+func (r *fakeChangeRecorder) RecordSettingsChanged(ctx context.Context, tx repository.Tx, event command.SettingsChangedBroadcast) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *fakeChangeRecorder) Notify() {
+	r.notified++
+}
+
+func baseSettings() domain.RuntimeSettings {
+	return domain.RuntimeSettings{
+		SessionDefaultDuration:   time.Hour,
+		MinExtensionMinutes:      1,
+		MaxExtensionMinutes:      360,
+		CheerBonusPoints:         0,
+		ChannelPointsPerRaziiipo: domain.DefaultChannelPointsPerRaziiipo,
+	}
+}
+
+type testService struct {
+	*Service
+	watcher  *Watcher
+	txs      *fakeTxBeginner
+	repo     *fakeRuntimeSettingRepository
+	audit    *fakeAuditLogRepository
+	recorder *fakeChangeRecorder
+}
+
+func newTestService() testService {
+	repo := &fakeRuntimeSettingRepository{settings: map[string]*domain.RuntimeSetting{}}
+	watcher := NewWatcher(repo, baseSettings())
+	ts := testService{
+		watcher:  watcher,
+		txs:      &fakeTxBeginner{},
+		repo:     repo,
+		audit:    &fakeAuditLogRepository{},
+		recorder: &fakeChangeRecorder{},
+	}
+	ts.Service = NewService(ts.txs, repo, ts.audit, ts.recorder, watcher)
+	ts.now = func() time.Time { return testNow }
+	return ts
+}
+
+func TestWatcher_Reload(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("保存された値を起動時の設定に重ねる", func(t *testing.T) {
+		ts := newTestService()
+		ts.repo.settings[domain.SettingCheerBonusPoints] = &domain.RuntimeSetting{Key: domain.SettingCheerBonusPoints, Value: "7"}
+
+		if err := ts.watcher.Start(ctx); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+		if got := ts.watcher.CheerBonusPoints(); got != 7 {
+			t.Errorf("expected bonus 7, got %d", got)
+		}
+		if got := ts.watcher.SessionSettings().DefaultDuration; got != time.Hour {
+			t.Errorf("expected the base duration, got %s", got)
+		}
+		if ts.watcher.Base().CheerBonusPoints != 0 {
+			t.Errorf("base settings must not change")
+		}
+	})
+
+	t.Run("組み合わせが範囲外になる値は適用しない", func(t *testing.T) {
+		ts := newTestService()
+		ts.repo.settings[domain.SettingCheerBonusPoints] = &domain.RuntimeSetting{Key: domain.SettingCheerBonusPoints, Value: "3"}
+		if err := ts.watcher.Start(ctx); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+
+		// 不正な値が書き込まれた場合は、それまでの設定を使い続けてエラーを返す
+		ts.repo.settings[domain.SettingCheerBonusPoints] = &domain.RuntimeSetting{Key: domain.SettingCheerBonusPoints, Value: "9"}
+		ts.repo.settings[domain.SettingMinExtensionMinutes] = &domain.RuntimeSetting{Key: domain.SettingMinExtensionMinutes, Value: "500"}
+		if _, err := ts.watcher.Reload(ctx); !errors.Is(err, domain.ErrInvalidRuntimeSetting) {
+			t.Fatalf("expected ErrInvalidRuntimeSetting, got %v", err)
+		}
+		if got := ts.watcher.Current(); got.CheerBonusPoints != 3 || got.MinExtensionMinutes != 1 {
+			t.Errorf("expected the previous settings to be kept, got %+v", got)
+		}
+	})
+
+	t.Run("起動時は不正な保存値があっても起動時の設定で起動する", func(t *testing.T) {
+		ts := newTestService()
+		ts.repo.settings[domain.SettingMinExtensionMinutes] = &domain.RuntimeSetting{Key: domain.SettingMinExtensionMinutes, Value: "500"}
+		if err := ts.watcher.Start(ctx); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+		if got := ts.watcher.Current(); got != baseSettings() {
+			t.Errorf("expected the startup settings, got %+v", got)
+		}
+	})
+
+	t.Run("読み込みに失敗したらエラーを返す", func(t *testing.T) {
+		ts := newTestService()
+		ts.repo.listErr = errors.New("db down")
+		if err := ts.watcher.Start(ctx); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestService_Update(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("保存・監査ログ・イベントを記録して反映する", func(t *testing.T) {
+		ts := newTestService()
+
+		entries, err := ts.Update(ctx, map[string]string{
+			domain.SettingCheerBonusPoints:       "5",
+			domain.SettingSessionDefaultDuration: "90m",
+		}, "admin")
+		if err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if !ts.txs.tx.committed || ts.repo.locked != 1 {
+			t.Errorf("expected the settings to be locked and the transaction committed (locked %d)", ts.repo.locked)
+		}
+		if ts.watcher.CheerBonusPoints() != 5 || ts.watcher.SessionSettings().DefaultDuration != 90*time.Minute {
+			t.Errorf("settings were not applied: %+v", ts.watcher.Current())
+		}
+
+		if len(ts.audit.logs) != 2 {
+			t.Fatalf("expected 2 audit logs, got %d", len(ts.audit.logs))
+		}
+		if log := ts.audit.logs[0]; log.Action != domain.AuditActionRuntimeSettingUpdate ||
+			log.TargetID != domain.SettingCheerBonusPoints || string(log.Before) != `{"value":"0"}` || string(log.After) != `{"value":"5"}` {
+			t.Errorf("unexpected audit log: %+v", log)
+		}
+
+		if len(ts.recorder.events) != 1 || ts.recorder.notified != 1 {
+			t.Fatalf("expected one event to be recorded and delivered, got %d (notified %d)", len(ts.recorder.events), ts.recorder.notified)
+		}
+		event := ts.recorder.events[0]
+		if !reflect.DeepEqual(event.Changed, []string{domain.SettingSessionDefaultDuration, domain.SettingCheerBonusPoints}) ||
+			event.SessionDefaultSeconds != 5400 || event.CheerBonusPoints != 5 {
+			t.Errorf("unexpected event: %+v", event)
+		}
+
+		var bonus Entry
+		for _, e := range entries {
+			if e.Key == domain.SettingCheerBonusPoints {
+				bonus = e
+			}
+		}
+		if bonus.Value != "5" || bonus.Default != "0" || bonus.UpdatedBy != "admin" || bonus.UpdatedAt == nil {
+			t.Errorf("unexpected entry: %+v", bonus)
+		}
+	})
+
+	t.Run("同じ値の保存ではイベントを記録しない", func(t *testing.T) {
+		ts := newTestService()
+		if _, err := ts.Update(ctx, map[string]string{domain.SettingSessionDefaultDuration: "60m"}, "admin"); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if len(ts.recorder.events) != 0 {
+			t.Errorf("expected no event, got %+v", ts.recorder.events)
+		}
+		if len(ts.audit.logs) != 1 {
+			t.Errorf("expected the audit log to be written, got %d", len(ts.audit.logs))
+		}
+	})
+
+	t.Run("変更後の組み合わせが範囲外なら何も保存しない", func(t *testing.T) {
+		ts := newTestService()
+		_, err := ts.Update(ctx, map[string]string{
+			domain.SettingMinExtensionMinutes: "400",
+			domain.SettingCheerBonusPoints:    "5",
+		}, "admin")
+		if !errors.Is(err, domain.ErrInvalidRuntimeSetting) {
+			t.Fatalf("expected ErrInvalidRuntimeSetting, got %v", err)
+		}
+		if !ts.txs.tx.rolledBack || len(ts.repo.settings) != 0 || ts.watcher.CheerBonusPoints() != 0 {
+			t.Error("nothing should be saved")
+		}
+	})
+
+	t.Run("メモリ上の値ではなく DB に保存された値に重ねて確認する", func(t *testing.T) {
+		ts := newTestService()
+		// 別のプロセスが最小延長を 300 分にしたが、この Watcher はまだ読み直していない
+		ts.repo.settings[domain.SettingMinExtensionMinutes] = &domain.RuntimeSetting{Key: domain.SettingMinExtensionMinutes, Value: "300"}
+
+		_, err := ts.Update(ctx, map[string]string{domain.SettingMaxExtensionMinutes: "200"}, "admin")
+		if !errors.Is(err, domain.ErrInvalidRuntimeSetting) {
+			t.Fatalf("expected ErrInvalidRuntimeSetting, got %v", err)
+		}
+		if !ts.txs.tx.rolledBack || ts.repo.settings[domain.SettingMaxExtensionMinutes] != nil {
+			t.Error("nothing should be saved")
+		}
+	})
+
+	t.Run("コミットした設定を Watcher が適用しなければエラーを返す", func(t *testing.T) {
+		ts := newTestService()
+		// コミットの直後に、確認を経ずに不正な値が書き込まれた
+		ts.txs.onCommit = func() {
+			ts.repo.settings[domain.SettingMinExtensionMinutes] = &domain.RuntimeSetting{Key: domain.SettingMinExtensionMinutes, Value: "500"}
+		}
+
+		_, err := ts.Update(ctx, map[string]string{domain.SettingCheerBonusPoints: "5"}, "admin")
+		if !errors.Is(err, ErrNotApplied) {
+			t.Fatalf("expected ErrNotApplied, got %v", err)
+		}
+		if ts.watcher.CheerBonusPoints() != 0 {
+			t.Errorf("the rejected settings must not be applied, got %+v", ts.watcher.Current())
+		}
+	})
+
+	t.Run("未知のキーはエラー", func(t *testing.T) {
+		ts := newTestService()
+		if _, err := ts.Update(ctx, map[string]string{"session.unknown": "1"}, "admin"); !errors.Is(err, domain.ErrUnknownRuntimeSetting) {
+			t.Errorf("expected ErrUnknownRuntimeSetting, got %v", err)
+		}
+	})
+}
+
+func TestService_Reset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("起動時の値に戻す", func(t *testing.T) {
+		ts := newTestService()
+		if _, err := ts.Update(ctx, map[string]string{domain.SettingCheerBonusPoints: "5"}, "admin"); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+
+		if _, err := ts.Reset(ctx, domain.SettingCheerBonusPoints, "admin"); err != nil {
+			t.Fatalf("Reset failed: %v", err)
+		}
+		if ts.watcher.CheerBonusPoints() != 0 || len(ts.repo.settings) != 0 {
+			t.Errorf("expected the base value, got %d", ts.watcher.CheerBonusPoints())
+		}
+		if log := ts.audit.logs[len(ts.audit.logs)-1]; log.Action != domain.AuditActionRuntimeSettingReset || string(log.After) != `{"value":"0"}` {
+			t.Errorf("unexpected audit log: %+v", log)
+		}
+		if len(ts.recorder.events) != 2 {
+			t.Errorf("expected an event for the reset, got %d events", len(ts.recorder.events))
+		}
+	})
+
+	t.Run("変更されていない設定はエラーで、トランザクションを破棄する", func(t *testing.T) {
+		ts := newTestService()
+		if _, err := ts.Reset(ctx, domain.SettingCheerBonusPoints, "admin"); !errors.Is(err, domain.ErrRuntimeSettingNotFound) {
+			t.Fatalf("expected ErrRuntimeSettingNotFound, got %v", err)
+		}
+		if !ts.txs.tx.rolledBack {
+			t.Error("expected the transaction to be rolled back")
+		}
+	})
+}
@@ -0,0 +1,168 @@
+// Package settings 再起動せずに変更できる設定（domain.RuntimeSettings）を管理する
+// 起動時の設定に、管理 API で DB に保存した値を重ねたものを現在の値として使う
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+	"github.com/yamada-ai/workspace-backend/usecase/moderation"
+	"github.com/yamada-ai/workspace-backend/usecase/twitch"
+)
+
+// DefaultPollInterval Notify がなくても DB の値を読み直す間隔（別のプロセスで変更された場合に反映する）
+const DefaultPollInterval = 30 * time.Second
+
+// Ensure Watcher provides the settings read by the use cases
+var (
+	_ command.SessionSettingsSource      = (*Watcher)(nil)
+	_ command.CheerBonusSource           = (*Watcher)(nil)
+	_ twitch.ExchangeRateSource          = (*Watcher)(nil)
+	_ moderation.AutoBlockDurationSource = (*Watcher)(nil)
+)
+
+// Watcher 設定の現在の値をメモリに保持し、DB の変更を読み直して差し替える
+// ユースケースは呼び出しのたびに Watcher から値を読むため、変更は次のコマンドから適用される
+// 値はまとめて差し替えるので、1 回の読み取りで古い値と新しい値が混ざることはない
+type Watcher struct {
+	repo         repository.RuntimeSettingRepository
+	base         domain.RuntimeSettings
+	current      atomic.Pointer[domain.RuntimeSettings]
+	wake         chan struct{}
+	pollInterval time.Duration
+
+	// reloadMu 読み直しを直列にする（古い一覧で新しい値を上書きしない）
+	reloadMu sync.Mutex
+}
+
+// NewWatcher creates a new settings watcher
+// base は起動時の設定で、DB に値がない設定はこの値を使う
+func NewWatcher(repo repository.RuntimeSettingRepository, base domain.RuntimeSettings) *Watcher {
+	w := &Watcher{
+		repo:         repo,
+		base:         base,
+		wake:         make(chan struct{}, 1),
+		pollInterval: DefaultPollInterval,
+	}
+	w.current.Store(&base)
+	return w
+}
+
+// Current 現在の設定
+func (w *Watcher) Current() domain.RuntimeSettings {
+	return *w.current.Load()
+}
+
+// Base 起動時の設定（DB の値を削除するとこの値に戻る）
+func (w *Watcher) Base() domain.RuntimeSettings {
+	return w.base
+}
+
+// SessionSettings implements command.SessionSettingsSource
+func (w *Watcher) SessionSettings() command.SessionSettings {
+	s := w.current.Load()
+	return command.SessionSettings{
+		DefaultDuration:     s.SessionDefaultDuration,
+		MinExtensionMinutes: s.MinExtensionMinutes,
+		MaxExtensionMinutes: s.MaxExtensionMinutes,
+	}
+}
+
+// CheerBonusPoints implements command.CheerBonusSource
+func (w *Watcher) CheerBonusPoints() int64 {
+	return w.current.Load().CheerBonusPoints
+}
+
+// ChannelPointsPerRaziiipo implements twitch.ExchangeRateSource
+func (w *Watcher) ChannelPointsPerRaziiipo() int64 {
+	return w.current.Load().ChannelPointsPerRaziiipo
+}
+
+// AutoBlockDuration implements moderation.AutoBlockDurationSource
+func (w *Watcher) AutoBlockDuration() time.Duration {
+	return w.current.Load().AutoBlockDuration
+}
+
+// Notify 設定が変更されたことを知らせる（ブロックしない）
+func (w *Watcher) Notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+		// 既に通知済み
+	}
+}
+
+// Start DB に保存された値を読み込む（Run の前に呼ぶ）
+// 保存された値が起動時の設定と組み合わせて範囲外になる場合は、起動時の設定で起動する（管理 API で直せるように）
+func (w *Watcher) Start(ctx context.Context) error {
+	_, err := w.Reload(ctx)
+	if errors.Is(err, domain.ErrInvalidRuntimeSetting) || errors.Is(err, domain.ErrUnknownRuntimeSetting) {
+		logging.FromContext(ctx).Error("stored runtime settings are invalid, starting with the startup config", logging.Err(err))
+		return nil
+	}
+	return err
+}
+
+// Run ctx がキャンセルされるまで、Notify と一定間隔で DB の値を読み直す
+func (w *Watcher) Run(ctx context.Context) {
+	poll := time.NewTicker(w.pollInterval)
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.wake:
+		case <-poll.C:
+		}
+		if _, err := w.Reload(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			logging.FromContext(ctx).Error("failed to reload runtime settings", logging.Err(err))
+		}
+	}
+}
+
+// Reload DB の値を読み直して現在の設定を差し替え、読み込んだ値を返す
+// 不正な値や、組み合わせが範囲外になる値は適用せず、それまでの設定を使い続けてエラーを返す
+func (w *Watcher) Reload(ctx context.Context) ([]*domain.RuntimeSetting, error) {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	overrides, err := w.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	next := w.base
+	err = next.Apply(overrides)
+	if err == nil {
+		err = next.Validate()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stored runtime settings were not applied: %w", err)
+	}
+
+	prev := w.current.Swap(&next)
+	if changed := changedKeys(*prev, next); len(changed) > 0 {
+		logging.FromContext(ctx).Info("runtime settings applied", "changed", changed)
+	}
+	return overrides, nil
+}
+
+// changedKeys prev と next で値が異なる設定のキー
+func changedKeys(prev, next domain.RuntimeSettings) []string {
+	var changed []string
+	for _, key := range domain.RuntimeSettingKeys() {
+		if prev.Get(key) != next.Get(key) {
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
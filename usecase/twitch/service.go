@@ -0,0 +1,170 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
+
+// Redemption チャンネルポイント報酬の交換（channel.channel_points_custom_reward_redemption.add）
+type Redemption struct {
+	MessageID    string // Twitch-Eventsub-Message-Id（重複排除に使う）
+	RedemptionID string // 交換の ID（台帳の参照）
+	UserName     string
+	Cost         int64 // 消費したチャンネルポイント
+}
+
+// Subscription サブスクの開始（channel.subscribe）
+type Subscription struct {
+	MessageID string
+	UserName  string
+	Tier      domain.Tier
+}
+
+// ExchangeRateSource チャンネルポイントを Raziiipo に交換するレート（Raziiipo 1pt あたりのチャンネルポイント）
+// 交換のたびに読むため、配信中に変更したレートは次の交換から適用される
+type ExchangeRateSource interface {
+	ChannelPointsPerRaziiipo() int64
+}
+
+// Service Twitch EventSub の通知をポイント台帳とユーザーのティアに反映する
+// 通知は Message-Id ごとに 1 度だけ処理し、Twitch の再送は ErrEventSubMessageProcessed を返す
+type Service struct {
+	userRepository     repository.UserRepository
+	ledgerRepository   repository.PointLedgerRepository
+	eventSubRepository repository.TwitchEventSubRepository
+	exchangeRate       ExchangeRateSource
+	now                func() time.Time
+}
+
+// NewService creates a new Twitch EventSub service
+// exchangeRate が nil の場合は既定のレート（domain.DefaultChannelPointsPerRaziiipo）で交換する
+func NewService(
+	userRepository repository.UserRepository,
+	ledgerRepository repository.PointLedgerRepository,
+	eventSubRepository repository.TwitchEventSubRepository,
+	exchangeRate ExchangeRateSource,
+) *Service {
+	return &Service{
+		userRepository:     userRepository,
+		ledgerRepository:   ledgerRepository,
+		eventSubRepository: eventSubRepository,
+		exchangeRate:       exchangeRate,
+		now:                func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// CreditRedemption チャンネルポイントを Raziiipo に交換して付与する（既定は 100:10）
+// 未登録のユーザーは /in と同じく Tier1 で作成する。付与額が 0 の交換は記録だけして何もしない
+func (s *Service) CreditRedemption(ctx context.Context, redemption Redemption) (_ int64, err error) {
+	tx, err := s.userRepository.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = s.eventSubRepository.MarkProcessedWithTx(ctx, tx, redemption.MessageID, domain.EventSubTypeChannelPointsRedemption, s.now()); err != nil {
+		return 0, err
+	}
+
+	points := domain.ChannelPointsToRaziiipo(redemption.Cost, s.channelPointsPerRaziiipo())
+	if points > 0 {
+		var user *domain.User
+		if user, err = s.findOrCreateUser(ctx, tx, redemption.UserName, domain.Tier1); err != nil {
+			return 0, err
+		}
+		var entry *domain.PointEntry
+		if entry, err = domain.NewPointEntry(user.ID, points, domain.PointReasonChannelPoints, redemption.RedemptionID, s.now); err != nil {
+			return 0, err
+		}
+		if err = s.ledgerRepository.AppendWithTx(ctx, tx, entry); err != nil {
+			// 別の Message-Id で同じ交換が届いた場合も処理済みとして扱う
+			if errors.Is(err, domain.ErrDuplicatePointEntry) {
+				err = domain.ErrEventSubMessageProcessed
+			}
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return points, nil
+}
+
+// ApplySubscription サブスクのティアをユーザーに反映する（未登録のユーザーはそのティアで作成する）
+func (s *Service) ApplySubscription(ctx context.Context, subscription Subscription) (*domain.User, error) {
+	if !subscription.Tier.Valid() {
+		return nil, domain.ErrInvalidTier
+	}
+
+	tx, err := s.userRepository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = s.eventSubRepository.MarkProcessedWithTx(ctx, tx, subscription.MessageID, domain.EventSubTypeChannelSubscribe, s.now()); err != nil {
+		return nil, err
+	}
+
+	user, err := s.findOrCreateUser(ctx, tx, subscription.UserName, subscription.Tier)
+	if err != nil {
+		return nil, err
+	}
+	if user.Tier != subscription.Tier {
+		if err = user.ChangeTier(subscription.Tier, s.now); err != nil {
+			return nil, err
+		}
+		if err = s.userRepository.UpdateWithTx(ctx, tx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *Service) channelPointsPerRaziiipo() int64 {
+	if s.exchangeRate == nil {
+		return domain.DefaultChannelPointsPerRaziiipo
+	}
+	return s.exchangeRate.ChannelPointsPerRaziiipo()
+}
+
+// findOrCreateUser ユーザーをロックして取得する（いなければ作成する）
+func (s *Service) findOrCreateUser(ctx context.Context, tx repository.Tx, name string, tier domain.Tier) (*domain.User, error) {
+	user, err := s.userRepository.FindByNameWithTx(ctx, tx, name)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+
+	user, err = domain.NewUser(name, tier, s.now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepository.SaveWithTx(ctx, tx, user); err != nil {
+		if errors.Is(err, domain.ErrUserAlreadyExists) {
+			// 同時に作成された場合は作成済みのユーザーを使う
+			return s.userRepository.FindByNameWithTx(ctx, tx, name)
+		}
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,307 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+)
+
+type fakeTx struct {
+	committed  *int
+	rolledBack *int
+}
+
+func (t *fakeTx) Commit(ctx context.Context) error {
+	*t.committed++
+	return nil
+}
+
+func (t *fakeTx) Rollback(ctx context.Context) error {
+	*t.rolledBack++
+	return nil
+}
+
+// fakeUserRepository 名前をキーにユーザーを持つ
+type fakeUserRepository struct {
+	users      map[string]*domain.User
+	updated    int
+	committed  int
+	rolledBack int
+}
+
+func newFakeUserRepository(users ...*domain.User) *fakeUserRepository {
+	r := &fakeUserRepository{users: map[string]*domain.User{}}
+	for _, u := range users {
+		r.users[u.Name] = u
+	}
+	return r
+}
+
+func (r *fakeUserRepository) BeginTx(ctx context.Context) (repository.Tx, error) {
+	return &fakeTx{committed: &r.committed, rolledBack: &r.rolledBack}, nil
+}
+
+func (r *fakeUserRepository) FindByName(ctx context.Context, name string) (*domain.User, error) {
+	if u, ok := r.users[name]; ok {
+		return u, nil
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+func (r *fakeUserRepository) FindByID(ctx context.Context, id int64) (*domain.User, error) {
+	for _, u := range r.users {
+		if u.ID == id {
+			return u, nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+func (r *fakeUserRepository) Save(ctx context.Context, user *domain.User) error {
+	return r.SaveWithTx(ctx, nil, user)
+}
+
+func (r *fakeUserRepository) FindByNameWithTx(ctx context.Context, tx repository.Tx, name string) (*domain.User, error) {
+	return r.FindByName(ctx, name)
+}
+
+func (r *fakeUserRepository) FindByIDWithTx(ctx context.Context, tx repository.Tx, id int64) (*domain.User, error) {
+	return r.FindByID(ctx, id)
+}
+
+func (r *fakeUserRepository) SaveWithTx(ctx context.Context, tx repository.Tx, user *domain.User) error {
+	if _, ok := r.users[user.Name]; ok {
+		return domain.ErrUserAlreadyExists
+	}
+	user.ID = int64(len(r.users) + 1)
+	r.users[user.Name] = user
+	return nil
+}
+
+func (r *fakeUserRepository) UpdateWithTx(ctx context.Context, tx repository.Tx, user *domain.User) error {
+	r.updated++
+	r.users[user.Name] = user
+	return nil
+}
+
+type fakeLedgerRepository struct {
+	entries []*domain.PointEntry
+}
+
+func (r *fakeLedgerRepository) Balance(ctx context.Context, userID int64) (int64, error) {
+	var balance int64
+	for _, e := range r.entries {
+		if e.UserID == userID {
+			balance += e.Delta
+		}
+	}
+	return balance, nil
+}
+
+func (r *fakeLedgerRepository) BalanceWithTx(ctx context.Context, tx repository.Tx, userID int64) (int64, error) {
+	return r.Balance(ctx, userID)
+}
+
+func (r *fakeLedgerRepository) AppendWithTx(ctx context.Context, tx repository.Tx, entry *domain.PointEntry) error {
+	for _, e := range r.entries {
+		if e.Reason == entry.Reason && e.Reference == entry.Reference {
+			return domain.ErrDuplicatePointEntry
+		}
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+type fakeEventSubRepository struct {
+	processed map[string]string
+}
+
+func (r *fakeEventSubRepository) MarkProcessedWithTx(ctx context.Context, tx repository.Tx, messageID, subscriptionType string, receivedAt time.Time) error {
+	if _, ok := r.processed[messageID]; ok {
+		return domain.ErrEventSubMessageProcessed
+	}
+	r.processed[messageID] = subscriptionType
+	return nil
+}
+
+type serviceFixture struct {
+	service  *Service
+	users    *fakeUserRepository
+	ledger   *fakeLedgerRepository
+	eventSub *fakeEventSubRepository
+}
+
+func newServiceFixture(users ...*domain.User) *serviceFixture {
+	f := &serviceFixture{
+		users:    newFakeUserRepository(users...),
+		ledger:   &fakeLedgerRepository{},
+		eventSub: &fakeEventSubRepository{processed: map[string]string{}},
+	}
+	f.service = NewService(f.users, f.ledger, f.eventSub, nil)
+	return f
+}
+
+func TestService_CreditRedemption(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("チャンネルポイントを換算して付与する", func(t *testing.T) {
+		f := newServiceFixture(&domain.User{ID: 7, Name: "yamada", Tier: domain.Tier2})
+
+		points, err := f.service.CreditRedemption(ctx, Redemption{MessageID: "m1", RedemptionID: "r1", UserName: "yamada", Cost: 1050})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if points != 105 {
+			t.Errorf("expected 105 points, got %d", points)
+		}
+		if len(f.ledger.entries) != 1 {
+			t.Fatalf("expected 1 entry, got %d", len(f.ledger.entries))
+		}
+		entry := f.ledger.entries[0]
+		if entry.UserID != 7 || entry.Delta != 105 || entry.Reason != domain.PointReasonChannelPoints || entry.Reference != "r1" {
+			t.Errorf("unexpected entry: %+v", entry)
+		}
+		if f.eventSub.processed["m1"] != domain.EventSubTypeChannelPointsRedemption {
+			t.Errorf("message should be marked processed: %+v", f.eventSub.processed)
+		}
+		if f.users.committed != 1 {
+			t.Errorf("expected 1 commit, got %d", f.users.committed)
+		}
+	})
+
+	t.Run("未登録のユーザーは Tier1 で作成する", func(t *testing.T) {
+		f := newServiceFixture()
+
+		if _, err := f.service.CreditRedemption(ctx, Redemption{MessageID: "m1", RedemptionID: "r1", UserName: "newcomer", Cost: 100}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		user, ok := f.users.users["newcomer"]
+		if !ok || user.Tier != domain.Tier1 {
+			t.Fatalf("expected a Tier1 user, got %+v", user)
+		}
+		if len(f.ledger.entries) != 1 || f.ledger.entries[0].UserID != user.ID {
+			t.Errorf("unexpected entries: %+v", f.ledger.entries)
+		}
+	})
+
+	t.Run("同じ Message-Id の再送は付与しない", func(t *testing.T) {
+		f := newServiceFixture(&domain.User{ID: 7, Name: "yamada", Tier: domain.Tier1})
+		redemption := Redemption{MessageID: "m1", RedemptionID: "r1", UserName: "yamada", Cost: 100}
+
+		if _, err := f.service.CreditRedemption(ctx, redemption); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := f.service.CreditRedemption(ctx, redemption); !errors.Is(err, domain.ErrEventSubMessageProcessed) {
+			t.Fatalf("expected ErrEventSubMessageProcessed, got %v", err)
+		}
+		if len(f.ledger.entries) != 1 || f.users.rolledBack != 1 {
+			t.Errorf("duplicate must be rolled back: entries=%d rollbacks=%d", len(f.ledger.entries), f.users.rolledBack)
+		}
+	})
+
+	t.Run("別の Message-Id でも同じ交換は付与しない", func(t *testing.T) {
+		f := newServiceFixture(&domain.User{ID: 7, Name: "yamada", Tier: domain.Tier1})
+
+		if _, err := f.service.CreditRedemption(ctx, Redemption{MessageID: "m1", RedemptionID: "r1", UserName: "yamada", Cost: 100}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err := f.service.CreditRedemption(ctx, Redemption{MessageID: "m2", RedemptionID: "r1", UserName: "yamada", Cost: 100})
+		if !errors.Is(err, domain.ErrEventSubMessageProcessed) {
+			t.Fatalf("expected ErrEventSubMessageProcessed, got %v", err)
+		}
+		if len(f.ledger.entries) != 1 {
+			t.Errorf("expected 1 entry, got %d", len(f.ledger.entries))
+		}
+		// 処理済みの記録ごとトランザクションを巻き戻す
+		if f.users.committed != 1 || f.users.rolledBack != 1 {
+			t.Errorf("duplicate must be rolled back: commits=%d rollbacks=%d", f.users.committed, f.users.rolledBack)
+		}
+	})
+
+	t.Run("換算して 0 になる交換は記録だけする", func(t *testing.T) {
+		f := newServiceFixture(&domain.User{ID: 7, Name: "yamada", Tier: domain.Tier1})
+
+		points, err := f.service.CreditRedemption(ctx, Redemption{MessageID: "m1", RedemptionID: "r1", UserName: "yamada", Cost: 9})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if points != 0 || len(f.ledger.entries) != 0 {
+			t.Errorf("expected no credit, got %d (%d entries)", points, len(f.ledger.entries))
+		}
+		if _, ok := f.eventSub.processed["m1"]; !ok {
+			t.Errorf("message should be marked processed")
+		}
+	})
+}
+
+func TestService_ApplySubscription(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ティアを変更する", func(t *testing.T) {
+		f := newServiceFixture(&domain.User{ID: 7, Name: "yamada", Tier: domain.Tier1})
+
+		user, err := f.service.ApplySubscription(ctx, Subscription{MessageID: "m1", UserName: "yamada", Tier: domain.Tier3})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.Tier != domain.Tier3 || f.users.users["yamada"].Tier != domain.Tier3 {
+			t.Errorf("expected Tier3, got %v", user.Tier)
+		}
+		if f.users.updated != 1 {
+			t.Errorf("expected 1 update, got %d", f.users.updated)
+		}
+	})
+
+	t.Run("ティアが同じなら更新しない", func(t *testing.T) {
+		f := newServiceFixture(&domain.User{ID: 7, Name: "yamada", Tier: domain.Tier2})
+
+		if _, err := f.service.ApplySubscription(ctx, Subscription{MessageID: "m1", UserName: "yamada", Tier: domain.Tier2}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if f.users.updated != 0 {
+			t.Errorf("expected no update, got %d", f.users.updated)
+		}
+	})
+
+	t.Run("未登録のユーザーはそのティアで作成する", func(t *testing.T) {
+		f := newServiceFixture()
+
+		user, err := f.service.ApplySubscription(ctx, Subscription{MessageID: "m1", UserName: "newcomer", Tier: domain.Tier2})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.ID == 0 || user.Tier != domain.Tier2 {
+			t.Errorf("unexpected user: %+v", user)
+		}
+	})
+
+	t.Run("再送は処理済みを返す", func(t *testing.T) {
+		f := newServiceFixture(&domain.User{ID: 7, Name: "yamada", Tier: domain.Tier1})
+		sub := Subscription{MessageID: "m1", UserName: "yamada", Tier: domain.Tier2}
+
+		if _, err := f.service.ApplySubscription(ctx, sub); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := f.service.ApplySubscription(ctx, sub); !errors.Is(err, domain.ErrEventSubMessageProcessed) {
+			t.Fatalf("expected ErrEventSubMessageProcessed, got %v", err)
+		}
+		if f.users.updated != 1 {
+			t.Errorf("expected 1 update, got %d", f.users.updated)
+		}
+	})
+
+	t.Run("不正なティア", func(t *testing.T) {
+		f := newServiceFixture()
+
+		if _, err := f.service.ApplySubscription(ctx, Subscription{MessageID: "m1", UserName: "yamada", Tier: domain.TierUnknown}); !errors.Is(err, domain.ErrInvalidTier) {
+			t.Fatalf("expected ErrInvalidTier, got %v", err)
+		}
+		if len(f.eventSub.processed) != 0 {
+			t.Errorf("message must not be marked processed")
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/shared/logging"
+)
+
+// Webhook リクエストに付与するヘッダー
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	// SignaturePrefix 署名ヘッダーの値の接頭辞
+	SignaturePrefix = "sha256="
+)
+
+const (
+	// DefaultPollInterval 再送時刻を迎えた配信を確認する間隔
+	DefaultPollInterval = 5 * time.Second
+	// DefaultBatchSize 1 回の取得で送信する配信数
+	DefaultBatchSize = 50
+	// DefaultRequestTimeout 送信先の応答を待つ時間の上限
+	DefaultRequestTimeout = 10 * time.Second
+	// DefaultRetention 配信済みの記録を保持する期間
+	DefaultRetention = 7 * 24 * time.Hour
+	// purgeInterval 配信済みの記録を削除する間隔
+	purgeInterval = time.Hour
+	// maxErrorBodyBytes 失敗時に記録する応答本文の最大長
+	maxErrorBodyBytes = 512
+)
+
+// Sign Webhook の署名を計算する
+// 署名対象は "<timestamp>.<body>" で、受信側は同じ計算結果と X-Webhook-Signature を比較する
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return SignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliverer 配信キューから再送時刻を迎えた配信を取り出し、署名付きで POST する
+// 2xx 以外の応答・通信エラーは指数バックオフで再送し、上限に達したらデッドレターにする
+type Deliverer struct {
+	subscriptionRepository repository.WebhookSubscriptionRepository
+	deliveryRepository     repository.WebhookDeliveryRepository
+	client                 *http.Client
+	wake                   chan struct{}
+	pollInterval           time.Duration
+	batchSize              int32
+	retention              time.Duration
+	now                    func() time.Time
+}
+
+// NewDeliverer creates a new webhook deliverer
+func NewDeliverer(
+	subscriptionRepository repository.WebhookSubscriptionRepository,
+	deliveryRepository repository.WebhookDeliveryRepository,
+) *Deliverer {
+	return &Deliverer{
+		subscriptionRepository: subscriptionRepository,
+		deliveryRepository:     deliveryRepository,
+		client:                 &http.Client{Timeout: DefaultRequestTimeout},
+		wake:                   make(chan struct{}, 1),
+		pollInterval:           DefaultPollInterval,
+		batchSize:              DefaultBatchSize,
+		retention:              DefaultRetention,
+		now:                    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Notify 新しい配信がキューに積まれたことを知らせる（ブロックしない）
+func (d *Deliverer) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+		// 既に通知済み
+	}
+}
+
+// Run ctx がキャンセルされるまで配信キューを処理し続ける
+func (d *Deliverer) Run(ctx context.Context) {
+	poll := time.NewTicker(d.pollInterval)
+	defer poll.Stop()
+	purge := time.NewTicker(purgeInterval)
+	defer purge.Stop()
+
+	d.deliver(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+			d.deliver(ctx)
+		case <-poll.C:
+			d.deliver(ctx)
+		case <-purge.C:
+			deleted, err := d.deliveryRepository.DeleteDeliveredBefore(ctx, d.now().Add(-d.retention))
+			if err != nil {
+				if !errors.Is(err, context.Canceled) {
+					logging.FromContext(ctx).Error("failed to purge delivered webhooks", logging.Err(err))
+				}
+				continue
+			}
+			if deleted > 0 {
+				logging.FromContext(ctx).Info("purged delivered webhooks", "deleted", deleted)
+			}
+		}
+	}
+}
+
+func (d *Deliverer) deliver(ctx context.Context) {
+	if _, err := d.DeliverDue(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		logging.FromContext(ctx).Error("failed to deliver webhooks", logging.Err(err))
+	}
+}
+
+// DeliverDue 再送時刻を迎えた配信をすべて送信し、成功した配信数を返す
+func (d *Deliverer) DeliverDue(ctx context.Context) (int, error) {
+	delivered := 0
+	subs := make(map[int64]*domain.WebhookSubscription)
+	for {
+		deliveries, err := d.deliveryRepository.ListDue(ctx, d.now(), d.batchSize)
+		if err != nil {
+			return delivered, err
+		}
+
+		for _, delivery := range deliveries {
+			sub, ok := subs[delivery.SubscriptionID]
+			if !ok {
+				sub, err = d.subscriptionRepository.FindByID(ctx, delivery.SubscriptionID)
+				if errors.Is(err, domain.ErrWebhookNotFound) {
+					// 取得後に送信先が削除された（配信も一緒に削除されている）
+					continue
+				}
+				if err != nil {
+					return delivered, err
+				}
+				subs[sub.ID] = sub
+			}
+
+			statusCode, err := d.send(ctx, sub, delivery)
+			if err != nil {
+				if ctx.Err() != nil {
+					return delivered, ctx.Err()
+				}
+				delivery.MarkFailed(statusCode, err.Error(), d.now)
+				if delivery.Status == domain.WebhookDeliveryDead {
+					logging.FromContext(ctx).Warn("webhook delivery gave up",
+						"delivery_id", delivery.ID, "url", sub.URL, "attempts", delivery.Attempts, logging.Err(err))
+				}
+			} else {
+				delivery.MarkDelivered(statusCode, d.now)
+				delivered++
+			}
+			if err := d.deliveryRepository.Update(ctx, delivery); err != nil {
+				return delivered, err
+			}
+		}
+
+		if len(deliveries) < int(d.batchSize) {
+			return delivered, nil
+		}
+	}
+}
+
+// send 署名付きのリクエストを送信し、応答の HTTP ステータスを返す（応答がなければ 0）
+func (d *Deliverer) send(ctx context.Context, sub *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error) {
+	timestamp := d.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	// keep-alive で接続を再利用できるよう本文を読み捨てる
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/usecase/outbox"
+)
+
+const (
+	// DefaultListLimit 配信一覧の既定の取得件数
+	DefaultListLimit = 50
+	// MaxListLimit 配信一覧の取得件数の上限
+	MaxListLimit = 500
+	// secretBytes 自動生成するシークレットのバイト数
+	secretBytes = 32
+)
+
+// eventTypes 送信対象として指定できるイベント種別
+var eventTypes = map[string]bool{
+	outbox.EventTypeSessionStart:    true,
+	outbox.EventTypeSessionEnd:      true,
+	outbox.EventTypeSessionExtend:   true,
+	outbox.EventTypeWorkNameChange:  true,
+	outbox.EventTypeSettingsChanged: true,
+}
+
+// SubscriptionInput Webhook 送信先の登録内容
+type SubscriptionInput struct {
+	URL        string
+	EventTypes []string // 空なら全イベント
+	Secret     string   // 空なら自動生成する
+}
+
+// Service Webhook の送信先と配信キューを管理する
+type Service struct {
+	subscriptionRepository repository.WebhookSubscriptionRepository
+	deliveryRepository     repository.WebhookDeliveryRepository
+	auditLogRepository     repository.AuditLogRepository
+	deliverer              *Deliverer
+	now                    func() time.Time
+}
+
+// NewService creates a new webhook service
+func NewService(
+	subscriptionRepository repository.WebhookSubscriptionRepository,
+	deliveryRepository repository.WebhookDeliveryRepository,
+	auditLogRepository repository.AuditLogRepository,
+	deliverer *Deliverer,
+) *Service {
+	return &Service{
+		subscriptionRepository: subscriptionRepository,
+		deliveryRepository:     deliveryRepository,
+		auditLogRepository:     auditLogRepository,
+		deliverer:              deliverer,
+		now:                    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// ListSubscriptions 登録済みの送信先を返す
+func (s *Service) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	return s.subscriptionRepository.List(ctx)
+}
+
+// CreateSubscription 送信先を登録する
+// 返り値の Secret は署名の検証に必要なため、登録時のレスポンスでのみ返す
+func (s *Service) CreateSubscription(ctx context.Context, input SubscriptionInput, actor string) (*domain.WebhookSubscription, error) {
+	for _, eventType := range input.EventTypes {
+		if !eventTypes[eventType] {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidWebhookEventType, eventType)
+		}
+	}
+
+	secret := input.Secret
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	sub, err := domain.NewWebhookSubscription(input.URL, input.EventTypes, secret, actor, s.now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.subscriptionRepository.Save(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	if err := s.audit(ctx, actor, domain.AuditActionWebhookCreate, sub.ID, nil, newSubscriptionState(sub)); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// DeleteSubscription 送信先と、その配信キューを削除する
+func (s *Service) DeleteSubscription(ctx context.Context, id int64, actor string) error {
+	sub, err := s.subscriptionRepository.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.subscriptionRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	return s.audit(ctx, actor, domain.AuditActionWebhookDelete, id, newSubscriptionState(sub), nil)
+}
+
+// ListDeliveries 指定した状態の配信を返す（dead を指定するとデッドレターの一覧になる）
+func (s *Service) ListDeliveries(ctx context.Context, status domain.WebhookDeliveryStatus, limit int32) ([]*domain.WebhookDelivery, error) {
+	if !status.Valid() {
+		return nil, fmt.Errorf("invalid delivery status: %q", status)
+	}
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	if limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+	return s.deliveryRepository.ListByStatus(ctx, status, limit)
+}
+
+// RetryDelivery デッドレターの配信を再送キューに戻す
+func (s *Service) RetryDelivery(ctx context.Context, id int64, actor string) (*domain.WebhookDelivery, error) {
+	delivery, err := s.deliveryRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := newDeliveryState(delivery)
+
+	if err := delivery.Requeue(s.now); err != nil {
+		return nil, err
+	}
+	if err := s.deliveryRepository.Update(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	if err := s.audit(ctx, actor, domain.AuditActionWebhookRetry, delivery.SubscriptionID, before, newDeliveryState(delivery)); err != nil {
+		return nil, err
+	}
+	if s.deliverer != nil {
+		s.deliverer.Notify()
+	}
+	return delivery, nil
+}
+
+func (s *Service) audit(ctx context.Context, actor, action string, subscriptionID int64, before, after any) error {
+	auditLog, err := domain.NewAuditLog(
+		actor,
+		action,
+		domain.AuditTargetWebhook,
+		strconv.FormatInt(subscriptionID, 10),
+		before,
+		after,
+		"",
+		s.now,
+	)
+	if err != nil {
+		return err
+	}
+	return s.auditLogRepository.Save(ctx, auditLog)
+}
+
+// generateSecret 署名用のランダムなシークレットを生成する
+func generateSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/usecase/outbox"
+)
+
+// enqueueTimeout 1 イベント分の配信をキューに積むまでの待ち時間の上限
+const enqueueTimeout = 5 * time.Second
+
+// Ensure Sink implements outbox.Sink
+var _ outbox.Sink = (*Sink)(nil)
+
+// Payload Webhook で送信する JSON の本文
+type Payload struct {
+	EventID int64  `json:"event_id"`
+	Type    string `json:"type"`
+	Data    any    `json:"data"`
+}
+
+// Sink アウトボックスの sink として、イベントを受け付ける送信先ごとに配信キューへ積む
+// キューに積めなかったイベントはアウトボックスが配信済みにせず再試行するので、取りこぼさない
+// HTTP の送信は Deliverer が非同期に行うため、送信先の応答が遅くてもアウトボックスの配信を待たせない
+type Sink struct {
+	subscriptionRepository repository.WebhookSubscriptionRepository
+	deliveryRepository     repository.WebhookDeliveryRepository
+	deliverer              *Deliverer
+	now                    func() time.Time
+}
+
+// NewSink creates a new webhook sink
+// deliverer が nil の場合、キューに積んだ配信は次のポーリングで送信される
+func NewSink(
+	subscriptionRepository repository.WebhookSubscriptionRepository,
+	deliveryRepository repository.WebhookDeliveryRepository,
+	deliverer *Deliverer,
+) *Sink {
+	return &Sink{
+		subscriptionRepository: subscriptionRepository,
+		deliveryRepository:     deliveryRepository,
+		deliverer:              deliverer,
+		now:                    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Deliver implements outbox.Sink
+// 同じイベントが再試行で渡されても、送信先ごとの配信は (subscription_id, event_id) で 1 件にまとまる
+func (s *Sink) Deliver(ctx context.Context, event *domain.OutboxEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, enqueueTimeout)
+	defer cancel()
+
+	subs, err := s.subscriptionRepository.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load webhook subscriptions: %w", err)
+	}
+
+	var payload json.RawMessage
+	queued := 0
+	defer func() {
+		// 途中で失敗しても、積めた配信はすぐに送る
+		if queued > 0 && s.deliverer != nil {
+			s.deliverer.Notify()
+		}
+	}()
+	for _, sub := range subs {
+		if !sub.Accepts(event.EventType) {
+			continue
+		}
+		if payload == nil {
+			payload, err = json.Marshal(Payload{EventID: event.ID, Type: event.EventType, Data: event.Payload})
+			if err != nil {
+				return fmt.Errorf("failed to encode webhook payload: %w", err)
+			}
+		}
+
+		delivery := domain.NewWebhookDelivery(sub.ID, event.ID, event.EventType, payload, s.now)
+		created, err := s.deliveryRepository.Enqueue(ctx, delivery)
+		if err != nil {
+			return fmt.Errorf("failed to enqueue webhook delivery for subscription %d: %w", sub.ID, err)
+		}
+		if created {
+			queued++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+)
+
+// subscriptionState 監査ログに記録する送信先の状態（シークレットは記録しない）
+type subscriptionState struct {
+	ID         int64    `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+}
+
+func newSubscriptionState(sub *domain.WebhookSubscription) *subscriptionState {
+	return &subscriptionState{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: sub.EventTypes,
+	}
+}
+
+// deliveryState 監査ログに記録する配信の状態
+type deliveryState struct {
+	DeliveryID    int64     `json:"delivery_id"`
+	EventID       int64     `json:"event_id"`
+	Status        string    `json:"status"`
+	Attempts      int32     `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastError     string    `json:"last_error,omitempty"`
+}
+
+func newDeliveryState(delivery *domain.WebhookDelivery) *deliveryState {
+	return &deliveryState{
+		DeliveryID:    delivery.ID,
+		EventID:       delivery.EventID,
+		Status:        string(delivery.Status),
+		Attempts:      delivery.Attempts,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LastError:     delivery.LastError,
+	}
+}
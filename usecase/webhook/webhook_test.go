@@ -0,0 +1,393 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yamada-ai/workspace-backend/domain"
+	"github.com/yamada-ai/workspace-backend/domain/repository"
+	"github.com/yamada-ai/workspace-backend/usecase/command"
+	"github.com/yamada-ai/workspace-backend/usecase/outbox"
+)
+
+// fakeSubscriptionRepository インメモリの送信先
+type fakeSubscriptionRepository struct {
+	subs map[int64]*domain.WebhookSubscription
+}
+
+func newFakeSubscriptionRepository(subs ...*domain.WebhookSubscription) *fakeSubscriptionRepository {
+	r := &fakeSubscriptionRepository{subs: make(map[int64]*domain.WebhookSubscription)}
+	for _, sub := range subs {
+		r.subs[sub.ID] = sub
+	}
+	return r
+}
+
+func (r *fakeSubscriptionRepository) List(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	var subs []*domain.WebhookSubscription
+	for _, sub := range r.subs {
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].ID < subs[j].ID })
+	return subs, nil
+}
+
+func (r *fakeSubscriptionRepository) FindByID(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	sub, ok := r.subs[id]
+	if !ok {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return sub, nil
+}
+
+func (r *fakeSubscriptionRepository) Save(ctx context.Context, sub *domain.WebhookSubscription) error {
+	sub.ID = int64(len(r.subs) + 1)
+	r.subs[sub.ID] = sub
+	return nil
+}
+
+func (r *fakeSubscriptionRepository) Delete(ctx context.Context, id int64) error {
+	if _, ok := r.subs[id]; !ok {
+		return domain.ErrWebhookNotFound
+	}
+	delete(r.subs, id)
+	return nil
+}
+
+// fakeDeliveryRepository インメモリの配信キュー
+type fakeDeliveryRepository struct {
+	mu         sync.Mutex
+	deliveries map[int64]*domain.WebhookDelivery
+	nextID     int64
+	enqueueErr error
+}
+
+func newFakeDeliveryRepository() *fakeDeliveryRepository {
+	return &fakeDeliveryRepository{deliveries: make(map[int64]*domain.WebhookDelivery)}
+}
+
+func (r *fakeDeliveryRepository) Enqueue(ctx context.Context, delivery *domain.WebhookDelivery) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.enqueueErr != nil {
+		return false, r.enqueueErr
+	}
+	for _, d := range r.deliveries {
+		if d.SubscriptionID == delivery.SubscriptionID && d.EventID == delivery.EventID {
+			return false, nil
+		}
+	}
+	r.nextID++
+	delivery.ID = r.nextID
+	copied := *delivery
+	r.deliveries[delivery.ID] = &copied
+	return true, nil
+}
+
+func (r *fakeDeliveryRepository) ListDue(ctx context.Context, now time.Time, limit int32) ([]*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []*domain.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == domain.WebhookDeliveryPending && !d.NextAttemptAt.After(now) {
+			copied := *d
+			due = append(due, &copied)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	if int32(len(due)) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (r *fakeDeliveryRepository) FindByID(ctx context.Context, id int64) (*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.deliveries[id]
+	if !ok {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (r *fakeDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *delivery
+	r.deliveries[delivery.ID] = &copied
+	return nil
+}
+
+func (r *fakeDeliveryRepository) ListByStatus(ctx context.Context, status domain.WebhookDeliveryStatus, limit int32) ([]*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*domain.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == status {
+			copied := *d
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeDeliveryRepository) DeleteDeliveredBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// fakeAuditLogRepository 記録された監査ログを保持する
+type fakeAuditLogRepository struct {
+	logs []*domain.AuditLog
+}
+
+func (r *fakeAuditLogRepository) Save(ctx context.Context, log *domain.AuditLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *fakeAuditLogRepository) SaveWithTx(ctx context.Context, tx repository.Tx, log *domain.AuditLog) error {
+	return r.Save(ctx, log)
+}
+
+func (r *fakeAuditLogRepository) List(ctx context.Context, filter repository.AuditLogFilter) ([]*domain.AuditLog, error) {
+	return r.logs, nil
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{"a":1}' | openssl dgst -sha256 -hmac secret
+	got := Sign("secret", 1700000000, []byte(`{"a":1}`))
+	want := "sha256=49f24e537407743fa4a0242bb63b94b9a47ee99cbbe071ccd8a22550ae411686"
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if got == Sign("other", 1700000000, []byte(`{"a":1}`)) {
+		t.Error("signature should depend on the secret")
+	}
+	if got == Sign("secret", 1700000001, []byte(`{"a":1}`)) {
+		t.Error("signature should depend on the timestamp")
+	}
+}
+
+func TestSink_EnqueuesForAcceptingSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	subs := newFakeSubscriptionRepository(
+		&domain.WebhookSubscription{ID: 1, URL: "https://a.example.com", Secret: "s"},
+		&domain.WebhookSubscription{ID: 2, URL: "https://b.example.com", Secret: "s", EventTypes: []string{outbox.EventTypeSessionEnd}},
+	)
+	deliveries := newFakeDeliveryRepository()
+	s := NewSink(subs, deliveries, nil)
+
+	data, err := json.Marshal(command.SessionStartBroadcast{SessionID: 5, UserID: 3, UserName: "yamada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := &domain.OutboxEvent{ID: 10, EventType: outbox.EventTypeSessionStart, Payload: data}
+	if err := s.Deliver(ctx, event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 再試行で渡されても同じ送信先には 1 件だけ
+	if err := s.Deliver(ctx, event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(deliveries.deliveries) != 1 {
+		t.Fatalf("expected 1 delivery, got %d", len(deliveries.deliveries))
+	}
+	d := deliveries.deliveries[1]
+	if d.SubscriptionID != 1 || d.EventID != 10 || d.EventType != outbox.EventTypeSessionStart {
+		t.Errorf("unexpected delivery: %+v", d)
+	}
+
+	var payload struct {
+		EventID int64                         `json:"event_id"`
+		Type    string                        `json:"type"`
+		Data    command.SessionStartBroadcast `json:"data"`
+	}
+	if err := json.Unmarshal(d.Payload, &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if payload.EventID != 10 || payload.Type != outbox.EventTypeSessionStart || payload.Data.UserName != "yamada" {
+		t.Errorf("unexpected payload: %s", d.Payload)
+	}
+
+	if err := s.Deliver(ctx, &domain.OutboxEvent{ID: 11, EventType: outbox.EventTypeSessionEnd, Payload: []byte(`{"session_id":5}`)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deliveries.deliveries) != 3 {
+		t.Errorf("expected session_end to be queued for both subscriptions, got %d deliveries", len(deliveries.deliveries))
+	}
+}
+
+func TestSink_ReturnsQueueErrors(t *testing.T) {
+	subs := newFakeSubscriptionRepository(&domain.WebhookSubscription{ID: 1, URL: "https://a.example.com", Secret: "s"})
+	deliveries := newFakeDeliveryRepository()
+	deliveries.enqueueErr = errors.New("connection refused")
+	s := NewSink(subs, deliveries, nil)
+
+	// アウトボックスが再試行できるよう、キューに積めなければエラーを返す
+	event := &domain.OutboxEvent{ID: 10, EventType: outbox.EventTypeSessionStart, Payload: []byte(`{}`)}
+	if err := s.Deliver(context.Background(), event); !errors.Is(err, deliveries.enqueueErr) {
+		t.Fatalf("expected the enqueue error, got %v", err)
+	}
+}
+
+func TestDeliverer_DeliverDue(t *testing.T) {
+	now := time.Date(2025, 11, 24, 15, 0, 0, 0, time.UTC)
+
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	subs := newFakeSubscriptionRepository(&domain.WebhookSubscription{ID: 1, URL: server.URL, Secret: "topsecret"})
+	deliveries := newFakeDeliveryRepository()
+	payload := json.RawMessage(`{"event_id":10,"type":"session_start","data":{}}`)
+	if _, err := deliveries.Enqueue(context.Background(), domain.NewWebhookDelivery(1, 10, outbox.EventTypeSessionStart, payload, func() time.Time { return now })); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDeliverer(subs, deliveries)
+	d.now = func() time.Time { return now }
+
+	delivered, err := d.DeliverDue(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivered != 1 {
+		t.Fatalf("expected 1 delivered, got %d", delivered)
+	}
+
+	if string(body) != string(payload) {
+		t.Errorf("expected body %s, got %s", payload, body)
+	}
+	if got := received.Header.Get(HeaderEvent); got != outbox.EventTypeSessionStart {
+		t.Errorf("expected %s header %q, got %q", HeaderEvent, outbox.EventTypeSessionStart, got)
+	}
+	if got := received.Header.Get(HeaderDelivery); got != "1" {
+		t.Errorf("expected %s header 1, got %q", HeaderDelivery, got)
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	if got := received.Header.Get(HeaderTimestamp); got != timestamp {
+		t.Errorf("expected %s header %s, got %q", HeaderTimestamp, timestamp, got)
+	}
+	if got := received.Header.Get(HeaderSignature); got != Sign("topsecret", now.Unix(), payload) {
+		t.Errorf("unexpected signature %q", got)
+	}
+
+	stored := deliveries.deliveries[1]
+	if stored.Status != domain.WebhookDeliveryDelivered || stored.Attempts != 1 || stored.LastStatusCode != http.StatusNoContent {
+		t.Errorf("unexpected delivery state: %+v", stored)
+	}
+}
+
+func TestDeliverer_RetriesWithBackoffAndDeadLetters(t *testing.T) {
+	now := time.Date(2025, 11, 24, 15, 0, 0, 0, time.UTC)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	subs := newFakeSubscriptionRepository(&domain.WebhookSubscription{ID: 1, URL: server.URL, Secret: "s"})
+	deliveries := newFakeDeliveryRepository()
+	if _, err := deliveries.Enqueue(context.Background(), domain.NewWebhookDelivery(1, 10, outbox.EventTypeSessionEnd, json.RawMessage(`{}`), func() time.Time { return now })); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDeliverer(subs, deliveries)
+	d.now = func() time.Time { return now }
+
+	if _, err := d.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stored := deliveries.deliveries[1]
+	if stored.Status != domain.WebhookDeliveryPending || stored.Attempts != 1 || stored.LastStatusCode != http.StatusInternalServerError {
+		t.Fatalf("unexpected delivery state after failure: %+v", stored)
+	}
+	if !stored.NextAttemptAt.Equal(now.Add(domain.WebhookBackoff(1))) {
+		t.Errorf("expected next attempt at %v, got %v", now.Add(domain.WebhookBackoff(1)), stored.NextAttemptAt)
+	}
+
+	// 再送時刻前は送信しない
+	if _, err := d.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected no retry before backoff elapsed, got %d calls", calls)
+	}
+
+	for i := 1; i < domain.MaxWebhookAttempts; i++ {
+		now = now.Add(time.Hour)
+		if _, err := d.DeliverDue(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	stored = deliveries.deliveries[1]
+	if stored.Status != domain.WebhookDeliveryDead || stored.Attempts != domain.MaxWebhookAttempts {
+		t.Fatalf("expected delivery to be dead after %d attempts, got %+v", domain.MaxWebhookAttempts, stored)
+	}
+
+	// デッドレターを再送キューに戻す
+	audit := &fakeAuditLogRepository{}
+	svc := NewService(subs, deliveries, audit, nil)
+	svc.now = func() time.Time { return now }
+	retried, err := svc.RetryDelivery(context.Background(), 1, "admin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if retried.Status != domain.WebhookDeliveryPending || retried.Attempts != 0 {
+		t.Errorf("unexpected delivery state after retry: %+v", retried)
+	}
+	if len(audit.logs) != 1 || audit.logs[0].Action != domain.AuditActionWebhookRetry {
+		t.Errorf("expected a webhook.retry audit log, got %+v", audit.logs)
+	}
+}
+
+func TestService_CreateSubscription(t *testing.T) {
+	subs := newFakeSubscriptionRepository()
+	audit := &fakeAuditLogRepository{}
+	svc := NewService(subs, newFakeDeliveryRepository(), audit, nil)
+
+	if _, err := svc.CreateSubscription(context.Background(), SubscriptionInput{
+		URL:        "https://example.com/hook",
+		EventTypes: []string{"unknown"},
+	}, "admin"); err == nil {
+		t.Fatal("expected error for unknown event type")
+	}
+
+	sub, err := svc.CreateSubscription(context.Background(), SubscriptionInput{
+		URL:        "https://example.com/hook",
+		EventTypes: []string{outbox.EventTypeSessionStart},
+	}, "admin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sub.Secret) != secretBytes*2 {
+		t.Errorf("expected a generated secret, got %q", sub.Secret)
+	}
+
+	if len(audit.logs) != 1 || audit.logs[0].Action != domain.AuditActionWebhookCreate {
+		t.Fatalf("expected a webhook.create audit log, got %+v", audit.logs)
+	}
+	if strings.Contains(string(audit.logs[0].After), sub.Secret) {
+		t.Error("audit log must not contain the secret")
+	}
+}